@@ -0,0 +1,100 @@
+// Path: cmd/hypersphere/aggregate_view.go
+// Description: Aggregate every endpoint profile into one explorer view and route actions per endpoint.
+package main
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/takelley1/hypersphere/internal/inventory"
+	"github.com/takelley1/hypersphere/internal/tui"
+)
+
+const aggregateContextName = "all"
+
+var vmPowerActions = []string{"power-on", "power-off", "reset", "suspend"}
+
+type runtimeEndpointSource interface {
+	Endpoints() []string
+}
+
+type aggregateMemberSource interface {
+	Members() []inventory.Member
+}
+
+func (c *profileContextConnector) Endpoints() []string {
+	if c.active == aggregateContextName {
+		return c.profiles.Names()
+	}
+	return []string{c.active}
+}
+
+func (c *profileContextConnector) switchAggregate() error {
+	members := make([]inventory.Member, len(c.profiles.Profiles))
+	errs := make([]error, len(c.profiles.Profiles))
+	var group sync.WaitGroup
+	for index, entry := range c.profiles.Profiles {
+		group.Go(func() {
+			provider, err := c.login(entry)
+			members[index] = inventory.Member{Name: entry.Name, Provider: provider}
+			errs[index] = err
+		})
+	}
+	group.Wait()
+	if err := errors.Join(errs...); err != nil {
+		_ = inventory.NewAggregateProvider(members).Close()
+		return err
+	}
+	_ = c.Close()
+	c.active = aggregateContextName
+	c.provider = inventory.NewAggregateProvider(members)
+	return nil
+}
+
+func (r *runtimeActionExecutor) routeEndpoints(provider tui.InventoryProvider) {
+	r.endpoints = nil
+	source, ok := provider.(aggregateMemberSource)
+	if !ok {
+		return
+	}
+	r.endpoints = map[string]*runtimeActionExecutor{}
+	for _, member := range source.Members() {
+		executor := &runtimeActionExecutor{}
+		if power, ok := member.Provider.(runtimeVMPowerClient); ok {
+			executor.vmPower = power
+		}
+		r.endpoints[member.Name] = executor
+	}
+}
+
+func (r *runtimeActionExecutor) executeRouted(resource tui.Resource, action string, ids []string) error {
+	order := []string{}
+	targets := map[string][]string{}
+	for _, id := range ids {
+		endpoint, target, ok := strings.Cut(id, "/")
+		executor, known := r.endpoints[endpoint]
+		if !ok || !known {
+			return fmt.Errorf("%w: no context for target %s", tui.ErrInvalidAction, id)
+		}
+		if resource == tui.ResourceVM && slices.Contains(vmPowerActions, action) && executor.vmPower == nil {
+			return fmt.Errorf("%w: context %s has no VM power client for %s", tui.ErrInvalidAction, endpoint, action)
+		}
+		if _, seen := targets[endpoint]; !seen {
+			order = append(order, endpoint)
+		}
+		targets[endpoint] = append(targets[endpoint], target)
+	}
+	summaries := make([]string, 0, len(order))
+	for _, endpoint := range order {
+		executor := r.endpoints[endpoint]
+		if err := executor.Execute(resource, action, targets[endpoint]); err != nil {
+			return fmt.Errorf("context %s: %w", endpoint, err)
+		}
+		summaries = append(summaries, fmt.Sprintf("context=%s %s", endpoint, executor.last))
+	}
+	r.last = strings.Join(summaries, "; ")
+	return nil
+}
@@ -0,0 +1,156 @@
+// Path: cmd/hypersphere/aggregate_view_test.go
+// Description: Validate aggregate multi-vCenter contexts, merged overlays, and per-endpoint action routing.
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/takelley1/hypersphere/internal/inventory"
+	"github.com/takelley1/hypersphere/internal/tui"
+)
+
+const aggregateProfiles = `{"profiles":[{"name":"vc-a","url":"a"},{"name":"vc-b","url":"b"}]}`
+
+type recordingPowerProvider struct {
+	closingProvider
+	powered []string
+	err     error
+}
+
+func (p *recordingPowerProvider) PowerOn(ids []string) error {
+	p.powered = append(p.powered, ids...)
+	return p.err
+}
+
+func (p *recordingPowerProvider) PowerOff(_ []string) error { return nil }
+
+func (p *recordingPowerProvider) Reset(_ []string) error { return nil }
+
+func (p *recordingPowerProvider) Suspend(_ []string) error { return nil }
+
+func TestProfileContextConnectorAggregatesEveryProfile(t *testing.T) {
+	writeProfiles(t, aggregateProfiles)
+	setProfilePasswords(t)
+	dialer := newProfileDialer()
+	connector, err := loadProfileContextConnector("", dialer.dial)
+	if err != nil {
+		t.Fatalf("loadProfileContextConnector returned error: %v", err)
+	}
+	if strings.Join(connector.List(), ",") != "vc-a,vc-b,all" || strings.Join(connector.Endpoints(), ",") != "vc-a" {
+		t.Fatalf("expected aggregate context to be listed, got %v endpoints=%v", connector.List(), connector.Endpoints())
+	}
+	if err := connector.Switch("all"); err != nil {
+		t.Fatalf("Switch returned error: %v", err)
+	}
+	aggregate, ok := connector.Provider().(*inventory.AggregateProvider)
+	if !ok || connector.Active() != "all" || strings.Join(connector.Endpoints(), ",") != "vc-a,vc-b" {
+		t.Fatalf("expected aggregate provider over both profiles, got %T endpoints=%v", connector.Provider(), connector.Endpoints())
+	}
+	if len(aggregate.Members()) != 2 || dialer.providers["vc-a"].closed != 1 {
+		t.Fatalf("expected the single-endpoint session to be replaced by the aggregate")
+	}
+	if err := connector.Close(); err != nil || dialer.providers["vc-a"].closed != 2 || dialer.providers["vc-b"].closed != 1 {
+		t.Fatalf("expected Close to log out of every member, err=%v", err)
+	}
+}
+
+func TestProfileContextConnectorAggregateFailureKeepsSession(t *testing.T) {
+	writeProfiles(t, testProfiles)
+	setProfilePasswords(t)
+	dialer := newProfileDialer()
+	connector, err := loadProfileContextConnector("vc-b", dialer.dial)
+	if err != nil {
+		t.Fatalf("loadProfileContextConnector returned error: %v", err)
+	}
+	err = connector.Switch("all")
+	if err == nil || !strings.Contains(err.Error(), "context vc-down login failed") ||
+		!strings.Contains(err.Error(), "endpoint credential not found") {
+		t.Fatalf("expected joined member login failures, got %v", err)
+	}
+	if connector.Active() != "vc-b" || dialer.providers["vc-a"].closed != 1 {
+		t.Fatalf("expected failed aggregate to keep vc-b and log out of partial sessions")
+	}
+}
+
+func TestRuntimeAggregateContextMergesInventoryAndOverlays(t *testing.T) {
+	writeProfiles(t, aggregateProfiles)
+	setProfilePasswords(t)
+	aliasPath := filepath.Join(t.TempDir(), "aliases.yaml")
+	overlays := map[string]string{"vc-a": "go-a: :host\n", "vc-b": "go-b: :datastore\n"}
+	for endpoint, content := range overlays {
+		if err := os.WriteFile(endpointOverlayPath(aliasPath, endpoint), []byte(content), 0o600); err != nil {
+			t.Fatalf("write alias overlay: %v", err)
+		}
+	}
+	t.Setenv(aliasRegistryEnvPath, aliasPath)
+	connector, err := loadProfileContextConnector("all", newProfileDialer().dial)
+	if err != nil {
+		t.Fatalf("loadProfileContextConnector returned error: %v", err)
+	}
+	runtime := newExplorerRuntimeWithContexts(
+		connector.Provider(), runtimeContextManager{connector: connector}, false, "", false, false,
+	)
+	view := runtime.session.CurrentView()
+	if strings.Join(view.IDs, ",") != "vc-a/vm-on-a,vc-b/vm-on-b" || view.Columns[len(view.Columns)-1] != "VCENTER" {
+		t.Fatalf("expected merged vm rows with a VCENTER column, got %v %v", view.IDs, view.Columns)
+	}
+	if runtime.aliasRegistry.Resolve(":go-a") != ":host" || runtime.aliasRegistry.Resolve(":go-b") != ":datastore" {
+		t.Fatalf("expected overlays from every aggregated endpoint")
+	}
+	if len(runtime.actionExec.endpoints) != 2 {
+		t.Fatalf("expected actions to be routed to both endpoints, got %+v", runtime.actionExec.endpoints)
+	}
+	message, _ := runtime.handleLocalPromptCommand(":ctx vc-b")
+	if message != "context: vc-b" || runtime.actionExec.endpoints != nil {
+		t.Fatalf("expected single-endpoint switch to drop action routes, got %q", message)
+	}
+	if view := runtime.session.CurrentView(); strings.Join(view.IDs, ",") != "vm-on-b" {
+		t.Fatalf("expected unqualified vc-b rows after leaving aggregate mode, got %v", view.IDs)
+	}
+}
+
+func TestRuntimeActionExecutorRoutesAggregateTargetsByEndpoint(t *testing.T) {
+	powered := &recordingPowerProvider{}
+	other := &recordingPowerProvider{}
+	executor := &runtimeActionExecutor{}
+	executor.routeEndpoints(inventory.NewAggregateProvider([]inventory.Member{
+		{Name: "vc-a", Provider: powered},
+		{Name: "vc-b", Provider: other},
+		{Name: "vc-c", Provider: inventory.NewDemoProvider()},
+	}))
+	ids := []string{"vc-b/vm-2", "vc-a/vm-1", "vc-b/vm-3"}
+	if err := executor.Execute(tui.ResourceVM, "power-on", ids); err != nil {
+		t.Fatalf("Execute returned error: %v", err)
+	}
+	want := "context=vc-b vmware-api method=power_on resource=vm targets=vm-2,vm-3; " +
+		"context=vc-a vmware-api method=power_on resource=vm targets=vm-1"
+	if executor.last != want || strings.Join(powered.powered, ",") != "vm-1" || strings.Join(other.powered, ",") != "vm-2,vm-3" {
+		t.Fatalf("unexpected routed result %q powered=%v other=%v", executor.last, powered.powered, other.powered)
+	}
+	err := executor.Execute(tui.ResourceVM, "power-on", []string{"vc-a/vm-1", "vc-c/vm-9"})
+	if !errors.Is(err, tui.ErrInvalidAction) || !strings.Contains(err.Error(), "context vc-c has no VM power client") ||
+		len(powered.powered) != 1 {
+		t.Fatalf("expected power actions refused before any endpoint runs without a power client, got %v", err)
+	}
+	if err := executor.Execute(tui.ResourceVM, "migrate", []string{"vc-c/vm-9"}); err != nil {
+		t.Fatalf("expected non-power actions still routed, got %v", err)
+	}
+	for _, id := range []string{"vm-1", "vc-z/vm-1"} {
+		if err := executor.Execute(tui.ResourceVM, "power-on", []string{id}); !errors.Is(err, tui.ErrInvalidAction) {
+			t.Fatalf("expected invalid action for unrouted target %s, got %v", id, err)
+		}
+	}
+	powered.err = errors.New("task failed")
+	if err := executor.Execute(tui.ResourceVM, "power-on", []string{"vc-a/vm-1"}); err == nil ||
+		err.Error() != "context vc-a: task failed" {
+		t.Fatalf("expected endpoint-qualified failure, got %v", err)
+	}
+	executor.routeEndpoints(inventory.NewDemoProvider())
+	if err := executor.Execute(tui.ResourceHost, "refresh", []string{"esxi-01"}); err != nil || executor.endpoints != nil {
+		t.Fatalf("expected single-endpoint providers to clear routes, err=%v", err)
+	}
+}
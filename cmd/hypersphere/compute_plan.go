@@ -0,0 +1,38 @@
+// Path: cmd/hypersphere/compute_plan.go
+// Description: Plan vMotion host balancing and host evacuation moves from live inventory.
+package main
+
+import (
+	"github.com/takelley1/hypersphere/internal/app"
+	"github.com/takelley1/hypersphere/internal/config"
+	"github.com/takelley1/hypersphere/internal/migration"
+	"github.com/takelley1/hypersphere/internal/tui"
+)
+
+func runComputeWorkflow(application app.App, cfg config.Config, flags cliFlags) error {
+	contexts, provider, err := openExplorerContexts(flags)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = releaseExplorerContexts(contexts, provider) }()
+	if err := checkTagFilter(flags.filter, provider); err != nil {
+		return err
+	}
+	catalog, err := tui.LoadCatalog(provider)
+	if err != nil {
+		return err
+	}
+	vms, _ := app.MigrationInventory(catalog)
+	matched := []migration.VM{}
+	for _, vm := range vms {
+		if flags.filter.Match(vm) {
+			matched = append(matched, vm)
+		}
+	}
+	planner := migration.NewComputePlanner(cfg.ThresholdPercent)
+	_ = application.PlanCompute(matched, app.ComputeHosts(catalog), flags.evacuateHost, planner)
+	if cfg.Execute {
+		application.MigrationNotice("Compute plans are not executed yet; apply the moves with vMotion")
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+// Path: cmd/hypersphere/compute_plan_test.go
+// Description: Validate the compute workflow's balancing and host evacuation plans.
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestComputeWorkflowPlansHostEvacuation(t *testing.T) {
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+	args := []string{"--workflow", "compute", "--provider", "demo", "--evacuate-host", "esxi-01", "--execute"}
+	if code := run(args, stdout, stderr); code != 0 {
+		t.Fatalf("expected compute workflow to succeed, got %d stderr=%q", code, stderr.String())
+	}
+	out := stdout.String()
+	if !strings.Contains(out, "Compute Plan") || !strings.Contains(out, "1 vm-a esxi-01 esxi-05 78% READY") ||
+		!strings.Contains(out, "Compute plans are not executed yet") {
+		t.Fatalf("unexpected compute output %q", out)
+	}
+	stdout.Reset()
+	if code := run([]string{"--workflow", "compute", "--provider", "demo", "--threshold", "70", "--tag", "db"}, stdout, stderr); code != 0 {
+		t.Fatalf("expected balancing to succeed, got %d", code)
+	}
+	if !strings.Contains(stdout.String(), "1 vm-c esxi-05 - - HOST_OVERLOADED") || strings.Contains(stdout.String(), "vm-a") {
+		t.Fatalf("expected filtered balancing plan, got %q", stdout.String())
+	}
+}
+
+func TestComputeWorkflowReportsProviderFailures(t *testing.T) {
+	t.Setenv(vcenterURLEnvName, "")
+	stderr := &bytes.Buffer{}
+	if code := run([]string{"--workflow", "compute", "--provider", "vsphere"}, &bytes.Buffer{}, stderr); code != 1 ||
+		!strings.Contains(stderr.String(), "compute workflow failed") {
+		t.Fatalf("expected compute workflow failure, got %d stderr=%q", code, stderr.String())
+	}
+}
@@ -17,15 +17,23 @@ type endpointOverlays struct {
 	hotkeys map[string]string
 }
 
-func loadEndpointOverlays(endpoint string) endpointOverlays {
+func loadEndpointOverlays(endpoints ...string) endpointOverlays {
 	overlays := endpointOverlays{
 		aliases: commandAliasRegistry{aliases: map[string]string{}},
 		plugins: pluginRegistry{entries: []pluginEntry{}},
 		hotkeys: map[string]string{},
 	}
-	overlays.aliases = mergeAliasRegistries(loadAliasRegistryWithOverlay(endpoint))
-	overlays.plugins = mergePluginRegistries(loadPluginRegistryWithOverlay(endpoint))
-	overlays.hotkeys = mergeHotkeyBindings(loadHotkeyBindingsWithOverlay(endpoint))
+	for index, endpoint := range endpoints {
+		globalAliases, aliasOverlay := loadAliasRegistryWithOverlay(endpoint)
+		globalPlugins, pluginOverlay := loadPluginRegistryWithOverlay(endpoint)
+		globalHotkeys, hotkeyOverlay := loadHotkeyBindingsWithOverlay(endpoint)
+		if index == 0 {
+			overlays = endpointOverlays{aliases: globalAliases, plugins: globalPlugins, hotkeys: globalHotkeys}
+		}
+		overlays.aliases = mergeAliasRegistries(overlays.aliases, aliasOverlay)
+		overlays.plugins = mergePluginRegistries(overlays.plugins, pluginOverlay)
+		overlays.hotkeys = mergeHotkeyBindings(overlays.hotkeys, hotkeyOverlay)
+	}
 	return overlays
 }
 
@@ -0,0 +1,51 @@
+// Path: cmd/hypersphere/credentials_command.go
+// Description: Seal an endpoint profile password into the encrypted credential file.
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/takelley1/hypersphere/internal/profile"
+	"golang.org/x/term"
+)
+
+var credentialInput io.Reader = os.Stdin
+
+func runCredentialsCommand(input io.Reader, output io.Writer, name string) error {
+	path, err := defaultCredentialsPath()
+	if err != nil {
+		return err
+	}
+	password, err := readCredentialPassword(input, output)
+	if err != nil {
+		return err
+	}
+	if password == "" {
+		return errors.New("read an empty password from standard input")
+	}
+	credentials := profile.Credentials{Path: path, Key: os.Getenv(credentialsKeyEnvName)}
+	if err := credentials.Store(name, password); err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintf(output, "Stored password for %s in %s\n", name, path)
+	return nil
+}
+
+func readCredentialPassword(input io.Reader, output io.Writer) (string, error) {
+	if file, ok := input.(*os.File); ok && term.IsTerminal(int(file.Fd())) {
+		_, _ = fmt.Fprint(output, "Password: ")
+		password, err := term.ReadPassword(int(file.Fd()))
+		_, _ = fmt.Fprintln(output)
+		return string(password), err
+	}
+	line, err := bufio.NewReader(input).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
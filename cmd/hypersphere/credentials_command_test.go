@@ -0,0 +1,80 @@
+// Path: cmd/hypersphere/credentials_command_test.go
+// Description: Validate sealing profile passwords with the credentials set command.
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/takelley1/hypersphere/internal/profile"
+)
+
+func TestCredentialsSetSealsPasswordFromStdin(t *testing.T) {
+	key := base64.StdEncoding.EncodeToString(bytes.Repeat([]byte("k"), 32))
+	path := filepath.Join(t.TempDir(), "credentials.enc")
+	t.Setenv(credentialsEnvPath, path)
+	t.Setenv(credentialsKeyEnvName, key)
+	previous := credentialInput
+	defer func() { credentialInput = previous }()
+	credentialInput = strings.NewReader("s3cret\r\nignored\n")
+	stdout := &bytes.Buffer{}
+	if code := run([]string{"credentials", "set", "vc-lab"}, stdout, &bytes.Buffer{}); code != 0 ||
+		!strings.Contains(stdout.String(), "Stored password for vc-lab") {
+		t.Fatalf("expected password stored, got %d %q", code, stdout.String())
+	}
+	password, err := (profile.Credentials{Path: path, Key: key}).Password("vc-lab")
+	if err != nil || password != "s3cret" {
+		t.Fatalf("expected sealed password readable, got %q err=%v", password, err)
+	}
+	for _, input := range []string{"", "\n"} {
+		credentialInput = strings.NewReader(input)
+		stderr := &bytes.Buffer{}
+		if code := run([]string{"credentials", "set", "vc-lab"}, &bytes.Buffer{}, stderr); code != 1 || !strings.Contains(stderr.String(), "empty password") {
+			t.Fatalf("expected empty password rejected, got %d %q", code, stderr.String())
+		}
+	}
+	if err := runCredentialsCommand(iotest.ErrReader(errors.New("closed")), &bytes.Buffer{}, "vc-lab"); err == nil {
+		t.Fatalf("expected read failure reported")
+	}
+	t.Setenv(credentialsKeyEnvName, "")
+	if err := runCredentialsCommand(strings.NewReader("pw\n"), &bytes.Buffer{}, "vc-new"); !errors.Is(err, profile.ErrCredentialKey) {
+		t.Fatalf("expected missing key rejected, got %v", err)
+	}
+	t.Setenv(credentialsEnvPath, "")
+	t.Setenv("HOME", "")
+	if err := runCredentialsCommand(strings.NewReader("pw\n"), &bytes.Buffer{}, "vc-lab"); err == nil {
+		t.Fatalf("expected credential path failure without a home directory")
+	}
+}
+
+func TestReadCredentialPasswordReadsPipedFileWithoutPrompt(t *testing.T) {
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("create pipe: %v", err)
+	}
+	defer func() { _ = reader.Close() }()
+	_, _ = writer.WriteString("piped\n")
+	_ = writer.Close()
+	output := &bytes.Buffer{}
+	if password, err := readCredentialPassword(reader, output); err != nil || password != "piped" || output.Len() != 0 {
+		t.Fatalf("expected piped password read without a prompt, got %q %q err=%v", password, output.String(), err)
+	}
+}
+
+func TestParseFlagsReadsCredentialsCommand(t *testing.T) {
+	flags, err := parseFlags([]string{"credentials", "set", " vc-lab "})
+	if err != nil || flags.command != "credentials" || flags.credentialName != "vc-lab" || flags.planFile != "" {
+		t.Fatalf("expected credentials set vc-lab, got %+v err=%v", flags, err)
+	}
+	for _, args := range [][]string{{"credentials"}, {"credentials", "get", "vc-lab"}, {"credentials", "set", " "}, {"credentials", "set", "a", "b"}} {
+		if _, err := parseFlags(args); err == nil {
+			t.Fatalf("expected %v rejected", args)
+		}
+	}
+}
@@ -0,0 +1,30 @@
+// Path: cmd/hypersphere/deletion_exemptions.go
+// Description: Parse repeatable flags naming the VMs exempt from the deletion lifecycle.
+package main
+
+import (
+	"errors"
+	"flag"
+	"strings"
+)
+
+type listFlag []string
+
+func (l *listFlag) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *listFlag) Set(value string) error {
+	trimmed := strings.TrimSpace(value)
+	if trimmed == "" {
+		return errors.New("empty value")
+	}
+	*l = append(*l, trimmed)
+	return nil
+}
+
+func listFlagVar(flagSet *flag.FlagSet, name string, usage string) *listFlag {
+	value := &listFlag{}
+	flagSet.Var(value, name, usage)
+	return value
+}
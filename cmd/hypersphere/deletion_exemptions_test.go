@@ -0,0 +1,67 @@
+// Path: cmd/hypersphere/deletion_exemptions_test.go
+// Description: Validate deletion exemption flags and skipped VMs in the deletion workflow.
+package main
+
+import (
+	"bytes"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/takelley1/hypersphere/internal/deletion"
+	"github.com/takelley1/hypersphere/internal/inventory"
+)
+
+func TestParseFlagsReadsDeletionExemptions(t *testing.T) {
+	flags, err := parseFlags([]string{"--exempt-tag", "dr-standby", "--exempt-tag", " golden ", "--exempt-folder", "TEMPLATES/*", "--exempt-name", "^gold-"})
+	if err != nil {
+		t.Fatalf("expected exemption flags to parse, got %v", err)
+	}
+	exemptions := flags.exemptions
+	if len(exemptions.Tags) != 2 || exemptions.Tags[1] != "golden" || exemptions.FolderGlobs[0] != "TEMPLATES/*" ||
+		exemptions.NamePatterns[0].String() != "^gold-" {
+		t.Fatalf("expected exemptions from flags, got %+v", exemptions)
+	}
+	if _, err := parseFlags([]string{"--exempt-name", "("}); !errors.Is(err, deletion.ErrInvalidExemption) {
+		t.Fatalf("expected bad name pattern rejected, got %v", err)
+	}
+	if _, err := parseFlags([]string{"--exempt-file", filepath.Join(t.TempDir(), "missing")}); !errors.Is(err, deletion.ErrInvalidExemption) {
+		t.Fatalf("expected missing allowlist rejected, got %v", err)
+	}
+	if _, err := parseFlags([]string{"--exempt-tag", " "}); err == nil {
+		t.Fatalf("expected empty exemption rejected")
+	}
+}
+
+func TestCheckExemptionTagsNeedsProviderTags(t *testing.T) {
+	tagged := deletion.Exemptions{Tags: []string{"dr-standby"}}
+	if err := checkExemptionTags(deletion.Exemptions{}, taglessProvider{}); err != nil {
+		t.Fatalf("expected no exemption tags to pass, got %v", err)
+	}
+	if err := checkExemptionTags(tagged, inventory.NewDemoProvider()); err != nil {
+		t.Fatalf("expected demo tags to allow --exempt-tag, got %v", err)
+	}
+	if err := checkExemptionTags(tagged, taglessProvider{}); err == nil || !strings.Contains(err.Error(), "--exempt-tag cannot match") {
+		t.Fatalf("expected --exempt-tag rejected without tag data, got %v", err)
+	}
+}
+
+func TestDeletionWorkflowSkipsExemptVMs(t *testing.T) {
+	statePath := filepath.Join(t.TempDir(), "deletion.json")
+	t.Setenv(deletionEnvPath, statePath)
+	t.Setenv(outboxEnvPath, filepath.Join(t.TempDir(), "outbox"))
+	t.Setenv(scheduleEnvPath, filepath.Join(t.TempDir(), "schedule.json"))
+	seedPoweredOff(t, statePath)
+	args := []string{"--workflow", "deletion", "--execute", "--exempt-name", "^vm-b$"}
+	stdout := &bytes.Buffer{}
+	if code := run(args, stdout, &bytes.Buffer{}); code != 0 ||
+		!strings.Contains(stdout.String(), "skip vm-b default exempt: name matches ^vm-b$") ||
+		!strings.Contains(stdout.String(), "Summary applied=0") {
+		t.Fatalf("expected exempt VM skipped, got %d %q", code, stdout.String())
+	}
+	stored, err := deletion.NewFileStore(statePath).Load()
+	if err != nil || len(stored["vm-b"]) != 1 || stored["vm-b"][deletion.FieldPendingSince] != "" {
+		t.Fatalf("expected no mark persisted for an exempt VM, got %v err=%v", stored, err)
+	}
+}
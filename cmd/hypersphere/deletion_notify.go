@@ -0,0 +1,29 @@
+// Path: cmd/hypersphere/deletion_notify.go
+// Description: Build the owner notifier for the deletion workflow, falling back to a local outbox without SMTP.
+package main
+
+import (
+	"os"
+
+	"github.com/takelley1/hypersphere/internal/deletion"
+)
+
+const (
+	outboxEnvPath       = "HYPERSPHERE_OUTBOX_DIR"
+	smtpUserEnvName     = "HYPERSPHERE_SMTP_USER"
+	smtpPasswordEnvName = "HYPERSPHERE_SMTP_PASSWORD"
+)
+
+func deletionNotifier(smtp deletion.SMTPConfig) (deletion.Notifier, error) {
+	templates := deletion.DefaultTemplates()
+	if smtp.Addr != "" {
+		smtp.Username = os.Getenv(smtpUserEnvName)
+		smtp.Password = os.Getenv(smtpPasswordEnvName)
+		return deletion.NewSMTPNotifier(smtp, templates), nil
+	}
+	dir, err := configFilePath(outboxEnvPath, "outbox")
+	if err != nil {
+		return nil, err
+	}
+	return deletion.NewOutboxNotifier(dir, smtp.From, templates), nil
+}
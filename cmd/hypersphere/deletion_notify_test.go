@@ -0,0 +1,26 @@
+// Path: cmd/hypersphere/deletion_notify_test.go
+// Description: Validate deletion notifier selection between SMTP and the local outbox.
+package main
+
+import (
+	"testing"
+
+	"github.com/takelley1/hypersphere/internal/deletion"
+)
+
+func TestDeletionNotifierSelectsSMTPOrOutbox(t *testing.T) {
+	t.Setenv(smtpUserEnvName, "relay")
+	notifier, err := deletionNotifier(deletion.SMTPConfig{Addr: "127.0.0.1:1", From: "ops@example.com"})
+	if _, ok := notifier.(deletion.SMTPNotifier); err != nil || !ok {
+		t.Fatalf("expected SMTP notifier, got %T err=%v", notifier, err)
+	}
+	t.Setenv("HOME", t.TempDir())
+	notifier, err = deletionNotifier(deletion.SMTPConfig{From: "ops@example.com"})
+	if _, ok := notifier.(deletion.OutboxNotifier); err != nil || !ok {
+		t.Fatalf("expected outbox notifier, got %T err=%v", notifier, err)
+	}
+	t.Setenv("HOME", "")
+	if _, err := deletionNotifier(deletion.SMTPConfig{}); err == nil {
+		t.Fatalf("expected outbox path failure without a home directory")
+	}
+}
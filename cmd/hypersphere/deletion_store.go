@@ -0,0 +1,101 @@
+// Path: cmd/hypersphere/deletion_store.go
+// Description: Select where the deletion workflow keeps lifecycle metadata, which policies it applies, and how it performs and audits side effects.
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/takelley1/hypersphere/internal/app"
+	"github.com/takelley1/hypersphere/internal/deletion"
+	"github.com/takelley1/hypersphere/internal/inventory"
+	"github.com/takelley1/hypersphere/internal/tui"
+	"github.com/takelley1/hypersphere/internal/vsphere"
+)
+
+const (
+	deletionEnvPath = "HYPERSPHERE_DELETION_STATE"
+	auditEnvPath    = "HYPERSPHERE_DELETION_AUDIT"
+	policiesEnvPath = "HYPERSPHERE_DELETION_POLICIES"
+)
+
+func withDeletionInventory(flags cliFlags, policies deletion.PolicySet, run func(vms []deletion.VM, store deletion.MetadataStore, executor deletion.Executor) error) error {
+	contexts, provider, err := openExplorerContexts(flags)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = releaseExplorerContexts(contexts, provider) }()
+	if err := checkExemptionTags(flags.exemptions, provider); err != nil {
+		return err
+	}
+	if err := checkPolicyTags(policies, provider); err != nil {
+		return err
+	}
+	store, err := newDeletionStore(provider)
+	if err != nil {
+		return err
+	}
+	catalog, err := tui.LoadCatalog(provider)
+	if err != nil {
+		return err
+	}
+	return run(app.DeletionInventory(catalog), store, newDeletionExecutor(provider))
+}
+
+func checkExemptionTags(exemptions deletion.Exemptions, provider tui.InventoryProvider) error {
+	if len(exemptions.Tags) == 0 {
+		return nil
+	}
+	return requireTags(provider, "--exempt-tag")
+}
+
+func checkPolicyTags(policies deletion.PolicySet, provider tui.InventoryProvider) error {
+	for _, rule := range policies.Rules {
+		if rule.Tag != "" {
+			return requireTags(provider, fmt.Sprintf("deletion policy %q tag rule", rule.Name))
+		}
+	}
+	return nil
+}
+
+func newDeletionStore(provider tui.InventoryProvider) (deletion.MetadataStore, error) {
+	switch typed := provider.(type) {
+	case *vsphere.Provider:
+		return vsphere.NewAttributeStore(typed), nil
+	case inventory.DemoProvider:
+		path, err := configFilePath(deletionEnvPath, "deletion")
+		if err != nil {
+			return nil, err
+		}
+		return deletion.NewFileStore(path), nil
+	default:
+		return nil, fmt.Errorf("deletion metadata requires a single endpoint context, got %T", provider)
+	}
+}
+
+func newDeletionExecutor(provider tui.InventoryProvider) deletion.Executor {
+	if typed, ok := provider.(*vsphere.Provider); ok {
+		return vsphere.NewLifecycleExecutor(typed)
+	}
+	return nil
+}
+
+func loadDeletionPolicies() (deletion.PolicySet, error) {
+	path, err := configFilePath(policiesEnvPath, "policies")
+	if err != nil {
+		return deletion.PolicySet{}, err
+	}
+	return deletion.LoadPolicies(path)
+}
+
+func openDeletionAudit() (*deletion.AuditLog, error) {
+	path, err := configFilePath(auditEnvPath, "audit")
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, err
+	}
+	return deletion.OpenAuditLog(path)
+}
@@ -0,0 +1,162 @@
+// Path: cmd/hypersphere/deletion_store_test.go
+// Description: Validate deletion metadata store, policy, executor, and audit log selection and state carried between workflow runs.
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/takelley1/hypersphere/internal/deletion"
+	"github.com/takelley1/hypersphere/internal/inventory"
+	"github.com/takelley1/hypersphere/internal/vsphere"
+)
+
+func TestNewDeletionStoreSelectsByProvider(t *testing.T) {
+	if store, err := newDeletionStore(vsphere.NewProvider(nil)); err != nil || store == nil {
+		t.Fatalf("expected custom attribute store for vsphere provider, got %v %v", store, err)
+	}
+	t.Setenv("HOME", t.TempDir())
+	if _, err := newDeletionStore(inventory.NewDemoProvider()); err != nil {
+		t.Fatalf("expected file store for demo provider, got %v", err)
+	}
+	aggregate := inventory.NewAggregateProvider([]inventory.Member{{Name: "vc-a", Provider: inventory.NewDemoProvider()}})
+	if _, err := newDeletionStore(aggregate); err == nil {
+		t.Fatalf("expected aggregate provider to be rejected")
+	}
+	t.Setenv("HOME", "")
+	if _, err := newDeletionStore(inventory.NewDemoProvider()); err == nil {
+		t.Fatalf("expected state path failure without a home directory")
+	}
+}
+
+func TestNewDeletionExecutorAndAuditLog(t *testing.T) {
+	if executor := newDeletionExecutor(vsphere.NewProvider(nil)); executor == nil {
+		t.Fatalf("expected vCenter executor for vsphere provider")
+	}
+	if executor := newDeletionExecutor(inventory.NewDemoProvider()); executor != nil {
+		t.Fatalf("expected no executor for demo provider, got %T", executor)
+	}
+	auditPath := filepath.Join(t.TempDir(), "logs", "audit.jsonl")
+	t.Setenv(auditEnvPath, auditPath)
+	audit, err := openDeletionAudit()
+	if err != nil {
+		t.Fatalf("openDeletionAudit returned error: %v", err)
+	}
+	if err := audit.Record(deletion.AuditEntry{Step: deletion.StepDestroy}); err != nil || audit.Close() != nil {
+		t.Fatalf("expected audit entry recorded, got %v", err)
+	}
+	if content, err := os.ReadFile(auditPath); err != nil || !strings.Contains(string(content), `"step":"destroy"`) {
+		t.Fatalf("expected audit log written, got %q err=%v", content, err)
+	}
+	blocked := filepath.Join(t.TempDir(), "file")
+	if err := os.WriteFile(blocked, nil, 0o600); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+	t.Setenv(auditEnvPath, filepath.Join(blocked, "audit.jsonl"))
+	if _, err := openDeletionAudit(); err == nil {
+		t.Fatalf("expected audit log under a file to fail")
+	}
+	t.Setenv(auditEnvPath, "")
+	t.Setenv("HOME", "")
+	if _, err := openDeletionAudit(); err == nil {
+		t.Fatalf("expected audit path failure without a home directory")
+	}
+}
+
+func TestParseFlagsReadsRenamePrefix(t *testing.T) {
+	flags, err := parseFlags([]string{"--rename-prefix", " PD_ "})
+	if err != nil || flags.renamePrefix != "PD_" {
+		t.Fatalf("expected trimmed rename prefix, got %q err=%v", flags.renamePrefix, err)
+	}
+	if defaults, _ := parseFlags(nil); defaults.renamePrefix != "" {
+		t.Fatalf("expected names kept by default, got %q", defaults.renamePrefix)
+	}
+}
+
+// seedPoweredOff record the demo VM vm-b as powered off since long ago, so
+// the deletion workflow marks it on its next run.
+func seedPoweredOff(t *testing.T, statePath string) {
+	t.Helper()
+	fields := map[string]string{deletion.FieldPoweredOffSince: "2020-01-01"}
+	if err := deletion.NewFileStore(statePath).Save("vm-b", fields); err != nil {
+		t.Fatalf("seed state: %v", err)
+	}
+}
+
+func TestDeletionWorkflowShowsResolvedPolicies(t *testing.T) {
+	policiesPath := filepath.Join(t.TempDir(), "policies.json")
+	statePath := filepath.Join(t.TempDir(), "deletion.json")
+	t.Setenv(policiesEnvPath, policiesPath)
+	t.Setenv(deletionEnvPath, statePath)
+	t.Setenv(scheduleEnvPath, filepath.Join(t.TempDir(), "schedule.json"))
+	seedPoweredOff(t, statePath)
+	rules := `{"rules": [{"name": "dev", "folder_prefix": "/Datacenters/dc-2/vm/Dev", "mark_after_days": 14, "purge_after_days": 7}]}`
+	if err := os.WriteFile(policiesPath, []byte(rules), 0o600); err != nil {
+		t.Fatalf("write policies: %v", err)
+	}
+	args := []string{"--workflow", "deletion", "--mode", "mark"}
+	stdout := &bytes.Buffer{}
+	if code := run(args, stdout, &bytes.Buffer{}); code != 0 ||
+		stdout.String() != "Pending Deletion Plan\nACTION VM POLICY NOTES\nmark vm-b dev \n" {
+		t.Fatalf("expected plan under the workloads policy, got %d %q", code, stdout.String())
+	}
+	if err := os.WriteFile(policiesPath, []byte(`{"rules": [{"name": "dev"}]}`), 0o600); err != nil {
+		t.Fatalf("write policies: %v", err)
+	}
+	stderr := &bytes.Buffer{}
+	if code := run(args, &bytes.Buffer{}, stderr); code != 1 || !strings.Contains(stderr.String(), "invalid deletion policy") {
+		t.Fatalf("expected malformed policies rejected, got %d %q", code, stderr.String())
+	}
+	t.Setenv(policiesEnvPath, "")
+	t.Setenv("HOME", "")
+	if _, err := loadDeletionPolicies(); err == nil {
+		t.Fatalf("expected policy path failure without a home directory")
+	}
+}
+
+func TestCheckPolicyTagsNeedsProviderTags(t *testing.T) {
+	folders := deletion.PolicySet{Rules: []deletion.PolicyRule{{Name: "dev", FolderPrefix: "DEV"}}}
+	tagged := deletion.PolicySet{Rules: append(folders.Rules, deletion.PolicyRule{Name: "prod", Tag: "prod"})}
+	if err := checkPolicyTags(folders, taglessProvider{}); err != nil {
+		t.Fatalf("expected policies without tag rules to pass, got %v", err)
+	}
+	if err := checkPolicyTags(tagged, inventory.NewDemoProvider()); err != nil {
+		t.Fatalf("expected demo tags to allow tag rules, got %v", err)
+	}
+	if err := checkPolicyTags(tagged, taglessProvider{}); err == nil || !strings.Contains(err.Error(), `deletion policy "prod" tag rule cannot match`) {
+		t.Fatalf("expected tag rules rejected without tag data, got %v", err)
+	}
+}
+
+func TestDeletionWorkflowCarriesMetadataBetweenRuns(t *testing.T) {
+	statePath := filepath.Join(t.TempDir(), "deletion.json")
+	t.Setenv(deletionEnvPath, statePath)
+	t.Setenv(outboxEnvPath, filepath.Join(t.TempDir(), "outbox"))
+	t.Setenv(scheduleEnvPath, filepath.Join(t.TempDir(), "schedule.json"))
+	seedPoweredOff(t, statePath)
+	args := []string{"--workflow", "deletion", "--mode", "all", "--execute"}
+	stdout := &bytes.Buffer{}
+	if code := run(args, stdout, &bytes.Buffer{}); code != 0 || !strings.Contains(stdout.String(), "mark vm-b") ||
+		strings.Contains(stdout.String(), "mark vm-f") {
+		t.Fatalf("expected first run to mark only the VM long powered off, got %d %q", code, stdout.String())
+	}
+	stored, err := deletion.NewFileStore(statePath).Load()
+	if err != nil || stored["vm-b"][deletion.FieldPendingSince] == "" || stored["vm-b"][deletion.FieldInitialNoticeSent] != "true" ||
+		stored["vm-f"][deletion.FieldPoweredOffSince] == "" || stored["vm-a"] != nil {
+		t.Fatalf("expected mark and powered-off dates persisted, got %v err=%v", stored, err)
+	}
+	stdout.Reset()
+	if code := run(args, stdout, &bytes.Buffer{}); code != 0 || !strings.Contains(stdout.String(), "reset vm-b") {
+		t.Fatalf("expected second run to see the stored mark, got %d %q", code, stdout.String())
+	}
+	if err := os.WriteFile(statePath, []byte("{"), 0o600); err != nil {
+		t.Fatalf("write state: %v", err)
+	}
+	stderr := &bytes.Buffer{}
+	if code := run(args, &bytes.Buffer{}, stderr); code != 1 || !strings.Contains(stderr.String(), "invalid deletion metadata") {
+		t.Fatalf("expected malformed state rejected, got %d %q", code, stderr.String())
+	}
+}
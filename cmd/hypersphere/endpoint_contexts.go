@@ -0,0 +1,205 @@
+// Path: cmd/hypersphere/endpoint_contexts.go
+// Description: Switch explorer contexts between vCenter endpoint profiles with real logins.
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/takelley1/hypersphere/internal/inventory"
+	"github.com/takelley1/hypersphere/internal/profile"
+	"github.com/takelley1/hypersphere/internal/tui"
+)
+
+const (
+	profilesEnvPath       = "HYPERSPHERE_CONTEXTS_FILE"
+	credentialsEnvPath    = "HYPERSPHERE_CREDENTIALS_FILE"
+	credentialsKeyEnvName = "HYPERSPHERE_CREDENTIALS_KEY"
+)
+
+type endpointDialer func(entry profile.Profile, password string) (tui.InventoryProvider, error)
+
+type runtimeProviderSource interface {
+	Provider() tui.InventoryProvider
+}
+
+type profileContextConnector struct {
+	profiles    profile.Set
+	credentials profile.Credentials
+	dial        endpointDialer
+	active      string
+	provider    tui.InventoryProvider
+}
+
+func openExplorerContexts(flags cliFlags) (runtimeContextManager, tui.InventoryProvider, error) {
+	if flags.provider == inventory.ProviderVSphere && flags.vcenter.URL == "" {
+		connector, err := loadProfileContextConnector(flags.context, dialProfile)
+		if err != nil {
+			return runtimeContextManager{}, nil, err
+		}
+		if connector != nil {
+			return runtimeContextManager{connector: connector}, connector.Provider(), nil
+		}
+	}
+	provider, err := inventory.Open(flags.provider, inventory.Options{Endpoint: flags.vcenter})
+	return newRuntimeContextManager(), provider, err
+}
+
+func releaseExplorerContexts(contexts runtimeContextManager, provider tui.InventoryProvider) error {
+	if closer, ok := contexts.connector.(io.Closer); ok {
+		return closer.Close()
+	}
+	if closer, ok := provider.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+func loadProfileContextConnector(initial string, dial endpointDialer) (*profileContextConnector, error) {
+	profilesPath, err := defaultProfilesPath()
+	if err != nil {
+		return nil, err
+	}
+	profiles, err := profile.Load(profilesPath)
+	if err != nil {
+		return nil, err
+	}
+	if len(profiles.Profiles) == 0 {
+		return nil, nil
+	}
+	credentialsPath, err := defaultCredentialsPath()
+	if err != nil {
+		return nil, err
+	}
+	connector := &profileContextConnector{
+		profiles: profiles,
+		credentials: profile.Credentials{
+			Getenv: os.Getenv,
+			Path:   credentialsPath,
+			Key:    os.Getenv(credentialsKeyEnvName),
+		},
+		dial: dial,
+	}
+	if strings.TrimSpace(initial) == "" {
+		initial = profiles.Initial()
+	}
+	if err := connector.Switch(initial); err != nil {
+		return nil, err
+	}
+	return connector, nil
+}
+
+func dialProfile(entry profile.Profile, password string) (tui.InventoryProvider, error) {
+	return inventory.Open(inventory.ProviderVSphere, inventory.Options{
+		Endpoint:   entry.Endpoint(password),
+		Datacenter: entry.Datacenter,
+	})
+}
+
+func defaultProfilesPath() (string, error) {
+	return configFilePath(profilesEnvPath, "contexts")
+}
+
+func defaultCredentialsPath() (string, error) {
+	return configFilePath(credentialsEnvPath, "credentials")
+}
+
+func configFilePath(envName string, key string) (string, error) {
+	if override := strings.TrimSpace(os.Getenv(envName)); override != "" {
+		return override, nil
+	}
+	paths, err := infoPaths()
+	if err != nil {
+		return "", err
+	}
+	return paths[key], nil
+}
+
+func (c *profileContextConnector) List() []string {
+	names := c.profiles.Names()
+	if len(names) > 1 {
+		names = append(names, aggregateContextName)
+	}
+	return names
+}
+
+func (c *profileContextConnector) Active() string {
+	return c.active
+}
+
+func (c *profileContextConnector) Provider() tui.InventoryProvider {
+	return c.provider
+}
+
+func (c *profileContextConnector) Switch(name string) error {
+	if name == aggregateContextName {
+		return c.switchAggregate()
+	}
+	entry, err := c.profiles.Find(name)
+	if err != nil {
+		return fmt.Errorf("unknown context: %s", name)
+	}
+	provider, err := c.login(entry)
+	if err != nil {
+		return err
+	}
+	_ = c.Close()
+	c.active = entry.Name
+	c.provider = provider
+	return nil
+}
+
+func (c *profileContextConnector) login(entry profile.Profile) (tui.InventoryProvider, error) {
+	password, err := c.credentials.Password(entry.Name)
+	if err != nil {
+		return nil, err
+	}
+	provider, err := c.dial(entry, password)
+	if err != nil {
+		return nil, fmt.Errorf("context %s login failed: %w", entry.Name, err)
+	}
+	return provider, nil
+}
+
+func (c *profileContextConnector) Close() error {
+	if closer, ok := c.provider.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+func (r *explorerRuntime) switchContext(name string) string {
+	resume := r.pauseInventoryRefresh()
+	defer resume()
+	if err := r.contexts.Switch(name); err != nil {
+		return statusFromError(err, "")
+	}
+	if err := r.activateContext(); err != nil {
+		return statusFromError(err, "")
+	}
+	return statusFromError(refreshActiveView(&r.session), fmt.Sprintf("context: %s", r.contexts.Active()))
+}
+
+func (r *explorerRuntime) activateContext() error {
+	r.applyEndpointOverlays()
+	source, ok := r.contexts.connector.(runtimeProviderSource)
+	if !ok {
+		return nil
+	}
+	r.provider = source.Provider()
+	r.actionExec.routeEndpoints(r.provider)
+	catalog, err := tui.LoadCatalog(r.provider)
+	if err != nil {
+		return err
+	}
+	return r.session.ApplyCatalogChanges(tui.DiffCatalog(r.session.Catalog(), catalog))
+}
+
+func (r *explorerRuntime) applyEndpointOverlays() {
+	overlays := loadEndpointOverlays(r.contexts.Endpoints()...)
+	r.aliasRegistry = overlays.aliases
+	r.pluginRegistry = overlays.plugins
+	r.session.SetHotkeyBindings(overlays.hotkeys)
+}
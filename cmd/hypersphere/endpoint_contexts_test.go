@@ -0,0 +1,236 @@
+// Path: cmd/hypersphere/endpoint_contexts_test.go
+// Description: Validate profile-backed context switching, credential lookup, and runtime catalog reloads.
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/takelley1/hypersphere/internal/inventory"
+	"github.com/takelley1/hypersphere/internal/profile"
+	"github.com/takelley1/hypersphere/internal/tui"
+)
+
+type closingProvider struct {
+	tui.InventoryProvider
+	closed int
+}
+
+func (p *closingProvider) Close() error {
+	p.closed++
+	return nil
+}
+
+type profileDialer struct {
+	mutex     sync.Mutex
+	providers map[string]*closingProvider
+	passwords []string
+}
+
+func (d *profileDialer) dial(entry profile.Profile, password string) (tui.InventoryProvider, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.passwords = append(d.passwords, entry.Name+"="+password)
+	provider, ok := d.providers[entry.Name]
+	if !ok {
+		return nil, errors.New("connection refused")
+	}
+	return provider, nil
+}
+
+func newProfileDialer() *profileDialer {
+	vms := func(name string) tui.InventoryProvider {
+		return &changingProvider{InventoryProvider: inventory.NewDemoProvider(), vms: [][]tui.VMRow{{{Name: name}}}}
+	}
+	return &profileDialer{providers: map[string]*closingProvider{
+		"vc-a": {InventoryProvider: vms("vm-on-a")},
+		"vc-b": {InventoryProvider: vms("vm-on-b")},
+		"vc-broken": {InventoryProvider: &changingProvider{
+			InventoryProvider: inventory.NewDemoProvider(), fail: true,
+		}},
+	}}
+}
+
+func writeProfiles(t *testing.T, content string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "contexts.json")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("write profiles: %v", err)
+	}
+	t.Setenv(profilesEnvPath, path)
+	t.Setenv(credentialsEnvPath, filepath.Join(t.TempDir(), "credentials.enc"))
+}
+
+const testProfiles = `{"profiles":[` +
+	`{"name":"vc-a","url":"vc-a.example.com"},{"name":"vc-b","url":"vc-b.example.com"},` +
+	`{"name":"vc-broken","url":"vc-broken.example.com"},{"name":"vc-down","url":"vc-down.example.com"},` +
+	`{"name":"vc-nopass","url":"vc-nopass.example.com"}]}`
+
+func setProfilePasswords(t *testing.T) {
+	t.Helper()
+	for _, name := range []string{"vc-a", "vc-b", "vc-broken", "vc-down"} {
+		t.Setenv(profile.PasswordEnvName(name), "pw-"+name)
+	}
+}
+
+func TestProfileContextConnectorLogsInBeforeLoggingOut(t *testing.T) {
+	writeProfiles(t, testProfiles)
+	setProfilePasswords(t)
+	dialer := newProfileDialer()
+	connector, err := loadProfileContextConnector("", dialer.dial)
+	if err != nil {
+		t.Fatalf("loadProfileContextConnector returned error: %v", err)
+	}
+	if connector.Active() != "vc-a" || connector.Provider() != dialer.providers["vc-a"] || len(connector.List()) != 6 {
+		t.Fatalf("expected first profile to be connected, got active=%s list=%v", connector.Active(), connector.List())
+	}
+	if err := connector.Switch("vc-b"); err != nil {
+		t.Fatalf("Switch returned error: %v", err)
+	}
+	if connector.Active() != "vc-b" || dialer.providers["vc-a"].closed != 1 {
+		t.Fatalf("expected vc-a to be logged out after switching to vc-b")
+	}
+	if strings.Join(dialer.passwords, ",") != "vc-a=pw-vc-a,vc-b=pw-vc-b" {
+		t.Fatalf("expected env passwords to be used for login, got %v", dialer.passwords)
+	}
+	failures := map[string]string{
+		"vc-missing": "unknown context: vc-missing",
+		"vc-down":    "context vc-down login failed: connection refused",
+		"vc-nopass":  "endpoint credential not found",
+	}
+	for name, want := range failures {
+		if err := connector.Switch(name); err == nil || !strings.Contains(err.Error(), want) {
+			t.Fatalf("expected %q switching to %s, got %v", want, name, err)
+		}
+	}
+	if connector.Active() != "vc-b" || dialer.providers["vc-b"].closed != 0 {
+		t.Fatalf("expected failed switches to keep the vc-b session, got %s", connector.Active())
+	}
+	if err := connector.Close(); err != nil || dialer.providers["vc-b"].closed != 1 {
+		t.Fatalf("expected Close to log out of vc-b, err=%v", err)
+	}
+}
+
+func TestLoadProfileContextConnectorReadsSealedCredentials(t *testing.T) {
+	writeProfiles(t, `{"default":"vc-b","profiles":[{"name":"vc-a","url":"a"},{"name":"vc-b","url":"b"}]}`)
+	key := "c2VjcmV0LWtleS1mb3ItdGVzdGluZy0zMi1ieXRlcyE="
+	sealed, err := profile.Seal(map[string]string{"vc-a": "sealed-a", "vc-b": "sealed-b"}, key)
+	if err != nil {
+		t.Fatalf("Seal returned error: %v", err)
+	}
+	if err := os.WriteFile(os.Getenv(credentialsEnvPath), sealed, 0o600); err != nil {
+		t.Fatalf("write credentials: %v", err)
+	}
+	t.Setenv(credentialsKeyEnvName, key)
+	dialer := newProfileDialer()
+	connector, err := loadProfileContextConnector("", dialer.dial)
+	if err != nil || connector.Active() != "vc-b" {
+		t.Fatalf("expected default profile vc-b, got %+v err=%v", connector, err)
+	}
+	if _, err := loadProfileContextConnector("vc-a", dialer.dial); err != nil {
+		t.Fatalf("expected explicit initial profile to connect, got %v", err)
+	}
+	if strings.Join(dialer.passwords, ",") != "vc-b=sealed-b,vc-a=sealed-a" {
+		t.Fatalf("expected sealed passwords to be used for login, got %v", dialer.passwords)
+	}
+	if _, err := loadProfileContextConnector("vc-z", dialer.dial); err == nil {
+		t.Fatalf("expected unknown initial profile to fail")
+	}
+}
+
+func TestLoadProfileContextConnectorWithoutProfiles(t *testing.T) {
+	writeProfiles(t, "")
+	if connector, err := loadProfileContextConnector("", newProfileDialer().dial); connector != nil || err != nil {
+		t.Fatalf("expected no connector without profiles, got %+v err=%v", connector, err)
+	}
+	writeProfiles(t, `{"profiles":[{"name":"vc-a"}]}`)
+	if _, err := loadProfileContextConnector("", newProfileDialer().dial); !errors.Is(err, profile.ErrInvalidProfile) {
+		t.Fatalf("expected invalid profile error, got %v", err)
+	}
+}
+
+func TestOpenExplorerContextsSelectsConnectorByProvider(t *testing.T) {
+	writeProfiles(t, `{"profiles":[{"name":"vc-a","url":"http://127.0.0.1:1"}]}`)
+	t.Setenv(profile.PasswordEnvName("vc-a"), "secret")
+	contexts, provider, err := openExplorerContexts(cliFlags{provider: inventory.ProviderDemo})
+	if err != nil || contexts.Active() != "vc-primary" {
+		t.Fatalf("expected demo provider to keep in-memory contexts, got %s err=%v", contexts.Active(), err)
+	}
+	if err := releaseExplorerContexts(contexts, provider); err != nil {
+		t.Fatalf("expected demo release to be a no-op, got %v", err)
+	}
+	if _, _, err := openExplorerContexts(cliFlags{provider: inventory.ProviderVSphere}); err == nil ||
+		!strings.Contains(err.Error(), "context vc-a login failed") {
+		t.Fatalf("expected profile login failure, got %v", err)
+	}
+	writeProfiles(t, "")
+	if _, _, err := openExplorerContexts(cliFlags{provider: inventory.ProviderVSphere}); err == nil {
+		t.Fatalf("expected vsphere provider without profiles or --vcenter to fail")
+	}
+	closer := &closingProvider{InventoryProvider: inventory.NewDemoProvider()}
+	if err := releaseExplorerContexts(newRuntimeContextManager(), closer); err != nil || closer.closed != 1 {
+		t.Fatalf("expected static provider to be closed on release, got %d err=%v", closer.closed, err)
+	}
+}
+
+func TestRuntimeSwitchContextReloadsCatalogOverlaysAndRefresh(t *testing.T) {
+	writeProfiles(t, testProfiles)
+	setProfilePasswords(t)
+	aliasPath := filepath.Join(t.TempDir(), "aliases.yaml")
+	if err := os.WriteFile(strings.TrimSuffix(aliasPath, ".yaml")+".vc-b.yaml", []byte("go-view: :host\n"), 0o600); err != nil {
+		t.Fatalf("write alias overlay: %v", err)
+	}
+	t.Setenv(aliasRegistryEnvPath, aliasPath)
+	dialer := newProfileDialer()
+	connector, err := loadProfileContextConnector("", dialer.dial)
+	if err != nil {
+		t.Fatalf("loadProfileContextConnector returned error: %v", err)
+	}
+	runtime := newExplorerRuntimeWithContexts(
+		connector.Provider(), runtimeContextManager{connector: connector}, false, "", false, false,
+	)
+	runtime.refreshEvery = time.Hour
+	runtime.watchInventory()
+	t.Cleanup(runtime.stopInventoryRefresh)
+	epoch := runtime.refreshEpoch
+	message, handled := runtime.handleLocalPromptCommand(":ctx vc-b")
+	if !handled || message != "context: vc-b" {
+		t.Fatalf("expected context switch status, got %q handled=%v", message, handled)
+	}
+	if vms := runtime.session.Catalog().VMs; len(vms) != 1 || vms[0].Name != "vm-on-b" {
+		t.Fatalf("expected catalog to be rebuilt from vc-b, got %+v", vms)
+	}
+	if runtime.aliasRegistry.Resolve(":go-view") != ":host" {
+		t.Fatalf("expected vc-b alias overlay to be loaded")
+	}
+	if runtime.provider != dialer.providers["vc-b"] || runtime.stopRefresh == nil || runtime.refreshEpoch != epoch+1 {
+		t.Fatalf("expected refresh to restart against the vc-b provider")
+	}
+	message, _ = runtime.handleLocalPromptCommand(":ctx vc-missing")
+	if message != "[red]command error: unknown context: vc-missing" {
+		t.Fatalf("expected unknown context error, got %q", message)
+	}
+	message, _ = runtime.handleLocalPromptCommand(":ctx vc-broken")
+	if message != "[red]command error: list vms failed" || runtime.session.Catalog().VMs[0].Name != "vm-on-b" {
+		t.Fatalf("expected catalog load failure to keep the previous rows, got %q", message)
+	}
+	if _, handled := runtime.handleLocalPromptCommand(":ctx"); handled {
+		t.Fatalf("expected bare :ctx to fall through to the context list command")
+	}
+}
+
+func TestRuntimeSwitchContextWithoutProviderKeepsCatalog(t *testing.T) {
+	runtime := newExplorerRuntime()
+	before := len(runtime.session.Catalog().VMs)
+	if message := runtime.switchContext("vc-lab"); message != "context: vc-lab" {
+		t.Fatalf("expected in-memory context switch, got %q", message)
+	}
+	if len(runtime.session.Catalog().VMs) != before || runtime.stopRefresh != nil {
+		t.Fatalf("expected in-memory switch to keep the demo catalog without starting refresh")
+	}
+}
@@ -9,9 +9,11 @@ import (
 	"io"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
+	"github.com/takelley1/hypersphere/internal/inventory"
 	"github.com/takelley1/hypersphere/internal/tui"
 )
 
@@ -77,11 +79,16 @@ type explorerRuntime struct {
 	logEntries     []runtimeLogEntry
 	aliasRegistry  commandAliasRegistry
 	pluginRegistry pluginRegistry
+	provider       tui.InventoryProvider
+	refreshEvery   time.Duration
+	stopRefresh    func()
+	refreshEpoch   int
 }
 
 type runtimeActionExecutor struct {
-	last    string
-	vmPower runtimeVMPowerClient
+	last      string
+	vmPower   runtimeVMPowerClient
+	endpoints map[string]*runtimeActionExecutor
 }
 
 type runtimeLogEntry struct {
@@ -141,6 +148,9 @@ type skinPalette struct {
 }
 
 func (r *runtimeActionExecutor) Execute(resource tui.Resource, action string, ids []string) error {
+	if len(r.endpoints) > 0 {
+		return r.executeRouted(resource, action, ids)
+	}
 	if resource == tui.ResourceVM {
 		return r.executeVMAction(action, ids)
 	}
@@ -235,6 +245,13 @@ func (m runtimeContextManager) Switch(name string) error {
 	return m.connector.Switch(name)
 }
 
+func (m runtimeContextManager) Endpoints() []string {
+	if source, ok := m.connector.(runtimeEndpointSource); ok {
+		return source.Endpoints()
+	}
+	return []string{m.Active()}
+}
+
 func (c *inMemoryContextConnector) List() []string {
 	values := append([]string{}, c.endpoints...)
 	return values
@@ -256,12 +273,18 @@ func (c *inMemoryContextConnector) Switch(name string) error {
 
 func runExplorerWorkflow(
 	output io.Writer,
+	provider tui.InventoryProvider,
+	contexts runtimeContextManager,
 	readOnly bool,
 	startupCommand string,
 	headless bool,
 	crumbsless bool,
+	refresh time.Duration,
 ) {
-	runtime := newExplorerRuntimeWithRenderOptions(readOnly, startupCommand, headless, crumbsless)
+	runtime := newExplorerRuntimeWithContexts(provider, contexts, readOnly, startupCommand, headless, crumbsless)
+	runtime.refreshEvery = refresh
+	runtime.watchInventory()
+	defer runtime.stopInventoryRefresh()
 	if err := runtime.run(); err != nil {
 		_, _ = fmt.Fprintf(output, "tui error: %v\n", err)
 	}
@@ -293,12 +316,50 @@ func newExplorerRuntimeWithRenderOptions(
 	headless bool,
 	crumbsless bool,
 ) explorerRuntime {
-	runtime := explorerRuntime{
+	return newExplorerRuntimeWithProvider(
+		inventory.NewDemoProvider(),
+		readOnly,
+		startupCommand,
+		headless,
+		crumbsless,
+	)
+}
+
+func newExplorerRuntimeWithProvider(
+	provider tui.InventoryProvider,
+	readOnly bool,
+	startupCommand string,
+	headless bool,
+	crumbsless bool,
+) explorerRuntime {
+	return *newExplorerRuntimeWithContexts(
+		provider,
+		newRuntimeContextManager(),
+		readOnly,
+		startupCommand,
+		headless,
+		crumbsless,
+	)
+}
+
+func newExplorerRuntimeWithContexts(
+	provider tui.InventoryProvider,
+	contexts runtimeContextManager,
+	readOnly bool,
+	startupCommand string,
+	headless bool,
+	crumbsless bool,
+) *explorerRuntime {
+	session, loadErr := tui.NewSessionFromProvider(provider)
+	if loadErr != nil {
+		session = tui.NewSession(tui.Catalog{})
+	}
+	runtime := &explorerRuntime{
 		app:            tview.NewApplication(),
-		session:        tui.NewSession(defaultCatalog()),
+		session:        session,
 		promptState:    tui.NewPromptState(defaultPromptHistorySize),
 		actionExec:     &runtimeActionExecutor{},
-		contexts:       newRuntimeContextManager(),
+		contexts:       contexts,
 		headless:       headless,
 		crumbsless:     crumbsless,
 		theme:          readTheme(),
@@ -316,14 +377,15 @@ func newExplorerRuntimeWithRenderOptions(
 		logEntries:     defaultRuntimeLogEntries(),
 		aliasRegistry:  commandAliasRegistry{aliases: map[string]string{}},
 		pluginRegistry: pluginRegistry{entries: []pluginEntry{}},
+		provider:       provider,
 	}
-	activeEndpoint := runtime.contexts.Active()
-	overlays := loadEndpointOverlays(activeEndpoint)
-	runtime.aliasRegistry = overlays.aliases
-	runtime.pluginRegistry = overlays.plugins
-	runtime.session.SetHotkeyBindings(overlays.hotkeys)
+	runtime.applyEndpointOverlays()
+	runtime.actionExec.routeEndpoints(provider)
 	runtime.session.SetReadOnly(readOnly)
 	message := startupCommandStatus(&runtime.session, startupCommand)
+	if loadErr != nil {
+		message = statusFromError(loadErr, message)
+	}
 	runtime.configureWidgets()
 	runtime.configureHandlers()
 	runtime.render(message)
@@ -682,6 +744,11 @@ func (r *explorerRuntime) handleLocalPromptCommand(line string) (string, bool) {
 		return fmt.Sprintf("view: %s", r.session.CurrentView().Resource), true
 	case ":cols", ":columns":
 		return handleColumnsPromptCommand(&r.session, fields, line), true
+	case ":ctx":
+		if len(fields) != 2 {
+			return "", false
+		}
+		return r.switchContext(fields[1]), true
 	default:
 		return "", false
 	}
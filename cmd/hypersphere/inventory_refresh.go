@@ -0,0 +1,149 @@
+// Path: cmd/hypersphere/inventory_refresh.go
+// Description: Stream inventory changes into the running explorer session at the refresh interval.
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/takelley1/hypersphere/internal/tui"
+)
+
+type inventoryWatcher interface {
+	WatchChanges(ctx context.Context, maxWait time.Duration) ([]tui.CatalogChange, error)
+}
+
+type pollingInventoryWatcher struct {
+	provider tui.InventoryProvider
+	previous tui.Catalog
+	loaded   bool
+}
+
+func newInventoryWatcher(provider tui.InventoryProvider) inventoryWatcher {
+	if watcher, ok := provider.(inventoryWatcher); ok {
+		return watcher
+	}
+	return &pollingInventoryWatcher{provider: provider}
+}
+
+// WatchChanges re-list the provider after maxWait and diff it against the previous listing.
+func (w *pollingInventoryWatcher) WatchChanges(
+	ctx context.Context,
+	maxWait time.Duration,
+) ([]tui.CatalogChange, error) {
+	if !w.loaded {
+		catalog, err := tui.LoadCatalog(w.provider)
+		if err != nil {
+			return nil, err
+		}
+		w.previous = catalog
+		w.loaded = true
+	}
+	if !sleepContext(ctx, maxWait) {
+		return nil, ctx.Err()
+	}
+	next, err := tui.LoadCatalog(w.provider)
+	if err != nil {
+		return nil, err
+	}
+	changes := tui.DiffCatalog(w.previous, next)
+	w.previous = next
+	return changes, nil
+}
+
+func refreshInterval(refreshSeconds float64) time.Duration {
+	return time.Duration(refreshSeconds * float64(time.Second))
+}
+
+func (r *explorerRuntime) startInventoryRefresh(watcher inventoryWatcher, interval time.Duration) func() {
+	r.refreshEpoch++
+	epoch := r.refreshEpoch
+	ctx, cancel := context.WithCancel(context.Background())
+	go runInventoryRefresh(
+		ctx,
+		watcher,
+		interval,
+		func(changes []tui.CatalogChange) {
+			r.app.QueueUpdateDraw(func() {
+				if epoch == r.refreshEpoch {
+					r.applyInventoryChanges(changes)
+				}
+			})
+		},
+		func(err error) {
+			r.app.QueueUpdateDraw(func() {
+				if epoch == r.refreshEpoch {
+					r.emitStatus(fmt.Errorf("inventory refresh failed: %w", err))
+				}
+			})
+		},
+	)
+	return cancel
+}
+
+func (r *explorerRuntime) watchInventory() {
+	r.stopInventoryRefresh()
+	r.stopRefresh = r.startInventoryRefresh(newInventoryWatcher(r.provider), r.refreshEvery)
+}
+
+func (r *explorerRuntime) stopInventoryRefresh() {
+	if r.stopRefresh != nil {
+		r.stopRefresh()
+		r.stopRefresh = nil
+	}
+}
+
+func (r *explorerRuntime) pauseInventoryRefresh() func() {
+	if r.stopRefresh == nil {
+		return func() {}
+	}
+	r.stopInventoryRefresh()
+	return r.watchInventory
+}
+
+func (r *explorerRuntime) applyInventoryChanges(changes []tui.CatalogChange) {
+	if err := r.session.ApplyCatalogChanges(changes); err != nil {
+		r.emitStatus(err)
+		return
+	}
+	r.render("")
+}
+
+func runInventoryRefresh(
+	ctx context.Context,
+	watcher inventoryWatcher,
+	interval time.Duration,
+	apply func([]tui.CatalogChange),
+	report func(error),
+) {
+	for {
+		started := time.Now()
+		changes, err := watcher.WatchChanges(ctx, interval)
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			report(err)
+		} else if len(changes) > 0 {
+			apply(changes)
+		}
+		if !sleepContext(ctx, interval-time.Since(started)) {
+			return
+		}
+	}
+}
+
+func sleepContext(ctx context.Context, duration time.Duration) bool {
+	if duration <= 0 {
+		return ctx.Err() == nil
+	}
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
@@ -0,0 +1,142 @@
+// Path: cmd/hypersphere/inventory_refresh_test.go
+// Description: Validate inventory refresh loops, polling fallback, and in-place session updates.
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/takelley1/hypersphere/internal/inventory"
+	"github.com/takelley1/hypersphere/internal/tui"
+)
+
+type changingProvider struct {
+	tui.InventoryProvider
+	vms  [][]tui.VMRow
+	fail bool
+}
+
+func (p *changingProvider) ListVMs() ([]tui.VMRow, error) {
+	if p.fail {
+		return nil, errors.New("list vms failed")
+	}
+	rows := p.vms[0]
+	if len(p.vms) > 1 {
+		p.vms = p.vms[1:]
+	}
+	return rows, nil
+}
+
+type scriptedWatcher struct {
+	results []error
+	calls   int
+	cancel  context.CancelFunc
+}
+
+func (w *scriptedWatcher) WatchChanges(_ context.Context, _ time.Duration) ([]tui.CatalogChange, error) {
+	w.calls++
+	if w.calls == len(w.results) {
+		w.cancel()
+	}
+	err := w.results[w.calls-1]
+	if err != nil {
+		return nil, err
+	}
+	row := tui.VMRow{Name: "vm-watched"}
+	return []tui.CatalogChange{{Op: tui.CatalogUpsert, Resource: tui.ResourceVM, ID: row.Name, Row: row}}, nil
+}
+
+func TestPollingInventoryWatcherDiffsSuccessiveListings(t *testing.T) {
+	provider := &changingProvider{
+		InventoryProvider: inventory.NewDemoProvider(),
+		vms:               [][]tui.VMRow{{{Name: "vm-a"}}, {{Name: "vm-a", PowerState: "off"}}},
+	}
+	watcher := newInventoryWatcher(provider)
+	changes, err := watcher.WatchChanges(t.Context(), 0)
+	if err != nil {
+		t.Fatalf("WatchChanges returned error: %v", err)
+	}
+	if len(changes) != 1 || changes[0].ID != "vm-a" || changes[0].Row.(tui.VMRow).PowerState != "off" {
+		t.Fatalf("expected vm-a power change, got %+v", changes)
+	}
+	changes, err = watcher.WatchChanges(t.Context(), 0)
+	if err != nil || len(changes) != 0 {
+		t.Fatalf("expected unchanged listing to produce no changes, got %+v err=%v", changes, err)
+	}
+	provider.fail = true
+	if _, err := watcher.WatchChanges(t.Context(), 0); err == nil {
+		t.Fatalf("expected relisting failure")
+	}
+	if _, err := newInventoryWatcher(provider).WatchChanges(t.Context(), 0); err == nil {
+		t.Fatalf("expected baseline listing failure")
+	}
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+	provider.fail = false
+	if _, err := newInventoryWatcher(provider).WatchChanges(ctx, time.Hour); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected canceled wait, got %v", err)
+	}
+}
+
+func TestNewInventoryWatcherPrefersProviderWatch(t *testing.T) {
+	watcher := &scriptedWatcher{}
+	provider := struct {
+		tui.InventoryProvider
+		inventoryWatcher
+	}{inventory.NewDemoProvider(), watcher}
+	if got := newInventoryWatcher(provider); got != inventoryWatcher(provider) {
+		t.Fatalf("expected provider watch support to be used directly, got %T", got)
+	}
+}
+
+func TestRunInventoryRefreshAppliesChangesAndReportsErrors(t *testing.T) {
+	ctx, cancel := context.WithCancel(t.Context())
+	watcher := &scriptedWatcher{results: []error{nil, errors.New("session expired"), nil}, cancel: cancel}
+	applied := 0
+	reported := []error{}
+	runInventoryRefresh(ctx, watcher, time.Millisecond, func(changes []tui.CatalogChange) {
+		applied += len(changes)
+	}, func(err error) {
+		reported = append(reported, err)
+	})
+	if watcher.calls != 3 || applied != 1 || len(reported) != 1 {
+		t.Fatalf("expected loop to stop on cancel after one apply and one error, calls=%d applied=%d errors=%v",
+			watcher.calls, applied, reported)
+	}
+	ctx, cancel = context.WithCancel(t.Context())
+	watcher = &scriptedWatcher{results: []error{nil, nil}, cancel: func() {}}
+	runInventoryRefresh(ctx, watcher, time.Hour, func([]tui.CatalogChange) { cancel() }, func(error) {})
+	if watcher.calls != 1 {
+		t.Fatalf("expected cancellation during the interval wait to stop the loop, got %d calls", watcher.calls)
+	}
+}
+
+func TestApplyInventoryChangesKeepsSelectionInPlace(t *testing.T) {
+	runtime := newExplorerRuntime()
+	view := runtime.session.CurrentView()
+	if len(view.IDs) < 2 {
+		t.Fatalf("expected default catalog to contain multiple VMs")
+	}
+	selected := view.IDs[1]
+	runtime.session.SetSelection(1, 0)
+	row := tui.VMRow{Name: "aaa-new", PowerState: "on"}
+	runtime.applyInventoryChanges([]tui.CatalogChange{{Op: tui.CatalogUpsert, Resource: tui.ResourceVM, ID: row.Name, Row: row}})
+	view = runtime.session.CurrentView()
+	if view.IDs[runtime.session.SelectedRow()] != selected || view.IDs[len(view.IDs)-1] != "aaa-new" {
+		t.Fatalf("expected selection to stay on %s after refresh, got %v row=%d", selected, view.IDs, runtime.session.SelectedRow())
+	}
+	runtime.applyInventoryChanges([]tui.CatalogChange{{Op: "merge", Resource: tui.ResourceVM}})
+	if runtime.status.GetText(true) == "" {
+		t.Fatalf("expected invalid change to surface in the status bar")
+	}
+	stop := runtime.startInventoryRefresh(&scriptedWatcher{results: []error{errors.New("offline")}, cancel: func() {}}, time.Hour)
+	stop()
+}
+
+func TestRefreshIntervalConvertsSeconds(t *testing.T) {
+	if got := refreshInterval(2.5); got != 2500*time.Millisecond {
+		t.Fatalf("expected 2.5s interval, got %s", got)
+	}
+}
@@ -3,6 +3,7 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"io"
@@ -15,20 +16,38 @@ import (
 	"github.com/takelley1/hypersphere/internal/app"
 	"github.com/takelley1/hypersphere/internal/config"
 	"github.com/takelley1/hypersphere/internal/deletion"
+	"github.com/takelley1/hypersphere/internal/inventory"
 	"github.com/takelley1/hypersphere/internal/migration"
 	"github.com/takelley1/hypersphere/internal/tui"
+	"github.com/takelley1/hypersphere/internal/vsphere"
 )
 
 type cliFlags struct {
 	command        string
+	planFile       string
+	credentialName string
 	startupCommand string
 	headless       bool
 	crumbsless     bool
 	workflow       string
+	provider       string
+	vcenter        vsphere.Endpoint
+	context        string
 	mode           string
 	execute        bool
 	readOnly       bool
 	threshold      int
+	filter         migration.Filter
+	limits         migration.Limits
+	throttle       migration.Throttle
+	resultsFile    string
+	retry          retrySettings
+	maxSnapshots   int
+	smtp           deletion.SMTPConfig
+	renamePrefix   string
+	exemptions     deletion.Exemptions
+	strategy       migration.Strategy
+	evacuateHost   string
 	refreshSeconds float64
 	logLevel       logLevel
 	logFile        string
@@ -36,6 +55,13 @@ type cliFlags struct {
 
 type logLevel string
 
+const (
+	providerEnvName        = "HYPERSPHERE_PROVIDER"
+	vcenterURLEnvName      = "HYPERSPHERE_VCENTER_URL"
+	vcenterUserEnvName     = "HYPERSPHERE_VCENTER_USER"
+	vcenterPasswordEnvName = "HYPERSPHERE_VCENTER_PASSWORD"
+)
+
 const (
 	logLevelDebug logLevel = "debug"
 	logLevelInfo  logLevel = "info"
@@ -56,11 +82,42 @@ type startupFlagValues struct {
 	headless       *bool
 	crumbsless     *bool
 	workflow       *string
+	provider       *string
+	vcenterURL     *string
+	vcenterUser    *string
+	insecure       *bool
+	context        *string
 	mode           *string
 	execute        *bool
 	readOnly       *bool
 	write          *bool
 	threshold      *int
+	out            *string
+	sourceDS       *string
+	cluster        *string
+	folder         *string
+	tag            *string
+	concurrency    *int
+	perSource      *int
+	perTarget      *int
+	perHost        *int
+	maxLatency     *int
+	latencyBackoff *time.Duration
+	latencyMaxWait *time.Duration
+	results        *string
+	retries        *int
+	retryBackoff   *time.Duration
+	retryTimeout   *time.Duration
+	maxSnapshots   *int
+	smtpAddr       *string
+	smtpFrom       *string
+	renamePrefix   *string
+	exemptTags     *listFlag
+	exemptFolders  *listFlag
+	exemptNames    *listFlag
+	exemptFile     *string
+	strategy       *string
+	evacuateHost   *string
 	refresh        *float64
 	level          *string
 	logFile        *string
@@ -91,21 +148,63 @@ func run(args []string, output io.Writer, errOutput io.Writer) int {
 		}
 		return 0
 	}
-	cfg := config.Config{Mode: flags.mode, Execute: flags.execute, ThresholdPercent: flags.threshold}
+	if flags.command == "credentials" {
+		if err := runCredentialsCommand(credentialInput, output, flags.credentialName); err != nil {
+			_, _ = fmt.Fprintf(errOutput, "credentials command failed: %v\n", err)
+			return 1
+		}
+		return 0
+	}
+	cfg := config.Config{
+		Mode:             flags.mode,
+		Execute:          flags.execute,
+		ThresholdPercent: flags.threshold,
+		Provider:         flags.provider,
+		Retries:          flags.retry.count,
+		RetryBackoff:     flags.retry.backoff,
+		RetryTimeout:     flags.retry.timeout,
+	}
 	application := app.New(output)
+	if flags.command == "plan" || flags.command == "apply" || flags.command == "rollback" {
+		if err := runPlanFileCommand(application, cfg, flags); err != nil {
+			_, _ = fmt.Fprintf(errOutput, "%s command failed: %v\n", flags.command, err)
+			return 1
+		}
+		return 0
+	}
 	switch flags.workflow {
 	case "deletion":
-		runDeletionWorkflow(application, cfg)
+		if err := runDeletionWorkflow(application, cfg, flags); err != nil {
+			_, _ = fmt.Fprintf(errOutput, "deletion workflow failed: %v\n", err)
+			return 1
+		}
+	case "compute":
+		if err := runComputeWorkflow(application, cfg, flags); err != nil {
+			_, _ = fmt.Fprintf(errOutput, "compute workflow failed: %v\n", err)
+			return 1
+		}
 	case "explorer":
+		contexts, provider, err := openExplorerContexts(flags)
+		if err != nil {
+			_, _ = fmt.Fprintf(errOutput, "inventory provider failed: %v\n", err)
+			return 1
+		}
+		defer func() { _ = releaseExplorerContexts(contexts, provider) }()
 		runExplorerWorkflow(
 			os.Stdout,
+			provider,
+			contexts,
 			flags.readOnly,
 			flags.startupCommand,
 			flags.headless,
 			flags.crumbsless,
+			refreshInterval(flags.refreshSeconds),
 		)
 	default:
-		runMigrationWorkflow(application, cfg)
+		if err := runMigrationWorkflow(application, cfg, flags); err != nil {
+			_, _ = fmt.Fprintf(errOutput, "migration workflow failed: %v\n", err)
+			return 1
+		}
 	}
 	return 0
 }
@@ -115,10 +214,14 @@ func parseFlags(args []string) (cliFlags, error) {
 	if err := flagSet.Parse(args); err != nil {
 		return cliFlags{}, err
 	}
-	command, err := parseSubcommand(flagSet.Args())
+	command, planFile, err := parseSubcommand(flagSet, values.out)
 	if err != nil {
 		return cliFlags{}, err
 	}
+	credentialName := ""
+	if command == "credentials" {
+		planFile, credentialName = "", planFile
+	}
 	resolvedLevel, err := parseLogLevel(*values.level)
 	if err != nil {
 		return cliFlags{}, err
@@ -127,20 +230,66 @@ func parseFlags(args []string) (cliFlags, error) {
 	if err != nil {
 		return cliFlags{}, err
 	}
+	provider, err := resolveProviderName(*values.provider)
+	if err != nil {
+		return cliFlags{}, err
+	}
 	readOnly, err := resolveStartupReadOnly(*values.readOnly, *values.write)
 	if err != nil {
 		return cliFlags{}, err
 	}
+	strategy, err := migration.ParseStrategy(*values.strategy)
+	if err != nil {
+		return cliFlags{}, err
+	}
+	retry, err := resolveRetrySettings(flagSet, values)
+	if err != nil {
+		return cliFlags{}, err
+	}
+	exemptions, err := deletion.ParseExemptions(*values.exemptTags, *values.exemptFolders, *values.exemptNames, strings.TrimSpace(*values.exemptFile))
+	if err != nil {
+		return cliFlags{}, err
+	}
 	return cliFlags{
 		command:        command,
+		planFile:       planFile,
+		credentialName: credentialName,
 		startupCommand: normalizeStartupCommand(*values.startupCommand),
 		headless:       *values.headless,
 		crumbsless:     *values.crumbsless,
 		workflow:       workflow,
+		provider:       provider,
+		vcenter:        resolveVCenterEndpoint(*values.vcenterURL, *values.vcenterUser, *values.insecure),
+		context:        strings.TrimSpace(*values.context),
 		mode:           strings.TrimSpace(*values.mode),
 		execute:        *values.execute,
 		readOnly:       readOnly,
 		threshold:      *values.threshold,
+		filter: migration.Filter{
+			SourceDatastore: strings.TrimSpace(*values.sourceDS),
+			Cluster:         strings.TrimSpace(*values.cluster),
+			Folder:          strings.TrimSpace(*values.folder),
+			Tag:             strings.TrimSpace(*values.tag),
+		},
+		limits: migration.Limits{
+			Global:    *values.concurrency,
+			PerSource: *values.perSource,
+			PerTarget: *values.perTarget,
+			PerHost:   *values.perHost,
+		},
+		throttle: migration.Throttle{
+			LatencyMS: *values.maxLatency,
+			Backoff:   *values.latencyBackoff,
+			MaxWait:   *values.latencyMaxWait,
+		},
+		resultsFile:    strings.TrimSpace(*values.results),
+		retry:          retry,
+		maxSnapshots:   *values.maxSnapshots,
+		smtp:           deletion.SMTPConfig{Addr: strings.TrimSpace(*values.smtpAddr), From: strings.TrimSpace(*values.smtpFrom)},
+		renamePrefix:   strings.TrimSpace(*values.renamePrefix),
+		exemptions:     exemptions,
+		strategy:       strategy,
+		evacuateHost:   strings.TrimSpace(*values.evacuateHost),
 		refreshSeconds: clampRefreshSeconds(*values.refresh),
 		logLevel:       resolvedLevel,
 		logFile:        strings.TrimSpace(*values.logFile),
@@ -154,12 +303,43 @@ func newStartupFlagSet() (*flag.FlagSet, startupFlagValues) {
 		startupCommand: flagSet.String("command", "", "startup resource view command"),
 		headless:       flagSet.Bool("headless", false, "hide table header line"),
 		crumbsless:     flagSet.Bool("crumbsless", false, "hide breadcrumb line"),
-		workflow:       flagSet.String("workflow", "explorer", "workflow: explorer, migration, or deletion"),
+		workflow:       flagSet.String("workflow", "explorer", "workflow: explorer, migration, compute, or deletion"),
+		provider:       flagSet.String("provider", "", "inventory provider: demo or vsphere"),
+		vcenterURL:     flagSet.String("vcenter", "", "vCenter URL for the vsphere provider"),
+		vcenterUser:    flagSet.String("vcenter-user", "", "vCenter username for the vsphere provider"),
+		insecure:       flagSet.Bool("insecure", false, "skip vCenter TLS certificate verification"),
+		context:        flagSet.String("context", "", "initial endpoint profile for the vsphere provider, or all to aggregate every profile"),
 		mode:           flagSet.String("mode", "all", "mode: mark, purge, or all"),
 		execute:        flagSet.Bool("execute", false, "execute mutating actions"),
 		readOnly:       flagSet.Bool("readonly", false, "start in read-only mode"),
 		write:          flagSet.Bool("write", false, "override config read-only default"),
 		threshold:      flagSet.Int("threshold", 85, "target utilization threshold percent"),
+		out:            flagSet.String("out", "", "plan file written by plan migration"),
+		sourceDS:       flagSet.String("source-datastore", "", "migrate only VMs on this datastore"),
+		cluster:        flagSet.String("cluster", "", "migrate only VMs in this cluster"),
+		folder:         flagSet.String("folder", "", "migrate only VMs under this inventory folder path"),
+		tag:            flagSet.String("tag", "", "migrate only VMs carrying this tag"),
+		concurrency:    flagSet.Int("concurrency", 1, "maximum concurrent migrations, 0 for unlimited"),
+		perSource:      flagSet.Int("per-source", 0, "maximum concurrent migrations off one datastore, 0 for unlimited"),
+		perTarget:      flagSet.Int("per-target", 0, "maximum concurrent migrations onto one datastore, 0 for unlimited"),
+		perHost:        flagSet.Int("per-host", 0, "maximum concurrent migrations per ESXi host, 0 for unlimited"),
+		maxLatency:     flagSet.Int("max-latency", 0, "hold new migrations touching a datastore above this latency in ms, 0 to disable"),
+		latencyBackoff: flagSet.Duration("throttle-backoff", 30*time.Second, "wait between datastore latency re-checks while throttled"),
+		latencyMaxWait: flagSet.Duration("throttle-max-wait", 0, "fail throttled migrations after backing off this long, 0 to wait indefinitely"),
+		results:        flagSet.String("results", "", "write per-step migration results to this JSON file"),
+		retries:        flagSet.Int("retries", config.DefaultRetries, "retries for a failed migration, overriding the retries config key"),
+		retryBackoff:   flagSet.Duration("retry-backoff", config.DefaultRetryBackoff, "wait before the first migration retry, doubling with jitter after each, overriding retry_backoff"),
+		retryTimeout:   flagSet.Duration("retry-timeout", 0, "stop retrying a migration after this long, 0 for no limit, overriding retry_timeout"),
+		maxSnapshots:   flagSet.Int("max-snapshots", 3, "skip migrating VMs with more snapshots than this"),
+		smtpAddr:       flagSet.String("smtp", "", "SMTP relay host:port for deletion notices, empty to write them to the local outbox"),
+		smtpFrom:       flagSet.String("smtp-from", "hypersphere@localhost", "sender address for deletion notices"),
+		renamePrefix:   flagSet.String("rename-prefix", "", "prefix added to the names of VMs marked for deletion, empty to keep names"),
+		exemptTags:     listFlagVar(flagSet, "exempt-tag", "never mark or purge VMs carrying this tag, repeatable"),
+		exemptFolders:  listFlagVar(flagSet, "exempt-folder", "never mark or purge VMs in folders matching this glob, repeatable"),
+		exemptNames:    listFlagVar(flagSet, "exempt-name", "never mark or purge VMs with names matching this regular expression, repeatable"),
+		exemptFile:     flagSet.String("exempt-file", "", "file listing VM names, one per line, never marked or purged"),
+		strategy:       flagSet.String("strategy", string(migration.StrategyGreedy), "placement strategy: greedy, first-fit-decreasing, best-fit, or balance"),
+		evacuateHost:   flagSet.String("evacuate-host", "", "compute workflow: plan moves off this ESXi host instead of balancing"),
 		refresh:        flagSet.Float64("refresh", defaultRefreshSeconds, "inventory refresh interval in seconds"),
 		level:          flagSet.String("log-level", string(logLevelInfo), "log level: debug, info, warn, or error"),
 		logFile:        flagSet.String("log-file", "", "path to runtime log output file"),
@@ -173,12 +353,40 @@ func normalizeStartupCommand(value string) string {
 
 func validateWorkflow(value string) (string, error) {
 	workflow := strings.ToLower(strings.TrimSpace(value))
-	if workflow == "migration" || workflow == "deletion" || workflow == "explorer" {
+	if workflow == "migration" || workflow == "compute" || workflow == "deletion" || workflow == "explorer" {
 		return workflow, nil
 	}
 	return "", fmt.Errorf("unsupported workflow %q", value)
 }
 
+func resolveProviderName(value string) (string, error) {
+	if strings.TrimSpace(value) == "" {
+		value = os.Getenv(providerEnvName)
+	}
+	name := inventory.NormalizeName(value)
+	for _, known := range inventory.Names() {
+		if name == known {
+			return name, nil
+		}
+	}
+	return "", fmt.Errorf("unsupported inventory provider %q", value)
+}
+
+func resolveVCenterEndpoint(url string, user string, insecure bool) vsphere.Endpoint {
+	if strings.TrimSpace(url) == "" {
+		url = os.Getenv(vcenterURLEnvName)
+	}
+	if strings.TrimSpace(user) == "" {
+		user = os.Getenv(vcenterUserEnvName)
+	}
+	return vsphere.Endpoint{
+		URL:      strings.TrimSpace(url),
+		Username: strings.TrimSpace(user),
+		Password: os.Getenv(vcenterPasswordEnvName),
+		Insecure: insecure,
+	}
+}
+
 func resolveStartupReadOnly(readOnly bool, write bool) (bool, error) {
 	if write {
 		return false, nil
@@ -190,37 +398,33 @@ func resolveStartupReadOnly(readOnly bool, write bool) (bool, error) {
 }
 
 func readOnlyConfigDefault() (bool, error) {
-	paths, err := infoPaths()
-	if err != nil {
-		return false, err
-	}
-	content, err := os.ReadFile(paths["config"])
+	configured, err := mainConfigValues()
 	if err != nil {
-		if os.IsNotExist(err) {
-			return false, nil
-		}
 		return false, err
 	}
-	return parseReadOnlyConfigValue(string(content)), nil
+	parsed, err := strconv.ParseBool(strings.ToLower(configured["readonly"]))
+	return parsed && err == nil, nil
 }
 
-func parseReadOnlyConfigValue(content string) bool {
-	lines := strings.Split(content, "\n")
-	for _, line := range lines {
+func mainConfigValues() (map[string]string, error) {
+	paths, err := infoPaths()
+	if err != nil {
+		return nil, err
+	}
+	content, err := os.ReadFile(paths["config"])
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	values := map[string]string{}
+	for _, line := range strings.Split(string(content), "\n") {
 		trimmed := strings.TrimSpace(line)
 		if strings.HasPrefix(trimmed, "#") || !strings.Contains(trimmed, ":") {
 			continue
 		}
 		fields := strings.SplitN(trimmed, ":", 2)
-		if strings.ToLower(strings.TrimSpace(fields[0])) != "readonly" {
-			continue
-		}
-		parsed, err := strconv.ParseBool(strings.ToLower(strings.TrimSpace(fields[1])))
-		if err == nil {
-			return parsed
-		}
+		values[strings.ToLower(strings.TrimSpace(fields[0]))] = strings.TrimSpace(fields[1])
 	}
-	return false
+	return values, nil
 }
 
 func clampRefreshSeconds(refreshSeconds float64) float64 {
@@ -259,15 +463,44 @@ func writeStartupLog(logPath string, level logLevel) error {
 	return err
 }
 
-func parseSubcommand(args []string) (string, error) {
+func parseSubcommand(flagSet *flag.FlagSet, out *string) (string, string, error) {
+	args := flagSet.Args()
 	if len(args) == 0 {
-		return "", nil
+		return "", "", nil
 	}
 	command := strings.ToLower(strings.TrimSpace(args[0]))
-	if command == "version" || command == "info" {
-		return command, nil
+	switch command {
+	case "version", "info":
+		return command, "", nil
+	case "credentials":
+		if len(args) != 3 || strings.ToLower(strings.TrimSpace(args[1])) != "set" || strings.TrimSpace(args[2]) == "" {
+			return "", "", fmt.Errorf("usage: credentials set <profile>")
+		}
+		return command, strings.TrimSpace(args[2]), nil
+	case "plan", "apply", "rollback":
+	default:
+		return "", "", fmt.Errorf("unsupported command %q", args[0])
+	}
+	if len(args) < 2 {
+		return "", "", fmt.Errorf("%s command needs an argument", command)
+	}
+	operand := strings.TrimSpace(args[1])
+	if err := flagSet.Parse(args[2:]); err != nil {
+		return "", "", err
+	}
+	if flagSet.NArg() > 0 {
+		return "", "", fmt.Errorf("unexpected argument %q", flagSet.Arg(0))
+	}
+	if command == "apply" || command == "rollback" {
+		return command, operand, nil
 	}
-	return "", fmt.Errorf("unsupported command %q", args[0])
+	if strings.ToLower(operand) != "migration" {
+		return "", "", fmt.Errorf("unsupported plan target %q", operand)
+	}
+	if strings.TrimSpace(*out) == "" {
+		return "", "", fmt.Errorf("plan migration needs --out")
+	}
+	return command, strings.TrimSpace(*out), nil
 }
 
 func writeVersion(output io.Writer) {
@@ -285,7 +518,7 @@ func writeInfo(output io.Writer) error {
 	if err != nil {
 		return err
 	}
-	keys := []string{"config", "logs", "dumps", "skins", "plugins", "hotkeys"}
+	keys := []string{"config", "logs", "dumps", "skins", "plugins", "hotkeys", "contexts", "credentials", "migration", "schedule", "outbox", "deletion", "audit", "policies"}
 	for _, key := range keys {
 		_, _ = fmt.Fprintf(output, "%s=%s\n", key, paths[key])
 	}
@@ -302,214 +535,161 @@ func infoPaths() (map[string]string, error) {
 		return nil, err
 	}
 	return map[string]string{
-		"config":  filepath.Join(configRoot, "config.yaml"),
-		"logs":    filepath.Join(configRoot, "logs"),
-		"dumps":   filepath.Join(configRoot, "dumps"),
-		"skins":   filepath.Join(configRoot, "skins.yaml"),
-		"plugins": filepath.Join(configRoot, "plugins.yaml"),
-		"hotkeys": filepath.Join(configRoot, "hotkeys.yaml"),
+		"config":      filepath.Join(configRoot, "config.yaml"),
+		"logs":        filepath.Join(configRoot, "logs"),
+		"dumps":       filepath.Join(configRoot, "dumps"),
+		"skins":       filepath.Join(configRoot, "skins.yaml"),
+		"plugins":     filepath.Join(configRoot, "plugins.yaml"),
+		"hotkeys":     filepath.Join(configRoot, "hotkeys.yaml"),
+		"contexts":    filepath.Join(configRoot, "contexts.json"),
+		"credentials": filepath.Join(configRoot, "credentials.enc"),
+		"migration":   filepath.Join(configRoot, "migration.json"),
+		"schedule":    filepath.Join(configRoot, "schedule.json"),
+		"outbox":      filepath.Join(configRoot, "outbox"),
+		"deletion":    filepath.Join(configRoot, "deletion.json"),
+		"audit":       filepath.Join(configRoot, "deletion-audit.jsonl"),
+		"policies":    filepath.Join(configRoot, "deletion-policies.json"),
 	}, nil
 }
 
-func runMigrationWorkflow(application app.App, cfg config.Config) {
-	planner := migration.NewPlanner(cfg.ThresholdPercent)
-	vms := []migration.VM{{Name: "example-vm-01", SizeGB: 15, SourceDatastore: "source-ds"}}
-	stores := []migration.Datastore{{Name: "source-ds", CapacityGB: 100, UsedGB: 30, Tier: migration.TierPrimary}, {Name: "target-ds", CapacityGB: 100, UsedGB: 20, Tier: migration.TierPrimary}}
-	_ = application.RunMigration(cfg, vms, stores, planner)
-}
-
-func runDeletionWorkflow(application app.App, cfg config.Config) {
-	engine := deletion.NewEngine(deletion.Policy{MarkAfterDays: 30, PurgeAfterDays: 14, PendingFolder: "PENDING_DELETION"})
-	adapter := deletionAdapter{engine: engine}
-	vms := []deletion.VM{{Name: "example-vm-02", Folder: "WORKLOADS", PoweredOffDays: 45, OwnerEmail: "owner@example.com", Metadata: map[string]string{}}}
-	mode := deletion.Mode(cfg.Mode)
-	_ = application.RunDeletion(vms, mode, app.TimeValue{Value: time.Now().UTC()}, adapter)
-}
-
-func defaultCatalog() tui.Catalog {
-	return tui.Catalog{
-		VMs:           defaultVMRows(),
-		LUNs:          defaultLUNRows(),
-		Clusters:      defaultClusterRows(),
-		Datacenters:   defaultDatacenterRows(),
-		ResourcePools: defaultResourcePoolRows(),
-		Networks:      defaultNetworkRows(),
-		Templates:     defaultTemplateRows(),
-		Snapshots:     defaultSnapshotRows(),
-		Tasks:         defaultTaskRows(),
-		Events:        defaultEventRows(),
-		Alarms:        defaultAlarmRows(),
-		Folders:       defaultFolderRows(),
-		Tags:          defaultTagRows(),
-		Hosts:         defaultHostRows(),
-		Datastores:    defaultDatastoreRows(),
-	}
-}
-
-func defaultVMRows() []tui.VMRow {
-	return []tui.VMRow{
-		{Name: "vm-a", Tags: "prod,linux", Cluster: "cluster-east", Host: "esxi-01", Network: "dvpg-prod-100", PowerState: "on", Datastore: "ds-1", AttachedStorage: "vsan-east", IPAddress: "10.10.1.21", DNSName: "vm-a.prod.local", CPUCount: 4, MemoryMB: 8192, UsedCPUPercent: 63, UsedMemoryMB: 5632, UsedStorageGB: 76, LargestDiskGB: 80, SnapshotTotalGB: 9, Owner: "a@example.com", SnapshotCount: 2},
-		{Name: "vm-b", Tags: "dev,windows", Cluster: "cluster-west", Host: "esxi-02", Network: "dvpg-dev-200", PowerState: "off", Datastore: "ds-2", AttachedStorage: "nfs-west", IPAddress: "10.20.2.34", DNSName: "vm-b.dev.local", CPUCount: 2, MemoryMB: 4096, UsedCPUPercent: 0, UsedMemoryMB: 0, UsedStorageGB: 48, LargestDiskGB: 60, SnapshotTotalGB: 4, Owner: "b@example.com", SnapshotCount: 1},
-		{Name: "vm-c", Tags: "prod,db", Cluster: "cluster-east", Host: "esxi-05", Network: "dvpg-prod-100", PowerState: "on", Datastore: "ds-3", AttachedStorage: "vvol-central", IPAddress: "10.10.1.45", DNSName: "vm-c.db.local", CPUCount: 8, MemoryMB: 16384, UsedCPUPercent: 71, UsedMemoryMB: 13240, UsedStorageGB: 220, LargestDiskGB: 200, SnapshotTotalGB: 26, Owner: "c@example.com", SnapshotCount: 3},
-		{Name: "vm-d", Tags: "qa,linux", Cluster: "cluster-central", Host: "esxi-03", Network: "dvpg-storage-120", PowerState: "suspended", Datastore: "ds-4", AttachedStorage: "iscsi-edge", IPAddress: "10.30.3.18", DNSName: "vm-d.qa.local", CPUCount: 2, MemoryMB: 6144, UsedCPUPercent: 9, UsedMemoryMB: 840, UsedStorageGB: 32, LargestDiskGB: 40, SnapshotTotalGB: 2, Owner: "d@example.com", SnapshotCount: 1},
-		{Name: "vm-e", Tags: "edge,linux", Cluster: "cluster-edge", Host: "esxi-04", Network: "dvpg-edge-trunk", PowerState: "on", Datastore: "ds-5", AttachedStorage: "ds-5", IPAddress: "172.16.40.11", DNSName: "vm-e.edge.local", CPUCount: 4, MemoryMB: 8192, UsedCPUPercent: 44, UsedMemoryMB: 2980, UsedStorageGB: 54, LargestDiskGB: 64, SnapshotTotalGB: 0, Owner: "e@example.com", SnapshotCount: 0},
-		{Name: "vm-f", Tags: "dev,api", Cluster: "cluster-west", Host: "esxi-06", Network: "dvpg-dev-200", PowerState: "off", Datastore: "ds-6", AttachedStorage: "ds-6", IPAddress: "10.20.2.58", DNSName: "vm-f.api.local", CPUCount: 6, MemoryMB: 12288, UsedCPUPercent: 0, UsedMemoryMB: 0, UsedStorageGB: 89, LargestDiskGB: 100, SnapshotTotalGB: 7, Owner: "f@example.com", SnapshotCount: 2},
-		{Name: "vm-g", Tags: "ops,jump", Cluster: "cluster-east", Host: "esxi-07", Network: "vmk-mgmt", PowerState: "on", Datastore: "ds-7", AttachedStorage: "ds-7", IPAddress: "10.50.5.7", DNSName: "vm-g.ops.local", CPUCount: 2, MemoryMB: 4096, UsedCPUPercent: 37, UsedMemoryMB: 2112, UsedStorageGB: 28, LargestDiskGB: 32, SnapshotTotalGB: 0, Owner: "g@example.com", SnapshotCount: 0},
-		{Name: "vm-h", Tags: "prod,cache", Cluster: "cluster-central", Host: "esxi-08", Network: "dvpg-storage-120", PowerState: "on", Datastore: "ds-8", AttachedStorage: "ds-8", IPAddress: "10.30.3.88", DNSName: "vm-h.cache.local", CPUCount: 4, MemoryMB: 8192, UsedCPUPercent: 58, UsedMemoryMB: 4760, UsedStorageGB: 66, LargestDiskGB: 80, SnapshotTotalGB: 3, Owner: "h@example.com", SnapshotCount: 1},
+func runMigrationWorkflow(application app.App, cfg config.Config, flags cliFlags) error {
+	policy, err := loadMigrationPolicy()
+	if err != nil {
+		return err
 	}
-}
-
-func defaultLUNRows() []tui.LUNRow {
-	return []tui.LUNRow{
-		{Name: "lun-001", Tags: "gold", Cluster: "cluster-east", Datastore: "san-a", CapacityGB: 1000, UsedGB: 450},
-		{Name: "lun-002", Tags: "silver", Cluster: "cluster-west", Datastore: "san-b", CapacityGB: 2000, UsedGB: 900},
-		{Name: "lun-003", Tags: "bronze", Cluster: "cluster-central", Datastore: "san-c", CapacityGB: 1500, UsedGB: 700},
-		{Name: "lun-004", Tags: "archive", Cluster: "cluster-edge", Datastore: "san-d", CapacityGB: 3000, UsedGB: 1200},
-		{Name: "lun-005", Tags: "flash", Cluster: "cluster-east", Datastore: "san-e", CapacityGB: 1200, UsedGB: 840},
-		{Name: "lun-006", Tags: "backup", Cluster: "cluster-west", Datastore: "san-f", CapacityGB: 2500, UsedGB: 1250},
-		{Name: "lun-007", Tags: "gold", Cluster: "cluster-central", Datastore: "san-g", CapacityGB: 1800, UsedGB: 1080},
-		{Name: "lun-008", Tags: "silver", Cluster: "cluster-edge", Datastore: "san-h", CapacityGB: 1600, UsedGB: 640},
+	gate, err := loadMaintenanceGate()
+	if err != nil {
+		return err
 	}
-}
-
-func defaultClusterRows() []tui.ClusterRow {
-	return []tui.ClusterRow{
-		{Name: "cluster-east", Tags: "prod", Datacenter: "dc-1", Hosts: 8, VMCount: 120, CPUUsagePercent: 63, MemUsagePercent: 58, ResourcePoolCount: 4, NetworkCount: 9},
-		{Name: "cluster-west", Tags: "dev", Datacenter: "dc-2", Hosts: 6, VMCount: 90, CPUUsagePercent: 52, MemUsagePercent: 49, ResourcePoolCount: 3, NetworkCount: 7},
-		{Name: "cluster-central", Tags: "qa", Datacenter: "dc-1", Hosts: 5, VMCount: 64, CPUUsagePercent: 57, MemUsagePercent: 55, ResourcePoolCount: 2, NetworkCount: 6},
-		{Name: "cluster-edge", Tags: "edge", Datacenter: "dc-3", Hosts: 4, VMCount: 33, CPUUsagePercent: 47, MemUsagePercent: 44, ResourcePoolCount: 2, NetworkCount: 5},
+	return withMigrationInventory(flags, policy, func(source migration.Inventory, mover migration.Mover) error {
+		if err := checkExecuteScope(flags.filter, cfg.Execute, mover); err != nil {
+			return err
+		}
+		residents, stores, err := source.Load()
+		if err != nil {
+			return err
+		}
+		vms, stores := flags.filter.Apply(residents, stores)
+		planner := migration.NewPlanner(cfg.ThresholdPercent).
+			WithPolicy(policy).
+			WithResidents(residents).
+			WithStrategy(flags.strategy).
+			WithLimits(flags.limits).
+			WithThrottle(flags.throttle).
+			WithRetry(retryPolicy(cfg)).
+			WithChecks(migration.DefaultChecks(flags.maxSnapshots)...).
+			WithInventory(flags.filter.Inventory(source)).
+			WithSchedule(gate).
+			WithProgress(application.MigrationProgress)
+		summary := application.RunMigration(cfg, vms, stores, planner, mover)
+		return writeStepResults(application, flags.resultsFile, summary)
+	})
+}
+
+func withMigrationInventory(flags cliFlags, policy migration.Policy, run func(source migration.Inventory, mover migration.Mover) error) error {
+	contexts, provider, err := openExplorerContexts(flags)
+	if err != nil {
+		return err
 	}
-}
-
-func defaultDatacenterRows() []tui.DatacenterRow {
-	return []tui.DatacenterRow{
-		{Name: "dc-1", ClusterCount: 2, HostCount: 13, VMCount: 184, DatastoreCount: 6, CPUUsagePercent: 60, MemUsagePercent: 57},
-		{Name: "dc-2", ClusterCount: 1, HostCount: 6, VMCount: 90, DatastoreCount: 4, CPUUsagePercent: 52, MemUsagePercent: 49},
-		{Name: "dc-3", ClusterCount: 1, HostCount: 4, VMCount: 33, DatastoreCount: 3, CPUUsagePercent: 46, MemUsagePercent: 43},
+	defer func() { _ = releaseExplorerContexts(contexts, provider) }()
+	mover, err := newMigrationMover(provider, flags.retry.timeout)
+	if err != nil {
+		return err
 	}
-}
-
-func defaultResourcePoolRows() []tui.ResourcePoolRow {
-	return []tui.ResourcePoolRow{
-		{Name: "rp-prod", Cluster: "cluster-east", CPUReservationMHz: 6400, MemReservationMB: 8192, VMCount: 24, CPULimitMHz: 12000, MemLimitMB: 16384},
-		{Name: "rp-dev", Cluster: "cluster-west", CPUReservationMHz: 3200, MemReservationMB: 4096, VMCount: 18, CPULimitMHz: 9000, MemLimitMB: 12288},
-		{Name: "rp-qa", Cluster: "cluster-central", CPUReservationMHz: 2800, MemReservationMB: 3072, VMCount: 12, CPULimitMHz: 7000, MemLimitMB: 10240},
-		{Name: "rp-edge", Cluster: "cluster-edge", CPUReservationMHz: 2000, MemReservationMB: 2048, VMCount: 9, CPULimitMHz: 5000, MemLimitMB: 8192},
+	if err := checkTagFilter(flags.filter, provider); err != nil {
+		return err
 	}
-}
-
-func defaultNetworkRows() []tui.NetworkRow {
-	return []tui.NetworkRow{
-		{Name: "dvpg-prod-100", Type: "distributed-portgroup", VLAN: "100", Switch: "dvs-core-a", AttachedVMs: 41, MTU: 9000, Uplinks: 4},
-		{Name: "dvpg-dev-200", Type: "distributed-portgroup", VLAN: "200", Switch: "dvs-core-b", AttachedVMs: 27, MTU: 9000, Uplinks: 4},
-		{Name: "vmk-mgmt", Type: "vmkernel", VLAN: "10", Switch: "vss-mgmt-01", AttachedVMs: 8, MTU: 1500, Uplinks: 2},
-		{Name: "dvpg-storage-120", Type: "distributed-portgroup", VLAN: "120", Switch: "dvs-storage", AttachedVMs: 19, MTU: 9000, Uplinks: 2},
-		{Name: "dvpg-backup-130", Type: "distributed-portgroup", VLAN: "130", Switch: "dvs-backup", AttachedVMs: 11, MTU: 9000, Uplinks: 2},
-		{Name: "dvpg-edge-trunk", Type: "distributed-portgroup", VLAN: "trunk", Switch: "dvs-edge", AttachedVMs: 7, MTU: 1600, Uplinks: 2},
+	if err := checkTagTiers(policy.Tiers, provider); err != nil {
+		return err
 	}
+	return run(policy.Tiers.Inventory(liveMigrationInventory(provider)), mover)
 }
 
-func defaultTemplateRows() []tui.TemplateRow {
-	return []tui.TemplateRow{
-		{Name: "tpl-rhel9-base", OS: "rhel9", Datastore: "vsan-east", Folder: "/Templates/Linux", Age: "45d", CPUCount: 4, MemoryMB: 8192},
-		{Name: "tpl-ubuntu2204-base", OS: "ubuntu22.04", Datastore: "vvol-central", Folder: "/Templates/Linux", Age: "32d", CPUCount: 2, MemoryMB: 4096},
-		{Name: "tpl-windows2022-base", OS: "windows2022", Datastore: "nfs-west", Folder: "/Templates/Windows", Age: "54d", CPUCount: 4, MemoryMB: 8192},
-		{Name: "tpl-sles15-base", OS: "sles15", Datastore: "vsan-east", Folder: "/Templates/Linux", Age: "27d", CPUCount: 2, MemoryMB: 4096},
-		{Name: "tpl-centos7-legacy", OS: "centos7", Datastore: "ds-6", Folder: "/Templates/Legacy", Age: "143d", CPUCount: 2, MemoryMB: 2048},
-		{Name: "tpl-debian12-app", OS: "debian12", Datastore: "ds-7", Folder: "/Templates/App", Age: "18d", CPUCount: 4, MemoryMB: 6144},
-	}
+func liveMigrationInventory(provider tui.InventoryProvider) migration.Inventory {
+	loads := 0
+	return migration.InventoryFunc(func() ([]migration.VM, []migration.Datastore, error) {
+		if err := refreshMigrationInventory(provider, loads); err != nil {
+			return nil, nil, err
+		}
+		loads++
+		catalog, err := tui.LoadCatalog(provider)
+		if err != nil {
+			return nil, nil, err
+		}
+		vms, stores := app.MigrationInventory(catalog)
+		return vms, stores, nil
+	})
 }
 
-func defaultSnapshotRows() []tui.SnapshotRow {
-	return []tui.SnapshotRow{
-		{VM: "vm-a", Snapshot: "pre-patch", Size: "12G", Created: "2026-02-10T12:00:00Z", Age: "6d", Quiesced: "yes", Owner: "a@example.com"},
-		{VM: "vm-b", Snapshot: "before-upgrade", Size: "8G", Created: "2026-02-08T09:15:00Z", Age: "8d", Quiesced: "no", Owner: "b@example.com"},
-		{VM: "vm-c", Snapshot: "monthly-backup", Size: "24G", Created: "2026-01-31T22:40:00Z", Age: "16d", Quiesced: "yes", Owner: "c@example.com"},
-		{VM: "vm-d", Snapshot: "pre-maintenance", Size: "6G", Created: "2026-02-14T03:05:00Z", Age: "2d", Quiesced: "no", Owner: "d@example.com"},
-		{VM: "vm-e", Snapshot: "schema-change", Size: "10G", Created: "2026-02-12T18:20:00Z", Age: "4d", Quiesced: "yes", Owner: "e@example.com"},
-		{VM: "vm-f", Snapshot: "pre-hotfix", Size: "5G", Created: "2026-02-15T07:55:00Z", Age: "1d", Quiesced: "no", Owner: "f@example.com"},
+func refreshMigrationInventory(provider tui.InventoryProvider, loads int) error {
+	if loads == 0 {
+		return nil
 	}
-}
-
-func defaultTaskRows() []tui.TaskRow {
-	return []tui.TaskRow{
-		{Entity: "vm-a", Action: "power-off", State: "success", Started: "2026-02-16T08:10:00Z", Duration: "24s", Owner: "ops@example.com"},
-		{Entity: "vm-b", Action: "clone", State: "running", Started: "2026-02-16T08:16:00Z", Duration: "2m14s", Owner: "dev@example.com"},
-		{Entity: "esxi-01", Action: "enter-maintenance", State: "queued", Started: "2026-02-16T08:20:00Z", Duration: "0s", Owner: "infra@example.com"},
-		{Entity: "ds-7", Action: "rescan", State: "success", Started: "2026-02-16T08:01:00Z", Duration: "11s", Owner: "storage@example.com"},
-		{Entity: "vm-c", Action: "snapshot-create", State: "failed", Started: "2026-02-16T07:55:00Z", Duration: "38s", Owner: "dba@example.com"},
-		{Entity: "cluster-west", Action: "rebalance", State: "running", Started: "2026-02-16T08:05:00Z", Duration: "6m02s", Owner: "sre@example.com"},
+	if watcher, ok := provider.(inventoryWatcher); ok {
+		_, err := watcher.WatchChanges(context.Background(), 0)
+		return err
 	}
-}
-
-func defaultEventRows() []tui.EventRow {
-	return []tui.EventRow{
-		{Time: "2026-02-16T08:04:00Z", Severity: "info", Entity: "vm-a", Message: "power state changed to on", User: "ops@example.com"},
-		{Time: "2026-02-16T08:09:00Z", Severity: "warning", Entity: "esxi-06", Message: "host entered disconnected state", User: "infra@example.com"},
-		{Time: "2026-02-16T08:12:00Z", Severity: "error", Entity: "ds-7", Message: "datastore latency threshold exceeded", User: "storage@example.com"},
-		{Time: "2026-02-16T08:18:00Z", Severity: "info", Entity: "vm-b", Message: "snapshot created", User: "dev@example.com"},
-		{Time: "2026-02-16T08:20:00Z", Severity: "warning", Entity: "cluster-west", Message: "demand imbalance detected", User: "sre@example.com"},
-		{Time: "2026-02-16T08:23:00Z", Severity: "info", Entity: "vm-c", Message: "guest tools upgraded", User: "dba@example.com"},
+	if reloader, ok := provider.(interface{ Reload() error }); ok {
+		return reloader.Reload()
 	}
+	return nil
 }
 
-func defaultAlarmRows() []tui.AlarmRow {
-	return []tui.AlarmRow{
-		{Entity: "vm-a", Alarm: "CPU usage high", Status: "yellow", Triggered: "2026-02-16T08:05:00Z", AckedBy: "-"},
-		{Entity: "vm-c", Alarm: "Datastore latency critical", Status: "red", Triggered: "2026-02-16T08:12:00Z", AckedBy: "storage@example.com"},
-		{Entity: "esxi-06", Alarm: "Host disconnected", Status: "red", Triggered: "2026-02-16T08:09:00Z", AckedBy: "infra@example.com"},
-		{Entity: "cluster-west", Alarm: "Imbalance detected", Status: "yellow", Triggered: "2026-02-16T08:20:00Z", AckedBy: "-"},
-		{Entity: "ds-7", Alarm: "Space utilization warning", Status: "yellow", Triggered: "2026-02-16T08:18:00Z", AckedBy: "ops@example.com"},
-		{Entity: "vm-b", Alarm: "Snapshot chain length high", Status: "yellow", Triggered: "2026-02-16T08:22:00Z", AckedBy: "-"},
+func newMigrationMover(provider tui.InventoryProvider, timeout time.Duration) (migration.Mover, error) {
+	switch typed := provider.(type) {
+	case *vsphere.Provider:
+		return vsphere.NewMover(context.Background(), typed).WithTimeout(timeout), nil
+	case inventory.DemoProvider:
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("migration requires a single endpoint context, got %T", provider)
 	}
 }
 
-func defaultFolderRows() []tui.FolderRow {
-	return []tui.FolderRow{
-		{Path: "/Datacenters/dc-1/vm/Prod", Type: "vm-folder", Children: 6, VMCount: 74},
-		{Path: "/Datacenters/dc-1/vm/QA", Type: "vm-folder", Children: 3, VMCount: 28},
-		{Path: "/Datacenters/dc-2/vm/Dev", Type: "vm-folder", Children: 5, VMCount: 52},
-		{Path: "/Datacenters/dc-3/vm/Edge", Type: "vm-folder", Children: 2, VMCount: 17},
-		{Path: "/Datacenters/dc-1/host/Compute", Type: "host-folder", Children: 4, VMCount: 0},
-		{Path: "/Datacenters/dc-2/network/Distributed", Type: "network-folder", Children: 7, VMCount: 0},
+func runDeletionWorkflow(application app.App, cfg config.Config, flags cliFlags) error {
+	gate, err := loadMaintenanceGate()
+	if err != nil {
+		return err
 	}
-}
-
-func defaultTagRows() []tui.TagRow {
-	return []tui.TagRow{
-		{Tag: "env:prod", Category: "environment", Cardinality: "single", AttachedObjects: 74},
-		{Tag: "env:dev", Category: "environment", Cardinality: "single", AttachedObjects: 53},
-		{Tag: "tier:gold", Category: "service-tier", Cardinality: "single", AttachedObjects: 38},
-		{Tag: "tier:silver", Category: "service-tier", Cardinality: "single", AttachedObjects: 47},
-		{Tag: "backup:daily", Category: "backup-policy", Cardinality: "multiple", AttachedObjects: 26},
-		{Tag: "compliance:pci", Category: "compliance", Cardinality: "multiple", AttachedObjects: 12},
+	notifier, err := deletionNotifier(flags.smtp)
+	if err != nil {
+		return err
 	}
-}
-
-func defaultHostRows() []tui.HostRow {
-	return []tui.HostRow{
-		{Name: "esxi-01", Tags: "gpu", Cluster: "cluster-east", CPUUsagePercent: 72, MemUsagePercent: 67, ConnectionState: "connected", CoreCount: 24, ThreadCount: 48, VMCount: 29},
-		{Name: "esxi-02", Tags: "general", Cluster: "cluster-west", CPUUsagePercent: 44, MemUsagePercent: 52, ConnectionState: "connected", CoreCount: 20, ThreadCount: 40, VMCount: 21},
-		{Name: "esxi-03", Tags: "storage", Cluster: "cluster-central", CPUUsagePercent: 51, MemUsagePercent: 60, ConnectionState: "connected", CoreCount: 20, ThreadCount: 40, VMCount: 17},
-		{Name: "esxi-04", Tags: "compute", Cluster: "cluster-edge", CPUUsagePercent: 38, MemUsagePercent: 41, ConnectionState: "maintenance", CoreCount: 16, ThreadCount: 32, VMCount: 9},
-		{Name: "esxi-05", Tags: "gpu", Cluster: "cluster-east", CPUUsagePercent: 68, MemUsagePercent: 73, ConnectionState: "connected", CoreCount: 24, ThreadCount: 48, VMCount: 26},
-		{Name: "esxi-06", Tags: "general", Cluster: "cluster-west", CPUUsagePercent: 40, MemUsagePercent: 46, ConnectionState: "disconnected", CoreCount: 20, ThreadCount: 40, VMCount: 14},
-		{Name: "esxi-07", Tags: "network", Cluster: "cluster-central", CPUUsagePercent: 49, MemUsagePercent: 58, ConnectionState: "connected", CoreCount: 20, ThreadCount: 40, VMCount: 15},
-		{Name: "esxi-08", Tags: "edge", Cluster: "cluster-edge", CPUUsagePercent: 36, MemUsagePercent: 39, ConnectionState: "connected", CoreCount: 16, ThreadCount: 32, VMCount: 11},
+	policies, err := loadDeletionPolicies()
+	if err != nil {
+		return err
 	}
+	policy := deletion.Policy{MarkAfterDays: 30, PurgeAfterDays: 14, PendingFolder: "PENDING_DELETION", RenamePrefix: flags.renamePrefix}
+	engine := deletion.NewEngine(policy).WithSchedule(gate).WithNotifier(notifier).WithExemptions(flags.exemptions).WithPolicies(policies)
+	return withDeletionInventory(flags, policies, func(vms []deletion.VM, store deletion.MetadataStore, executor deletion.Executor) error {
+		loaded, err := deletion.LoadMetadata(store, vms)
+		if err != nil {
+			return err
+		}
+		mode := deletion.Mode(cfg.Mode)
+		now := app.TimeValue{Value: time.Now().UTC()}
+		vms = deletion.TrackPoweredOff(loaded, now.Value)
+		actions := application.RunDeletion(vms, mode, now, deletionAdapter{engine: engine})
+		if !cfg.Execute {
+			return nil
+		}
+		engine = engine.WithMetadataStore(store)
+		if executor != nil {
+			audit, err := openDeletionAudit()
+			if err != nil {
+				return err
+			}
+			defer func() { _ = audit.Close() }()
+			engine = engine.WithExecutor(executor, audit)
+		}
+		applied := application.ApplyDeletion(vms, actions, now, deletionAdapter{engine: engine})
+		return deletion.SaveMetadata(store, loaded, applied)
+	})
 }
 
-func defaultDatastoreRows() []tui.DatastoreRow {
-	return []tui.DatastoreRow{
-		{Name: "vsan-east", Tags: "flash", Cluster: "cluster-east", CapacityGB: 8000, UsedGB: 4200, FreeGB: 3800, Type: "vsan", LatencyMS: 2},
-		{Name: "nfs-west", Tags: "archive", Cluster: "cluster-west", CapacityGB: 12000, UsedGB: 7200, FreeGB: 4800, Type: "nfs", LatencyMS: 6},
-		{Name: "vvol-central", Tags: "tier-1", Cluster: "cluster-central", CapacityGB: 9000, UsedGB: 5100, FreeGB: 3900, Type: "vvol", LatencyMS: 4},
-		{Name: "iscsi-edge", Tags: "edge", Cluster: "cluster-edge", CapacityGB: 4000, UsedGB: 1900, FreeGB: 2100, Type: "iscsi", LatencyMS: 5},
-		{Name: "ds-5", Tags: "backup", Cluster: "cluster-east", CapacityGB: 6000, UsedGB: 2500, FreeGB: 3500, Type: "nfs", LatencyMS: 7},
-		{Name: "ds-6", Tags: "dev", Cluster: "cluster-west", CapacityGB: 5500, UsedGB: 2100, FreeGB: 3400, Type: "vsan", LatencyMS: 3},
-		{Name: "ds-7", Tags: "prod", Cluster: "cluster-central", CapacityGB: 10000, UsedGB: 6900, FreeGB: 3100, Type: "vvol", LatencyMS: 4},
-		{Name: "ds-8", Tags: "qa", Cluster: "cluster-edge", CapacityGB: 5000, UsedGB: 2200, FreeGB: 2800, Type: "iscsi", LatencyMS: 5},
-	}
+func defaultCatalog() tui.Catalog {
+	catalog, _ := tui.LoadCatalog(inventory.NewDemoProvider())
+	return catalog
 }
 
 type deletionAdapter struct {
@@ -523,3 +703,11 @@ func (d deletionAdapter) Plan(vms []deletion.VM, mode deletion.Mode, now app.Tim
 	}
 	return d.engine.Plan(vms, mode, resolved)
 }
+
+func (d deletionAdapter) ApplyPlan(vms []deletion.VM, actions []deletion.Action, now app.TimeValue) ([]deletion.VM, deletion.ApplySummary) {
+	resolved := now.Value
+	if resolved.IsZero() {
+		resolved = time.Now().UTC()
+	}
+	return d.engine.ApplyPlan(vms, actions, resolved)
+}
@@ -9,8 +9,12 @@ import (
 	"regexp"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/takelley1/hypersphere/internal/inventory"
+	"github.com/takelley1/hypersphere/internal/migration"
 	"github.com/takelley1/hypersphere/internal/tui"
+	"github.com/takelley1/hypersphere/internal/vsphere"
 )
 
 func TestRunVersionCommandPrintsBuildFields(t *testing.T) {
@@ -41,7 +45,7 @@ func TestRunInfoCommandPrintsAbsolutePaths(t *testing.T) {
 	}
 	output := strings.TrimSpace(stdout.String())
 	lines := strings.Split(output, "\n")
-	expectedKeys := []string{"config", "logs", "dumps", "skins", "plugins", "hotkeys"}
+	expectedKeys := []string{"config", "logs", "dumps", "skins", "plugins", "hotkeys", "contexts", "credentials", "migration", "schedule", "outbox", "deletion", "audit", "policies"}
 	if len(lines) != len(expectedKeys) {
 		t.Fatalf("expected %d info lines, got %d (%q)", len(expectedKeys), len(lines), output)
 	}
@@ -307,3 +311,131 @@ func catalogContainsHostConnection(rows []tui.HostRow, state string) bool {
 	}
 	return false
 }
+
+func TestParseFlagsResolvesInventoryProvider(t *testing.T) {
+	t.Setenv(providerEnvName, "")
+	flags, err := parseFlags(nil)
+	if err != nil {
+		t.Fatalf("expected default provider to parse, got error: %v", err)
+	}
+	if flags.provider != "demo" {
+		t.Fatalf("expected demo provider by default, got %q", flags.provider)
+	}
+	t.Setenv(providerEnvName, "DEMO")
+	flags, err = parseFlags(nil)
+	if err != nil || flags.provider != "demo" {
+		t.Fatalf("expected env provider demo, got %q (%v)", flags.provider, err)
+	}
+	if _, err := parseFlags([]string{"--provider", "mystery"}); err == nil {
+		t.Fatalf("expected unknown provider to fail")
+	}
+}
+
+func TestParseFlagsResolvesVCenterEndpoint(t *testing.T) {
+	t.Setenv(vcenterURLEnvName, "https://vc-env.example.com")
+	t.Setenv(vcenterUserEnvName, "env-user")
+	t.Setenv(vcenterPasswordEnvName, "env-secret")
+	flags, err := parseFlags([]string{"--provider", "vsphere", "--vcenter-user", "cli-user", "--insecure", "--context", " vc-lab "})
+	if err != nil {
+		t.Fatalf("expected vsphere flags to parse, got error: %v", err)
+	}
+	endpoint := flags.vcenter
+	if flags.provider != "vsphere" || endpoint.URL != "https://vc-env.example.com" ||
+		endpoint.Username != "cli-user" || endpoint.Password != "env-secret" || !endpoint.Insecure || flags.context != "vc-lab" {
+		t.Fatalf("unexpected vcenter endpoint: %+v", endpoint)
+	}
+}
+
+func TestRunReportsVSphereConnectionFailure(t *testing.T) {
+	t.Setenv(vcenterURLEnvName, "")
+	t.Setenv(profilesEnvPath, filepath.Join(t.TempDir(), "contexts.json"))
+	output := &bytes.Buffer{}
+	errOutput := &bytes.Buffer{}
+	code := run([]string{"--provider", "vsphere", "--readonly"}, output, errOutput)
+	if code != 1 || !strings.Contains(errOutput.String(), "vcenter endpoint url is required") {
+		t.Fatalf("expected vsphere connection failure, got code=%d err=%q", code, errOutput.String())
+	}
+}
+
+func TestRunMigrationWorkflowPlansFilteredInventory(t *testing.T) {
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+	exitCode := run([]string{"--workflow", "migration", "--provider", "demo", "--cluster", "cluster-east", "--tag", "PROD"}, stdout, stderr)
+	if exitCode != 0 {
+		t.Fatalf("expected exit code 0, got %d with stderr %q", exitCode, stderr.String())
+	}
+	output := stdout.String()
+	for _, name := range []string{"vm-a", "vm-c"} {
+		if !strings.Contains(output, name) {
+			t.Fatalf("expected %s in filtered plan, got %q", name, output)
+		}
+	}
+	for _, name := range []string{"vm-b", "vm-g", "example-vm-01"} {
+		if strings.Contains(output, name) {
+			t.Fatalf("expected %s to be filtered out, got %q", name, output)
+		}
+	}
+}
+
+func TestRunMigrationWorkflowReportsProviderFailure(t *testing.T) {
+	stderr := &bytes.Buffer{}
+	exitCode := run([]string{"--workflow", "migration", "--provider", "vsphere", "--vcenter", "http://127.0.0.1:1"}, &bytes.Buffer{}, stderr)
+	if exitCode != 1 || !strings.Contains(stderr.String(), "migration workflow failed") {
+		t.Fatalf("expected migration failure, got code=%d stderr=%q", exitCode, stderr.String())
+	}
+}
+
+func TestNewMigrationMoverSelectsByProvider(t *testing.T) {
+	if mover, err := newMigrationMover(vsphere.NewProvider(nil), time.Minute); err != nil || mover == nil {
+		t.Fatalf("expected Storage vMotion mover for vsphere provider, got %v %v", mover, err)
+	}
+	if mover, err := newMigrationMover(inventory.NewDemoProvider(), 0); err != nil || mover != nil {
+		t.Fatalf("expected noop mover for demo provider, got %v %v", mover, err)
+	}
+	aggregate := inventory.NewAggregateProvider([]inventory.Member{{Name: "vc-a", Provider: inventory.NewDemoProvider()}})
+	if _, err := newMigrationMover(aggregate, 0); err == nil {
+		t.Fatalf("expected aggregate provider to be rejected")
+	}
+}
+
+func TestRunMigrationWorkflowExecutesConcurrentlyWithProgress(t *testing.T) {
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+	args := []string{"--workflow", "migration", "--provider", "demo", "--execute", "--concurrency", "4", "--per-target", "1", "--tag", "prod"}
+	if exitCode := run(args, stdout, stderr); exitCode != 0 {
+		t.Fatalf("expected exit code 0, got %d with stderr %q", exitCode, stderr.String())
+	}
+	output := stdout.String()
+	if !strings.Contains(output, "Progress #1 started vm-a") || !strings.Contains(output, "migrated vm-a") ||
+		!strings.Contains(output, "Summary migrated=3 dry_run=0 failed=0") {
+		t.Fatalf("expected progress events and summary, got %q", output)
+	}
+}
+
+func TestParseFlagsReadsMigrationLimits(t *testing.T) {
+	flags, err := parseFlags([]string{"--concurrency", "8", "--per-source", "2", "--per-target", "3", "--per-host", "1"})
+	if err != nil {
+		t.Fatalf("expected limits to parse, got %v", err)
+	}
+	if flags.limits != (migration.Limits{Global: 8, PerSource: 2, PerTarget: 3, PerHost: 1}) {
+		t.Fatalf("unexpected limits: %+v", flags.limits)
+	}
+	if defaults, _ := parseFlags(nil); defaults.limits.Global != 1 || defaults.throttle != (migration.Throttle{Backoff: 30 * time.Second}) {
+		t.Fatalf("expected serial unthrottled execution by default, got %+v %+v", defaults.limits, defaults.throttle)
+	}
+	flags, err = parseFlags([]string{"--max-latency", "25", "--throttle-backoff", "10s", "--throttle-max-wait", "5m"})
+	if err != nil || flags.throttle != (migration.Throttle{LatencyMS: 25, Backoff: 10 * time.Second, MaxWait: 5 * time.Minute}) {
+		t.Fatalf("unexpected throttle: %+v err=%v", flags.throttle, err)
+	}
+}
+
+func TestMigrationWorkflowSkipsVMsFailingPreflightChecks(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	output := &bytes.Buffer{}
+	if code := run([]string{"--workflow", "migration", "--provider", "demo", "--max-snapshots", "0"}, output, &bytes.Buffer{}); code != 0 {
+		t.Fatalf("expected migration workflow to succeed, got %d", code)
+	}
+	if !strings.Contains(output.String(), "1 vm-a ds-1  - TOO_MANY_SNAPSHOTS") {
+		t.Fatalf("expected snapshot pre-flight skip, got %q", output.String())
+	}
+}
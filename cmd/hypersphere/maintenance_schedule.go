@@ -0,0 +1,19 @@
+// Path: cmd/hypersphere/maintenance_schedule.go
+// Description: Load the maintenance window schedule that gates migration and deletion changes.
+package main
+
+import "github.com/takelley1/hypersphere/internal/schedule"
+
+const scheduleEnvPath = "HYPERSPHERE_SCHEDULE_FILE"
+
+func loadMaintenanceGate() (schedule.Gate, error) {
+	path, err := configFilePath(scheduleEnvPath, "schedule")
+	if err != nil {
+		return schedule.Gate{}, err
+	}
+	windows, err := schedule.Load(path)
+	if err != nil {
+		return schedule.Gate{}, err
+	}
+	return schedule.NewGate(windows), nil
+}
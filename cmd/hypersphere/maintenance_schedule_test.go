@@ -0,0 +1,61 @@
+// Path: cmd/hypersphere/maintenance_schedule_test.go
+// Description: Validate maintenance schedule loading for migration and deletion workflows.
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/takelley1/hypersphere/internal/migration"
+)
+
+func TestWorkflowsRejectInvalidMaintenanceSchedule(t *testing.T) {
+	dir := t.TempDir()
+	schedulePath := filepath.Join(dir, "schedule.json")
+	t.Setenv(scheduleEnvPath, schedulePath)
+	if err := os.WriteFile(schedulePath, []byte(`{"windows":[{"start":"0 22 * *","duration":"7h"}]}`), 0o600); err != nil {
+		t.Fatalf("write schedule: %v", err)
+	}
+	planPath := filepath.Join(dir, "plan.json")
+	if err := migration.WritePlanFile(planPath, migration.PlanFile{Version: 1}); err != nil {
+		t.Fatalf("write plan: %v", err)
+	}
+	for _, args := range [][]string{
+		{"--workflow", "migration", "--provider", "demo"},
+		{"--workflow", "deletion"},
+		{"--provider", "demo", "apply", planPath},
+	} {
+		stderr := &bytes.Buffer{}
+		if code := run(args, &bytes.Buffer{}, stderr); code != 1 || !strings.Contains(stderr.String(), "invalid maintenance schedule") {
+			t.Fatalf("expected %v to reject schedule, got %d stderr=%q", args, code, stderr.String())
+		}
+	}
+}
+
+func TestDeletionWorkflowAppliesInsideMaintenanceWindow(t *testing.T) {
+	schedulePath := filepath.Join(t.TempDir(), "schedule.json")
+	t.Setenv(scheduleEnvPath, schedulePath)
+	if err := os.WriteFile(schedulePath, []byte(`{"windows":[{"start":"* * * * *","duration":"1m"}]}`), 0o600); err != nil {
+		t.Fatalf("write schedule: %v", err)
+	}
+	outbox := filepath.Join(t.TempDir(), "outbox")
+	t.Setenv(outboxEnvPath, outbox)
+	statePath := filepath.Join(t.TempDir(), "deletion.json")
+	t.Setenv(deletionEnvPath, statePath)
+	seedPoweredOff(t, statePath)
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+	if code := run([]string{"--workflow", "deletion", "--mode", "all", "--execute"}, stdout, stderr); code != 0 {
+		t.Fatalf("expected deletion workflow to succeed, got %d stderr=%q", code, stderr.String())
+	}
+	if !strings.Contains(stdout.String(), "Summary applied=1 deferred=0 paused=0 paused_for=0s notify_failed=0") {
+		t.Fatalf("expected deletion apply summary, got %q", stdout.String())
+	}
+	notice, err := os.ReadFile(filepath.Join(outbox, "vm-b-mark.eml"))
+	if err != nil || !strings.Contains(string(notice), "To: b@example.com") {
+		t.Fatalf("expected mark notice in the outbox, got %q err=%v", notice, err)
+	}
+}
@@ -0,0 +1,198 @@
+// Path: cmd/hypersphere/migration_plan.go
+// Description: Write reviewed migration plan files, apply them with drift checks and a resume journal, and roll them back.
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/takelley1/hypersphere/internal/app"
+	"github.com/takelley1/hypersphere/internal/config"
+	"github.com/takelley1/hypersphere/internal/migration"
+)
+
+const migrationPolicyEnvPath = "HYPERSPHERE_MIGRATION_FILE"
+
+func loadMigrationPolicy() (migration.Policy, error) {
+	path, err := configFilePath(migrationPolicyEnvPath, "migration")
+	if err != nil {
+		return migration.Policy{}, err
+	}
+	return migration.LoadPolicy(path)
+}
+
+func runPlanFileCommand(application app.App, cfg config.Config, flags cliFlags) error {
+	switch flags.command {
+	case "plan":
+		return runPlanMigration(application, cfg, flags)
+	case "rollback":
+		return runRollbackPlan(application, cfg, flags)
+	}
+	return runApplyPlan(application, cfg, flags)
+}
+
+func runPlanMigration(application app.App, cfg config.Config, flags cliFlags) error {
+	policy, err := loadMigrationPolicy()
+	if err != nil {
+		return err
+	}
+	return withMigrationInventory(flags, policy, func(source migration.Inventory, _ migration.Mover) error {
+		residents, stores, err := source.Load()
+		if err != nil {
+			return err
+		}
+		vms, stores := flags.filter.Apply(residents, stores)
+		planner := migration.NewPlanner(cfg.ThresholdPercent).
+			WithPolicy(policy).
+			WithResidents(residents).
+			WithStrategy(flags.strategy).
+			WithChecks(migration.DefaultChecks(flags.maxSnapshots)...)
+		plan := application.PlanMigration(vms, stores, planner)
+		file := migration.NewPlanFile(plan, vms, stores, cfg.ThresholdPercent, time.Now())
+		file.Strategy = flags.strategy
+		if err := migration.WritePlanFile(flags.planFile, file); err != nil {
+			return err
+		}
+		if err := os.Remove(migration.JournalPath(flags.planFile)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		application.MigrationNotice("Plan written to " + flags.planFile)
+		return nil
+	})
+}
+
+func runApplyPlan(application app.App, cfg config.Config, flags cliFlags) error {
+	file, err := migration.ReadPlanFile(flags.planFile)
+	if err != nil {
+		return err
+	}
+	policy, err := loadMigrationPolicy()
+	if err != nil {
+		return err
+	}
+	gate, err := loadMaintenanceGate()
+	if err != nil {
+		return err
+	}
+	journalPath := migration.JournalPath(flags.planFile)
+	entries, err := migration.ReadJournal(journalPath)
+	if err != nil {
+		return err
+	}
+	completed := migration.Completed(entries, file.Steps)
+	return withMigrationInventory(flags, policy, func(source migration.Inventory, mover migration.Mover) error {
+		vms, stores, err := source.Load()
+		if err != nil {
+			return err
+		}
+		pending, err := file.Pending(completed, vms, stores)
+		if err != nil {
+			return err
+		}
+		journal, err := migration.OpenJournal(journalPath)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = journal.Close() }()
+		if len(completed) > 0 {
+			application.MigrationNotice(fmt.Sprintf("Resuming plan with %d completed steps", len(completed)))
+		}
+		var recordErr error
+		planner := migration.NewPlanner(file.ThresholdPercent).
+			WithPolicy(policy).
+			WithStrategy(file.Strategy).
+			WithLimits(flags.limits).
+			WithThrottle(flags.throttle).
+			WithRetry(retryPolicy(cfg)).
+			WithChecks(migration.DefaultChecks(flags.maxSnapshots)...).
+			WithInventory(file.Scope(source)).
+			WithSchedule(gate).
+			WithProgress(func(event migration.ProgressEvent) {
+				application.MigrationProgress(event)
+				recordErr = errors.Join(recordErr, journal.Record(event))
+			})
+		cfg.Execute = true
+		summary := application.ApplyMigration(cfg, pending, planner, mover)
+		if err := writeStepResults(application, flags.resultsFile, summary); err != nil {
+			return err
+		}
+		if recordErr != nil {
+			return fmt.Errorf("journal %s: %w", journalPath, recordErr)
+		}
+		return nil
+	})
+}
+
+func runRollbackPlan(application app.App, cfg config.Config, flags cliFlags) error {
+	file, err := migration.ReadPlanFile(flags.planFile)
+	if err != nil {
+		return err
+	}
+	policy, err := loadMigrationPolicy()
+	if err != nil {
+		return err
+	}
+	gate, err := loadMaintenanceGate()
+	if err != nil {
+		return err
+	}
+	journalPath := migration.JournalPath(flags.planFile)
+	entries, err := migration.ReadJournal(journalPath)
+	if err != nil {
+		return err
+	}
+	return withMigrationInventory(flags, policy, func(source migration.Inventory, mover migration.Mover) error {
+		source = file.Scope(source)
+		vms, stores, err := source.Load()
+		if err != nil {
+			return err
+		}
+		journal, err := migration.OpenJournal(journalPath)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = journal.Close() }()
+		var recordErr error
+		planner := migration.NewPlanner(file.ThresholdPercent).
+			WithPolicy(policy).
+			WithStrategy(file.Strategy).
+			WithLimits(flags.limits).
+			WithThrottle(flags.throttle).
+			WithRetry(retryPolicy(cfg)).
+			WithChecks(migration.DefaultChecks(flags.maxSnapshots)...).
+			WithInventory(source).
+			WithSchedule(gate).
+			WithProgress(func(event migration.ProgressEvent) {
+				application.MigrationProgress(event)
+				recordErr = errors.Join(recordErr, journal.Record(event))
+			})
+		plan := planner.Rollback(entries, vms, stores)
+		if len(plan) == 0 {
+			application.MigrationNotice("Nothing to roll back for " + flags.planFile)
+			return nil
+		}
+		application.MigrationNotice(fmt.Sprintf("Rolling back %d migrated VMs", len(plan)))
+		cfg.Execute = true
+		summary := application.ApplyMigration(cfg, plan, planner, mover)
+		if err := writeStepResults(application, flags.resultsFile, summary); err != nil {
+			return err
+		}
+		if recordErr != nil {
+			return fmt.Errorf("journal %s: %w", journalPath, recordErr)
+		}
+		return nil
+	})
+}
+
+func writeStepResults(application app.App, path string, summary migration.ExecutionSummary) error {
+	if path == "" {
+		return nil
+	}
+	if err := migration.WriteResults(path, summary.Results); err != nil {
+		return err
+	}
+	application.MigrationNotice("Step results written to " + path)
+	return nil
+}
@@ -0,0 +1,281 @@
+// Path: cmd/hypersphere/migration_plan_test.go
+// Description: Validate plan migration, apply, and rollback subcommands with drift checks and journal resume.
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/takelley1/hypersphere/internal/inventory"
+	"github.com/takelley1/hypersphere/internal/migration"
+	"github.com/takelley1/hypersphere/internal/tui"
+)
+
+func TestParseFlagsReadsPlanAndApplySubcommands(t *testing.T) {
+	flags, err := parseFlags([]string{"--threshold", "80", "plan", "migration", "--out", "plan.json", "--tag", "prod"})
+	if err != nil {
+		t.Fatalf("expected plan subcommand to parse, got %v", err)
+	}
+	if flags.command != "plan" || flags.planFile != "plan.json" || flags.filter.Tag != "prod" || flags.threshold != 80 {
+		t.Fatalf("unexpected plan flags: %+v", flags)
+	}
+	flags, err = parseFlags([]string{"apply", "plan.json", "--concurrency", "4"})
+	if err != nil || flags.command != "apply" || flags.planFile != "plan.json" || flags.limits.Global != 4 {
+		t.Fatalf("unexpected apply flags: %+v err=%v", flags, err)
+	}
+	for _, args := range [][]string{
+		{"plan"},
+		{"plan", "deletion", "--out", "plan.json"},
+		{"plan", "migration"},
+		{"apply", "plan.json", "extra"},
+		{"apply", "plan.json", "--bogus"},
+	} {
+		if _, err := parseFlags(args); err == nil {
+			t.Fatalf("expected %v to be rejected", args)
+		}
+	}
+}
+
+func TestPlanThenApplyResumesFromJournal(t *testing.T) {
+	planPath := filepath.Join(t.TempDir(), "plan.json")
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+	if code := run([]string{"--provider", "demo", "plan", "migration", "--out", planPath, "--tag", "prod"}, stdout, stderr); code != 0 {
+		t.Fatalf("expected plan to succeed, got %d stderr=%q", code, stderr.String())
+	}
+	if !strings.Contains(stdout.String(), "Plan written to "+planPath) || strings.Contains(stdout.String(), "Summary") {
+		t.Fatalf("expected plan output without execution, got %q", stdout.String())
+	}
+	stdout.Reset()
+	resultsPath := filepath.Join(t.TempDir(), "results.json")
+	if code := run([]string{"--provider", "demo", "--results", resultsPath, "apply", planPath}, stdout, stderr); code != 0 {
+		t.Fatalf("expected apply to succeed, got %d stderr=%q", code, stderr.String())
+	}
+	if !strings.Contains(stdout.String(), "Summary migrated=3 dry_run=0 failed=0") || !strings.Contains(stdout.String(), "Step Results") ||
+		!strings.Contains(stdout.String(), "Step results written to "+resultsPath) {
+		t.Fatalf("expected every planned step executed, got %q", stdout.String())
+	}
+	if content, err := os.ReadFile(resultsPath); err != nil || strings.Count(string(content), `"status": "migrated"`) != 3 {
+		t.Fatalf("expected exported step results, got %s err=%v", content, err)
+	}
+	entries, err := migration.ReadJournal(migration.JournalPath(planPath))
+	if err != nil || len(entries) != 6 {
+		t.Fatalf("expected started and migrated entries per step, got %+v err=%v", entries, err)
+	}
+	stdout.Reset()
+	if code := run([]string{"--provider", "demo", "apply", planPath}, stdout, stderr); code != 0 {
+		t.Fatalf("expected resumed apply to succeed, got %d stderr=%q", code, stderr.String())
+	}
+	if !strings.Contains(stdout.String(), "Resuming plan with 3 completed steps") ||
+		!strings.Contains(stdout.String(), "Summary migrated=0 dry_run=0 failed=0") {
+		t.Fatalf("expected completed steps skipped on resume, got %q", stdout.String())
+	}
+	if code := run([]string{"--provider", "demo", "plan", "migration", "--out", planPath}, &bytes.Buffer{}, stderr); code != 0 {
+		t.Fatalf("expected replan to succeed, got %d stderr=%q", code, stderr.String())
+	}
+	if _, err := os.Stat(migration.JournalPath(planPath)); !os.IsNotExist(err) {
+		t.Fatalf("expected replan to discard the stale journal, got %v", err)
+	}
+}
+
+func TestApplyRejectsDriftedAndInvalidPlans(t *testing.T) {
+	dir := t.TempDir()
+	planPath := filepath.Join(dir, "plan.json")
+	file := migration.PlanFile{Version: 1, ThresholdPercent: 85, Steps: []migration.PlanStep{
+		{Order: 1, VMName: "vm-a", SourceDatastore: "ds-9", TargetDatastore: "vsan-east"},
+	}}
+	if err := migration.WritePlanFile(planPath, file); err != nil {
+		t.Fatalf("WritePlanFile returned error: %v", err)
+	}
+	stderr := &bytes.Buffer{}
+	if code := run([]string{"--provider", "demo", "apply", planPath}, &bytes.Buffer{}, stderr); code != 1 ||
+		!strings.Contains(stderr.String(), "apply command failed: inventory drifted since plan was built: vm vm-a is on ds-1, plan expects ds-9") {
+		t.Fatalf("expected drift failure, got %d stderr=%q", code, stderr.String())
+	}
+	stderr.Reset()
+	if code := run([]string{"--provider", "demo", "apply", filepath.Join(dir, "missing.json")}, &bytes.Buffer{}, stderr); code != 1 {
+		t.Fatalf("expected missing plan failure, got %d", code)
+	}
+	if err := os.WriteFile(migration.JournalPath(planPath), []byte("garbage\n{}\n"), 0o600); err != nil {
+		t.Fatalf("write journal: %v", err)
+	}
+	if code := run([]string{"--provider", "demo", "apply", planPath}, &bytes.Buffer{}, stderr); code != 1 {
+		t.Fatalf("expected corrupt journal failure, got %d", code)
+	}
+	if code := run([]string{"--provider", "demo", "plan", "migration", "--out", filepath.Join(dir, "absent", "plan.json")}, &bytes.Buffer{}, stderr); code != 1 {
+		t.Fatalf("expected plan write failure, got %d", code)
+	}
+}
+
+type reloadingProvider struct {
+	inventory.DemoProvider
+	reloads int
+	err     error
+}
+
+func (p *reloadingProvider) Reload() error {
+	p.reloads++
+	return p.err
+}
+
+func TestLiveMigrationInventoryReloadsAfterFirstLoad(t *testing.T) {
+	provider := &reloadingProvider{}
+	source := liveMigrationInventory(provider)
+	for range 2 {
+		if vms, stores, err := source.Load(); err != nil || len(vms) == 0 || len(stores) == 0 {
+			t.Fatalf("expected demo inventory, got %d vms %d stores err=%v", len(vms), len(stores), err)
+		}
+	}
+	if provider.reloads != 1 {
+		t.Fatalf("expected one reload for the second load, got %d", provider.reloads)
+	}
+	provider.err = errors.New("session expired")
+	if _, _, err := source.Load(); err == nil {
+		t.Fatalf("expected reload failure")
+	}
+}
+
+type watchingProvider struct {
+	reloadingProvider
+	waits   []time.Duration
+	changes error
+}
+
+func (p *watchingProvider) WatchChanges(_ context.Context, maxWait time.Duration) ([]tui.CatalogChange, error) {
+	p.waits = append(p.waits, maxWait)
+	return nil, p.changes
+}
+
+func TestLiveMigrationInventoryAppliesWatchedChangesInsteadOfReloading(t *testing.T) {
+	provider := &watchingProvider{}
+	source := liveMigrationInventory(provider)
+	for range 3 {
+		if vms, _, err := source.Load(); err != nil || len(vms) == 0 {
+			t.Fatalf("expected demo inventory, got %d vms err=%v", len(vms), err)
+		}
+	}
+	if provider.reloads != 0 || len(provider.waits) != 2 || provider.waits[0] != 0 {
+		t.Fatalf("expected two non-blocking change polls and no reload, got reloads=%d waits=%v", provider.reloads, provider.waits)
+	}
+	provider.changes = errors.New("session expired")
+	if _, _, err := source.Load(); err == nil {
+		t.Fatalf("expected watch failure")
+	}
+}
+
+func TestPlanMigrationRecordsStrategyAndScore(t *testing.T) {
+	planPath := filepath.Join(t.TempDir(), "plan.json")
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+	if code := run([]string{"--provider", "demo", "--strategy", "ffd", "plan", "migration", "--out", planPath}, stdout, stderr); code != 0 {
+		t.Fatalf("expected plan to succeed, got %d stderr=%q", code, stderr.String())
+	}
+	if !strings.Contains(stdout.String(), "Score strategy=first-fit-decreasing placed=") {
+		t.Fatalf("expected plan score line, got %q", stdout.String())
+	}
+	file, err := migration.ReadPlanFile(planPath)
+	if err != nil || file.Strategy != migration.StrategyFirstFitDecreasing {
+		t.Fatalf("expected strategy stored in plan file, got %+v err=%v", file, err)
+	}
+	if _, err := parseFlags([]string{"--strategy", "random"}); err == nil {
+		t.Fatalf("expected unknown strategy to be rejected")
+	}
+}
+
+func TestMigrationWorkflowEnforcesTierPolicyFile(t *testing.T) {
+	policyPath := filepath.Join(t.TempDir(), "migration.json")
+	t.Setenv(migrationPolicyEnvPath, policyPath)
+	if err := os.WriteFile(policyPath, []byte(`{"tiers":{"tag_tiers":{"prod":["tertiary"]}}}`), 0o600); err != nil {
+		t.Fatalf("write policy: %v", err)
+	}
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+	if code := run([]string{"--workflow", "migration", "--provider", "demo", "--tag", "prod"}, stdout, stderr); code != 1 ||
+		!strings.Contains(stderr.String(), "datastore tier unknown") {
+		t.Fatalf("expected untiered datastores rejected under tier rules, got %d stderr=%q", code, stderr.String())
+	}
+	tiered := `{"tiers":{"tag_tiers":{"prod":["tertiary"]},"datastores":[{"pattern":"*","tier":"primary"}]}}`
+	if err := os.WriteFile(policyPath, []byte(tiered), 0o600); err != nil {
+		t.Fatalf("write policy: %v", err)
+	}
+	stderr.Reset()
+	if code := run([]string{"--workflow", "migration", "--provider", "demo", "--tag", "prod"}, stdout, stderr); code != 0 {
+		t.Fatalf("expected workflow to succeed, got %d stderr=%q", code, stderr.String())
+	}
+	if strings.Count(stdout.String(), migration.SkipTierPolicy) != 3 {
+		t.Fatalf("expected every prod VM skipped by tier policy, got %q", stdout.String())
+	}
+	if err := os.WriteFile(policyPath, []byte(`{"tiers":{"thresholds":{"gold":80}}}`), 0o600); err != nil {
+		t.Fatalf("write policy: %v", err)
+	}
+	planPath := filepath.Join(t.TempDir(), "plan.json")
+	if err := migration.WritePlanFile(planPath, migration.PlanFile{Version: 1}); err != nil {
+		t.Fatalf("write plan: %v", err)
+	}
+	for _, args := range [][]string{
+		{"--workflow", "migration", "--provider", "demo"},
+		{"--provider", "demo", "plan", "migration", "--out", planPath},
+		{"--provider", "demo", "apply", planPath},
+	} {
+		stderr.Reset()
+		if code := run(args, &bytes.Buffer{}, stderr); code != 1 || !strings.Contains(stderr.String(), "invalid migration policy") {
+			t.Fatalf("expected invalid policy to fail %v, got %d stderr=%q", args, code, stderr.String())
+		}
+	}
+}
+
+func TestRollbackReturnsJournaledMovesToTheirOrigin(t *testing.T) {
+	planPath := filepath.Join(t.TempDir(), "plan.json")
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+	if code := run([]string{"--provider", "demo", "plan", "migration", "--out", planPath, "--tag", "prod"}, stdout, stderr); code != 0 {
+		t.Fatalf("expected plan to succeed, got %d stderr=%q", code, stderr.String())
+	}
+	stdout.Reset()
+	if code := run([]string{"--provider", "demo", "rollback", planPath}, stdout, stderr); code != 0 ||
+		!strings.Contains(stdout.String(), "Nothing to roll back for "+planPath) {
+		t.Fatalf("expected empty rollback without a journal, got %d stdout=%q stderr=%q", code, stdout.String(), stderr.String())
+	}
+	file, err := migration.ReadPlanFile(planPath)
+	if err != nil {
+		t.Fatalf("read plan: %v", err)
+	}
+	step := file.Steps[0]
+	journal, err := migration.OpenJournal(migration.JournalPath(planPath))
+	if err != nil {
+		t.Fatalf("open journal: %v", err)
+	}
+	moved := migration.PlanStep{Order: 1, VMName: step.VMName, SourceDatastore: step.TargetDatastore, TargetDatastore: step.SourceDatastore}
+	_ = journal.Record(migration.ProgressEvent{Step: moved, State: migration.ProgressMigrated})
+	_ = journal.Close()
+	stdout.Reset()
+	if code := run([]string{"--provider", "demo", "rollback", planPath}, stdout, stderr); code != 0 {
+		t.Fatalf("expected rollback to succeed, got %d stderr=%q", code, stderr.String())
+	}
+	ready := fmt.Sprintf("1 %s %s %s", step.VMName, step.SourceDatastore, step.TargetDatastore)
+	if !strings.Contains(stdout.String(), "Rolling back 1 migrated VMs") || !strings.Contains(stdout.String(), ready) ||
+		!strings.Contains(stdout.String(), "Summary migrated=1 dry_run=0 failed=0") {
+		t.Fatalf("expected journaled move rolled back, got %q", stdout.String())
+	}
+	entries, err := migration.ReadJournal(migration.JournalPath(planPath))
+	if err != nil || !entries[len(entries)-1].Rollback || entries[len(entries)-1].State != migration.ProgressMigrated {
+		t.Fatalf("expected the rollback journaled, got %+v err=%v", entries, err)
+	}
+	stdout.Reset()
+	if code := run([]string{"--provider", "demo", "rollback", planPath}, stdout, stderr); code != 0 ||
+		!strings.Contains(stdout.String(), "Nothing to roll back for "+planPath) {
+		t.Fatalf("expected a second rollback to find nothing, got %d stdout=%q stderr=%q", code, stdout.String(), stderr.String())
+	}
+	for _, args := range [][]string{{"rollback"}, {"--provider", "demo", "rollback", filepath.Join(t.TempDir(), "absent.json")}} {
+		if code := run(args, &bytes.Buffer{}, &bytes.Buffer{}); code == 0 {
+			t.Fatalf("expected %v to fail", args)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+// Path: cmd/hypersphere/migration_scope.go
+// Description: Reject migration filters and tier rules the provider cannot evaluate and unscoped moves against vCenter.
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/takelley1/hypersphere/internal/migration"
+	"github.com/takelley1/hypersphere/internal/tui"
+)
+
+var errUnscopedExecute = errors.New("--execute against vCenter needs --source-datastore, --cluster, --folder, or --tag")
+
+func checkTagFilter(filter migration.Filter, provider tui.InventoryProvider) error {
+	if filter.Tag == "" {
+		return nil
+	}
+	return requireTags(provider, fmt.Sprintf("--tag %q", filter.Tag))
+}
+
+func checkTagTiers(tiers migration.TierPolicy, provider tui.InventoryProvider) error {
+	if len(tiers.TagTiers) == 0 {
+		return nil
+	}
+	return requireTags(provider, "tiers.tag_tiers")
+}
+
+func requireTags(provider tui.InventoryProvider, usage string) error {
+	tags, err := provider.ListTags()
+	if err != nil {
+		return err
+	}
+	if len(tags) == 0 {
+		return fmt.Errorf("%s cannot match: the inventory provider reports no tags", usage)
+	}
+	return nil
+}
+
+func checkExecuteScope(filter migration.Filter, execute bool, mover migration.Mover) error {
+	if execute && mover != nil && filter == (migration.Filter{}) {
+		return errUnscopedExecute
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+// Path: cmd/hypersphere/migration_scope_test.go
+// Description: Validate rejection of unmatchable tag filters, tag tier rules, and unscoped vCenter moves.
+package main
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/takelley1/hypersphere/internal/inventory"
+	"github.com/takelley1/hypersphere/internal/migration"
+	"github.com/takelley1/hypersphere/internal/tui"
+	"github.com/takelley1/hypersphere/internal/vsphere"
+)
+
+type taglessProvider struct {
+	inventory.DemoProvider
+	err error
+}
+
+func (p taglessProvider) ListTags() ([]tui.TagRow, error) {
+	return nil, p.err
+}
+
+func TestCheckTagFilterNeedsProviderTags(t *testing.T) {
+	prod := migration.Filter{Tag: "prod"}
+	if err := checkTagFilter(prod, inventory.NewDemoProvider()); err != nil {
+		t.Fatalf("expected demo tags to allow --tag, got %v", err)
+	}
+	if err := checkTagFilter(migration.Filter{}, taglessProvider{}); err != nil {
+		t.Fatalf("expected no tag filter to pass, got %v", err)
+	}
+	if err := checkTagFilter(prod, taglessProvider{}); err == nil || !strings.Contains(err.Error(), "reports no tags") {
+		t.Fatalf("expected --tag rejected without tag data, got %v", err)
+	}
+	failure := errors.New("list tags failed")
+	if err := checkTagFilter(prod, taglessProvider{err: failure}); !errors.Is(err, failure) {
+		t.Fatalf("expected list failure returned, got %v", err)
+	}
+}
+
+func TestCheckTagTiersNeedsProviderTags(t *testing.T) {
+	gold := migration.TierPolicy{TagTiers: map[string][]migration.Tier{"gold": {migration.TierPrimary}}}
+	if err := checkTagTiers(migration.TierPolicy{}, taglessProvider{}); err != nil {
+		t.Fatalf("expected no tag tiers to pass, got %v", err)
+	}
+	if err := checkTagTiers(gold, inventory.NewDemoProvider()); err != nil {
+		t.Fatalf("expected demo tags to allow tag tiers, got %v", err)
+	}
+	if err := checkTagTiers(gold, taglessProvider{}); err == nil || !strings.Contains(err.Error(), "tiers.tag_tiers cannot match") {
+		t.Fatalf("expected tag tiers rejected without tag data, got %v", err)
+	}
+}
+
+func TestCheckExecuteScopeNeedsASelectorForVCenter(t *testing.T) {
+	mover := vsphere.NewMover(context.Background(), nil)
+	if err := checkExecuteScope(migration.Filter{}, true, mover); !errors.Is(err, errUnscopedExecute) {
+		t.Fatalf("expected unscoped execute rejected, got %v", err)
+	}
+	for _, ok := range []struct {
+		filter  migration.Filter
+		execute bool
+		mover   migration.Mover
+	}{
+		{migration.Filter{SourceDatastore: "ds-a"}, true, mover},
+		{migration.Filter{}, false, mover},
+		{migration.Filter{}, true, nil},
+	} {
+		if err := checkExecuteScope(ok.filter, ok.execute, ok.mover); err != nil {
+			t.Fatalf("expected %+v allowed, got %v", ok, err)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+// Path: cmd/hypersphere/retry_config.go
+// Description: Resolve migration retry settings from CLI flags, then the main config file, then defaults.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/takelley1/hypersphere/internal/config"
+	"github.com/takelley1/hypersphere/internal/migration"
+)
+
+const retryJitter = 0.2
+
+type retrySettings struct {
+	count   int
+	backoff time.Duration
+	timeout time.Duration
+}
+
+func resolveRetrySettings(flagSet *flag.FlagSet, values startupFlagValues) (retrySettings, error) {
+	settings := retrySettings{count: *values.retries, backoff: *values.retryBackoff, timeout: *values.retryTimeout}
+	explicit := map[string]bool{}
+	flagSet.Visit(func(set *flag.Flag) { explicit[set.Name] = true })
+	configured, err := mainConfigValues()
+	if err != nil {
+		return retrySettings{}, err
+	}
+	if value, ok := configured["retries"]; ok && !explicit["retries"] {
+		if settings.count, err = strconv.Atoi(value); err != nil {
+			return retrySettings{}, fmt.Errorf("invalid retries in config: %q", value)
+		}
+	}
+	if value, ok := configured["retry_backoff"]; ok && !explicit["retry-backoff"] {
+		if settings.backoff, err = time.ParseDuration(value); err != nil {
+			return retrySettings{}, fmt.Errorf("invalid retry_backoff in config: %q", value)
+		}
+	}
+	if value, ok := configured["retry_timeout"]; ok && !explicit["retry-timeout"] {
+		if settings.timeout, err = time.ParseDuration(value); err != nil {
+			return retrySettings{}, fmt.Errorf("invalid retry_timeout in config: %q", value)
+		}
+	}
+	if settings.count < 0 || settings.backoff < 0 || settings.timeout < 0 {
+		return retrySettings{}, fmt.Errorf("retry settings must not be negative")
+	}
+	return settings, nil
+}
+
+func retryPolicy(cfg config.Config) migration.RetryPolicy {
+	return migration.RetryPolicy{Backoff: cfg.RetryBackoff, MaxElapsed: cfg.RetryTimeout, Jitter: retryJitter}
+}
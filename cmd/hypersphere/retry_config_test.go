@@ -0,0 +1,64 @@
+// Path: cmd/hypersphere/retry_config_test.go
+// Description: Validate migration retry settings precedence across flags, config file, and defaults.
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/takelley1/hypersphere/internal/config"
+)
+
+func writeMainConfig(t *testing.T, content string) {
+	t.Helper()
+	homeDir := t.TempDir()
+	t.Setenv("HOME", homeDir)
+	configDir := filepath.Join(homeDir, ".hypersphere")
+	if err := os.MkdirAll(configDir, 0o755); err != nil {
+		t.Fatalf("create config dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(configDir, "config.yaml"), []byte(content), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+}
+
+func TestParseFlagsResolvesRetrySettings(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	flags, err := parseFlags(nil)
+	if err != nil || flags.retry != (retrySettings{count: config.DefaultRetries, backoff: config.DefaultRetryBackoff}) {
+		t.Fatalf("expected default retry settings, got %+v err=%v", flags.retry, err)
+	}
+	writeMainConfig(t, "# retries\nretries: 4\nretry_backoff: 5s\nretry_timeout: 10m\n")
+	flags, err = parseFlags([]string{"--retry-backoff", "2s"})
+	if err != nil || flags.retry != (retrySettings{count: 4, backoff: 2 * time.Second, timeout: 10 * time.Minute}) {
+		t.Fatalf("expected config retries with flag backoff, got %+v err=%v", flags.retry, err)
+	}
+	policy := retryPolicy(config.Config{RetryBackoff: time.Second, RetryTimeout: time.Minute})
+	if policy.Backoff != time.Second || policy.MaxElapsed != time.Minute || policy.Jitter != retryJitter {
+		t.Fatalf("unexpected retry policy %+v", policy)
+	}
+}
+
+func TestParseFlagsRejectsInvalidRetrySettings(t *testing.T) {
+	for _, content := range []string{"retries: many\n", "retry_backoff: soon\n", "retry_timeout: never\n", "retries: -1\n"} {
+		writeMainConfig(t, content)
+		if _, err := parseFlags(nil); err == nil || !strings.Contains(err.Error(), "retr") {
+			t.Fatalf("expected %q rejected, got %v", content, err)
+		}
+	}
+	t.Setenv("HOME", "")
+	if _, err := mainConfigValues(); err == nil {
+		t.Fatalf("expected missing home directory to fail")
+	}
+	homeDir := t.TempDir()
+	t.Setenv("HOME", homeDir)
+	if err := os.MkdirAll(filepath.Join(homeDir, ".hypersphere", "config.yaml"), 0o755); err != nil {
+		t.Fatalf("create config dir in place of file: %v", err)
+	}
+	if _, err := mainConfigValues(); err == nil {
+		t.Fatalf("expected unreadable config to fail")
+	}
+}
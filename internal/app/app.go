@@ -22,6 +22,7 @@ type TimeValue struct {
 type MigrationPlanner interface {
 	BuildPlan(vms []migration.VM, stores []migration.Datastore) []migration.PlanStep
 	ExecutePlan(plan []migration.PlanStep, execute bool, retries int, mover migration.Mover) migration.ExecutionSummary
+	Score(plan []migration.PlanStep, stores []migration.Datastore) migration.PlanScore
 }
 
 // DeletionEngine defines pending deletion workflow behavior.
@@ -29,6 +30,11 @@ type DeletionEngine interface {
 	Plan(vms []deletion.VM, mode deletion.Mode, now TimeValue) []deletion.Action
 }
 
+// DeletionApplier applies planned pending deletion actions.
+type DeletionApplier interface {
+	ApplyPlan(vms []deletion.VM, actions []deletion.Action, now TimeValue) ([]deletion.VM, deletion.ApplySummary)
+}
+
 // App prints workflow outputs.
 type App struct {
 	out io.Writer
@@ -39,15 +45,112 @@ func New(out io.Writer) App {
 	return App{out: out}
 }
 
-// RunMigration render and execute migration plan.
-func (a App) RunMigration(cfg config.Config, vms []migration.VM, stores []migration.Datastore, planner MigrationPlanner) migration.ExecutionSummary {
+// RunMigration build, render, and execute a migration plan; a nil mover moves nothing.
+func (a App) RunMigration(
+	cfg config.Config,
+	vms []migration.VM,
+	stores []migration.Datastore,
+	planner MigrationPlanner,
+	mover migration.Mover,
+) migration.ExecutionSummary {
+	return a.executeMigration(cfg, a.PlanMigration(vms, stores, planner), planner, mover)
+}
+
+// PlanMigration build and render a migration plan and its score without executing it.
+func (a App) PlanMigration(vms []migration.VM, stores []migration.Datastore, planner MigrationPlanner) []migration.PlanStep {
 	plan := planner.BuildPlan(vms, stores)
 	_, _ = fmt.Fprint(a.out, tui.RenderMigrationPlan(plan))
-	summary := planner.ExecutePlan(plan, cfg.Execute, 2, noopMover{})
-	_, _ = fmt.Fprintf(a.out, "Summary migrated=%d dry_run=%d failed=%d\n", summary.MigratedCount, summary.DryRunCount, summary.FailedCount)
+	score := planner.Score(plan, stores)
+	_, _ = fmt.Fprintf(
+		a.out,
+		"Score strategy=%s placed=%d skipped=%d placed_gb=%d skipped_gb=%d max_util=%d util_stddev=%.1f score=%.1f\n",
+		score.Strategy,
+		score.Placed,
+		score.Skipped,
+		score.PlacedGB,
+		score.SkippedGB,
+		score.MaxUtil,
+		score.UtilStdDev,
+		score.Score,
+	)
+	return plan
+}
+
+// ApplyMigration render and execute an existing migration plan; a nil mover moves nothing.
+func (a App) ApplyMigration(
+	cfg config.Config,
+	plan []migration.PlanStep,
+	planner MigrationPlanner,
+	mover migration.Mover,
+) migration.ExecutionSummary {
+	_, _ = fmt.Fprint(a.out, tui.RenderMigrationPlan(plan))
+	return a.executeMigration(cfg, plan, planner, mover)
+}
+
+func (a App) executeMigration(
+	cfg config.Config,
+	plan []migration.PlanStep,
+	planner MigrationPlanner,
+	mover migration.Mover,
+) migration.ExecutionSummary {
+	if mover == nil {
+		mover = noopMover{}
+	}
+	summary := planner.ExecutePlan(plan, cfg.Execute, cfg.Retries+1, mover)
+	if cfg.Execute {
+		_, _ = fmt.Fprint(a.out, tui.RenderStepResults(summary.Results))
+	}
+	_, _ = fmt.Fprintf(
+		a.out,
+		"Summary migrated=%d dry_run=%d failed=%d drifted=%d paused=%d paused_for=%s throttled=%d throttled_for=%s\n",
+		summary.MigratedCount,
+		summary.DryRunCount,
+		summary.FailedCount,
+		summary.DriftedCount,
+		summary.PausedCount,
+		summary.Paused,
+		summary.ThrottledCount,
+		summary.Throttled,
+	)
 	return summary
 }
 
+// PlanCompute build and render a host balancing plan, or an evacuation plan
+// for host when it is set.
+func (a App) PlanCompute(vms []migration.VM, hosts []migration.Host, host string, planner migration.ComputePlanner) []migration.PlanStep {
+	plan := planner.Balance(vms, hosts)
+	if host != "" {
+		plan = planner.Evacuate(vms, hosts, host)
+	}
+	_, _ = fmt.Fprint(a.out, tui.RenderComputePlan(plan))
+	return plan
+}
+
+// MigrationProgress print one migration execution progress event.
+func (a App) MigrationProgress(event migration.ProgressEvent) {
+	switch event.State {
+	case migration.ProgressPaused:
+		_, _ = fmt.Fprintf(a.out, "Progress paused outside maintenance window until %s\n", event.Until.Format(time.RFC3339))
+		return
+	case migration.ProgressResumed:
+		_, _ = fmt.Fprintln(a.out, "Progress resumed inside maintenance window")
+		return
+	}
+	line := fmt.Sprintf("Progress #%d %s %s -> %s", event.Step.Order, event.State, event.Step.VMName, event.Step.TargetDatastore)
+	if event.Attempts > 0 {
+		line += fmt.Sprintf(" attempts=%d", event.Attempts)
+	}
+	if event.Err != nil {
+		line += fmt.Sprintf(" error=%v", event.Err)
+	}
+	_, _ = fmt.Fprintln(a.out, line)
+}
+
+// MigrationNotice print one informational migration workflow line.
+func (a App) MigrationNotice(message string) {
+	_, _ = fmt.Fprintln(a.out, message)
+}
+
 // RunDeletion render pending deletion actions.
 func (a App) RunDeletion(vms []deletion.VM, mode deletion.Mode, now TimeValue, engine DeletionEngine) []deletion.Action {
 	actions := engine.Plan(vms, mode, now)
@@ -55,6 +158,33 @@ func (a App) RunDeletion(vms []deletion.VM, mode deletion.Mode, now TimeValue, e
 	return actions
 }
 
+// ApplyDeletion apply planned pending deletion actions and print failed
+// owner notices, failed executor steps, and the apply summary.
+func (a App) ApplyDeletion(vms []deletion.VM, actions []deletion.Action, now TimeValue, engine DeletionApplier) []deletion.VM {
+	updated, summary := engine.ApplyPlan(vms, actions, now)
+	for _, err := range summary.NotifyErrors {
+		_, _ = fmt.Fprintf(a.out, "Notice failed: %v\n", err)
+	}
+	for _, err := range summary.StepErrors {
+		_, _ = fmt.Fprintf(a.out, "Step failed: %v\n", err)
+	}
+	for _, err := range summary.SaveErrors {
+		_, _ = fmt.Fprintf(a.out, "Save failed: %v\n", err)
+	}
+	_, _ = fmt.Fprintf(
+		a.out,
+		"Summary applied=%d deferred=%d paused=%d paused_for=%s notify_failed=%d step_failed=%d save_failed=%d\n",
+		summary.AppliedCount,
+		summary.DeferredCount,
+		summary.PausedCount,
+		summary.Paused,
+		len(summary.NotifyErrors),
+		len(summary.StepErrors),
+		len(summary.SaveErrors),
+	)
+	return updated
+}
+
 type noopMover struct{}
 
 func (noopMover) Move(string, string) error {
@@ -17,7 +17,7 @@ func TestRunMigrationWithConcretePlannerCoversNoopMover(t *testing.T) {
 	cfg := config.Config{Execute: true}
 	vms := []migration.VM{{Name: "vm", SizeGB: 1, SourceDatastore: "src"}}
 	stores := []migration.Datastore{{Name: "src", CapacityGB: 100, UsedGB: 10, Tier: migration.TierPrimary}, {Name: "dst", CapacityGB: 100, UsedGB: 20, Tier: migration.TierPrimary}}
-	summary := application.RunMigration(cfg, vms, stores, planner)
+	summary := application.RunMigration(cfg, vms, stores, planner, nil)
 	if summary.MigratedCount != 1 {
 		t.Fatalf("expected one migrated VM, got %+v", summary)
 	}
@@ -4,7 +4,9 @@ package app
 
 import (
 	"bytes"
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/takelley1/hypersphere/internal/config"
 	"github.com/takelley1/hypersphere/internal/deletion"
@@ -24,6 +26,10 @@ func (f fakePlanner) ExecutePlan(_ []migration.PlanStep, _ bool, _ int, _ migrat
 	return f.sum
 }
 
+func (f fakePlanner) Score(_ []migration.PlanStep, _ []migration.Datastore) migration.PlanScore {
+	return migration.PlanScore{Strategy: migration.StrategyBestFit, Placed: len(f.plan), Score: 87.5}
+}
+
 type fakeDeletionEngine struct {
 	actions []deletion.Action
 }
@@ -32,12 +38,23 @@ func (f fakeDeletionEngine) Plan(_ []deletion.VM, _ deletion.Mode, _ TimeValue)
 	return f.actions
 }
 
+func (f fakeDeletionEngine) ApplyPlan(vms []deletion.VM, _ []deletion.Action, _ TimeValue) ([]deletion.VM, deletion.ApplySummary) {
+	return vms, deletion.ApplySummary{
+		AppliedCount: len(f.actions),
+		PausedCount:  1,
+		Paused:       time.Hour,
+		NotifyErrors: []error{errors.New("relay down")},
+		StepErrors:   []error{errors.New("destroy refused")},
+		SaveErrors:   []error{errors.New("store down")},
+	}
+}
+
 func TestRunMigrationWorkflow(t *testing.T) {
 	buf := &bytes.Buffer{}
 	application := New(buf)
 	cfg := config.Config{Execute: false}
 	planner := fakePlanner{plan: []migration.PlanStep{{Order: 1, VMName: "vm-a", TargetDatastore: "ds-1"}}, sum: migration.ExecutionSummary{DryRunCount: 1}}
-	summary := application.RunMigration(cfg, nil, nil, planner)
+	summary := application.RunMigration(cfg, nil, nil, planner, nil)
 	if summary.DryRunCount != 1 {
 		t.Fatalf("unexpected summary: %+v", summary)
 	}
@@ -50,8 +67,38 @@ func TestRunDeletionWorkflow(t *testing.T) {
 	buf := &bytes.Buffer{}
 	application := New(buf)
 	engine := fakeDeletionEngine{actions: []deletion.Action{{Type: deletion.ActionMark, VMName: "vm-a"}}}
-	application.RunDeletion([]deletion.VM{}, deletion.ModeAll, TimeValue{}, engine)
+	actions := application.RunDeletion([]deletion.VM{}, deletion.ModeAll, TimeValue{}, engine)
 	if buf.Len() == 0 {
 		t.Fatalf("expected workflow output")
 	}
+	application.ApplyDeletion([]deletion.VM{}, actions, TimeValue{}, engine)
+	if !bytes.HasSuffix(buf.Bytes(), []byte("Notice failed: relay down\nStep failed: destroy refused\nSave failed: store down\n"+
+		"Summary applied=1 deferred=0 paused=1 paused_for=1h0m0s notify_failed=1 step_failed=1 save_failed=1\n")) {
+		t.Fatalf("expected apply summary, got %q", buf.String())
+	}
+}
+
+func TestPlanMigrationRendersWithoutExecuting(t *testing.T) {
+	buf := &bytes.Buffer{}
+	planner := fakePlanner{plan: []migration.PlanStep{{Order: 1, VMName: "vm-a", TargetDatastore: "ds-1"}}, sum: migration.ExecutionSummary{MigratedCount: 1}}
+	plan := New(buf).PlanMigration(nil, nil, planner)
+	New(buf).MigrationNotice("Plan written to plan.json")
+	if len(plan) != 1 || !bytes.Contains(buf.Bytes(), []byte("vm-a")) || bytes.Contains(buf.Bytes(), []byte("Summary")) ||
+		!bytes.Contains(buf.Bytes(), []byte("Score strategy=best-fit placed=1 skipped=0 placed_gb=0 skipped_gb=0 max_util=0 util_stddev=0.0 score=87.5\n")) ||
+		!bytes.HasSuffix(buf.Bytes(), []byte("Plan written to plan.json\n")) {
+		t.Fatalf("expected rendered plan without execution summary, got %q", buf.String())
+	}
+}
+
+func TestApplyMigrationExecutesExistingPlan(t *testing.T) {
+	buf := &bytes.Buffer{}
+	results := []migration.StepResult{{Order: 1, VMName: "vm-a", SourceDatastore: "src", TargetDatastore: "ds-1", Status: migration.ProgressMigrated, Attempts: 1}}
+	planner := fakePlanner{sum: migration.ExecutionSummary{MigratedCount: 1, DriftedCount: 2, Results: results}}
+	plan := []migration.PlanStep{{Order: 1, VMName: "vm-a", TargetDatastore: "ds-1"}}
+	summary := New(buf).ApplyMigration(config.Config{Execute: true}, plan, planner, nil)
+	if summary.MigratedCount != 1 || !bytes.Contains(buf.Bytes(), []byte("vm-a")) ||
+		!bytes.Contains(buf.Bytes(), []byte("Step Results\n# VM SOURCE TARGET STATUS ATTEMPTS DURATION GB ERROR\n1 vm-a src ds-1 migrated 1 0s 0 -\n")) ||
+		!bytes.Contains(buf.Bytes(), []byte("Summary migrated=1 dry_run=0 failed=0 drifted=2 paused=0 paused_for=0s throttled=0 throttled_for=0s")) || bytes.Contains(buf.Bytes(), []byte("Score")) {
+		t.Fatalf("expected rendered plan and summary without a score, got %q", buf.String())
+	}
 }
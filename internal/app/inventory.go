@@ -0,0 +1,116 @@
+// Path: internal/app/inventory.go
+// Description: Convert explorer inventory catalogs into migration planner and deletion lifecycle inputs.
+package app
+
+import (
+	"strings"
+
+	"github.com/takelley1/hypersphere/internal/deletion"
+	"github.com/takelley1/hypersphere/internal/migration"
+	"github.com/takelley1/hypersphere/internal/tui"
+)
+
+// MigrationInventory map catalog VM and datastore rows to migration candidates and targets.
+// Device backings, backup state, and datastore host mounts carry over for
+// the pre-flight checks.
+// Committed storage already holds snapshot deltas and a running VM's swap
+// file, so snapshots are split out of it and swap is only added for VMs that
+// are not powered on.
+func MigrationInventory(catalog tui.Catalog) ([]migration.VM, []migration.Datastore) {
+	vms := make([]migration.VM, 0, len(catalog.VMs))
+	for _, row := range catalog.VMs {
+		vm := migration.VM{
+			Name:             row.Name,
+			SizeGB:           max(row.UsedStorageGB-row.SnapshotTotalGB, 0),
+			ProvisionedGB:    max(row.ProvisionedStorageGB-row.SnapshotTotalGB, 0),
+			SnapshotGB:       row.SnapshotTotalGB,
+			CPUMHz:           row.UsedCPUMHz,
+			MemoryMB:         row.MemoryMB,
+			SourceDatastore:  row.Datastore,
+			Cluster:          row.Cluster,
+			Folder:           row.Folder,
+			Host:             row.Host,
+			Tags:             splitTags(row.Tags),
+			Snapshots:        row.SnapshotCount,
+			ISODatastore:     row.ISODatastore,
+			IndependentDisks: row.IndependentDisks,
+			RDMDisks:         row.RDMDisks,
+			BackingUp:        row.BackupRunning,
+		}
+		if row.PowerState != "on" {
+			vm.SwapGB = row.SwapGB
+		}
+		vms = append(vms, vm)
+	}
+	stores := make([]migration.Datastore, 0, len(catalog.Datastores))
+	for _, row := range catalog.Datastores {
+		stores = append(stores, migration.Datastore{
+			Name:          row.Name,
+			CapacityGB:    row.CapacityGB,
+			UsedGB:        row.UsedGB,
+			ProvisionedGB: row.ProvisionedGB,
+			Tier:          datastoreTier(splitTags(row.Tags)),
+			Cluster:       row.Cluster,
+			LatencyMS:     row.LatencyMS,
+			Local:         row.Local,
+			Hosts:         splitTags(row.Hosts),
+		})
+	}
+	return vms, stores
+}
+
+// DeletionInventory map catalog VM rows to deletion lifecycle candidates. Only
+// the current power state is known; the metadata store supplies how long it
+// has held through deletion.TrackPoweredOff.
+func DeletionInventory(catalog tui.Catalog) []deletion.VM {
+	vms := make([]deletion.VM, 0, len(catalog.VMs))
+	for _, row := range catalog.VMs {
+		vms = append(vms, deletion.VM{
+			Name:       row.Name,
+			Folder:     row.Folder,
+			Cluster:    row.Cluster,
+			Tags:       splitTags(row.Tags),
+			PoweredOff: row.PowerState == "off",
+			OwnerEmail: row.Owner,
+		})
+	}
+	return vms
+}
+
+// ComputeHosts map catalog host rows to vMotion targets.
+func ComputeHosts(catalog tui.Catalog) []migration.Host {
+	hosts := make([]migration.Host, 0, len(catalog.Hosts))
+	for _, row := range catalog.Hosts {
+		hosts = append(hosts, migration.Host{
+			Name:            row.Name,
+			Cluster:         row.Cluster,
+			State:           row.ConnectionState,
+			CPUMHz:          row.CPUMHz,
+			MemoryMB:        row.MemoryMB,
+			CPUUsagePercent: row.CPUUsagePercent,
+			MemUsagePercent: row.MemUsagePercent,
+		})
+	}
+	return hosts
+}
+
+// datastoreTier read the tier from a primary, secondary, tertiary, or tier=<name>
+// tag, leaving it empty for the migration policy to resolve.
+func datastoreTier(tags []string) migration.Tier {
+	for _, tag := range tags {
+		if tier, ok := migration.ParseTier(tag); ok {
+			return tier
+		}
+	}
+	return ""
+}
+
+func splitTags(value string) []string {
+	tags := []string{}
+	for _, tag := range strings.Split(value, ",") {
+		if trimmed := strings.TrimSpace(tag); trimmed != "" {
+			tags = append(tags, trimmed)
+		}
+	}
+	return tags
+}
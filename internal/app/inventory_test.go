@@ -0,0 +1,162 @@
+// Path: internal/app/inventory_test.go
+// Description: Validate catalog conversion into migration and deletion inputs and mover pass-through.
+package app
+
+import (
+	"bytes"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/takelley1/hypersphere/internal/config"
+	"github.com/takelley1/hypersphere/internal/migration"
+	"github.com/takelley1/hypersphere/internal/tui"
+)
+
+type recordingMover struct {
+	moves []string
+	err   error
+}
+
+func (m *recordingMover) Move(vmName string, target string) error {
+	m.moves = append(m.moves, vmName+"->"+target)
+	return m.err
+}
+
+type busyError struct{}
+
+func (busyError) Error() string {
+	return "busy"
+}
+
+func (busyError) Retriable() bool {
+	return true
+}
+
+func TestMigrationInventoryMapsCatalogRows(t *testing.T) {
+	catalog := tui.Catalog{
+		VMs: []tui.VMRow{
+			{Name: "vm-a", Tags: "prod, linux,", Cluster: "east", Folder: "/dc/vm/Prod", Datastore: "ds-1", PowerState: "off",
+				UsedStorageGB: 40, ProvisionedStorageGB: 100, SnapshotTotalGB: 5, SwapGB: 4, SnapshotCount: 3},
+			{Name: "vm-b", Datastore: "ds-1", PowerState: "on", UsedStorageGB: 3, SnapshotTotalGB: 5, SwapGB: 4},
+		},
+		Datastores: []tui.DatastoreRow{{Name: "ds-1", Cluster: "east", CapacityGB: 100, UsedGB: 30, ProvisionedGB: 90, LatencyMS: 12}, {Name: "ds-2", Tags: "nfs,tier=tertiary"}},
+	}
+	vms, stores := MigrationInventory(catalog)
+	if len(vms) != 2 || vms[0].SizeGB != 35 || vms[0].SourceDatastore != "ds-1" || vms[0].Folder != "/dc/vm/Prod" ||
+		len(vms[0].Tags) != 2 || vms[0].Tags[1] != "linux" {
+		t.Fatalf("unexpected VM mapping: %+v", vms)
+	}
+	if vms[0].ProvisionedGB != 95 || vms[0].SnapshotGB != 5 || vms[0].SwapGB != 4 || vms[0].Snapshots != 3 || vms[1].SizeGB != 0 || vms[1].SwapGB != 0 {
+		t.Fatalf("unexpected VM headroom mapping: %+v", vms)
+	}
+	if len(stores) != 2 || stores[0].Cluster != "east" || stores[0].Tier != "" || stores[0].UsedGB != 30 ||
+		stores[0].ProvisionedGB != 90 || stores[0].LatencyMS != 12 || stores[1].Tier != migration.TierTertiary {
+		t.Fatalf("unexpected datastore mapping: %+v", stores)
+	}
+}
+
+func TestMigrationInventoryFeedsPreflightChecks(t *testing.T) {
+	catalog := tui.Catalog{
+		VMs: []tui.VMRow{
+			{Name: "vm-backup", Host: "esx-1", Datastore: "src", UsedStorageGB: 1, BackupRunning: true},
+			{Name: "vm-far", Host: "esx-2", Datastore: "src", UsedStorageGB: 1},
+			{Name: "vm-disks", Host: "esx-1", Datastore: "src", UsedStorageGB: 1, ISODatastore: "local-iso", IndependentDisks: 2, RDMDisks: 1},
+		},
+		Datastores: []tui.DatastoreRow{
+			{Name: "src", CapacityGB: 100, UsedGB: 90},
+			{Name: "dst", CapacityGB: 100, UsedGB: 10, Hosts: "esx-1, esx-3"},
+			{Name: "local-iso", CapacityGB: 100, UsedGB: 90, Local: true, Hosts: "esx-1"},
+		},
+	}
+	vms, stores := MigrationInventory(catalog)
+	planner := migration.NewPlanner(85).WithChecks(migration.DefaultChecks(0)...)
+	plan := planner.BuildPlan(vms, stores)
+	if plan[0].SkipReason != migration.SkipBackupRunning || plan[1].SkipReason != migration.SkipHostUnreachable {
+		t.Fatalf("expected backup and reachability skips, got %+v", plan)
+	}
+	want := []string{"ISO mounted from local datastore local-iso", "independent disks: 2", "raw device mappings: 1"}
+	if plan[2].TargetDatastore != "dst" || !slices.Equal(plan[2].Warnings, want) {
+		t.Fatalf("expected device warnings on the placed step, got %+v", plan[2])
+	}
+}
+
+func TestDeletionInventoryMapsCatalogRows(t *testing.T) {
+	catalog := tui.Catalog{VMs: []tui.VMRow{
+		{Name: "vm-a", Tags: "dev, linux", Cluster: "east", Folder: "/dc/vm/Dev", PowerState: "off", Owner: "a@example.com"},
+		{Name: "vm-b", PowerState: "suspended"},
+	}}
+	vms := DeletionInventory(catalog)
+	if len(vms) != 2 || vms[0].Name != "vm-a" || vms[0].Folder != "/dc/vm/Dev" || vms[0].Cluster != "east" ||
+		!slices.Equal(vms[0].Tags, []string{"dev", "linux"}) || !vms[0].PoweredOff || vms[0].OwnerEmail != "a@example.com" || vms[1].PoweredOff {
+		t.Fatalf("unexpected deletion VM mapping: %+v", vms)
+	}
+}
+
+func TestPlanComputeBalancesOrEvacuatesCatalogHosts(t *testing.T) {
+	catalog := tui.Catalog{
+		VMs: []tui.VMRow{
+			{Name: "vm-a", Host: "esx-1", Cluster: "east", UsedCPUMHz: 2000, MemoryMB: 1000},
+			{Name: "vm-b", Host: "esx-2", Cluster: "east", UsedCPUMHz: 100, MemoryMB: 100},
+		},
+		Hosts: []tui.HostRow{
+			{Name: "esx-1", Cluster: "east", ConnectionState: "connected", CPUUsagePercent: 90, MemUsagePercent: 50, CPUMHz: 10000, MemoryMB: 10000},
+			{Name: "esx-2", Cluster: "east", ConnectionState: "maintenance", CPUMHz: 10000, MemoryMB: 10000},
+			{Name: "esx-3", Cluster: "east", ConnectionState: "connected", CPUUsagePercent: 20, MemUsagePercent: 20, CPUMHz: 10000, MemoryMB: 10000},
+		},
+	}
+	vms, _ := MigrationInventory(catalog)
+	hosts := ComputeHosts(catalog)
+	if vms[0].CPUMHz != 2000 || vms[0].MemoryMB != 1000 || hosts[1].State != "maintenance" || hosts[0].CPUMHz != 10000 || hosts[0].CPUUsagePercent != 90 {
+		t.Fatalf("unexpected compute mapping: %+v %+v", vms, hosts)
+	}
+	buf := &bytes.Buffer{}
+	planner := migration.NewComputePlanner(85)
+	if plan := New(buf).PlanCompute(vms, hosts, "", planner); len(plan) != 1 || plan[0].TargetHost != "esx-3" {
+		t.Fatalf("expected esx-1 balanced onto esx-3, got %+v", plan)
+	}
+	if plan := New(buf).PlanCompute(vms, hosts, "esx-2", planner); len(plan) != 1 || plan[0].VMName != "vm-b" || plan[0].TargetHost != "esx-3" {
+		t.Fatalf("expected esx-2 evacuated, got %+v", plan)
+	}
+	if !strings.Contains(buf.String(), "1 vm-a esx-1 esx-3 40% READY") || !strings.Contains(buf.String(), "1 vm-b esx-2 esx-3 21% READY") {
+		t.Fatalf("unexpected compute plan output %q", buf.String())
+	}
+}
+
+func TestRunMigrationExecutesThroughMover(t *testing.T) {
+	mover := &recordingMover{}
+	vms := []migration.VM{{Name: "vm", SizeGB: 1, SourceDatastore: "src"}}
+	stores := []migration.Datastore{{Name: "src", CapacityGB: 100, Tier: migration.TierPrimary}, {Name: "dst", CapacityGB: 100, Tier: migration.TierPrimary}}
+	summary := New(&bytes.Buffer{}).RunMigration(config.Config{Execute: true}, vms, stores, migration.NewPlanner(90), mover)
+	if summary.MigratedCount != 1 || len(mover.moves) != 1 || mover.moves[0] != "vm->dst" {
+		t.Fatalf("expected move through supplied mover, got %+v %v", summary, mover.moves)
+	}
+}
+
+func TestRunMigrationRetriesConfiguredTimes(t *testing.T) {
+	mover := &recordingMover{err: busyError{}}
+	vms := []migration.VM{{Name: "vm", SizeGB: 1, SourceDatastore: "src"}}
+	stores := []migration.Datastore{{Name: "src", CapacityGB: 100, Tier: migration.TierPrimary}, {Name: "dst", CapacityGB: 100, Tier: migration.TierPrimary}}
+	summary := New(&bytes.Buffer{}).RunMigration(config.Config{Execute: true, Retries: 2}, vms, stores, migration.NewPlanner(90), mover)
+	if summary.FailedCount != 1 || len(mover.moves) != 3 || summary.Results[0].Attempts != 3 {
+		t.Fatalf("expected the first attempt plus two retries, got %+v %v", summary, mover.moves)
+	}
+}
+
+func TestMigrationProgressPrintsEvents(t *testing.T) {
+	buf := &bytes.Buffer{}
+	application := New(buf)
+	step := migration.PlanStep{Order: 2, VMName: "vm-a", TargetDatastore: "ds-2"}
+	application.MigrationProgress(migration.ProgressEvent{Step: step, State: migration.ProgressStarted})
+	application.MigrationProgress(migration.ProgressEvent{Step: step, State: migration.ProgressFailed, Attempts: 2, Err: errors.New("boom")})
+	until := time.Date(2026, 10, 16, 22, 0, 0, 0, time.UTC)
+	application.MigrationProgress(migration.ProgressEvent{State: migration.ProgressPaused, Until: until})
+	application.MigrationProgress(migration.ProgressEvent{State: migration.ProgressResumed})
+	want := "Progress #2 started vm-a -> ds-2\nProgress #2 failed vm-a -> ds-2 attempts=2 error=boom\n" +
+		"Progress paused outside maintenance window until 2026-10-16T22:00:00Z\nProgress resumed inside maintenance window\n"
+	if buf.String() != want {
+		t.Fatalf("unexpected progress output %q", buf.String())
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 )
 
 const (
@@ -17,6 +18,13 @@ const (
 	envHome      = "HOME"
 )
 
+const (
+	// DefaultRetries counts the retries a failed migration gets when none are configured.
+	DefaultRetries = 1
+	// DefaultRetryBackoff is the wait before the first retry of a failed migration.
+	DefaultRetryBackoff = time.Second
+)
+
 // Prompter asks users for values during interactive configuration.
 type Prompter interface {
 	Ask(key string) (string, error)
@@ -31,13 +39,19 @@ type CLIInput struct {
 	NonInteractive   bool
 }
 
-// Config stores the resolved runtime settings.
+// Config stores the resolved runtime settings. Retries counts the extra
+// attempts a failed migration gets; RetryBackoff paces them and RetryTimeout,
+// when set, bounds the time spent retrying one VM.
 type Config struct {
 	Mode             string
 	Execute          bool
 	ThresholdPercent int
 	NonInteractive   bool
 	ConfigDir        string
+	Provider         string
+	Retries          int
+	RetryBackoff     time.Duration
+	RetryTimeout     time.Duration
 }
 
 // Resolve load configuration with CLI, then env, then prompt precedence.
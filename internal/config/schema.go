@@ -25,6 +25,10 @@ func mainConfigSchema() map[string]any {
 		"threshold":        nil,
 		"non_interactive":  nil,
 		"config_dir":       nil,
+		"provider":         nil,
+		"retries":          nil,
+		"retry_backoff":    nil,
+		"retry_timeout":    nil,
 		"ui":               map[string]any{"theme": nil},
 		"hotkeys_file":     nil,
 		"aliases_file":     nil,
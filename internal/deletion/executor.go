@@ -0,0 +1,117 @@
+// Path: internal/deletion/executor.go
+// Description: Perform and audit the inventory side effects of lifecycle actions through an executor.
+package deletion
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+)
+
+// ErrStepFailed indicates an executor step that did not complete; the action
+// is left for the next run to retry.
+var ErrStepFailed = errors.New("lifecycle step failed")
+
+const (
+	StepPowerOff = "power_off"
+	StepRename   = "rename"
+	StepMove     = "move"
+	StepDestroy  = "destroy"
+)
+
+const (
+	AuditStarted   = "started"
+	AuditSucceeded = "succeeded"
+	AuditFailed    = "failed"
+)
+
+// Executor performs lifecycle side effects on VMs by name. PowerOff leaves
+// powered-off VMs alone.
+type Executor interface {
+	PowerOff(vmName string) error
+	Rename(vmName string, newName string) error
+	MoveToFolder(vmName string, folder string) error
+	Destroy(vmName string) error
+}
+
+// AuditEntry records one executor step transition. Detail holds the step
+// argument, such as the new name or target folder.
+type AuditEntry struct {
+	Time   time.Time  `json:"time"`
+	Action ActionType `json:"action"`
+	VMName string     `json:"vm"`
+	Step   string     `json:"step"`
+	Detail string     `json:"detail,omitempty"`
+	State  string     `json:"state"`
+	Error  string     `json:"error,omitempty"`
+}
+
+// Auditor records executor steps.
+type Auditor interface {
+	Record(entry AuditEntry) error
+}
+
+// WithExecutor return an engine that performs each action's side effects
+// through the executor, auditing every step to the auditor. A step that
+// cannot be audited as started is not performed.
+func (e Engine) WithExecutor(executor Executor, auditor Auditor) Engine {
+	e.executor = executor
+	e.auditor = auditor
+	return e
+}
+
+// step perform one executor step between started and finished audit entries.
+// Without an executor the step is only planned and nothing is audited.
+func (e Engine) step(action ActionType, vmName string, name string, detail string, run func(Executor) error) error {
+	if e.executor == nil {
+		return nil
+	}
+	entry := AuditEntry{Action: action, VMName: vmName, Step: name, Detail: detail, State: AuditStarted}
+	if err := e.auditor.Record(entry); err != nil {
+		return fmt.Errorf("%w: %s %s on %s: audit: %w", ErrStepFailed, action, name, vmName, err)
+	}
+	err := run(e.executor)
+	entry.State = AuditSucceeded
+	if err != nil {
+		entry.State = AuditFailed
+		entry.Error = err.Error()
+	}
+	auditErr := e.auditor.Record(entry)
+	if err != nil {
+		return fmt.Errorf("%w: %s %s on %s: %w", ErrStepFailed, action, name, vmName, err)
+	}
+	if auditErr != nil {
+		return fmt.Errorf("%w: %s %s on %s: audit: %w", ErrStepFailed, action, name, vmName, auditErr)
+	}
+	return nil
+}
+
+// AuditLog appends audit entries as JSON lines written synchronously to disk.
+type AuditLog struct {
+	file *os.File
+	now  func() time.Time
+}
+
+// OpenAuditLog open an audit log for appending, creating it when absent.
+func OpenAuditLog(path string) (*AuditLog, error) {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY|os.O_SYNC, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	return &AuditLog{file: file, now: time.Now}, nil
+}
+
+// Record stamp and append one entry to the log.
+func (a *AuditLog) Record(entry AuditEntry) error {
+	entry.Time = a.now().UTC()
+	content, _ := json.Marshal(entry)
+	_, err := a.file.Write(append(content, '\n'))
+	return err
+}
+
+// Close close the audit log file.
+func (a *AuditLog) Close() error {
+	return a.file.Close()
+}
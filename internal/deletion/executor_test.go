@@ -0,0 +1,196 @@
+// Path: internal/deletion/executor_test.go
+// Description: Validate executor side effects, step auditing, and retries after failed steps.
+package deletion
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+type fakeExecutor struct {
+	calls []string
+	fail  string
+}
+
+func (f *fakeExecutor) do(call string) error {
+	f.calls = append(f.calls, call)
+	if strings.HasPrefix(call, f.fail+" ") {
+		return errors.New("vcenter refused " + f.fail)
+	}
+	return nil
+}
+
+func (f *fakeExecutor) PowerOff(vmName string) error { return f.do(StepPowerOff + " " + vmName) }
+
+func (f *fakeExecutor) Rename(vmName string, newName string) error {
+	return f.do(StepRename + " " + vmName + " " + newName)
+}
+
+func (f *fakeExecutor) MoveToFolder(vmName string, folder string) error {
+	return f.do(StepMove + " " + vmName + " " + folder)
+}
+
+func (f *fakeExecutor) Destroy(vmName string) error { return f.do(StepDestroy + " " + vmName) }
+
+type fakeAuditor struct {
+	entries []AuditEntry
+	failAt  int
+}
+
+func (f *fakeAuditor) Record(entry AuditEntry) error {
+	f.entries = append(f.entries, entry)
+	if len(f.entries) == f.failAt {
+		return errors.New("audit disk full")
+	}
+	return nil
+}
+
+func (f *fakeAuditor) states() []string {
+	states := []string{}
+	for _, entry := range f.entries {
+		states = append(states, entry.Step+" "+entry.State)
+	}
+	return states
+}
+
+func executorPolicy() Policy {
+	return Policy{MarkAfterDays: 30, PurgeAfterDays: 14, PendingFolder: "PENDING_DELETION", RenamePrefix: "PD_"}
+}
+
+func TestExecutorPerformsAndAuditsLifecycleSteps(t *testing.T) {
+	executor := &fakeExecutor{}
+	auditor := &fakeAuditor{}
+	notifier := &fakeNotifier{}
+	engine := NewEngine(executorPolicy()).WithExecutor(executor, auditor).WithNotifier(notifier)
+	marked, err := engine.Apply(VM{Name: "vm-a", Folder: "WORKLOADS", OwnerEmail: "a@example.com"}, Action{Type: ActionMark}, fixedNow())
+	if err != nil || marked.Name != "PD_vm-a" || marked.Folder != "PENDING_DELETION" ||
+		marked.Metadata[FieldOriginalName] != "vm-a" || marked.Metadata[FieldOriginalFolder] != "WORKLOADS" ||
+		marked.Metadata[FieldPendingSince] != "2026-02-16" {
+		t.Fatalf("unexpected marked VM %+v err=%v", marked, err)
+	}
+	want := []string{"power_off vm-a", "rename vm-a PD_vm-a", "move PD_vm-a PENDING_DELETION"}
+	if !slices.Equal(executor.calls, want) || notifier.notices[0].VMName != "vm-a" {
+		t.Fatalf("unexpected mark steps %v notices=%+v", executor.calls, notifier.notices)
+	}
+	audited := []string{"power_off started", "power_off succeeded", "rename started", "rename succeeded", "move started", "move succeeded"}
+	if !slices.Equal(auditor.states(), audited) || auditor.entries[3].Detail != "PD_vm-a" || auditor.entries[3].Action != ActionMark {
+		t.Fatalf("unexpected mark audit %+v", auditor.entries)
+	}
+	executor.calls = nil
+	purged, err := engine.Apply(marked, Action{Type: ActionPurge}, fixedNow())
+	if err != nil || !purged.Deleted || !slices.Equal(executor.calls, []string{"power_off PD_vm-a", "destroy PD_vm-a"}) {
+		t.Fatalf("unexpected purge %+v steps=%v err=%v", purged, executor.calls, err)
+	}
+	executor.calls = nil
+	reclaimed := marked
+	reclaimed.Folder = "KEEP"
+	reset, err := engine.Apply(reclaimed, Action{Type: ActionReset}, fixedNow())
+	if err != nil || reset.Name != "vm-a" || reset.Folder != "WORKLOADS" || len(reset.Metadata) != 0 ||
+		!slices.Equal(executor.calls, []string{"rename PD_vm-a vm-a", "move vm-a WORKLOADS"}) {
+		t.Fatalf("unexpected reset %+v steps=%v err=%v", reset, executor.calls, err)
+	}
+}
+
+func TestFailedStepsLeaveActionsForTheNextRun(t *testing.T) {
+	executor := &fakeExecutor{fail: StepMove}
+	engine := NewEngine(executorPolicy()).WithExecutor(executor, &fakeAuditor{})
+	vms := []VM{{Name: "vm-a", Folder: "WORKLOADS", PoweredOffDays: 45}}
+	updated, summary := engine.ApplyPlan(vms, engine.Plan(vms, ModeAll, fixedNow()), fixedNow())
+	if summary.AppliedCount != 0 || len(summary.StepErrors) != 1 || !errors.Is(summary.StepErrors[0], ErrStepFailed) ||
+		!strings.Contains(summary.StepErrors[0].Error(), "mark move on PD_vm-a: vcenter refused move") {
+		t.Fatalf("expected failed move reported, got %+v", summary)
+	}
+	if updated[0].Name != "PD_vm-a" || updated[0].Metadata[FieldPendingSince] != "" || updated[0].Metadata[FieldOriginalName] != "vm-a" {
+		t.Fatalf("expected partial mark recorded without pending date, got %+v", updated[0])
+	}
+	executor.fail = ""
+	executor.calls = nil
+	retried, summary := engine.ApplyPlan(updated, engine.Plan(updated, ModeAll, fixedNow()), fixedNow())
+	if summary.AppliedCount != 1 || retried[0].Metadata[FieldPendingSince] == "" ||
+		!slices.Equal(executor.calls, []string{"power_off PD_vm-a", "move PD_vm-a PENDING_DELETION"}) {
+		t.Fatalf("expected retried mark to skip the finished rename, got %+v steps=%v", retried[0], executor.calls)
+	}
+	pending := retried[0]
+	for _, step := range []string{StepPowerOff, StepRename} {
+		executor.fail = step
+		if _, err := engine.Apply(VM{Name: "vm-b"}, Action{Type: ActionMark}, fixedNow()); !errors.Is(err, ErrStepFailed) {
+			t.Fatalf("expected mark %s failure, got %v", step, err)
+		}
+	}
+	for _, step := range []string{StepPowerOff, StepDestroy} {
+		executor.fail = step
+		if purged, err := engine.Apply(pending, Action{Type: ActionPurge}, fixedNow()); !errors.Is(err, ErrStepFailed) || purged.Deleted {
+			t.Fatalf("expected purge %s failure to keep the VM, got %+v err=%v", step, purged, err)
+		}
+	}
+	pending.Folder = "KEEP"
+	for _, step := range []string{StepRename, StepMove} {
+		executor.fail = step
+		if reset, err := engine.Apply(pending, Action{Type: ActionReset}, fixedNow()); !errors.Is(err, ErrStepFailed) ||
+			reset.Metadata[FieldPendingSince] == "" {
+			t.Fatalf("expected reset %s failure to keep the metadata, got %+v err=%v", step, reset, err)
+		}
+	}
+}
+
+func TestUnauditedStepsAreNotPerformed(t *testing.T) {
+	executor := &fakeExecutor{}
+	engine := NewEngine(executorPolicy()).WithExecutor(executor, &fakeAuditor{failAt: 1})
+	if _, err := engine.Apply(VM{Name: "vm-a"}, Action{Type: ActionPurge}, fixedNow()); !errors.Is(err, ErrStepFailed) ||
+		!strings.Contains(err.Error(), "audit: audit disk full") || len(executor.calls) != 0 {
+		t.Fatalf("expected the step refused without an audit entry, got %v steps=%v", err, executor.calls)
+	}
+	engine = NewEngine(executorPolicy()).WithExecutor(executor, &fakeAuditor{failAt: 2})
+	if purged, err := engine.Apply(VM{Name: "vm-a"}, Action{Type: ActionPurge}, fixedNow()); !errors.Is(err, ErrStepFailed) ||
+		purged.Deleted || len(executor.calls) != 1 {
+		t.Fatalf("expected a step without a finished entry reported, got %+v err=%v steps=%v", purged, err, executor.calls)
+	}
+}
+
+func TestAuditLogAppendsStampedEntries(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	log, err := OpenAuditLog(path)
+	if err != nil {
+		t.Fatalf("OpenAuditLog returned error: %v", err)
+	}
+	log.now = fixedNow
+	engine := NewEngine(executorPolicy()).WithExecutor(&fakeExecutor{fail: StepDestroy}, log)
+	if _, err := engine.Apply(VM{Name: "vm-a"}, Action{Type: ActionPurge}, fixedNow()); !errors.Is(err, ErrStepFailed) {
+		t.Fatalf("expected destroy failure, got %v", err)
+	}
+	if err := log.Close(); err != nil {
+		t.Fatalf("Close returned error: %v", err)
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("open audit log: %v", err)
+	}
+	defer file.Close()
+	entries := []AuditEntry{}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		entry := AuditEntry{}
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			t.Fatalf("decode audit line %q: %v", scanner.Text(), err)
+		}
+		entries = append(entries, entry)
+	}
+	last := entries[len(entries)-1]
+	if len(entries) != 4 || !last.Time.Equal(fixedNow()) || last.Step != StepDestroy || last.State != AuditFailed ||
+		last.Error != "vcenter refused destroy" || last.VMName != "vm-a" {
+		t.Fatalf("unexpected audit entries %+v", entries)
+	}
+	if err := log.Record(AuditEntry{Time: time.Now()}); err == nil {
+		t.Fatalf("expected record on a closed log to fail")
+	}
+	if _, err := OpenAuditLog(t.TempDir()); err == nil {
+		t.Fatalf("expected opening a directory to fail")
+	}
+}
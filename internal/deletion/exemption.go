@@ -0,0 +1,127 @@
+// Path: internal/deletion/exemption.go
+// Description: Exempt VMs from the deletion lifecycle by tag, folder, name, allowlist, or owner snooze.
+package deletion
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+)
+
+// FieldSnoozeUntil holds a date, set by the VM owner, before which the VM is
+// exempt from the lifecycle. The lifecycle reads it but never writes it.
+const FieldSnoozeUntil = "pd_snooze_until"
+
+// ActionSkip reports an exempt VM that would otherwise have been marked,
+// reminded, or purged. Applying it changes nothing.
+const ActionSkip ActionType = "skip"
+
+// ErrInvalidExemption indicates a malformed exemption rule or allowlist.
+var ErrInvalidExemption = errors.New("invalid deletion exemption")
+
+// Exemptions lists the rules that keep VMs out of the lifecycle. A VM is
+// exempt when it carries one of Tags, its folder matches one of FolderGlobs,
+// its name matches one of NamePatterns, or it is named in Allowlist.
+type Exemptions struct {
+	Tags         []string
+	FolderGlobs  []string
+	NamePatterns []*regexp.Regexp
+	Allowlist    []string
+}
+
+// ParseExemptions build exemption rules from tag names, folder globs, name
+// regular expressions, and an optional allowlist file.
+func ParseExemptions(tags []string, folderGlobs []string, namePatterns []string, allowlistPath string) (Exemptions, error) {
+	exemptions := Exemptions{Tags: tags, FolderGlobs: folderGlobs}
+	for _, glob := range folderGlobs {
+		if _, err := path.Match(glob, ""); err != nil {
+			return Exemptions{}, fmt.Errorf("%w: folder glob %q: %v", ErrInvalidExemption, glob, err)
+		}
+	}
+	for _, pattern := range namePatterns {
+		compiled, err := regexp.Compile(pattern)
+		if err != nil {
+			return Exemptions{}, fmt.Errorf("%w: name pattern %q: %v", ErrInvalidExemption, pattern, err)
+		}
+		exemptions.NamePatterns = append(exemptions.NamePatterns, compiled)
+	}
+	if allowlistPath == "" {
+		return exemptions, nil
+	}
+	allowlist, err := LoadAllowlist(allowlistPath)
+	if err != nil {
+		return Exemptions{}, err
+	}
+	exemptions.Allowlist = allowlist
+	return exemptions, nil
+}
+
+// LoadAllowlist read VM names from a file, one per line. Blank lines and
+// lines starting with # are ignored.
+func LoadAllowlist(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("%w: allowlist: %v", ErrInvalidExemption, err)
+	}
+	defer file.Close()
+	names := []string{}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		names = append(names, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%w: allowlist: %v", ErrInvalidExemption, err)
+	}
+	return names, nil
+}
+
+// Exempt report whether the VM is exempt at now and why. The original name
+// recorded at mark time is matched as well, so renamed VMs stay exempt, and
+// folder globs match the original folder, like policy rules. Tags match
+// regardless of case.
+func (x Exemptions) Exempt(vm VM, now time.Time) (string, bool) {
+	names := []string{vm.Name}
+	if original := vm.Metadata[FieldOriginalName]; original != "" && original != vm.Name {
+		names = append(names, original)
+	}
+	for _, name := range names {
+		if slices.Contains(x.Allowlist, name) {
+			return "exempt: allowlisted", true
+		}
+	}
+	for _, tag := range x.Tags {
+		if hasTag(vm.Tags, tag) {
+			return "exempt: tag " + tag, true
+		}
+	}
+	folder := vm.Folder
+	if original := vm.Metadata[FieldOriginalFolder]; original != "" {
+		folder = original
+	}
+	for _, glob := range x.FolderGlobs {
+		if matched, _ := path.Match(glob, folder); matched {
+			return "exempt: folder matches " + glob, true
+		}
+	}
+	for _, pattern := range x.NamePatterns {
+		for _, name := range names {
+			if pattern.MatchString(name) {
+				return "exempt: name matches " + pattern.String(), true
+			}
+		}
+	}
+	if until, err := time.Parse("2006-01-02", vm.Metadata[FieldSnoozeUntil]); err == nil && now.Before(until) {
+		return "exempt: snoozed until " + vm.Metadata[FieldSnoozeUntil], true
+	}
+	return "", false
+}
@@ -0,0 +1,143 @@
+// Path: internal/deletion/exemption_test.go
+// Description: Validate exemption rules, allowlist loading, and skipped lifecycle actions.
+package deletion
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestExemptVMsAreSkippedWithNotes(t *testing.T) {
+	allowlist := filepath.Join(t.TempDir(), "allowlist.txt")
+	if err := os.WriteFile(allowlist, []byte("# golden images\n\n  gold-base  \n"), 0o600); err != nil {
+		t.Fatalf("write allowlist: %v", err)
+	}
+	exemptions, err := ParseExemptions([]string{"dr-standby"}, []string{"TEMPLATES/*"}, []string{"^dr-"}, allowlist)
+	if err != nil {
+		t.Fatalf("ParseExemptions returned error: %v", err)
+	}
+	policy := Policy{MarkAfterDays: 30, PurgeAfterDays: 14, PendingFolder: "PENDING_DELETION", RenamePrefix: "PD_"}
+	engine := NewEngine(policy).WithExemptions(exemptions)
+	vms := []VM{
+		{Name: "gold-base", Folder: "WORKLOADS", PoweredOffDays: 45},
+		{Name: "standby", Folder: "WORKLOADS", Tags: []string{"prod", "dr-standby"}, PoweredOffDays: 45},
+		{Name: "image", Folder: "TEMPLATES/linux", PoweredOffDays: 45},
+		{Name: "PD_dr-db", Folder: "PENDING_DELETION", PoweredOffDays: 60, Metadata: map[string]string{
+			FieldPendingSince: "2026-01-01",
+			FieldDeleteOn:     "2026-02-10",
+			FieldOriginalName: "dr-db",
+		}},
+		{Name: "snoozed", Folder: "WORKLOADS", PoweredOffDays: 45, Metadata: map[string]string{FieldSnoozeUntil: "2026-03-01"}},
+		{Name: "woke", Folder: "WORKLOADS", PoweredOffDays: 45, Metadata: map[string]string{FieldSnoozeUntil: "2026-02-16"}},
+		{Name: "typo", Folder: "WORKLOADS", PoweredOffDays: 45, Metadata: map[string]string{FieldSnoozeUntil: "soon"}},
+		{Name: "running", Folder: "TEMPLATES/linux", PoweredOffDays: 0},
+		{Name: "restored", Folder: "TEMPLATES/linux", PoweredOffDays: 45, Metadata: map[string]string{FieldPendingSince: "2026-01-01"}},
+	}
+	want := []Action{
+		{Type: ActionSkip, VMName: "gold-base", Policy: DefaultPolicyName, Notes: "exempt: allowlisted"},
+		{Type: ActionSkip, VMName: "standby", Policy: DefaultPolicyName, Notes: "exempt: tag dr-standby"},
+		{Type: ActionSkip, VMName: "image", Policy: DefaultPolicyName, Notes: "exempt: folder matches TEMPLATES/*"},
+		{Type: ActionSkip, VMName: "PD_dr-db", Policy: DefaultPolicyName, Notes: "exempt: name matches ^dr-"},
+		{Type: ActionSkip, VMName: "snoozed", Policy: DefaultPolicyName, Notes: "exempt: snoozed until 2026-03-01"},
+		{Type: ActionMark, VMName: "woke", Policy: DefaultPolicyName},
+		{Type: ActionMark, VMName: "typo", Policy: DefaultPolicyName},
+		{Type: ActionReset, VMName: "restored", Policy: DefaultPolicyName},
+	}
+	plan := engine.Plan(vms, ModeAll, fixedNow())
+	if len(plan) != len(want) {
+		t.Fatalf("expected %d actions, got %+v", len(want), plan)
+	}
+	for index := range want {
+		if plan[index] != want[index] {
+			t.Fatalf("expected action %d to be %+v, got %+v", index, want[index], plan[index])
+		}
+	}
+	applied, summary := engine.ApplyPlan(vms, plan, fixedNow())
+	if summary.AppliedCount != 3 || applied[0].Metadata[FieldPendingSince] != "" || applied[5].Metadata[FieldPendingSince] == "" {
+		t.Fatalf("expected only unexempt actions applied, got %+v %+v", summary, applied)
+	}
+	if applied[4].Metadata[FieldSnoozeUntil] != "2026-03-01" {
+		t.Fatalf("expected snooze date left to its owner, got %v", applied[4].Metadata)
+	}
+	if plan := NewEngine(policy).WithExemptions(exemptions).Plan(vms[:1], ModePurge, fixedNow()); len(plan) != 0 {
+		t.Fatalf("expected no skip for a VM the mode would not act on, got %+v", plan)
+	}
+}
+
+func TestExemptionsMatchOriginalFolderAndTagsInAnyCase(t *testing.T) {
+	exemptions, err := ParseExemptions([]string{"dr-standby"}, []string{"TEMPLATES/*"}, nil, "")
+	if err != nil {
+		t.Fatalf("ParseExemptions returned error: %v", err)
+	}
+	pending := VM{Name: "image", Folder: "PENDING_DELETION", Metadata: map[string]string{FieldOriginalFolder: "TEMPLATES/linux"}}
+	if notes, exempt := exemptions.Exempt(pending, fixedNow()); !exempt || notes != "exempt: folder matches TEMPLATES/*" {
+		t.Fatalf("expected a pending VM exempt by its original folder, got %q %v", notes, exempt)
+	}
+	if notes, exempt := exemptions.Exempt(VM{Name: "standby", Tags: []string{" DR-Standby "}}, fixedNow()); !exempt || notes != "exempt: tag dr-standby" {
+		t.Fatalf("expected tags matched regardless of case, got %q %v", notes, exempt)
+	}
+	engine := NewEngine(Policy{}).WithPolicies(PolicySet{Rules: []PolicyRule{
+		{Name: "prod", Tag: "prod", PolicyTimings: PolicyTimings{MarkAfterDays: 90, PurgeAfterDays: 30}},
+	}})
+	if policy := engine.Resolve(VM{Tags: []string{"PROD"}}); policy.Name != "prod" {
+		t.Fatalf("expected policy tags matched regardless of case, got %+v", policy)
+	}
+}
+
+func TestParseExemptionsRejectsBadRules(t *testing.T) {
+	missing := filepath.Join(t.TempDir(), "missing.txt")
+	long := filepath.Join(t.TempDir(), "long.txt")
+	if err := os.WriteFile(long, []byte(strings.Repeat("x", 70000)), 0o600); err != nil {
+		t.Fatalf("write allowlist: %v", err)
+	}
+	cases := map[string]func() error{
+		"glob": func() error {
+			_, err := ParseExemptions(nil, []string{"["}, nil, "")
+			return err
+		},
+		"pattern": func() error {
+			_, err := ParseExemptions(nil, nil, []string{"("}, "")
+			return err
+		},
+		"missing allowlist": func() error {
+			_, err := ParseExemptions(nil, nil, nil, missing)
+			return err
+		},
+		"long allowlist line": func() error {
+			_, err := LoadAllowlist(long)
+			return err
+		},
+	}
+	for name, parse := range cases {
+		if err := parse(); !errors.Is(err, ErrInvalidExemption) {
+			t.Fatalf("expected %s rejected, got %v", name, err)
+		}
+	}
+	if exemptions, err := ParseExemptions(nil, nil, nil, ""); err != nil || len(exemptions.Allowlist) != 0 {
+		t.Fatalf("expected empty exemptions without an allowlist, got %+v err=%v", exemptions, err)
+	}
+}
+
+func TestLoadMetadataReadsOwnerSnooze(t *testing.T) {
+	store := &fakeStore{stored: map[string]map[string]string{"vm-a": {FieldSnoozeUntil: "2026-03-01"}}}
+	loaded, err := LoadMetadata(store, []VM{{Name: "vm-a"}})
+	if err != nil || loaded[0].Metadata[FieldSnoozeUntil] != "2026-03-01" {
+		t.Fatalf("expected snooze date loaded, got %v err=%v", loaded, err)
+	}
+	if persisted, ok := persistedFields(loaded[0])[FieldSnoozeUntil]; ok {
+		t.Fatalf("expected snooze date never written back, got %q", persisted)
+	}
+	file := NewFileStore(filepath.Join(t.TempDir(), "deletion.json"))
+	if err := file.Save("vm-a", map[string]string{FieldSnoozeUntil: "2026-03-01"}); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+	if err := file.Save("vm-a", map[string]string{FieldPendingSince: "2026-02-16", FieldDeleteOn: ""}); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+	if stored, err := file.Load(); err != nil || stored["vm-a"][FieldSnoozeUntil] != "2026-03-01" || stored["vm-a"][FieldPendingSince] != "2026-02-16" {
+		t.Fatalf("expected the owner's snooze date kept beside lifecycle fields, got %v err=%v", stored, err)
+	}
+}
@@ -2,7 +2,15 @@
 // Description: Plan and apply pending-deletion lifecycle actions using VM metadata fields.
 package deletion
 
-import "time"
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/takelley1/hypersphere/internal/schedule"
+)
 
 const (
 	FieldPendingSince       = "pd_pending_since"
@@ -11,6 +19,7 @@ const (
 	FieldInitialNoticeSent  = "pd_initial_notice_sent"
 	FieldReminderNoticeSent = "pd_reminder_notice_sent"
 	FieldOriginalName       = "pd_original_name"
+	FieldOriginalFolder     = "pd_original_folder"
 )
 
 // Mode selects workflow phases.
@@ -32,33 +41,67 @@ const (
 	ActionReset  ActionType = "reset"
 )
 
-// Policy configures lifecycle timings.
+// Policy configures lifecycle timings. RenamePrefix, when set, is prepended
+// to the names of marked VMs. Name identifies the policy in plans.
 type Policy struct {
+	Name           string
 	MarkAfterDays  int
 	PurgeAfterDays int
 	PendingFolder  string
+	RenamePrefix   string
 }
 
-// VM holds lifecycle-relevant VM state.
+// VM holds lifecycle-relevant VM state. PoweredOff reports the current power
+// state, which TrackPoweredOff turns into PoweredOffDays.
 type VM struct {
 	Name           string
 	Folder         string
+	Cluster        string
+	Tags           []string
+	PoweredOff     bool
 	PoweredOffDays int
 	OwnerEmail     string
 	Metadata       map[string]string
 	Deleted        bool
 }
 
-// Action represents a planned lifecycle operation.
+// Action represents a planned lifecycle operation. Policy names the policy
+// the VM was planned under, and Notes explains skipped actions and notice
+// retries.
 type Action struct {
 	Type   ActionType
 	VMName string
+	Policy string
 	Notes  string
 }
 
+// ApplySummary tracks plan apply outcomes. Deferred actions were left
+// unapplied because the maintenance schedule never reopened; PausedCount and
+// Paused record how often and how long apply waited for a window.
+// NotifyErrors holds notices that failed to deliver for applied actions.
+// StepErrors holds executor steps that failed; those actions are not counted
+// as applied. SaveErrors holds metadata that failed to save after an action;
+// apply stops there, so no further VM changes without a record.
+type ApplySummary struct {
+	AppliedCount  int
+	DeferredCount int
+	PausedCount   int
+	Paused        time.Duration
+	NotifyErrors  []error
+	StepErrors    []error
+	SaveErrors    []error
+}
+
 // Engine plans and applies lifecycle operations.
 type Engine struct {
-	policy Policy
+	policy     Policy
+	rules      []PolicyRule
+	gate       *schedule.Gate
+	notifier   Notifier
+	executor   Executor
+	auditor    Auditor
+	exemptions Exemptions
+	store      MetadataStore
 }
 
 // NewEngine build a lifecycle engine from policy.
@@ -66,6 +109,36 @@ func NewEngine(policy Policy) Engine {
 	return Engine{policy: policy}
 }
 
+// WithSchedule return an engine that applies actions only while the gate's
+// maintenance schedule is open, pausing until it reopens.
+func (e Engine) WithSchedule(gate schedule.Gate) Engine {
+	e.gate = &gate
+	return e
+}
+
+// WithNotifier return an engine that tells owners about marks, reminders, and
+// purges through the notifier.
+func (e Engine) WithNotifier(notifier Notifier) Engine {
+	e.notifier = notifier
+	return e
+}
+
+// WithExemptions return an engine that skips exempt VMs instead of marking,
+// reminding, or purging them. Resets still run, since they only undo a mark.
+func (e Engine) WithExemptions(exemptions Exemptions) Engine {
+	e.exemptions = exemptions
+	return e
+}
+
+// WithMetadataStore return an engine that saves each VM's metadata to the
+// store as soon as an action on it is applied, so a run that stops part way
+// never leaves VMs powered off, renamed, or moved without their lifecycle
+// fields.
+func (e Engine) WithMetadataStore(store MetadataStore) Engine {
+	e.store = store
+	return e
+}
+
 // Plan generate lifecycle actions for each VM under the selected mode.
 func (e Engine) Plan(vms []VM, mode Mode, now time.Time) []Action {
 	actions := make([]Action, 0, len(vms))
@@ -79,59 +152,228 @@ func (e Engine) Plan(vms []VM, mode Mode, now time.Time) []Action {
 }
 
 func (e Engine) planAction(vm VM, mode Mode, now time.Time) (Action, bool) {
-	if shouldReset(vm, e.policy.PendingFolder) && allows(mode, ActionReset) {
-		return Action{Type: ActionReset, VMName: vm.Name}, true
-	}
-	if shouldPurge(vm, now, e.policy.PendingFolder) && allows(mode, ActionPurge) {
-		return Action{Type: ActionPurge, VMName: vm.Name}, true
+	policy := e.Resolve(vm)
+	if shouldReset(vm, policy.PendingFolder) && allows(mode, ActionReset) {
+		return Action{Type: ActionReset, VMName: vm.Name, Policy: policy.Name}, true
 	}
-	if shouldRemind(vm, now, e.policy.PurgeAfterDays, e.policy.PendingFolder) && allows(mode, ActionRemind) {
-		return Action{Type: ActionRemind, VMName: vm.Name}, true
+	var action ActionType
+	notes := ""
+	switch {
+	case shouldPurge(vm, now, policy.PendingFolder) && allows(mode, ActionPurge):
+		action = ActionPurge
+	case e.notifier != nil && shouldResendMark(vm, policy.PendingFolder) && allows(mode, ActionMark):
+		action, notes = ActionMark, "retry mark notice"
+	case shouldRemind(vm, now, policy.PurgeAfterDays, policy.PendingFolder) && allows(mode, ActionRemind):
+		action = ActionRemind
+	case shouldMark(vm, policy.MarkAfterDays, policy.PendingFolder) && allows(mode, ActionMark):
+		action = ActionMark
+	default:
+		return Action{}, false
 	}
-	if shouldMark(vm, e.policy.MarkAfterDays, e.policy.PendingFolder) && allows(mode, ActionMark) {
-		return Action{Type: ActionMark, VMName: vm.Name}, true
+	if notes, exempt := e.exemptions.Exempt(vm, now); exempt {
+		return Action{Type: ActionSkip, VMName: vm.Name, Policy: policy.Name, Notes: notes}, true
 	}
-	return Action{}, false
+	return Action{Type: action, VMName: vm.Name, Policy: policy.Name, Notes: notes}, true
 }
 
-// Apply mutate VM metadata for the provided action.
-func (e Engine) Apply(vm VM, action Action, now time.Time) VM {
+// Apply update a copy of the VM for the provided action, performing its side
+// effects through the engine's executor, and notify the owner of marks,
+// reminders, and purges. Notice flags are set only once
+// the notifier delivers, so an engine without one leaves them unset and a
+// failed delivery returns the VM with its other changes and the error.
+func (e Engine) Apply(vm VM, action Action, now time.Time) (VM, error) {
+	vm.Metadata = maps.Clone(vm.Metadata)
 	if vm.Metadata == nil {
 		vm.Metadata = map[string]string{}
 	}
+	policy := e.Resolve(vm)
 	switch action.Type {
 	case ActionMark:
-		applyMark(&vm, e.policy.PurgeAfterDays, now)
+		if err := e.applyMark(&vm, policy, now); err != nil {
+			return vm, err
+		}
+		return vm, e.notify(&vm, policy, action.Type, FieldInitialNoticeSent)
 	case ActionRemind:
-		vm.Metadata[FieldReminderNoticeSent] = "true"
+		return vm, e.notify(&vm, policy, action.Type, FieldReminderNoticeSent)
 	case ActionPurge:
-		vm.Deleted = true
+		if err := e.applyPurge(&vm); err != nil {
+			return vm, err
+		}
+		return vm, e.notify(&vm, policy, action.Type, "")
 	case ActionReset:
-		applyReset(&vm)
+		return vm, e.applyReset(&vm)
+	}
+	return vm, nil
+}
+
+// notify deliver an event notice once, setting flag after it succeeds.
+func (e Engine) notify(vm *VM, policy Policy, event ActionType, flag string) error {
+	if e.notifier == nil || (flag != "" && vm.Metadata[flag] == "true") {
+		return nil
+	}
+	owner := vm.Metadata[FieldOwnerEmail]
+	if owner == "" {
+		owner = vm.OwnerEmail
+	}
+	name := vm.Metadata[FieldOriginalName]
+	if name == "" {
+		name = vm.Name
+	}
+	notice := Notice{
+		Event:         event,
+		VMName:        name,
+		OwnerEmail:    owner,
+		DeleteOn:      vm.Metadata[FieldDeleteOn],
+		PendingFolder: policy.PendingFolder,
+	}
+	if err := e.notifier.Notify(notice); err != nil {
+		return fmt.Errorf("%s notice for %s: %w", event, vm.Name, err)
+	}
+	if flag != "" {
+		vm.Metadata[flag] = "true"
+	}
+	return nil
+}
+
+// ApplyPlan apply each action to its VM in order, checking the maintenance
+// schedule before every action, and return the updated VMs. Skipped actions
+// are left out.
+func (e Engine) ApplyPlan(vms []VM, actions []Action, now time.Time) ([]VM, ApplySummary) {
+	updated := append([]VM(nil), vms...)
+	actions = slices.DeleteFunc(slices.Clone(actions), func(action Action) bool {
+		return action.Type == ActionSkip
+	})
+	summary := ApplySummary{}
+	for index, action := range actions {
+		if e.gate != nil {
+			pause, err := e.gate.Check()
+			if err != nil {
+				summary.DeferredCount += len(actions) - index
+				break
+			}
+			if !pause.Until.IsZero() {
+				e.gate.Wait(pause)
+				summary.PausedCount++
+				summary.Paused += pause.Duration()
+				now = now.Add(pause.Duration())
+			}
+		}
+		failed := false
+		for position, vm := range updated {
+			if vm.Name != action.VMName {
+				continue
+			}
+			var err error
+			updated[position], err = e.Apply(vm, action, now)
+			switch {
+			case errors.Is(err, ErrStepFailed):
+				summary.StepErrors = append(summary.StepErrors, err)
+				failed = true
+			case err != nil:
+				summary.NotifyErrors = append(summary.NotifyErrors, err)
+			}
+			if e.store == nil {
+				continue
+			}
+			if err := SaveMetadata(e.store, []VM{vm}, updated[position:position+1]); err != nil {
+				summary.SaveErrors = append(summary.SaveErrors, err)
+			}
+		}
+		if !failed {
+			summary.AppliedCount++
+		}
+		if len(summary.SaveErrors) > 0 {
+			break
+		}
 	}
-	return vm
+	return updated, summary
 }
 
-func applyMark(vm *VM, purgeAfterDays int, now time.Time) {
-	if vm.Metadata[FieldPendingSince] == "" {
-		vm.Metadata[FieldPendingSince] = now.Format("2006-01-02")
-		vm.Metadata[FieldDeleteOn] = now.AddDate(0, 0, purgeAfterDays).Format("2006-01-02")
-		vm.Metadata[FieldOwnerEmail] = vm.OwnerEmail
+// applyMark power off, rename, and move the VM into the pending folder, then
+// record when it became pending. The original name and folder are recorded
+// first, so a mark interrupted by a failed step resumes on the next run.
+func (e Engine) applyMark(vm *VM, policy Policy, now time.Time) error {
+	if vm.Metadata[FieldPendingSince] != "" {
+		return nil
+	}
+	if vm.Metadata[FieldOriginalName] == "" {
 		vm.Metadata[FieldOriginalName] = vm.Name
+		vm.Metadata[FieldOriginalFolder] = vm.Folder
+	}
+	if err := e.step(ActionMark, vm.Name, StepPowerOff, "", func(executor Executor) error {
+		return executor.PowerOff(vm.Name)
+	}); err != nil {
+		return err
 	}
-	vm.Metadata[FieldInitialNoticeSent] = "true"
+	if target := policy.RenamePrefix + vm.Metadata[FieldOriginalName]; target != vm.Name {
+		if err := e.rename(ActionMark, vm, target); err != nil {
+			return err
+		}
+	}
+	if vm.Folder != policy.PendingFolder {
+		if err := e.move(ActionMark, vm, policy.PendingFolder); err != nil {
+			return err
+		}
+	}
+	vm.Metadata[FieldPendingSince] = now.Format("2006-01-02")
+	vm.Metadata[FieldDeleteOn] = now.AddDate(0, 0, policy.PurgeAfterDays).Format("2006-01-02")
+	vm.Metadata[FieldOwnerEmail] = vm.OwnerEmail
+	return nil
 }
 
-func applyReset(vm *VM) {
-	if original := vm.Metadata[FieldOriginalName]; original != "" {
-		vm.Name = original
+// applyPurge power off and destroy the VM.
+func (e Engine) applyPurge(vm *VM) error {
+	if err := e.step(ActionPurge, vm.Name, StepPowerOff, "", func(executor Executor) error {
+		return executor.PowerOff(vm.Name)
+	}); err != nil {
+		return err
 	}
-	delete(vm.Metadata, FieldPendingSince)
-	delete(vm.Metadata, FieldDeleteOn)
-	delete(vm.Metadata, FieldOwnerEmail)
-	delete(vm.Metadata, FieldInitialNoticeSent)
-	delete(vm.Metadata, FieldReminderNoticeSent)
-	delete(vm.Metadata, FieldOriginalName)
+	if err := e.step(ActionPurge, vm.Name, StepDestroy, "", func(executor Executor) error {
+		return executor.Destroy(vm.Name)
+	}); err != nil {
+		return err
+	}
+	vm.Deleted = true
+	return nil
+}
+
+// applyReset restore the original name and folder recorded at mark time and
+// clear the lifecycle fields once both are restored.
+func (e Engine) applyReset(vm *VM) error {
+	if original := vm.Metadata[FieldOriginalName]; original != "" && original != vm.Name {
+		if err := e.rename(ActionReset, vm, original); err != nil {
+			return err
+		}
+	}
+	if folder := vm.Metadata[FieldOriginalFolder]; folder != "" && folder != vm.Folder {
+		if err := e.move(ActionReset, vm, folder); err != nil {
+			return err
+		}
+	}
+	for _, field := range lifecycleFields {
+		delete(vm.Metadata, field)
+	}
+	return nil
+}
+
+func (e Engine) rename(action ActionType, vm *VM, name string) error {
+	if err := e.step(action, vm.Name, StepRename, name, func(executor Executor) error {
+		return executor.Rename(vm.Name, name)
+	}); err != nil {
+		return err
+	}
+	vm.Name = name
+	return nil
+}
+
+func (e Engine) move(action ActionType, vm *VM, folder string) error {
+	if err := e.step(action, vm.Name, StepMove, folder, func(executor Executor) error {
+		return executor.MoveToFolder(vm.Name, folder)
+	}); err != nil {
+		return err
+	}
+	vm.Folder = folder
+	return nil
 }
 
 func allows(mode Mode, action ActionType) bool {
@@ -157,6 +399,12 @@ func shouldMark(vm VM, markAfterDays int, pendingFolder string) bool {
 	return vm.PoweredOffDays >= markAfterDays
 }
 
+// shouldResendMark report whether a pending VM's mark notice was never
+// delivered, so the mark is planned again to retry it.
+func shouldResendMark(vm VM, pendingFolder string) bool {
+	return vm.Folder == pendingFolder && vm.Metadata[FieldPendingSince] != "" && vm.Metadata[FieldInitialNoticeSent] != "true"
+}
+
 func shouldPurge(vm VM, now time.Time, pendingFolder string) bool {
 	if vm.Folder != pendingFolder {
 		return false
@@ -19,8 +19,8 @@ func TestShouldRemindSkipsWhenAlreadySent(t *testing.T) {
 
 func TestApplyUnknownActionAndNilMetadata(t *testing.T) {
 	engine := NewEngine(Policy{PurgeAfterDays: 10, PendingFolder: "PENDING_DELETION"})
-	updated := engine.Apply(VM{Name: "vm"}, Action{Type: ActionType("unknown")}, time.Date(2026, 2, 16, 0, 0, 0, 0, time.UTC))
-	if updated.Metadata == nil {
+	updated, err := engine.Apply(VM{Name: "vm"}, Action{Type: ActionType("unknown")}, time.Date(2026, 2, 16, 0, 0, 0, 0, time.UTC))
+	if err != nil || updated.Metadata == nil {
 		t.Fatalf("expected metadata map initialization")
 	}
 	if len(updated.Metadata) != 0 {
@@ -11,15 +11,15 @@ func TestApplySupportsRemindPurgeAndReset(t *testing.T) {
 	engine := NewEngine(Policy{PurgeAfterDays: 10, PendingFolder: "PENDING_DELETION"})
 	now := time.Date(2026, 2, 16, 0, 0, 0, 0, time.UTC)
 	vm := VM{Name: "vm", Metadata: map[string]string{FieldOriginalName: "old", FieldPendingSince: "2026-01-01", FieldDeleteOn: "2026-01-05", FieldOwnerEmail: "a@b", FieldInitialNoticeSent: "true", FieldReminderNoticeSent: "true"}}
-	reminded := engine.Apply(vm, Action{Type: ActionRemind}, now)
+	reminded, _ := engine.Apply(vm, Action{Type: ActionRemind}, now)
 	if reminded.Metadata[FieldReminderNoticeSent] != "true" {
 		t.Fatalf("expected reminder flag to remain true")
 	}
-	purged := engine.Apply(reminded, Action{Type: ActionPurge}, now)
+	purged, _ := engine.Apply(reminded, Action{Type: ActionPurge}, now)
 	if !purged.Deleted {
 		t.Fatalf("expected deleted flag after purge")
 	}
-	reset := engine.Apply(purged, Action{Type: ActionReset}, now)
+	reset, _ := engine.Apply(purged, Action{Type: ActionReset}, now)
 	if reset.Name != "old" || len(reset.Metadata) != 0 {
 		t.Fatalf("expected metadata cleared and original name restored: %+v", reset)
 	}
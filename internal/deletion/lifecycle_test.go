@@ -5,6 +5,8 @@ package deletion
 import (
 	"testing"
 	"time"
+
+	"github.com/takelley1/hypersphere/internal/schedule"
 )
 
 func fixedNow() time.Time {
@@ -50,16 +52,20 @@ func TestPlanAllActions(t *testing.T) {
 
 func TestApplyMarkIsIdempotent(t *testing.T) {
 	policy := Policy{MarkAfterDays: 30, PurgeAfterDays: 14, PendingFolder: "PENDING_DELETION"}
-	engine := NewEngine(policy)
+	notifier := &fakeNotifier{}
+	engine := NewEngine(policy).WithNotifier(notifier)
 	vm := VM{Name: "vm", Folder: "WORKLOADS", PoweredOffDays: 45, OwnerEmail: "a@example.com", Metadata: map[string]string{}}
 	action := Action{Type: ActionMark, VMName: vm.Name}
-	first := engine.Apply(vm, action, fixedNow())
-	second := engine.Apply(first, action, fixedNow())
+	first, _ := engine.Apply(vm, action, fixedNow())
+	second, err := engine.Apply(first, action, fixedNow())
 	if first.Metadata[FieldPendingSince] != second.Metadata[FieldPendingSince] {
 		t.Fatalf("expected idempotent pending since")
 	}
-	if second.Metadata[FieldInitialNoticeSent] != "true" {
-		t.Fatalf("expected initial notice sent true")
+	if err != nil || second.Metadata[FieldInitialNoticeSent] != "true" {
+		t.Fatalf("expected initial notice sent true, got err=%v", err)
+	}
+	if len(notifier.notices) != 1 {
+		t.Fatalf("expected one initial notice, got %+v", notifier.notices)
 	}
 }
 
@@ -72,3 +78,27 @@ func TestPlanRespectsMode(t *testing.T) {
 		t.Fatalf("expected only mark action, got %+v", plan)
 	}
 }
+
+func TestApplyPlanWaitsForMaintenanceWindow(t *testing.T) {
+	window, err := schedule.Parse([]byte(`{"windows":[{"start":"0 22 * * *","duration":"1h"}]}`))
+	if err != nil {
+		t.Fatalf("parse schedule: %v", err)
+	}
+	now := fixedNow()
+	gate := schedule.NewGate(window).WithClock(func() time.Time { return now }, func(d time.Duration) { now = now.Add(d) })
+	engine := NewEngine(Policy{PurgeAfterDays: 14, PendingFolder: "PENDING_DELETION"}).WithSchedule(gate)
+	vms := []VM{{Name: "mark-me", OwnerEmail: "a@example.com"}, {Name: "purge-me"}}
+	actions := []Action{{Type: ActionMark, VMName: "mark-me"}, {Type: ActionPurge, VMName: "purge-me"}}
+	updated, summary := engine.ApplyPlan(vms, actions, fixedNow())
+	if summary.AppliedCount != 2 || summary.PausedCount != 1 || summary.Paused != 10*time.Hour {
+		t.Fatalf("expected one ten hour pause, got %+v", summary)
+	}
+	if updated[0].Metadata[FieldPendingSince] != "2026-02-16" || !updated[1].Deleted || vms[1].Deleted {
+		t.Fatalf("expected actions applied to copies after the pause, got %+v", updated)
+	}
+	never, _ := schedule.Spec{Windows: []schedule.WindowSpec{{Start: "0 0 30 2 *", Duration: "1h"}}}.Schedule()
+	updated, summary = NewEngine(Policy{}).WithSchedule(schedule.NewGate(never)).ApplyPlan(vms, actions, fixedNow())
+	if summary.DeferredCount != 2 || summary.AppliedCount != 0 || updated[1].Deleted {
+		t.Fatalf("expected every action deferred without an opening, got %+v", summary)
+	}
+}
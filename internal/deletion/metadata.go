@@ -0,0 +1,190 @@
+// Path: internal/deletion/metadata.go
+// Description: Persist lifecycle metadata fields between runs in a local JSON file or another metadata store.
+package deletion
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// ErrInvalidMetadata indicates a malformed lifecycle metadata file.
+var ErrInvalidMetadata = errors.New("invalid deletion metadata")
+
+// lifecycleFields lists the metadata fields the lifecycle owns and persists.
+var lifecycleFields = []string{
+	FieldPendingSince,
+	FieldDeleteOn,
+	FieldOwnerEmail,
+	FieldInitialNoticeSent,
+	FieldReminderNoticeSent,
+	FieldOriginalName,
+	FieldOriginalFolder,
+	FieldPoweredOffSince,
+}
+
+// FieldPoweredOffSince records the date a run first saw the VM powered off,
+// since inventories report power state but not how long it has held.
+const FieldPoweredOffSince = "pd_powered_off_since"
+
+// MetadataStore persists lifecycle fields by VM name between runs. Load
+// returns the stored fields of every known VM, which may include fields the
+// lifecycle does not own. Save receives every lifecycle
+// field of one VM, with an empty value for each field that was cleared.
+type MetadataStore interface {
+	Load() (map[string]map[string]string, error)
+	Save(vmName string, fields map[string]string) error
+}
+
+// LoadMetadata return copies of the VMs with their stored lifecycle fields and
+// owner snooze date merged over any metadata they already carry. Other stored
+// fields are ignored.
+func LoadMetadata(store MetadataStore, vms []VM) ([]VM, error) {
+	stored, err := store.Load()
+	if err != nil {
+		return nil, err
+	}
+	loaded := make([]VM, 0, len(vms))
+	for _, vm := range vms {
+		metadata := maps.Clone(vm.Metadata)
+		if metadata == nil {
+			metadata = map[string]string{}
+		}
+		for _, field := range append([]string{FieldSnoozeUntil}, lifecycleFields...) {
+			if value := stored[vm.Name][field]; value != "" {
+				metadata[field] = value
+			}
+		}
+		vm.Metadata = metadata
+		loaded = append(loaded, vm)
+	}
+	return loaded, nil
+}
+
+// TrackPoweredOff return copies of the loaded VMs with their powered-off age
+// counted from the date recorded when a run first saw them powered off. VMs
+// newly seen powered off record now, and VMs seen running clear the date, so
+// the age restarts the next time they power off.
+func TrackPoweredOff(vms []VM, now time.Time) []VM {
+	tracked := make([]VM, 0, len(vms))
+	for _, vm := range vms {
+		vm.Metadata = maps.Clone(vm.Metadata)
+		if vm.Metadata == nil {
+			vm.Metadata = map[string]string{}
+		}
+		vm.PoweredOffDays = 0
+		if !vm.PoweredOff {
+			delete(vm.Metadata, FieldPoweredOffSince)
+			tracked = append(tracked, vm)
+			continue
+		}
+		since, err := time.Parse("2006-01-02", vm.Metadata[FieldPoweredOffSince])
+		if err != nil {
+			vm.Metadata[FieldPoweredOffSince] = now.Format("2006-01-02")
+			since = now
+		}
+		vm.PoweredOffDays = max(int(now.Sub(since)/(24*time.Hour)), 0)
+		tracked = append(tracked, vm)
+	}
+	return tracked
+}
+
+// SaveMetadata write back the lifecycle fields of each applied VM whose fields
+// or name changed, keyed by its current name. A renamed VM's fields are then
+// cleared under the name it was loaded with, and purged VMs have their fields
+// cleared. Every VM is attempted and failures are joined.
+func SaveMetadata(store MetadataStore, loaded []VM, applied []VM) error {
+	var errs []error
+	save := func(name string, fields map[string]string) {
+		if err := store.Save(name, fields); err != nil {
+			errs = append(errs, fmt.Errorf("save metadata for %s: %w", name, err))
+		}
+	}
+	for index, vm := range applied {
+		before := persistedFields(loaded[index])
+		after := persistedFields(vm)
+		if vm.Deleted {
+			after = persistedFields(VM{})
+		}
+		renamed := vm.Name != loaded[index].Name
+		if maps.Equal(before, after) && !renamed {
+			continue
+		}
+		save(vm.Name, after)
+		if renamed {
+			save(loaded[index].Name, persistedFields(VM{}))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func persistedFields(vm VM) map[string]string {
+	fields := make(map[string]string, len(lifecycleFields))
+	for _, field := range lifecycleFields {
+		fields[field] = vm.Metadata[field]
+	}
+	return fields
+}
+
+// FileStore keeps lifecycle fields in a local JSON file that maps VM names to
+// their non-empty fields, for inventories without vCenter custom attributes.
+type FileStore struct {
+	path string
+}
+
+// NewFileStore build a metadata store backed by the JSON file at path.
+func NewFileStore(path string) FileStore {
+	return FileStore{path: path}
+}
+
+// Load read the stored fields, returning none when the file is absent.
+func (s FileStore) Load() (map[string]map[string]string, error) {
+	content, err := os.ReadFile(s.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return map[string]map[string]string{}, nil
+		}
+		return nil, err
+	}
+	stored := map[string]map[string]string{}
+	if strings.TrimSpace(string(content)) == "" {
+		return stored, nil
+	}
+	if err := json.Unmarshal(content, &stored); err != nil {
+		return nil, fmt.Errorf("%w: %s: %v", ErrInvalidMetadata, s.path, err)
+	}
+	return stored, nil
+}
+
+// Save update one VM's stored fields, keeping fields it was not given, such
+// as an owner's snooze date, and dropping the VM once every field is empty.
+func (s FileStore) Save(vmName string, fields map[string]string) error {
+	stored, err := s.Load()
+	if err != nil {
+		return err
+	}
+	kept := maps.Clone(stored[vmName])
+	if kept == nil {
+		kept = map[string]string{}
+	}
+	for field, value := range fields {
+		kept[field] = value
+		if value == "" {
+			delete(kept, field)
+		}
+	}
+	delete(stored, vmName)
+	if len(kept) > 0 {
+		stored[vmName] = kept
+	}
+	content, _ := json.MarshalIndent(stored, "", "  ")
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o700); err != nil {
+		return err
+	}
+	return os.WriteFile(s.path, append(content, '\n'), 0o600)
+}
@@ -0,0 +1,155 @@
+// Path: internal/deletion/metadata_test.go
+// Description: Validate lifecycle metadata persistence across runs through the JSON file store.
+package deletion
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+type fakeStore struct {
+	stored map[string]map[string]string
+	saved  []string
+	err    error
+}
+
+func (f *fakeStore) Load() (map[string]map[string]string, error) {
+	return f.stored, f.err
+}
+
+func (f *fakeStore) Save(vmName string, _ map[string]string) error {
+	f.saved = append(f.saved, vmName)
+	return f.err
+}
+
+func TestFileStoreCarriesLifecycleAcrossRuns(t *testing.T) {
+	store := NewFileStore(filepath.Join(t.TempDir(), "state", "deletion.json"))
+	engine := NewEngine(Policy{MarkAfterDays: 30, PurgeAfterDays: 14, PendingFolder: "PENDING_DELETION"})
+	run := func(folder string, days int) []Action {
+		vms, err := LoadMetadata(store, []VM{{Name: "vm-a", Folder: folder, PoweredOffDays: 45, OwnerEmail: "a@example.com"}})
+		if err != nil {
+			t.Fatalf("LoadMetadata returned error: %v", err)
+		}
+		now := fixedNow().AddDate(0, 0, days)
+		actions := engine.Plan(vms, ModeAll, now)
+		applied, _ := engine.ApplyPlan(vms, actions, now)
+		if err := SaveMetadata(store, vms, applied); err != nil {
+			t.Fatalf("SaveMetadata returned error: %v", err)
+		}
+		return actions
+	}
+	if actions := run("WORKLOADS", 0); len(actions) != 1 || actions[0].Type != ActionMark {
+		t.Fatalf("expected first run to mark, got %+v", actions)
+	}
+	stored, err := store.Load()
+	if err != nil || stored["vm-a"][FieldDeleteOn] != "2026-03-02" || stored["vm-a"][FieldOriginalName] != "vm-a" {
+		t.Fatalf("expected mark persisted, got %v err=%v", stored, err)
+	}
+	if actions := run("PENDING_DELETION", 7); len(actions) != 1 || actions[0].Type != ActionRemind {
+		t.Fatalf("expected a later run to remind from stored state, got %+v", actions)
+	}
+	if actions := run("PENDING_DELETION", 14); len(actions) != 1 || actions[0].Type != ActionPurge {
+		t.Fatalf("expected the purge run to purge from stored state, got %+v", actions)
+	}
+	if stored, err := store.Load(); err != nil || len(stored) != 0 {
+		t.Fatalf("expected purged VM dropped from the store, got %v err=%v", stored, err)
+	}
+}
+
+func TestLoadMetadataMergesOnlyLifecycleFields(t *testing.T) {
+	store := &fakeStore{stored: map[string]map[string]string{
+		"vm-a": {FieldPendingSince: "2026-02-01", FieldDeleteOn: "", "Owner": "ops"},
+	}}
+	vms := []VM{{Name: "vm-a", Metadata: map[string]string{FieldDeleteOn: "2026-02-15", "note": "kept"}}, {Name: "vm-b"}}
+	loaded, err := LoadMetadata(store, vms)
+	if err != nil || loaded[0].Metadata[FieldPendingSince] != "2026-02-01" || loaded[0].Metadata[FieldDeleteOn] != "2026-02-15" ||
+		loaded[0].Metadata["note"] != "kept" || loaded[0].Metadata["Owner"] != "" || loaded[1].Metadata == nil {
+		t.Fatalf("unexpected loaded metadata %+v err=%v", loaded, err)
+	}
+	if vms[0].Metadata[FieldPendingSince] != "" {
+		t.Fatalf("expected input VMs left untouched, got %+v", vms[0])
+	}
+	store.err = errors.New("store down")
+	if _, err := LoadMetadata(store, vms); !errors.Is(err, store.err) {
+		t.Fatalf("expected load error, got %v", err)
+	}
+	changed := []VM{{Name: "renamed", Metadata: map[string]string{FieldPendingSince: "2026-02-16"}}, loaded[1]}
+	err = SaveMetadata(store, loaded, changed)
+	if !errors.Is(err, store.err) || !strings.Contains(err.Error(), "save metadata for vm-a") || !slices.Equal(store.saved, []string{"renamed", "vm-a"}) {
+		t.Fatalf("expected the renamed VM saved under its new name and cleared under its old one, got %v saved=%v", err, store.saved)
+	}
+}
+
+func TestTrackPoweredOffCountsFromTheFirstRunThatSawIt(t *testing.T) {
+	vms := []VM{
+		{Name: "new", PoweredOff: true},
+		{Name: "old", PoweredOff: true, Metadata: map[string]string{FieldPoweredOffSince: "2026-01-01"}},
+		{Name: "back", PoweredOffDays: 9, Metadata: map[string]string{FieldPoweredOffSince: "2026-01-01"}},
+	}
+	tracked := TrackPoweredOff(vms, fixedNow())
+	if tracked[0].PoweredOffDays != 0 || tracked[0].Metadata[FieldPoweredOffSince] != "2026-02-16" {
+		t.Fatalf("expected a newly powered-off VM to start counting today, got %+v", tracked[0])
+	}
+	if tracked[1].PoweredOffDays != 46 || tracked[2].PoweredOffDays != 0 || tracked[2].Metadata[FieldPoweredOffSince] != "" {
+		t.Fatalf("expected age from the recorded date and cleared for a running VM, got %+v %+v", tracked[1], tracked[2])
+	}
+	if vms[2].Metadata[FieldPoweredOffSince] == "" {
+		t.Fatalf("expected input VMs left untouched, got %+v", vms[2])
+	}
+	store := &fakeStore{}
+	if err := SaveMetadata(store, vms, tracked); err != nil || !slices.Equal(store.saved, []string{"new", "back"}) {
+		t.Fatalf("expected changed power tracking saved, got %v saved=%v", err, store.saved)
+	}
+}
+
+func TestApplyPlanSavesEachVMAsItsActionApplies(t *testing.T) {
+	executor := &fakeExecutor{fail: StepMove}
+	store := &fakeStore{}
+	engine := NewEngine(executorPolicy()).WithExecutor(executor, &fakeAuditor{}).WithMetadataStore(store)
+	vms := []VM{{Name: "vm-a", Folder: "WORKLOADS", PoweredOffDays: 45}, {Name: "vm-b", Folder: "WORKLOADS", PoweredOffDays: 45}}
+	_, summary := engine.ApplyPlan(vms, engine.Plan(vms, ModeAll, fixedNow()), fixedNow())
+	if len(summary.StepErrors) != 2 || !slices.Equal(store.saved, []string{"PD_vm-a", "vm-a", "PD_vm-b", "vm-b"}) {
+		t.Fatalf("expected each partly marked VM saved under its new name, got %+v saved=%v", summary, store.saved)
+	}
+	executor.fail, executor.calls = "", nil
+	store.saved, store.err = nil, errors.New("store down")
+	_, summary = engine.ApplyPlan(vms, engine.Plan(vms, ModeAll, fixedNow()), fixedNow())
+	if len(summary.SaveErrors) != 1 || summary.AppliedCount != 1 || slices.ContainsFunc(executor.calls, func(call string) bool {
+		return strings.HasSuffix(call, "vm-b")
+	}) {
+		t.Fatalf("expected apply to stop before touching another VM once a save fails, got %+v steps=%v", summary, executor.calls)
+	}
+}
+
+func TestFileStoreRejectsBadFiles(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "deletion.json")
+	for _, content := range []string{"", "  \n"} {
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatalf("write file: %v", err)
+		}
+		if stored, err := NewFileStore(path).Load(); err != nil || len(stored) != 0 {
+			t.Fatalf("expected empty file to load as empty, got %v err=%v", stored, err)
+		}
+	}
+	if err := os.WriteFile(path, []byte("{"), 0o600); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+	if err := NewFileStore(path).Save("vm-a", nil); !errors.Is(err, ErrInvalidMetadata) {
+		t.Fatalf("expected malformed file rejected, got %v", err)
+	}
+	if _, err := NewFileStore(dir).Load(); err == nil {
+		t.Fatalf("expected directory read to fail")
+	}
+	dangling := filepath.Join(dir, "dangling")
+	if err := os.Symlink(filepath.Join(dir, "missing", "target"), dangling); err != nil {
+		t.Fatalf("symlink: %v", err)
+	}
+	if err := NewFileStore(filepath.Join(dangling, "deletion.json")).Save("vm-a", nil); err == nil {
+		t.Fatalf("expected store under a dangling link to fail")
+	}
+}
@@ -0,0 +1,178 @@
+// Path: internal/deletion/notify.go
+// Description: Notify VM owners of lifecycle events with templated messages over SMTP or to a local outbox.
+package deletion
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/smtp"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+var (
+	// ErrNoRecipient indicates a notice for a VM without an owner email.
+	ErrNoRecipient = errors.New("no owner email for notice")
+	// ErrInvalidTemplate indicates a notice template that is missing or does not render.
+	ErrInvalidTemplate = errors.New("invalid notice template")
+)
+
+// Notice describes one lifecycle event an owner is told about.
+type Notice struct {
+	Event         ActionType
+	VMName        string
+	OwnerEmail    string
+	DeleteOn      string
+	PendingFolder string
+}
+
+// Notifier delivers lifecycle notices to VM owners.
+type Notifier interface {
+	Notify(notice Notice) error
+}
+
+// Template holds the text/template sources for one event's subject and body,
+// executed against a Notice.
+type Template struct {
+	Subject string
+	Body    string
+}
+
+// Templates maps lifecycle events to their message templates.
+type Templates map[ActionType]Template
+
+// Message is a rendered notice ready for delivery.
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// DefaultTemplates return the built-in mark, remind, and purge messages.
+func DefaultTemplates() Templates {
+	reclaim := "To keep this VM, move it out of the {{.PendingFolder}} folder before {{.DeleteOn}}.\n"
+	return Templates{
+		ActionMark: {
+			Subject: "VM {{.VMName}} is scheduled for deletion on {{.DeleteOn}}",
+			Body: "Your VM {{.VMName}} has been powered off long enough to be marked for deletion.\n" +
+				"It will be deleted on {{.DeleteOn}}.\n" + reclaim,
+		},
+		ActionRemind: {
+			Subject: "Reminder: VM {{.VMName}} will be deleted on {{.DeleteOn}}",
+			Body:    "Your VM {{.VMName}} is still pending deletion and will be deleted on {{.DeleteOn}}.\n" + reclaim,
+		},
+		ActionPurge: {
+			Subject: "VM {{.VMName}} has been deleted",
+			Body: "Your VM {{.VMName}} was deleted on {{.DeleteOn}} after its pending-deletion period ended.\n" +
+				"Contact your vSphere administrators if it needs to be restored from backup.\n",
+		},
+	}
+}
+
+// Render execute the event's templates for a notice.
+func (t Templates) Render(notice Notice) (Message, error) {
+	source, ok := t[notice.Event]
+	if !ok {
+		return Message{}, fmt.Errorf("%w: no template for %s", ErrInvalidTemplate, notice.Event)
+	}
+	subject, err := renderTemplate(source.Subject, notice)
+	if err != nil {
+		return Message{}, err
+	}
+	body, err := renderTemplate(source.Body, notice)
+	if err != nil {
+		return Message{}, err
+	}
+	return Message{To: notice.OwnerEmail, Subject: strings.TrimSpace(subject), Body: body}, nil
+}
+
+func renderTemplate(source string, notice Notice) (string, error) {
+	parsed, err := template.New("notice").Option("missingkey=error").Parse(source)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidTemplate, err)
+	}
+	builder := &strings.Builder{}
+	if err := parsed.Execute(builder, notice); err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidTemplate, err)
+	}
+	return builder.String(), nil
+}
+
+func (m Message) bytes(from string) []byte {
+	return []byte("From: " + from + "\r\n" +
+		"To: " + m.To + "\r\n" +
+		"Subject: " + m.Subject + "\r\n" +
+		"MIME-Version: 1.0\r\n" +
+		"Content-Type: text/plain; charset=UTF-8\r\n" +
+		"\r\n" + m.Body)
+}
+
+func render(templates Templates, notice Notice) (Message, error) {
+	if strings.TrimSpace(notice.OwnerEmail) == "" {
+		return Message{}, fmt.Errorf("%w: %s", ErrNoRecipient, notice.VMName)
+	}
+	return templates.Render(notice)
+}
+
+// SMTPConfig addresses an SMTP relay. Addr is host:port; Username enables
+// PLAIN authentication, which net/smtp only sends over TLS or to localhost.
+type SMTPConfig struct {
+	Addr     string
+	From     string
+	Username string
+	Password string
+}
+
+// SMTPNotifier sends notices through an SMTP relay.
+type SMTPNotifier struct {
+	config    SMTPConfig
+	templates Templates
+}
+
+// NewSMTPNotifier build a notifier that renders templates and sends them over SMTP.
+func NewSMTPNotifier(config SMTPConfig, templates Templates) SMTPNotifier {
+	return SMTPNotifier{config: config, templates: templates}
+}
+
+// Notify render the notice and send it to the owner.
+func (n SMTPNotifier) Notify(notice Notice) error {
+	message, err := render(n.templates, notice)
+	if err != nil {
+		return err
+	}
+	var auth smtp.Auth
+	if n.config.Username != "" {
+		host, _, _ := net.SplitHostPort(n.config.Addr)
+		auth = smtp.PlainAuth("", n.config.Username, n.config.Password, host)
+	}
+	return smtp.SendMail(n.config.Addr, auth, n.config.From, []string{message.To}, message.bytes(n.config.From))
+}
+
+// OutboxNotifier stands in for SMTP by writing each notice to a local
+// directory as an .eml file named after the VM and event.
+type OutboxNotifier struct {
+	dir       string
+	from      string
+	templates Templates
+}
+
+// NewOutboxNotifier build a notifier that writes rendered notices under dir.
+func NewOutboxNotifier(dir string, from string, templates Templates) OutboxNotifier {
+	return OutboxNotifier{dir: dir, from: from, templates: templates}
+}
+
+// Notify render the notice and write it to the outbox.
+func (n OutboxNotifier) Notify(notice Notice) error {
+	message, err := render(n.templates, notice)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(n.dir, 0o700); err != nil {
+		return err
+	}
+	name := fmt.Sprintf("%s-%s.eml", strings.ReplaceAll(notice.VMName, "/", "_"), notice.Event)
+	return os.WriteFile(filepath.Join(n.dir, name), message.bytes(n.from), 0o600)
+}
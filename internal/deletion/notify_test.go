@@ -0,0 +1,243 @@
+// Path: internal/deletion/notify_test.go
+// Description: Validate owner notices over a local fake SMTP server, the outbox stand-in, and notice flag handling.
+package deletion
+
+import (
+	"errors"
+	"net"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+type fakeNotifier struct {
+	notices []Notice
+	err     error
+}
+
+func (f *fakeNotifier) Notify(notice Notice) error {
+	f.notices = append(f.notices, notice)
+	return f.err
+}
+
+type smtpMessage struct {
+	auth string
+	from string
+	to   []string
+	data string
+}
+
+type fakeSMTPServer struct {
+	addr     string
+	mu       sync.Mutex
+	messages []smtpMessage
+}
+
+func newFakeSMTPServer(t *testing.T) *fakeSMTPServer {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	t.Cleanup(func() { _ = listener.Close() })
+	server := &fakeSMTPServer{addr: listener.Addr().String()}
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go server.serve(conn)
+		}
+	}()
+	return server
+}
+
+// serve speak just enough SMTP for net/smtp, rejecting bounce@ recipients.
+func (s *fakeSMTPServer) serve(conn net.Conn) {
+	defer conn.Close()
+	text := textproto.NewConn(conn)
+	_ = text.PrintfLine("220 localhost ESMTP")
+	message := smtpMessage{}
+	for {
+		line, err := text.ReadLine()
+		if err != nil {
+			return
+		}
+		verb, arg, _ := strings.Cut(line, " ")
+		switch strings.ToUpper(verb) {
+		case "EHLO":
+			_ = text.PrintfLine("250-localhost")
+			_ = text.PrintfLine("250 AUTH PLAIN")
+		case "AUTH":
+			message.auth = arg
+			_ = text.PrintfLine("235 authenticated")
+		case "MAIL":
+			message.from = arg
+			_ = text.PrintfLine("250 ok")
+		case "RCPT":
+			if strings.Contains(arg, "bounce@") {
+				_ = text.PrintfLine("550 no such user")
+				continue
+			}
+			message.to = append(message.to, arg)
+			_ = text.PrintfLine("250 ok")
+		case "DATA":
+			_ = text.PrintfLine("354 send data")
+			lines, _ := text.ReadDotLines()
+			message.data = strings.Join(lines, "\n")
+			s.mu.Lock()
+			s.messages = append(s.messages, message)
+			s.mu.Unlock()
+			_ = text.PrintfLine("250 queued")
+		case "QUIT":
+			_ = text.PrintfLine("221 bye")
+			return
+		default:
+			_ = text.PrintfLine("250 ok")
+		}
+	}
+}
+
+func (s *fakeSMTPServer) received() []smtpMessage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]smtpMessage(nil), s.messages...)
+}
+
+func TestSMTPNotifierDeliversNoticesBeforeSettingFlags(t *testing.T) {
+	server := newFakeSMTPServer(t)
+	notifier := NewSMTPNotifier(
+		SMTPConfig{Addr: server.addr, From: "hypersphere@example.com", Username: "relay", Password: "secret"},
+		DefaultTemplates(),
+	)
+	engine := NewEngine(Policy{PurgeAfterDays: 14, PendingFolder: "PENDING_DELETION"}).WithNotifier(notifier)
+	pending := func(owner string) map[string]string {
+		return map[string]string{FieldPendingSince: "2026-02-01", FieldDeleteOn: "2026-02-15", FieldOwnerEmail: owner}
+	}
+	vms := []VM{
+		{Name: "mark-me", OwnerEmail: "a@example.com"},
+		{Name: "remind-me", Metadata: pending("b@example.com")},
+		{Name: "bounce-me", Metadata: pending("bounce@example.com")},
+		{Name: "purge-me", Metadata: pending("c@example.com")},
+	}
+	actions := []Action{
+		{Type: ActionMark, VMName: "mark-me"},
+		{Type: ActionRemind, VMName: "remind-me"},
+		{Type: ActionRemind, VMName: "bounce-me"},
+		{Type: ActionPurge, VMName: "purge-me"},
+	}
+	updated, summary := engine.ApplyPlan(vms, actions, fixedNow())
+	if summary.AppliedCount != 4 || len(summary.NotifyErrors) != 1 ||
+		!strings.Contains(summary.NotifyErrors[0].Error(), "remind notice for bounce-me: 550") {
+		t.Fatalf("expected one bounced notice, got %+v", summary)
+	}
+	if updated[0].Metadata[FieldInitialNoticeSent] != "true" || updated[1].Metadata[FieldReminderNoticeSent] != "true" ||
+		updated[2].Metadata[FieldReminderNoticeSent] != "" || !updated[3].Deleted {
+		t.Fatalf("expected flags set only for delivered notices, got %+v", updated)
+	}
+	messages := server.received()
+	if len(messages) != 3 || messages[0].auth == "" || messages[0].from != "FROM:<hypersphere@example.com>" ||
+		messages[0].to[0] != "TO:<a@example.com>" {
+		t.Fatalf("unexpected SMTP envelopes: %+v", messages)
+	}
+	mark := messages[0].data
+	for _, want := range []string{
+		"To: a@example.com",
+		"Subject: VM mark-me is scheduled for deletion on 2026-03-02",
+		"move it out of the PENDING_DELETION folder before 2026-03-02",
+	} {
+		if !strings.Contains(mark, want) {
+			t.Fatalf("expected %q in mark notice:\n%s", want, mark)
+		}
+	}
+	if !strings.Contains(messages[1].data, "Subject: Reminder: VM remind-me will be deleted on 2026-02-15") ||
+		!strings.Contains(messages[2].data, "Subject: VM purge-me has been deleted") {
+		t.Fatalf("unexpected remind and purge notices: %+v", messages[1:])
+	}
+}
+
+func TestNoticeFlagsStayUnsetWithoutDelivery(t *testing.T) {
+	vm := VM{Name: "vm", OwnerEmail: "a@example.com"}
+	unnotified, err := NewEngine(Policy{PurgeAfterDays: 14}).Apply(vm, Action{Type: ActionMark}, fixedNow())
+	if err != nil || unnotified.Metadata[FieldPendingSince] == "" || unnotified.Metadata[FieldInitialNoticeSent] != "" {
+		t.Fatalf("expected mark without a notifier to leave the notice flag unset, got %+v err=%v", unnotified, err)
+	}
+	failing := &fakeNotifier{err: errors.New("relay down")}
+	marked, err := NewEngine(Policy{PurgeAfterDays: 14}).WithNotifier(failing).Apply(vm, Action{Type: ActionMark}, fixedNow())
+	if err == nil || marked.Metadata[FieldInitialNoticeSent] != "" || failing.notices[0].DeleteOn != "2026-03-02" {
+		t.Fatalf("expected failed delivery to leave the flag unset, got %+v err=%v", marked, err)
+	}
+	outbox := NewOutboxNotifier(t.TempDir(), "hypersphere@localhost", DefaultTemplates())
+	if _, err := NewEngine(Policy{}).WithNotifier(outbox).Apply(VM{Name: "orphan"}, Action{Type: ActionPurge}, fixedNow()); !errors.Is(err, ErrNoRecipient) {
+		t.Fatalf("expected missing owner to fail delivery, got %v", err)
+	}
+	if err := NewSMTPNotifier(SMTPConfig{}, DefaultTemplates()).Notify(Notice{Event: ActionMark}); !errors.Is(err, ErrNoRecipient) {
+		t.Fatalf("expected SMTP notice without owner rejected, got %v", err)
+	}
+}
+
+func TestFailedMarkNoticeIsRetriedOnTheNextRun(t *testing.T) {
+	policy := Policy{MarkAfterDays: 30, PurgeAfterDays: 14, PendingFolder: "PENDING_DELETION", RenamePrefix: "PD_"}
+	vms := []VM{{Name: "vm-a", Folder: "WORKLOADS", PoweredOffDays: 45, OwnerEmail: "a@example.com"}}
+	failing := &fakeNotifier{err: errors.New("relay down")}
+	first := NewEngine(policy).WithNotifier(failing)
+	marked, summary := first.ApplyPlan(vms, first.Plan(vms, ModeAll, fixedNow()), fixedNow())
+	if summary.AppliedCount != 1 || len(summary.NotifyErrors) != 1 || marked[0].Metadata[FieldInitialNoticeSent] != "" {
+		t.Fatalf("expected the mark applied with its notice failed, got %+v %+v", summary, marked)
+	}
+	if plan := NewEngine(policy).Plan(marked, ModeAll, fixedNow()); len(plan) != 0 {
+		t.Fatalf("expected no retry without a notifier, got %+v", plan)
+	}
+	delivering := &fakeNotifier{}
+	second := NewEngine(policy).WithNotifier(delivering)
+	plan := second.Plan(marked, ModeAll, fixedNow().AddDate(0, 0, 1))
+	if len(plan) != 1 || plan[0] != (Action{Type: ActionMark, VMName: "PD_vm-a", Policy: DefaultPolicyName, Notes: "retry mark notice"}) {
+		t.Fatalf("expected the mark notice retried, got %+v", plan)
+	}
+	notified, summary := second.ApplyPlan(marked, plan, fixedNow().AddDate(0, 0, 1))
+	if summary.AppliedCount != 1 || len(delivering.notices) != 1 || delivering.notices[0].Event != ActionMark ||
+		notified[0].Metadata[FieldInitialNoticeSent] != "true" || notified[0].Metadata[FieldPendingSince] != marked[0].Metadata[FieldPendingSince] {
+		t.Fatalf("expected only the notice delivered on retry, got %+v %+v %+v", summary, delivering.notices, notified)
+	}
+	if plan := second.Plan(notified, ModeAll, fixedNow().AddDate(0, 0, 1)); len(plan) != 0 {
+		t.Fatalf("expected nothing left to retry, got %+v", plan)
+	}
+}
+
+func TestOutboxNotifierWritesRenderedNotices(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "outbox")
+	notifier := NewOutboxNotifier(dir, "hypersphere@localhost", DefaultTemplates())
+	notice := Notice{Event: ActionRemind, VMName: "dc/vm-a", OwnerEmail: "a@example.com", DeleteOn: "2026-02-25", PendingFolder: "PENDING_DELETION"}
+	if err := notifier.Notify(notice); err != nil {
+		t.Fatalf("Notify returned error: %v", err)
+	}
+	content, err := os.ReadFile(filepath.Join(dir, "dc_vm-a-remind.eml"))
+	if err != nil || !strings.Contains(string(content), "From: hypersphere@localhost\r\n") ||
+		!strings.Contains(string(content), "will be deleted on 2026-02-25") {
+		t.Fatalf("unexpected outbox message %q err=%v", content, err)
+	}
+	blocked := filepath.Join(t.TempDir(), "file")
+	if err := os.WriteFile(blocked, nil, 0o600); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+	if err := NewOutboxNotifier(filepath.Join(blocked, "outbox"), "", DefaultTemplates()).Notify(notice); err == nil {
+		t.Fatalf("expected outbox under a file to fail")
+	}
+}
+
+func TestTemplatesRejectMissingOrBrokenTemplates(t *testing.T) {
+	notice := Notice{Event: ActionMark, VMName: "vm", OwnerEmail: "a@example.com"}
+	for _, templates := range []Templates{
+		{},
+		{ActionMark: {Subject: "{{", Body: "ok"}},
+		{ActionMark: {Subject: "ok", Body: "{{.Missing}}"}},
+	} {
+		if _, err := templates.Render(notice); !errors.Is(err, ErrInvalidTemplate) {
+			t.Fatalf("expected invalid template error for %+v, got %v", templates, err)
+		}
+	}
+}
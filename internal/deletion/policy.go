@@ -0,0 +1,154 @@
+// Path: internal/deletion/policy.go
+// Description: Load per-folder, per-tag, and per-cluster deletion policies and resolve the one that applies to a VM.
+package deletion
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/takelley1/hypersphere/internal/jsonfile"
+)
+
+// DefaultPolicyName names the policy applied to VMs no rule selects.
+const DefaultPolicyName = "default"
+
+// ErrInvalidPolicy indicates a malformed deletion policy file.
+var ErrInvalidPolicy = errors.New("invalid deletion policy")
+
+// PolicySet is the JSON form of deletion policies. Default overrides the
+// engine's default timings where set; Rules are tried in order and the first
+// one matching a VM supplies its timings.
+type PolicySet struct {
+	Default PolicyTimings `json:"default"`
+	Rules   []PolicyRule  `json:"rules"`
+}
+
+// PolicyTimings sets how long a VM stays powered off before it is marked and
+// how long it stays pending before it is purged.
+type PolicyTimings struct {
+	MarkAfterDays  int `json:"mark_after_days"`
+	PurgeAfterDays int `json:"purge_after_days"`
+}
+
+// PolicyRule selects its timings for VMs under FolderPrefix, carrying Tag, or
+// in Cluster. Every selector that is set must match, and at least one must be
+// set.
+type PolicyRule struct {
+	Name         string `json:"name"`
+	FolderPrefix string `json:"folder_prefix"`
+	Tag          string `json:"tag"`
+	Cluster      string `json:"cluster"`
+	PolicyTimings
+}
+
+// LoadPolicies read deletion policies, returning an empty set when the file is absent.
+func LoadPolicies(path string) (PolicySet, error) {
+	return jsonfile.Load(path, ParsePolicies)
+}
+
+// ParsePolicies decode and validate JSON deletion policies.
+func ParsePolicies(content []byte) (PolicySet, error) {
+	return jsonfile.Decode(content, ErrInvalidPolicy, (*PolicySet).validate)
+}
+
+func (s PolicySet) validate() error {
+	if s.Default.MarkAfterDays < 0 || s.Default.PurgeAfterDays < 0 {
+		return errors.New("default days must not be negative")
+	}
+	names := []string{DefaultPolicyName}
+	for _, rule := range s.Rules {
+		if rule.Name == "" || slices.Contains(names, rule.Name) {
+			return fmt.Errorf("rule name %q must be set and unique", rule.Name)
+		}
+		names = append(names, rule.Name)
+		if rule.FolderPrefix == "" && rule.Tag == "" && rule.Cluster == "" {
+			return fmt.Errorf("rule %q needs a folder_prefix, tag, or cluster", rule.Name)
+		}
+		if rule.MarkAfterDays < 1 || rule.PurgeAfterDays < 1 {
+			return fmt.Errorf("rule %q days must be at least 1", rule.Name)
+		}
+	}
+	return nil
+}
+
+// WithPolicies return an engine that resolves each VM's policy from the set,
+// falling back to the engine's policy with the set's default timings.
+func (e Engine) WithPolicies(set PolicySet) Engine {
+	if set.Default.MarkAfterDays > 0 {
+		e.policy.MarkAfterDays = set.Default.MarkAfterDays
+	}
+	if set.Default.PurgeAfterDays > 0 {
+		e.policy.PurgeAfterDays = set.Default.PurgeAfterDays
+	}
+	e.rules = set.Rules
+	return e
+}
+
+// Resolve return the policy that applies to the VM: the engine's policy with
+// the timings of the first matching rule, or unchanged under the default name.
+// Folders are matched against the folder recorded at mark time, so pending
+// VMs keep the policy they were marked under. The pending folder is resolved
+// to an inventory path in the VM's datacenter.
+func (e Engine) Resolve(vm VM) Policy {
+	policy := e.policy
+	if policy.Name == "" {
+		policy.Name = DefaultPolicyName
+	}
+	folder := vm.Folder
+	if original := vm.Metadata[FieldOriginalFolder]; original != "" {
+		folder = original
+	}
+	policy.PendingFolder = pendingPath(vm.Folder, policy.PendingFolder)
+	for _, rule := range e.rules {
+		if rule.matches(vm, folder) {
+			policy.Name = rule.Name
+			policy.MarkAfterDays = rule.MarkAfterDays
+			policy.PurgeAfterDays = rule.PurgeAfterDays
+			return policy
+		}
+	}
+	return policy
+}
+
+// pendingPath place a pending folder given by name in the VM folder of the
+// datacenter holding folder, the "vm" folder vCenter creates under every
+// datacenter, so each datacenter keeps its own pending folder and it compares
+// equal to the inventory paths VMs report. Pending folders given as a path,
+// and folders outside a datacenter, keep the pending folder as given.
+func pendingPath(folder string, pending string) string {
+	if pending == "" || strings.HasPrefix(pending, "/") || !strings.HasPrefix(folder, "/") {
+		return pending
+	}
+	parts := strings.Split(folder, "/")
+	index := slices.Index(parts, "vm")
+	if index < 0 {
+		return pending
+	}
+	return strings.Join(parts[:index+1], "/") + "/" + pending
+}
+
+func (r PolicyRule) matches(vm VM, folder string) bool {
+	if r.FolderPrefix != "" {
+		prefix := strings.TrimSuffix(r.FolderPrefix, "/")
+		if folder != prefix && !strings.HasPrefix(folder, prefix+"/") {
+			return false
+		}
+	}
+	if r.Tag != "" && !hasTag(vm.Tags, r.Tag) {
+		return false
+	}
+	return r.Cluster == "" || r.Cluster == vm.Cluster
+}
+
+// hasTag report whether tags holds tag, ignoring case and surrounding space
+// as migration tag filters do.
+func hasTag(tags []string, tag string) bool {
+	for _, candidate := range tags {
+		if strings.EqualFold(strings.TrimSpace(candidate), strings.TrimSpace(tag)) {
+			return true
+		}
+	}
+	return false
+}
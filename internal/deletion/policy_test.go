@@ -0,0 +1,143 @@
+// Path: internal/deletion/policy_test.go
+// Description: Validate deletion policy loading and per-folder, per-tag, and per-cluster resolution.
+package deletion
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const policyFile = `{
+  "default": {"mark_after_days": 45, "purge_after_days": 21},
+  "rules": [
+    {"name": "dev", "folder_prefix": "DEV/", "mark_after_days": 14, "purge_after_days": 7},
+    {"name": "prod", "tag": "prod", "mark_after_days": 90, "purge_after_days": 30},
+    {"name": "lab", "cluster": "lab", "tag": "gpu", "mark_after_days": 7, "purge_after_days": 3}
+  ]
+}`
+
+func TestEngineResolvesPoliciesByFolderTagAndCluster(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "policies.json")
+	if err := os.WriteFile(path, []byte(policyFile), 0o600); err != nil {
+		t.Fatalf("write policies: %v", err)
+	}
+	set, err := LoadPolicies(path)
+	if err != nil {
+		t.Fatalf("LoadPolicies returned error: %v", err)
+	}
+	base := Policy{MarkAfterDays: 30, PurgeAfterDays: 14, PendingFolder: "PENDING_DELETION", RenamePrefix: "PD_"}
+	engine := NewEngine(base).WithPolicies(set)
+	cases := []struct {
+		vm    VM
+		name  string
+		mark  int
+		purge int
+	}{
+		{VM{Folder: "DEV"}, "dev", 14, 7},
+		{VM{Folder: "DEV/web", Tags: []string{"prod"}}, "dev", 14, 7},
+		{VM{Folder: "DEVOPS", Tags: []string{"prod"}}, "prod", 90, 30},
+		{VM{Folder: "PENDING_DELETION", Metadata: map[string]string{FieldOriginalFolder: "DEV/db"}}, "dev", 14, 7},
+		{VM{Cluster: "lab", Tags: []string{"gpu"}}, "lab", 7, 3},
+		{VM{Cluster: "lab"}, DefaultPolicyName, 45, 21},
+	}
+	for _, tc := range cases {
+		policy := engine.Resolve(tc.vm)
+		if policy.Name != tc.name || policy.MarkAfterDays != tc.mark || policy.PurgeAfterDays != tc.purge ||
+			policy.PendingFolder != base.PendingFolder || policy.RenamePrefix != base.RenamePrefix {
+			t.Fatalf("expected %+v resolved to %s %d/%d, got %+v", tc.vm, tc.name, tc.mark, tc.purge, policy)
+		}
+	}
+	named := NewEngine(Policy{Name: "global"}).WithPolicies(PolicySet{})
+	if policy := named.Resolve(VM{}); policy.Name != "global" {
+		t.Fatalf("expected the engine policy name kept, got %+v", policy)
+	}
+}
+
+func TestPlanAppliesEachVMsPolicy(t *testing.T) {
+	set, err := ParsePolicies([]byte(policyFile))
+	if err != nil {
+		t.Fatalf("ParsePolicies returned error: %v", err)
+	}
+	engine := NewEngine(Policy{MarkAfterDays: 30, PurgeAfterDays: 14, PendingFolder: "PENDING_DELETION"}).WithPolicies(set)
+	vms := []VM{
+		{Name: "dev-vm", Folder: "DEV/web", PoweredOffDays: 20},
+		{Name: "prod-vm", Folder: "APPS", Tags: []string{"prod"}, PoweredOffDays: 60},
+		{Name: "other-vm", Folder: "APPS", PoweredOffDays: 50},
+	}
+	plan := engine.Plan(vms, ModeAll, fixedNow())
+	if len(plan) != 2 || plan[0] != (Action{Type: ActionMark, VMName: "dev-vm", Policy: "dev"}) ||
+		plan[1] != (Action{Type: ActionMark, VMName: "other-vm", Policy: DefaultPolicyName}) {
+		t.Fatalf("expected dev and default marks, got %+v", plan)
+	}
+	applied, _ := engine.ApplyPlan(vms, plan, fixedNow())
+	if applied[0].Metadata[FieldDeleteOn] != "2026-02-23" || applied[2].Metadata[FieldDeleteOn] != "2026-03-09" {
+		t.Fatalf("expected delete dates from each VM's policy, got %v %v", applied[0].Metadata, applied[2].Metadata)
+	}
+	remind := engine.Plan(applied, ModeAll, fixedNow().AddDate(0, 0, 4))
+	if len(remind) != 1 || remind[0] != (Action{Type: ActionRemind, VMName: "dev-vm", Policy: "dev"}) {
+		t.Fatalf("expected the pending dev VM reminded under its policy, got %+v", remind)
+	}
+}
+
+func TestParsePoliciesRejectsBadFiles(t *testing.T) {
+	for _, content := range []string{
+		`{`,
+		`{"default": {"purge_after_days": -1}}`,
+		`{"rules": [{"folder_prefix": "DEV", "mark_after_days": 1, "purge_after_days": 1}]}`,
+		`{"rules": [{"name": "default", "tag": "x", "mark_after_days": 1, "purge_after_days": 1}]}`,
+		`{"rules": [{"name": "dev", "mark_after_days": 1, "purge_after_days": 1}]}`,
+		`{"rules": [{"name": "dev", "tag": "x", "mark_after_days": 1}]}`,
+	} {
+		if _, err := ParsePolicies([]byte(content)); !errors.Is(err, ErrInvalidPolicy) {
+			t.Fatalf("expected %s rejected, got %v", content, err)
+		}
+	}
+	if set, err := ParsePolicies([]byte(" \n")); err != nil || len(set.Rules) != 0 {
+		t.Fatalf("expected empty policies, got %+v err=%v", set, err)
+	}
+	for _, path := range []string{"", filepath.Join(t.TempDir(), "missing.json")} {
+		if set, err := LoadPolicies(path); err != nil || len(set.Rules) != 0 {
+			t.Fatalf("expected no policies from %q, got %+v err=%v", path, set, err)
+		}
+	}
+	if _, err := LoadPolicies(t.TempDir()); err == nil {
+		t.Fatalf("expected unreadable policy file rejected")
+	}
+}
+
+func TestPendingFolderResolvesToAPathInTheVMsDatacenter(t *testing.T) {
+	engine := NewEngine(Policy{MarkAfterDays: 30, PurgeAfterDays: 14, PendingFolder: "PENDING_DELETION"})
+	cases := map[string]string{
+		"/Datacenters/dc-1/vm/Prod/web":         "/Datacenters/dc-1/vm/PENDING_DELETION",
+		"/Datacenters/EU/dc-2/vm":               "/Datacenters/EU/dc-2/vm/PENDING_DELETION",
+		"/Datacenters/dc-2/vm/PENDING_DELETION": "/Datacenters/dc-2/vm/PENDING_DELETION",
+		"/Templates/Linux":                      "PENDING_DELETION",
+		"WORKLOADS":                             "PENDING_DELETION",
+	}
+	for folder, want := range cases {
+		if got := engine.Resolve(VM{Folder: folder}).PendingFolder; got != want {
+			t.Fatalf("expected %s to use pending folder %s, got %s", folder, want, got)
+		}
+	}
+	absolute := NewEngine(Policy{PendingFolder: "/Datacenters/dc-1/vm/PENDING_DELETION"})
+	if got := absolute.Resolve(VM{Folder: "/Datacenters/dc-2/vm/Dev"}).PendingFolder; got != "/Datacenters/dc-1/vm/PENDING_DELETION" {
+		t.Fatalf("expected a pending folder path kept, got %s", got)
+	}
+	vms := []VM{
+		{Name: "mark-me", Folder: "/Datacenters/dc-1/vm/Prod", PoweredOffDays: 45},
+		{Name: "remind-me", Folder: "/Datacenters/dc-2/vm/PENDING_DELETION", Metadata: map[string]string{
+			FieldPendingSince: "2026-02-01",
+			FieldDeleteOn:     "2026-02-25",
+		}},
+	}
+	plan := engine.Plan(vms, ModeAll, fixedNow())
+	if len(plan) != 2 || plan[0].Type != ActionMark || plan[1].Type != ActionRemind {
+		t.Fatalf("expected pending VMs recognized by folder path, got %+v", plan)
+	}
+	marked, _ := engine.Apply(vms[0], plan[0], fixedNow())
+	if marked.Folder != "/Datacenters/dc-1/vm/PENDING_DELETION" || marked.Metadata[FieldOriginalFolder] != "/Datacenters/dc-1/vm/Prod" {
+		t.Fatalf("expected mark to move the VM into its datacenter's pending folder, got %+v", marked)
+	}
+}
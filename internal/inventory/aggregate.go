@@ -0,0 +1,205 @@
+// Path: internal/inventory/aggregate.go
+// Description: Merge inventories listed concurrently from several vCenter endpoints into one provider.
+package inventory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/takelley1/hypersphere/internal/tui"
+)
+
+// Member names one endpoint provider inside an aggregate.
+type Member struct {
+	Name     string
+	Provider tui.InventoryProvider
+}
+
+// AggregateProvider lists every member concurrently and tags rows with their vCenter.
+type AggregateProvider struct {
+	members  []Member
+	previous tui.Catalog
+	loaded   bool
+}
+
+type memberWatcher interface {
+	WatchChanges(ctx context.Context, maxWait time.Duration) ([]tui.CatalogChange, error)
+}
+
+// NewAggregateProvider build a provider that merges member inventories in member order.
+func NewAggregateProvider(members []Member) *AggregateProvider {
+	return &AggregateProvider{members: append([]Member{}, members...)}
+}
+
+// Members return the endpoint providers merged by the aggregate.
+func (p *AggregateProvider) Members() []Member {
+	return append([]Member{}, p.members...)
+}
+
+// ListVMs return VM rows from every member.
+func (p *AggregateProvider) ListVMs() ([]tui.VMRow, error) {
+	return aggregateRows(p.members, tui.InventoryProvider.ListVMs)
+}
+
+// ListLUNs return LUN rows from every member.
+func (p *AggregateProvider) ListLUNs() ([]tui.LUNRow, error) {
+	return aggregateRows(p.members, tui.InventoryProvider.ListLUNs)
+}
+
+// ListClusters return cluster rows from every member.
+func (p *AggregateProvider) ListClusters() ([]tui.ClusterRow, error) {
+	return aggregateRows(p.members, tui.InventoryProvider.ListClusters)
+}
+
+// ListDatacenters return datacenter rows from every member.
+func (p *AggregateProvider) ListDatacenters() ([]tui.DatacenterRow, error) {
+	return aggregateRows(p.members, tui.InventoryProvider.ListDatacenters)
+}
+
+// ListResourcePools return resource pool rows from every member.
+func (p *AggregateProvider) ListResourcePools() ([]tui.ResourcePoolRow, error) {
+	return aggregateRows(p.members, tui.InventoryProvider.ListResourcePools)
+}
+
+// ListNetworks return network rows from every member.
+func (p *AggregateProvider) ListNetworks() ([]tui.NetworkRow, error) {
+	return aggregateRows(p.members, tui.InventoryProvider.ListNetworks)
+}
+
+// ListTemplates return template rows from every member.
+func (p *AggregateProvider) ListTemplates() ([]tui.TemplateRow, error) {
+	return aggregateRows(p.members, tui.InventoryProvider.ListTemplates)
+}
+
+// ListSnapshots return snapshot rows from every member.
+func (p *AggregateProvider) ListSnapshots() ([]tui.SnapshotRow, error) {
+	return aggregateRows(p.members, tui.InventoryProvider.ListSnapshots)
+}
+
+// ListTasks return task rows from every member.
+func (p *AggregateProvider) ListTasks() ([]tui.TaskRow, error) {
+	return aggregateRows(p.members, tui.InventoryProvider.ListTasks)
+}
+
+// ListEvents return event rows from every member.
+func (p *AggregateProvider) ListEvents() ([]tui.EventRow, error) {
+	return aggregateRows(p.members, tui.InventoryProvider.ListEvents)
+}
+
+// ListAlarms return alarm rows from every member.
+func (p *AggregateProvider) ListAlarms() ([]tui.AlarmRow, error) {
+	return aggregateRows(p.members, tui.InventoryProvider.ListAlarms)
+}
+
+// ListFolders return folder rows from every member.
+func (p *AggregateProvider) ListFolders() ([]tui.FolderRow, error) {
+	return aggregateRows(p.members, tui.InventoryProvider.ListFolders)
+}
+
+// ListTags return tag rows from every member.
+func (p *AggregateProvider) ListTags() ([]tui.TagRow, error) {
+	return aggregateRows(p.members, tui.InventoryProvider.ListTags)
+}
+
+// ListHosts return host rows from every member.
+func (p *AggregateProvider) ListHosts() ([]tui.HostRow, error) {
+	return aggregateRows(p.members, tui.InventoryProvider.ListHosts)
+}
+
+// ListDatastores return datastore rows from every member.
+func (p *AggregateProvider) ListDatastores() ([]tui.DatastoreRow, error) {
+	return aggregateRows(p.members, tui.InventoryProvider.ListDatastores)
+}
+
+// WatchChanges wait on every member concurrently, then diff the merged inventory.
+func (p *AggregateProvider) WatchChanges(ctx context.Context, maxWait time.Duration) ([]tui.CatalogChange, error) {
+	if !p.loaded {
+		catalog, err := tui.LoadCatalog(p)
+		if err != nil {
+			return nil, err
+		}
+		p.previous = catalog
+		p.loaded = true
+	}
+	errs := make([]error, len(p.members))
+	var group sync.WaitGroup
+	for index, member := range p.members {
+		group.Go(func() { errs[index] = watchMember(ctx, member, maxWait) })
+	}
+	group.Wait()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	next, err := tui.LoadCatalog(p)
+	if err != nil {
+		return nil, err
+	}
+	changes := tui.DiffCatalog(p.previous, next)
+	p.previous = next
+	return changes, nil
+}
+
+// Close log out of every member that holds a session.
+func (p *AggregateProvider) Close() error {
+	errs := []error{}
+	for _, member := range p.members {
+		if closer, ok := member.Provider.(io.Closer); ok {
+			errs = append(errs, closer.Close())
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func watchMember(ctx context.Context, member Member, maxWait time.Duration) error {
+	watcher, ok := member.Provider.(memberWatcher)
+	if !ok {
+		timer := time.NewTimer(maxWait)
+		defer timer.Stop()
+		select {
+		case <-ctx.Done():
+		case <-timer.C:
+		}
+		return nil
+	}
+	if _, err := watcher.WatchChanges(ctx, maxWait); err != nil {
+		return fmt.Errorf("%s: %w", member.Name, err)
+	}
+	return nil
+}
+
+func aggregateRows[T any, P interface {
+	*T
+	SetVCenter(string)
+}](members []Member, list func(tui.InventoryProvider) ([]T, error)) ([]T, error) {
+	listed := make([][]T, len(members))
+	errs := make([]error, len(members))
+	var group sync.WaitGroup
+	for index, member := range members {
+		group.Go(func() {
+			rows, err := list(member.Provider)
+			if err != nil {
+				errs[index] = fmt.Errorf("%s: %w", member.Name, err)
+			}
+			listed[index] = rows
+		})
+	}
+	group.Wait()
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	merged := []T{}
+	for index, rows := range listed {
+		for _, row := range rows {
+			P(&row).SetVCenter(members[index].Name)
+			merged = append(merged, row)
+		}
+	}
+	return merged, nil
+}
@@ -0,0 +1,101 @@
+// Path: internal/inventory/aggregate_test.go
+// Description: Validate merged multi-endpoint listings, watch fan-out, and member logout.
+package inventory
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/takelley1/hypersphere/internal/tui"
+)
+
+type watchedProvider struct {
+	DemoProvider
+	vms      []tui.VMRow
+	next     []tui.VMRow
+	watchErr error
+	failList bool
+	closed   int
+}
+
+func (p *watchedProvider) ListVMs() ([]tui.VMRow, error) {
+	if p.failList {
+		return nil, errors.New("list vms failed")
+	}
+	return p.vms, nil
+}
+
+func (p *watchedProvider) WatchChanges(ctx context.Context, maxWait time.Duration) ([]tui.CatalogChange, error) {
+	if p.watchErr != nil {
+		return nil, p.watchErr
+	}
+	p.vms = p.next
+	return nil, nil
+}
+
+func (p *watchedProvider) Close() error {
+	p.closed++
+	return errors.New("logout failed")
+}
+
+func TestAggregateProviderTagsRowsByMember(t *testing.T) {
+	watched := &watchedProvider{vms: []tui.VMRow{{Name: "vm-a"}}}
+	aggregate := NewAggregateProvider([]Member{{Name: "vc-a", Provider: watched}, {Name: "vc-b", Provider: NewDemoProvider()}})
+	catalog, err := tui.LoadCatalog(aggregate)
+	if err != nil {
+		t.Fatalf("LoadCatalog returned error: %v", err)
+	}
+	demo, _ := tui.LoadCatalog(NewDemoProvider())
+	if len(catalog.VMs) != len(demo.VMs)+1 || catalog.VMs[0].VCenter != "vc-a" || catalog.VMs[1].VCenter != "vc-b" {
+		t.Fatalf("expected member rows merged in member order, got %+v", catalog.VMs[:2])
+	}
+	if len(catalog.Hosts) != 2*len(demo.Hosts) || catalog.Hosts[len(catalog.Hosts)-1].VCenter != "vc-b" {
+		t.Fatalf("expected hosts from both members, got %d", len(catalog.Hosts))
+	}
+	if members := aggregate.Members(); len(members) != 2 || members[0].Provider != watched {
+		t.Fatalf("unexpected members: %+v", members)
+	}
+	watched.failList = true
+	if _, err := aggregate.ListVMs(); err == nil || err.Error() != "vc-a: list vms failed" {
+		t.Fatalf("expected member-qualified list error, got %v", err)
+	}
+	if err := aggregate.Close(); err == nil || watched.closed != 1 {
+		t.Fatalf("expected Close to log out members and report failures, err=%v", err)
+	}
+}
+
+func TestAggregateProviderWatchChangesDiffsMergedInventory(t *testing.T) {
+	watched := &watchedProvider{vms: []tui.VMRow{{Name: "vm-a"}}, next: []tui.VMRow{{Name: "vm-a"}, {Name: "vm-new"}}}
+	aggregate := NewAggregateProvider([]Member{{Name: "vc-a", Provider: watched}, {Name: "vc-b", Provider: NewDemoProvider()}})
+	changes, err := aggregate.WatchChanges(context.Background(), time.Millisecond)
+	if err != nil {
+		t.Fatalf("WatchChanges returned error: %v", err)
+	}
+	if len(changes) != 1 || changes[0].Op != tui.CatalogReplace || changes[0].Resource != tui.ResourceVM {
+		t.Fatalf("expected inserted vc-a row to reorder the merged vm table, got %+v", changes)
+	}
+	if changes, err := aggregate.WatchChanges(context.Background(), time.Millisecond); err != nil || len(changes) != 0 {
+		t.Fatalf("expected no changes on a quiet watch, got %+v err=%v", changes, err)
+	}
+	watched.watchErr = errors.New("session expired")
+	if _, err := aggregate.WatchChanges(context.Background(), time.Millisecond); err == nil ||
+		!strings.Contains(err.Error(), "vc-a: session expired") {
+		t.Fatalf("expected member watch error, got %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := aggregate.WatchChanges(ctx, time.Hour); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected cancelled watch, got %v", err)
+	}
+	watched.watchErr = nil
+	watched.failList = true
+	if _, err := aggregate.WatchChanges(context.Background(), time.Millisecond); err == nil {
+		t.Fatalf("expected reload failure after watch")
+	}
+	if _, err := NewAggregateProvider(aggregate.Members()).WatchChanges(context.Background(), 0); err == nil {
+		t.Fatalf("expected baseline load failure")
+	}
+}
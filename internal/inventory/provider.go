@@ -0,0 +1,61 @@
+// Path: internal/inventory/provider.go
+// Description: Resolve named inventory providers for explorer and workflow data sources.
+package inventory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/takelley1/hypersphere/internal/tui"
+	"github.com/takelley1/hypersphere/internal/vsphere"
+)
+
+const (
+	// ProviderDemo names the static sample-data provider.
+	ProviderDemo = "demo"
+	// ProviderVSphere names the live vCenter vim25 provider.
+	ProviderVSphere = "vsphere"
+)
+
+// ErrUnknownProvider indicates an unsupported inventory provider name.
+var ErrUnknownProvider = errors.New("unknown inventory provider")
+
+// Names list supported provider names.
+func Names() []string {
+	return []string{ProviderDemo, ProviderVSphere}
+}
+
+// Options carries connection settings for providers that reach live endpoints.
+type Options struct {
+	Endpoint   vsphere.Endpoint
+	Datacenter string
+}
+
+// NormalizeName canonicalize a provider name, defaulting to the demo provider.
+func NormalizeName(name string) string {
+	normalized := strings.ToLower(strings.TrimSpace(name))
+	if normalized == "" {
+		return ProviderDemo
+	}
+	return normalized
+}
+
+// Open resolve a named inventory provider, connecting to live endpoints as needed.
+func Open(name string, options Options) (tui.InventoryProvider, error) {
+	switch NormalizeName(name) {
+	case ProviderDemo:
+		return NewDemoProvider(), nil
+	case ProviderVSphere:
+		client, err := vsphere.Dial(context.Background(), options.Endpoint)
+		if err != nil {
+			return nil, err
+		}
+		provider := vsphere.NewProvider(client)
+		provider.ScopeDatacenter(options.Datacenter)
+		return provider, nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownProvider, name)
+	}
+}
@@ -0,0 +1,84 @@
+// Path: internal/inventory/provider_test.go
+// Description: Validate provider name resolution and demo inventory coverage.
+package inventory
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/takelley1/hypersphere/internal/tui"
+	"github.com/takelley1/hypersphere/internal/vsphere"
+)
+
+func TestOpenResolvesDemoProviderByDefault(t *testing.T) {
+	for _, name := range []string{"", " DEMO "} {
+		provider, err := Open(name, Options{})
+		if err != nil {
+			t.Fatalf("Open(%q) returned error: %v", name, err)
+		}
+		if _, ok := provider.(DemoProvider); !ok {
+			t.Fatalf("expected demo provider for %q, got %T", name, provider)
+		}
+	}
+}
+
+func TestOpenRejectsUnknownProvider(t *testing.T) {
+	_, err := Open("mystery", Options{})
+	if !errors.Is(err, ErrUnknownProvider) {
+		t.Fatalf("expected unknown provider error, got %v", err)
+	}
+}
+
+func TestNamesListsDemoProvider(t *testing.T) {
+	names := Names()
+	if len(names) != 2 || names[0] != ProviderDemo || names[1] != ProviderVSphere {
+		t.Fatalf("expected demo and vsphere provider names, got %v", names)
+	}
+}
+
+func TestOpenConnectsVSphereProvider(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		response := "<LoginResponse xmlns=\"urn:vim25\"/>"
+		if strings.Contains(string(body), "RetrieveServiceContent") {
+			response = "<RetrieveServiceContentResponse xmlns=\"urn:vim25\"><returnval>" +
+				"<sessionManager type=\"SessionManager\">SessionManager</sessionManager>" +
+				"</returnval></RetrieveServiceContentResponse>"
+		}
+		_, _ = io.WriteString(w, "<Envelope><Body>"+response+"</Body></Envelope>")
+	}))
+	defer server.Close()
+	provider, err := Open(" vSphere ", Options{Endpoint: vsphere.Endpoint{URL: server.URL}})
+	if err != nil {
+		t.Fatalf("Open returned error: %v", err)
+	}
+	if _, ok := provider.(*vsphere.Provider); !ok {
+		t.Fatalf("expected vsphere provider, got %T", provider)
+	}
+}
+
+func TestOpenVSphereRequiresEndpoint(t *testing.T) {
+	if _, err := Open(ProviderVSphere, Options{}); !errors.Is(err, vsphere.ErrEndpointRequired) {
+		t.Fatalf("expected missing endpoint error, got %v", err)
+	}
+}
+
+func TestDemoProviderLoadsFullCatalog(t *testing.T) {
+	catalog, err := tui.LoadCatalog(NewDemoProvider())
+	if err != nil {
+		t.Fatalf("LoadCatalog returned error: %v", err)
+	}
+	if len(catalog.VMs) < 8 || len(catalog.Hosts) < 8 || len(catalog.Datastores) < 8 {
+		t.Fatalf("expected dense demo inventory, got %d vms %d hosts %d datastores", len(catalog.VMs), len(catalog.Hosts), len(catalog.Datastores))
+	}
+	if len(catalog.LUNs) == 0 || len(catalog.Clusters) == 0 || len(catalog.Datacenters) == 0 ||
+		len(catalog.ResourcePools) == 0 || len(catalog.Networks) == 0 || len(catalog.Templates) == 0 ||
+		len(catalog.Snapshots) == 0 || len(catalog.Tasks) == 0 || len(catalog.Events) == 0 ||
+		len(catalog.Alarms) == 0 || len(catalog.Folders) == 0 || len(catalog.Tags) == 0 {
+		t.Fatalf("expected every demo resource to have rows: %+v", catalog)
+	}
+}
@@ -0,0 +1,44 @@
+// Path: internal/jsonfile/jsonfile.go
+// Description: Read optional JSON configuration files into validated values.
+package jsonfile
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Load read the file at path and decode it with parse, returning the zero
+// value when the path is blank or the file is absent.
+func Load[T any](path string, parse func(content []byte) (T, error)) (T, error) {
+	var zero T
+	if strings.TrimSpace(path) == "" {
+		return zero, nil
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return zero, nil
+		}
+		return zero, err
+	}
+	return parse(content)
+}
+
+// Decode unmarshal JSON content and check it with validate, which may also
+// normalize the value. Blank content decodes to the zero value, and decode
+// and validation failures are wrapped in invalid.
+func Decode[T any](content []byte, invalid error, validate func(value *T) error) (T, error) {
+	var value, zero T
+	if strings.TrimSpace(string(content)) == "" {
+		return zero, nil
+	}
+	if err := json.Unmarshal(content, &value); err != nil {
+		return zero, fmt.Errorf("%w: %v", invalid, err)
+	}
+	if err := validate(&value); err != nil {
+		return zero, fmt.Errorf("%w: %w", invalid, err)
+	}
+	return value, nil
+}
@@ -0,0 +1,60 @@
+// Path: internal/jsonfile/jsonfile_test.go
+// Description: Validate optional JSON file loading, decoding, and error wrapping.
+package jsonfile
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var errInvalid = errors.New("invalid sample")
+
+type sample struct {
+	Name string `json:"name"`
+}
+
+func parseSample(content []byte) (sample, error) {
+	return Decode(content, errInvalid, func(value *sample) error {
+		if value.Name == "" {
+			return errors.New("name must be set")
+		}
+		value.Name += "!"
+		return nil
+	})
+}
+
+func TestLoadReadsOptionalFiles(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "sample.json")
+	if err := os.WriteFile(path, []byte(`{"name": "a"}`), 0o600); err != nil {
+		t.Fatalf("write sample: %v", err)
+	}
+	if value, err := Load(path, parseSample); err != nil || value.Name != "a!" {
+		t.Fatalf("expected validated sample, got %+v err=%v", value, err)
+	}
+	for _, path := range []string{" ", filepath.Join(dir, "missing.json")} {
+		if value, err := Load(path, parseSample); err != nil || value != (sample{}) {
+			t.Fatalf("expected zero sample from %q, got %+v err=%v", path, value, err)
+		}
+	}
+	if _, err := Load(dir, parseSample); err == nil || errors.Is(err, errInvalid) {
+		t.Fatalf("expected unreadable file returned as is, got %v", err)
+	}
+}
+
+func TestDecodeWrapsFailures(t *testing.T) {
+	if value, err := parseSample([]byte(" \n")); err != nil || value != (sample{}) {
+		t.Fatalf("expected zero sample from blank content, got %+v err=%v", value, err)
+	}
+	for _, content := range []string{`{`, `{"name": ""}`} {
+		if value, err := parseSample([]byte(content)); !errors.Is(err, errInvalid) || value != (sample{}) {
+			t.Fatalf("expected %s rejected, got %+v err=%v", content, value, err)
+		}
+	}
+	cause := errors.New("cause")
+	if _, err := Decode([]byte(`{}`), errInvalid, func(*sample) error { return cause }); !errors.Is(err, cause) {
+		t.Fatalf("expected the validation cause kept, got %v", err)
+	}
+}
@@ -0,0 +1,219 @@
+// Path: internal/migration/affinity.go
+// Description: Enforce anti-affinity and co-location rules for VM groups during placement.
+package migration
+
+import (
+	"fmt"
+	"path"
+	"slices"
+)
+
+const (
+	// SkipAntiAffinity marks a step whose every target already holds a VM it must stay apart from.
+	SkipAntiAffinity = "ANTI_AFFINITY"
+	// SkipAffinity marks a step whose VM cannot land with the rest of its co-location group.
+	SkipAffinity = "AFFINITY"
+)
+
+// PlacementRule selects a group of VMs by tag or name pattern.
+type PlacementRule struct {
+	Name string `json:"name"`
+	// Tags selects VMs carrying any of these tags.
+	Tags []string `json:"tags"`
+	// Names selects VMs whose name matches any of these glob patterns.
+	Names []string `json:"names"`
+}
+
+func (r PlacementRule) matches(vm VM) bool {
+	for _, tag := range r.Tags {
+		if hasTag(vm.Tags, tag) {
+			return true
+		}
+	}
+	for _, pattern := range r.Names {
+		if matched, _ := path.Match(pattern, vm.Name); matched {
+			return true
+		}
+	}
+	return false
+}
+
+func validateRules(kind string, rules []PlacementRule, seen map[string]bool) error {
+	for _, rule := range rules {
+		if rule.Name == "" {
+			return fmt.Errorf("%s rule without a name", kind)
+		}
+		if seen[rule.Name] {
+			return fmt.Errorf("duplicate rule name %q", rule.Name)
+		}
+		seen[rule.Name] = true
+		if len(rule.Tags) == 0 && len(rule.Names) == 0 {
+			return fmt.Errorf("%s rule %s selects no VMs", kind, rule.Name)
+		}
+		for _, pattern := range rule.Names {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return fmt.Errorf("%s rule %s: bad name pattern %q", kind, rule.Name, pattern)
+			}
+		}
+	}
+	return nil
+}
+
+// placement tracks where each VM ends up as the plan grows, and which target
+// each co-location group has claimed.
+type placement struct {
+	vms       []VM
+	locations map[string]string
+	affinity  map[string]string
+	groups    map[string][]VM
+	pinned    map[string]string
+	refused   map[string]bool
+}
+
+// WithResidents return a planner that checks placement rules against every VM
+// in the inventory, including VMs a filter left out of the candidates.
+func (p Planner) WithResidents(vms []VM) Planner {
+	p.residents = vms
+	return p
+}
+
+// newPlacement group VMs under the first affinity rule each matches; rules
+// matching fewer than two VMs impose nothing. Residents that are not
+// candidates stay where they are, so anti-affinity keeps candidates off their
+// datastores and a co-location group holding one is pinned to its datastore.
+func (p Planner) newPlacement(residents []VM, candidates []VM) *placement {
+	placed := &placement{
+		vms:       slices.Clone(candidates),
+		locations: map[string]string{},
+		affinity:  map[string]string{},
+		groups:    map[string][]VM{},
+		pinned:    map[string]string{},
+		refused:   map[string]bool{},
+	}
+	moving := map[string]bool{}
+	for _, vm := range candidates {
+		moving[vm.Name] = true
+	}
+	for _, vm := range residents {
+		if !moving[vm.Name] {
+			placed.vms = append(placed.vms, vm)
+		}
+	}
+	for _, vm := range placed.vms {
+		placed.locations[vm.Name] = vm.SourceDatastore
+		for _, rule := range p.affinity {
+			if rule.matches(vm) {
+				placed.groups[rule.Name] = append(placed.groups[rule.Name], vm)
+				break
+			}
+		}
+	}
+	for rule, members := range placed.groups {
+		if len(members) < 2 {
+			delete(placed.groups, rule)
+			continue
+		}
+		for _, member := range members {
+			placed.affinity[member.Name] = rule
+			if !moving[member.Name] && placed.pinned[rule] == "" {
+				placed.pinned[rule] = member.SourceDatastore
+			}
+		}
+	}
+	return placed
+}
+
+// record note a planned step so later VMs see where its VM lands and its
+// group either follows it or stays put.
+func (placed *placement) record(vm VM, step PlanStep) {
+	rule := placed.affinity[vm.Name]
+	if step.SkipReason == "" {
+		placed.locations[vm.Name] = step.TargetDatastore
+		if rule != "" {
+			placed.pinned[rule] = step.TargetDatastore
+		}
+		return
+	}
+	if rule != "" && placed.pinned[rule] == "" {
+		placed.refused[rule] = true
+	}
+}
+
+// separated drop targets already holding a VM that shares an anti-affinity
+// rule with vm, returning the rule that left no target.
+func (p Planner) separated(vm VM, targets []Datastore, placed *placement) ([]Datastore, string) {
+	for _, rule := range p.antiAffinity {
+		if !rule.matches(vm) {
+			continue
+		}
+		occupied := map[string]bool{}
+		for _, other := range placed.vms {
+			if other.Name != vm.Name && rule.matches(other) {
+				occupied[placed.locations[other.Name]] = true
+			}
+		}
+		kept := make([]Datastore, 0, len(targets))
+		for _, target := range targets {
+			if !occupied[target.Name] {
+				kept = append(kept, target)
+			}
+		}
+		if len(kept) == 0 {
+			return nil, rule.Name
+		}
+		targets = kept
+	}
+	return targets, ""
+}
+
+// colocate narrow targets to where vm's co-location group can land together.
+// The first member placed picks a target every member may use with room for
+// the whole group; later members follow it. It returns the load to fit and
+// the group's rule, empty for VMs outside any group.
+func (p Planner) colocate(vm VM, targets []Datastore, state []Datastore, placed *placement) ([]Datastore, VM, string) {
+	rule := placed.affinity[vm.Name]
+	switch {
+	case rule == "":
+		return targets, vm, ""
+	case placed.refused[rule]:
+		return nil, vm, rule
+	case placed.pinned[rule] != "":
+		return named(targets, placed.pinned[rule]), vm, rule
+	}
+	group := VM{Name: rule}
+	for _, member := range placed.groups[rule] {
+		group.SizeGB += member.footprint()
+		group.ProvisionedGB += member.provisioned()
+		if member.Name == vm.Name {
+			continue
+		}
+		eligible, _, _ := p.eligibleTargets(member, state, placed)
+		kept := make([]Datastore, 0, len(targets))
+		for _, target := range targets {
+			if len(named(eligible, target.Name)) > 0 {
+				kept = append(kept, target)
+			}
+		}
+		targets = kept
+	}
+	return targets, group, rule
+}
+
+// boundRule return the first placement rule that names vm.
+func (p Planner) boundRule(vm VM) (string, bool) {
+	for _, rule := range append(append([]PlacementRule(nil), p.affinity...), p.antiAffinity...) {
+		if rule.matches(vm) {
+			return rule.Name, true
+		}
+	}
+	return "", false
+}
+
+func named(targets []Datastore, name string) []Datastore {
+	for _, target := range targets {
+		if target.Name == name {
+			return []Datastore{target}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+// Path: internal/migration/affinity_test.go
+// Description: Validate anti-affinity separation, co-location groups, and placement rule parsing.
+package migration
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestAntiAffinityKeepsGroupMembersApart(t *testing.T) {
+	planner := NewPlanner(85).WithPolicy(Policy{AntiAffinity: []PlacementRule{{Name: "db", Tags: []string{"db"}}}})
+	stores := []Datastore{
+		{Name: "src", CapacityGB: 100, UsedGB: 90},
+		{Name: "a", CapacityGB: 100, UsedGB: 10},
+		{Name: "b", CapacityGB: 100, UsedGB: 20},
+	}
+	vms := []VM{
+		{Name: "db-1", SizeGB: 10, SourceDatastore: "src", Tags: []string{"db"}},
+		{Name: "db-2", SizeGB: 10, SourceDatastore: "src", Tags: []string{"DB"}},
+		{Name: "db-3", SizeGB: 10, SourceDatastore: "src", Tags: []string{"db"}},
+		{Name: "web", SizeGB: 10, SourceDatastore: "src"},
+	}
+	plan := planner.BuildPlan(vms, stores)
+	want := map[string]string{"db-1": "a", "db-2": "b", "db-3": SkipAntiAffinity, "web": "a"}
+	for vm, target := range want {
+		if got := targetsOf(plan)[vm]; got != target {
+			t.Fatalf("expected %s on %s, got %s (%+v)", vm, target, got, plan)
+		}
+	}
+	if plan[2].Rule != "db" || plan[2].Tier != "-" || plan[0].Rule != "" {
+		t.Fatalf("expected the db rule on the skipped step only, got %+v", plan)
+	}
+}
+
+func TestAffinityGroupsLandTogether(t *testing.T) {
+	planner := NewPlanner(85).WithPolicy(Policy{Affinity: []PlacementRule{
+		{Name: "app", Names: []string{"app-*"}},
+		{Name: "cache", Names: []string{"cache-*"}},
+	}})
+	stores := []Datastore{
+		{Name: "src", CapacityGB: 100, UsedGB: 90},
+		{Name: "a", CapacityGB: 100, UsedGB: 10},
+		{Name: "b", CapacityGB: 100, UsedGB: 15},
+	}
+	vms := []VM{
+		{Name: "app-1", SizeGB: 10, SourceDatastore: "src"},
+		{Name: "app-2", SizeGB: 10, SourceDatastore: "src"},
+		{Name: "cache-1", SizeGB: 5, SourceDatastore: "src"},
+	}
+	plan := planner.BuildPlan(vms, stores)
+	if got := targetsOf(plan); got["app-1"] != "a" || got["app-2"] != "a" || got["cache-1"] != "b" {
+		t.Fatalf("expected app group together on a and cache alone on b, got %v", got)
+	}
+	vms[1].SourceDatastore = "a"
+	stores[2].UsedGB = 30
+	if got := targetsOf(planner.BuildPlan(vms, stores)); got["app-1"] != "b" || got["app-2"] != "b" {
+		t.Fatalf("expected the group to avoid every member's source, got %v", got)
+	}
+}
+
+func TestAffinityGroupsSkipWhenMembersCannotFollow(t *testing.T) {
+	planner := NewPlanner(85).WithPolicy(Policy{
+		Affinity: []PlacementRule{{Name: "app", Names: []string{"app-*"}}},
+		Tiers:    TierPolicy{TagTiers: map[string][]Tier{"gold": {TierPrimary}}},
+	})
+	vms := []VM{
+		{Name: "app-1", SizeGB: 10, SourceDatastore: "src"},
+		{Name: "big", SizeGB: 60, SourceDatastore: "src"},
+		{Name: "app-2", SizeGB: 10, SourceDatastore: "src"},
+	}
+	stores := []Datastore{{Name: "src", CapacityGB: 100, UsedGB: 90}, {Name: "a", CapacityGB: 100, UsedGB: 10}, {Name: "b", CapacityGB: 100, UsedGB: 50}}
+	plan := planner.BuildPlan(vms, stores)
+	if got := targetsOf(plan); got["app-1"] != "a" || got["big"] != "a" || got["app-2"] != SkipAffinity || plan[2].Rule != "app" {
+		t.Fatalf("expected app-2 unable to follow its group, got %+v", plan)
+	}
+	full := []Datastore{{Name: "src", CapacityGB: 100, UsedGB: 90}, {Name: "a", CapacityGB: 100, UsedGB: 70, Tier: TierSecondary}}
+	for _, members := range [][]VM{
+		{{Name: "app-1", SizeGB: 10, SourceDatastore: "src"}, {Name: "app-2", SizeGB: 10, SourceDatastore: "src"}},
+		{{Name: "app-1", SizeGB: 1, SourceDatastore: "src", Tags: []string{"gold"}}, {Name: "app-2", SizeGB: 1, SourceDatastore: "src"}},
+	} {
+		plan := planner.BuildPlan(members, full)
+		if plan[1].SkipReason != SkipAffinity || plan[1].Rule != "app" || plan[0].SkipReason == "" {
+			t.Fatalf("expected the whole group to stay put, got %+v", plan)
+		}
+	}
+}
+
+func TestPlacementRulesSeeResidentsOutsideTheCandidates(t *testing.T) {
+	planner := NewPlanner(85).WithPolicy(Policy{
+		AntiAffinity: []PlacementRule{{Name: "db", Tags: []string{"db"}}},
+		Affinity:     []PlacementRule{{Name: "app", Names: []string{"app-*"}}},
+	})
+	stores := []Datastore{
+		{Name: "src", CapacityGB: 100, UsedGB: 90},
+		{Name: "a", CapacityGB: 100, UsedGB: 10},
+		{Name: "b", CapacityGB: 100, UsedGB: 20},
+	}
+	candidates := []VM{
+		{Name: "db-1", SizeGB: 10, SourceDatastore: "src", Tags: []string{"db"}},
+		{Name: "app-1", SizeGB: 10, SourceDatastore: "src"},
+	}
+	residents := append([]VM{
+		{Name: "db-2", SizeGB: 10, SourceDatastore: "a", Tags: []string{"db"}},
+		{Name: "app-2", SizeGB: 10, SourceDatastore: "b"},
+	}, candidates...)
+	if got := targetsOf(planner.BuildPlan(candidates, stores)); got["db-1"] != "a" || got["app-1"] != "a" {
+		t.Fatalf("expected candidates alone to ignore residents, got %v", got)
+	}
+	if got := targetsOf(planner.WithResidents(residents).BuildPlan(candidates, stores)); got["db-1"] != "b" || got["app-1"] != "b" {
+		t.Fatalf("expected db-1 kept off db-2 and app-1 pinned with app-2, got %v", got)
+	}
+	stores[2].UsedGB = 80
+	plan := planner.WithResidents(residents).BuildPlan(candidates, stores)
+	if plan[0].TargetDatastore != "" || plan[1].SkipReason != SkipAffinity || plan[1].Rule != "app" {
+		t.Fatalf("expected no target once residents hold the only room, got %+v", plan)
+	}
+}
+
+func TestExecutePlanDriftsRuleBoundStepsInsteadOfReplanning(t *testing.T) {
+	plan := []PlanStep{{Order: 1, VMName: "db-1", SourceDatastore: "src", TargetDatastore: "dst", SizeGB: 10}}
+	vms := []VM{{Name: "db-1", SizeGB: 10, SourceDatastore: "src", Tags: []string{"db"}}}
+	stores := []Datastore{{Name: "dst", CapacityGB: 100, UsedGB: 90}, {Name: "alt", CapacityGB: 100}}
+	var drifted ProgressEvent
+	planner := NewPlanner(85).
+		WithPolicy(Policy{AntiAffinity: []PlacementRule{{Name: "db", Tags: []string{"db"}}}}).
+		WithInventory(staticInventory(vms, stores)).
+		WithProgress(func(event ProgressEvent) { drifted = event })
+	summary := planner.ExecutePlan(plan, true, 1, &targetMover{moves: map[string]string{}})
+	if summary.DriftedCount != 1 || !errors.Is(drifted.Err, ErrStepDrifted) || !strings.Contains(drifted.Err.Error(), "rule db") {
+		t.Fatalf("expected rule-bound step to drift, got %+v %+v", summary, drifted)
+	}
+}
+
+func TestParsePolicyValidatesPlacementRules(t *testing.T) {
+	policy, err := ParsePolicy([]byte(`{"anti_affinity":[{"name":"db","tags":["db"]}],"affinity":[{"name":"app","names":["app-*"]}]}`))
+	if err != nil || policy.AntiAffinity[0].Name != "db" || policy.Affinity[0].Names[0] != "app-*" {
+		t.Fatalf("unexpected policy %+v err=%v", policy, err)
+	}
+	for _, content := range []string{
+		`{"affinity":[{"tags":["db"]}]}`,
+		`{"affinity":[{"name":"db","tags":["db"]}],"anti_affinity":[{"name":"db","tags":["db"]}]}`,
+		`{"anti_affinity":[{"name":"db"}]}`,
+		`{"anti_affinity":[{"name":"db","names":["["]}]}`,
+	} {
+		if _, err := ParsePolicy([]byte(content)); !errors.Is(err, ErrInvalidPolicy) {
+			t.Fatalf("expected invalid policy for %s, got %v", content, err)
+		}
+	}
+}
@@ -0,0 +1,159 @@
+// Path: internal/migration/compute.go
+// Description: Plan vMotion moves that balance VMs across the usable hosts of a cluster.
+package migration
+
+import "sort"
+
+const (
+	// SkipNoEligibleHost marks a VM whose cluster has no other usable host.
+	SkipNoEligibleHost = "NO_ELIGIBLE_HOST"
+	// SkipHostOverloaded marks a VM that would push every usable host past the threshold.
+	SkipHostOverloaded = "HOST_OVERLOADED"
+)
+
+// Host represents a vMotion target and its current load. Only hosts whose
+// State is connected, or unreported, receive VMs; maintenance and
+// disconnected hosts are skipped.
+type Host struct {
+	Name            string
+	Cluster         string
+	State           string
+	CPUMHz          int
+	MemoryMB        int
+	CPUUsagePercent int
+	MemUsagePercent int
+}
+
+// ComputePlanner plans host moves under a CPU and memory utilization threshold.
+type ComputePlanner struct {
+	thresholdPercent int
+}
+
+// NewComputePlanner build a compute planner that keeps host CPU and memory under thresholdPercent.
+func NewComputePlanner(thresholdPercent int) ComputePlanner {
+	return ComputePlanner{thresholdPercent: thresholdPercent}
+}
+
+// BuildPlan place each VM, in order, on the least-loaded other usable host of its cluster.
+func (p ComputePlanner) BuildPlan(vms []VM, hosts []Host) []PlanStep {
+	loads := newHostLoads(hosts)
+	plan := make([]PlanStep, 0, len(vms))
+	for _, vm := range vms {
+		plan = append(plan, p.planStep(len(plan)+1, vm, loads))
+	}
+	return plan
+}
+
+// Evacuate plan moves for every VM on the named host, largest memory first.
+func (p ComputePlanner) Evacuate(vms []VM, hosts []Host, host string) []PlanStep {
+	evacuating := []VM{}
+	for _, vm := range vms {
+		if vm.Host == host {
+			evacuating = append(evacuating, vm)
+		}
+	}
+	return p.BuildPlan(byMemory(evacuating), hosts)
+}
+
+// Balance move VMs, largest memory first, off usable hosts above the
+// threshold until each is back under it or none of its VMs fit elsewhere.
+func (p ComputePlanner) Balance(vms []VM, hosts []Host) []PlanStep {
+	loads := newHostLoads(hosts)
+	ordered := byMemory(vms)
+	plan := []PlanStep{}
+	for _, load := range loads {
+		for _, vm := range ordered {
+			if vm.Host != load.Name || !load.usable() || load.util(0, 0) <= p.thresholdPercent {
+				continue
+			}
+			plan = append(plan, p.planStep(len(plan)+1, vm, loads))
+		}
+	}
+	return plan
+}
+
+func (p ComputePlanner) planStep(order int, vm VM, loads []*hostLoad) PlanStep {
+	step := PlanStep{Order: order, VMName: vm.Name, SourceDatastore: vm.SourceDatastore, Host: vm.Host, Tier: "-"}
+	cluster := vm.Cluster
+	var source *hostLoad
+	for _, load := range loads {
+		if load.Name == vm.Host {
+			source, cluster = load, load.Cluster
+		}
+	}
+	var target *hostLoad
+	eligible := false
+	for _, load := range loads {
+		if load.Name == vm.Host || load.Cluster != cluster || !load.usable() {
+			continue
+		}
+		eligible = true
+		util := load.util(vm.CPUMHz, vm.MemoryMB)
+		if util <= p.thresholdPercent && (target == nil || util < target.util(vm.CPUMHz, vm.MemoryMB)) {
+			target = load
+		}
+	}
+	switch {
+	case !eligible:
+		step.SkipReason = SkipNoEligibleHost
+		return step
+	case target == nil:
+		step.SkipReason = SkipHostOverloaded
+		return step
+	}
+	step.TargetHost = target.Name
+	step.ProjectedUtil = target.util(vm.CPUMHz, vm.MemoryMB)
+	target.cpuUsed += vm.CPUMHz
+	target.memUsed += vm.MemoryMB
+	if source != nil {
+		source.cpuUsed -= vm.CPUMHz
+		source.memUsed -= vm.MemoryMB
+	}
+	return step
+}
+
+type hostLoad struct {
+	Host
+	cpuUsed int
+	memUsed int
+}
+
+// newHostLoads convert hosts to absolute CPU and memory use, ordered by name.
+func newHostLoads(hosts []Host) []*hostLoad {
+	loads := make([]*hostLoad, 0, len(hosts))
+	for _, host := range hosts {
+		loads = append(loads, &hostLoad{
+			Host:    host,
+			cpuUsed: host.CPUMHz * host.CPUUsagePercent / 100,
+			memUsed: host.MemoryMB * host.MemUsagePercent / 100,
+		})
+	}
+	sort.SliceStable(loads, func(i int, j int) bool {
+		return loads[i].Name < loads[j].Name
+	})
+	return loads
+}
+
+func (h *hostLoad) usable() bool {
+	return h.State == "" || h.State == "connected"
+}
+
+// util return the higher of projected CPU and memory utilization after adding a VM.
+func (h *hostLoad) util(cpuMHz int, memoryMB int) int {
+	return max(percentOf(h.cpuUsed+cpuMHz, h.CPUMHz), percentOf(h.memUsed+memoryMB, h.MemoryMB))
+}
+
+func percentOf(used int, capacity int) int {
+	if capacity <= 0 {
+		return 100
+	}
+	return used * 100 / capacity
+}
+
+func byMemory(vms []VM) []VM {
+	ordered := append([]VM(nil), vms...)
+	sort.SliceStable(ordered, func(i int, j int) bool {
+		return ordered[i].MemoryMB > ordered[j].MemoryMB
+	})
+	return ordered
+}
@@ -0,0 +1,72 @@
+// Path: internal/migration/compute_test.go
+// Description: Validate compute placement, host state skips, evacuation, and cluster balancing.
+package migration
+
+import "testing"
+
+func computeHosts() []Host {
+	return []Host{
+		{Name: "esx-b", Cluster: "east", State: "connected", CPUMHz: 10000, MemoryMB: 10000, CPUUsagePercent: 50, MemUsagePercent: 40},
+		{Name: "esx-a", Cluster: "east", State: "connected", CPUMHz: 10000, MemoryMB: 10000, CPUUsagePercent: 90, MemUsagePercent: 60},
+		{Name: "esx-c", Cluster: "east", CPUMHz: 10000, MemoryMB: 10000, CPUUsagePercent: 40, MemUsagePercent: 50},
+		{Name: "esx-m", Cluster: "east", State: "maintenance", CPUMHz: 10000, MemoryMB: 10000},
+		{Name: "esx-d", Cluster: "east", State: "disconnected", CPUMHz: 10000, MemoryMB: 10000},
+		{Name: "esx-w", Cluster: "west", State: "connected", CPUMHz: 10000, MemoryMB: 10000},
+	}
+}
+
+func TestComputePlannerPlacesOnLeastLoadedUsableHost(t *testing.T) {
+	vms := []VM{
+		{Name: "vm-1", Host: "esx-a", CPUMHz: 1000, MemoryMB: 1000},
+		{Name: "vm-2", Host: "esx-a", CPUMHz: 1000, MemoryMB: 1000},
+		{Name: "vm-3", Host: "gone", Cluster: "west", CPUMHz: 1000, MemoryMB: 1000},
+		{Name: "vm-4", Host: "esx-w", CPUMHz: 1000, MemoryMB: 1000},
+		{Name: "vm-5", Host: "esx-a", CPUMHz: 9000, MemoryMB: 1000},
+	}
+	plan := NewComputePlanner(85).BuildPlan(vms, computeHosts())
+	want := []struct {
+		target string
+		util   int
+		skip   string
+	}{{"esx-b", 60, ""}, {"esx-c", 60, ""}, {"esx-w", 10, ""}, {"", 0, SkipNoEligibleHost}, {"", 0, SkipHostOverloaded}}
+	for index, expected := range want {
+		step := plan[index]
+		if step.TargetHost != expected.target || step.ProjectedUtil != expected.util || step.SkipReason != expected.skip || step.Order != index+1 {
+			t.Fatalf("unexpected step %d: %+v", index, step)
+		}
+	}
+	if plan[0].Host != "esx-a" || plan[0].TargetDatastore != "" || plan[0].Tier != "-" {
+		t.Fatalf("expected a host-only move, got %+v", plan[0])
+	}
+}
+
+func TestComputePlannerEvacuatesHostLargestMemoryFirst(t *testing.T) {
+	vms := []VM{
+		{Name: "small", Host: "esx-m", CPUMHz: 500, MemoryMB: 500},
+		{Name: "large", Host: "esx-m", CPUMHz: 500, MemoryMB: 3000},
+		{Name: "other", Host: "esx-b", CPUMHz: 500, MemoryMB: 500},
+	}
+	plan := NewComputePlanner(85).Evacuate(vms, computeHosts(), "esx-m")
+	if len(plan) != 2 || plan[0].VMName != "large" || plan[0].TargetHost != "esx-b" || plan[1].TargetHost != "esx-c" {
+		t.Fatalf("expected maintenance host evacuated across usable hosts, got %+v", plan)
+	}
+}
+
+func TestComputePlannerBalancesHostsOverThreshold(t *testing.T) {
+	vms := []VM{
+		{Name: "big", Host: "esx-a", CPUMHz: 1500, MemoryMB: 500},
+		{Name: "mid", Host: "esx-a", CPUMHz: 1000, MemoryMB: 400},
+		{Name: "tiny", Host: "esx-a", CPUMHz: 100, MemoryMB: 100},
+		{Name: "calm", Host: "esx-b", CPUMHz: 100, MemoryMB: 100},
+		{Name: "parked", Host: "esx-d", CPUMHz: 100, MemoryMB: 100},
+	}
+	hosts := append(computeHosts(), Host{Name: "esx-z", Cluster: "east"})
+	plan := NewComputePlanner(80).Balance(vms, hosts)
+	if len(plan) != 1 || plan[0].VMName != "big" || plan[0].TargetHost != "esx-c" || plan[0].ProjectedUtil != 55 {
+		t.Fatalf("expected one move to bring esx-a under 80%%, got %+v", plan)
+	}
+	plan = NewComputePlanner(55).Balance(vms, hosts)
+	if len(plan) != 3 || plan[0].TargetHost != "esx-c" || plan[1].SkipReason != SkipHostOverloaded || plan[2].TargetHost != "esx-b" {
+		t.Fatalf("expected balancing to stop only when nothing fits, got %+v", plan)
+	}
+}
@@ -0,0 +1,111 @@
+// Path: internal/migration/drift.go
+// Description: Re-validate plan steps against live inventory just before each move.
+package migration
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrStepDrifted indicates a step that no longer holds against live inventory.
+var ErrStepDrifted = errors.New("migration step drifted")
+
+// Inventory loads live migration candidates and targets.
+type Inventory interface {
+	Load() ([]VM, []Datastore, error)
+}
+
+// InventoryFunc adapts a function to Inventory.
+type InventoryFunc func() ([]VM, []Datastore, error)
+
+// Load call the wrapped function.
+func (f InventoryFunc) Load() ([]VM, []Datastore, error) {
+	return f()
+}
+
+// WithInventory return a planner that re-validates each step against fresh
+// inventory just before moving it, re-planning or skipping steps that drifted.
+func (p Planner) WithInventory(inventory Inventory) Planner {
+	p.inventory = inventory
+	return p
+}
+
+type liveInventory struct {
+	vms    map[string]VM
+	stores []Datastore
+	err    error
+}
+
+// revalidate check a step against inventory loaded once per admission pass,
+// moving it to a new target when its own no longer fits the threshold and
+// re-running pre-flight checks on the VM's live state.
+func (e *executor) revalidate(index int) bool {
+	if e.planner.inventory == nil {
+		return true
+	}
+	if e.live == nil {
+		e.live = loadLiveInventory(e.planner.inventory)
+	}
+	step := e.plan[index]
+	if e.live.err != nil {
+		e.summary.FailedCount++
+		e.finish(index, ProgressEvent{Step: step, State: ProgressFailed, Err: e.live.err})
+		return false
+	}
+	vm, ok := e.live.vms[step.VMName]
+	if !ok {
+		return e.drift(index, "vm %s no longer exists", step.VMName)
+	}
+	if vm.SourceDatastore != step.SourceDatastore {
+		return e.drift(index, "vm %s is on %s, plan expects %s", step.VMName, vm.SourceDatastore, step.SourceDatastore)
+	}
+	state := copyDatastores(e.live.stores)
+	for i := range state {
+		state[i] = state[i].plus(e.reserved[state[i].Name], e.promised[state[i].Name])
+		if state[i].Name == step.TargetDatastore && e.planner.fits(state[i], vm.footprint(), vm.provisioned()) {
+			e.plan[index].SizeGB = vm.footprint()
+			e.plan[index].ProvisionedGB = vm.provisioned()
+			checked := e.planner.preflight(e.plan[index], vm, state[i], state)
+			if checked.SkipReason != "" {
+				return e.drift(index, "vm %s failed pre-flight check %s", step.VMName, checked.SkipReason)
+			}
+			e.plan[index].Warnings = checked.Warnings
+			return true
+		}
+	}
+	if step.Rollback {
+		return e.drift(index, "vm %s no longer fits origin %s", step.VMName, step.TargetDatastore)
+	}
+	if rule, bound := e.planner.boundRule(vm); bound {
+		return e.drift(index, "vm %s no longer fits %s and rule %s prevents re-planning", step.VMName, step.TargetDatastore, rule)
+	}
+	vm.SourceDatastore = step.SourceDatastore
+	vm.Host = step.Host
+	replanned := e.planner.planStep(step.Order, vm, state, e.planner.newPlacement(nil, nil))
+	if replanned.SkipReason != "" {
+		return e.drift(index, "no target for vm %s under threshold %d%%", step.VMName, e.planner.thresholdPercent)
+	}
+	e.plan[index] = replanned
+	e.report(ProgressEvent{Step: replanned, State: ProgressReplanned})
+	return true
+}
+
+func (e *executor) drift(index int, format string, args ...any) bool {
+	e.summary.DriftedCount++
+	e.plan[index].SkipReason = SkipDrifted
+	e.finish(index, ProgressEvent{
+		Step:  e.plan[index],
+		State: ProgressDrifted,
+		Err:   fmt.Errorf("%w: %s", ErrStepDrifted, fmt.Sprintf(format, args...)),
+	})
+	return false
+}
+
+func loadLiveInventory(inventory Inventory) *liveInventory {
+	vms, stores, err := inventory.Load()
+	live := &liveInventory{vms: map[string]VM{}, stores: stores, err: err}
+	for _, vm := range vms {
+		live.vms[vm.Name] = vm
+	}
+	return live
+}
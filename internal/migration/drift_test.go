@@ -0,0 +1,122 @@
+// Path: internal/migration/drift_test.go
+// Description: Validate per-step re-validation, re-planning, and drift skips against live inventory.
+package migration
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+)
+
+type targetMover struct {
+	mu    sync.Mutex
+	moves map[string]string
+}
+
+func (m *targetMover) Move(vmName string, target string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.moves[vmName] = target
+	return nil
+}
+
+func staticInventory(vms []VM, stores []Datastore) InventoryFunc {
+	return func() ([]VM, []Datastore, error) {
+		return vms, stores, nil
+	}
+}
+
+func TestExecutePlanReplansStepsWhoseTargetFilledUp(t *testing.T) {
+	plan := []PlanStep{
+		{Order: 1, VMName: "vm-a", SourceDatastore: "src", TargetDatastore: "dst", SizeGB: 10},
+		{Order: 2, VMName: "vm-b", SourceDatastore: "src", TargetDatastore: "dst", SizeGB: 10},
+	}
+	vms := []VM{{Name: "vm-a", SizeGB: 30, SourceDatastore: "src"}, {Name: "vm-b", SizeGB: 30, SourceDatastore: "src"}}
+	stores := []Datastore{
+		{Name: "src", CapacityGB: 100, UsedGB: 90},
+		{Name: "dst", CapacityGB: 100, UsedGB: 40},
+		{Name: "alt", CapacityGB: 100, UsedGB: 50, Tier: TierSecondary},
+	}
+	mover := &targetMover{moves: map[string]string{}}
+	events := []ProgressEvent{}
+	planner := NewPlanner(85).WithLimits(Limits{}).WithInventory(staticInventory(vms, stores)).WithProgress(func(event ProgressEvent) {
+		events = append(events, event)
+	})
+	summary := planner.ExecutePlan(plan, true, 1, mover)
+	if summary.MigratedCount != 2 || summary.DriftedCount != 0 {
+		t.Fatalf("expected both steps migrated, got %+v", summary)
+	}
+	if mover.moves["vm-a"] != "dst" || mover.moves["vm-b"] != "alt" {
+		t.Fatalf("expected vm-b re-planned off the reserved target, got %v", mover.moves)
+	}
+	replanned := events[1]
+	if replanned.State != ProgressReplanned || replanned.Step.TargetDatastore != "alt" || replanned.Step.SizeGB != 30 || replanned.Step.ProjectedUtil != 80 {
+		t.Fatalf("expected replanned event for vm-b, got %+v", replanned)
+	}
+}
+
+func TestExecutePlanSkipsDriftedSteps(t *testing.T) {
+	plan := []PlanStep{
+		{Order: 1, VMName: "vm-moved", SourceDatastore: "src", TargetDatastore: "dst"},
+		{Order: 2, VMName: "vm-moved", SourceDatastore: "dst", TargetDatastore: "alt"},
+		{Order: 3, VMName: "vm-gone", SourceDatastore: "src", TargetDatastore: "dst"},
+		{Order: 4, VMName: "vm-big", SourceDatastore: "src", TargetDatastore: "dst"},
+	}
+	vms := []VM{{Name: "vm-moved", SourceDatastore: "other"}, {Name: "vm-big", SizeGB: 500, SourceDatastore: "src"}}
+	stores := []Datastore{{Name: "src", CapacityGB: 100}, {Name: "dst", CapacityGB: 100}}
+	mover := &targetMover{moves: map[string]string{}}
+	events := []ProgressEvent{}
+	planner := NewPlanner(85).WithInventory(staticInventory(vms, stores)).WithProgress(func(event ProgressEvent) {
+		events = append(events, event)
+	})
+	summary := planner.ExecutePlan(plan, true, 1, mover)
+	if summary.DriftedCount != 3 || summary.FailedCount != 1 || summary.MigratedCount != 0 || len(mover.moves) != 0 {
+		t.Fatalf("expected three drifted steps and one blocked step, got %+v moves=%v", summary, mover.moves)
+	}
+	reasons := []string{}
+	for _, event := range events {
+		if event.State == ProgressDrifted {
+			if event.Step.SkipReason != SkipDrifted || !errors.Is(event.Err, ErrStepDrifted) {
+				t.Fatalf("expected drifted skip reason and error, got %+v", event)
+			}
+			reasons = append(reasons, event.Err.Error())
+		}
+	}
+	joined := strings.Join(reasons, "\n")
+	for _, want := range []string{"vm vm-moved is on other, plan expects src", "vm vm-gone no longer exists", "no target for vm vm-big under threshold 85%"} {
+		if !strings.Contains(joined, want) {
+			t.Fatalf("expected %q among drift reasons, got %q", want, joined)
+		}
+	}
+}
+
+func TestExecutePlanFailsStepsWhenInventoryCannotLoad(t *testing.T) {
+	failing := InventoryFunc(func() ([]VM, []Datastore, error) {
+		return nil, nil, errors.New("session expired")
+	})
+	mover := &targetMover{moves: map[string]string{}}
+	plan := []PlanStep{{Order: 1, VMName: "vm-a", SourceDatastore: "src", TargetDatastore: "dst"}}
+	summary := NewPlanner(85).WithInventory(failing).ExecutePlan(plan, true, 1, mover)
+	if summary.FailedCount != 1 || len(mover.moves) != 0 {
+		t.Fatalf("expected unverifiable step to fail without moving, got %+v", summary)
+	}
+	if summary := NewPlanner(85).WithInventory(failing).ExecutePlan(plan, false, 1, mover); summary.DryRunCount != 1 {
+		t.Fatalf("expected dry-run to skip re-validation, got %+v", summary)
+	}
+}
+
+func TestExecutePlanHoldsReplannedStepsUntilTheirTargetHasRoom(t *testing.T) {
+	plan := []PlanStep{
+		{Order: 1, VMName: "vm-a", SourceDatastore: "src-a", TargetDatastore: "dst"},
+		{Order: 2, VMName: "vm-b", SourceDatastore: "src-b", TargetDatastore: "full"},
+	}
+	vms := []VM{{Name: "vm-a", SizeGB: 10, SourceDatastore: "src-a"}, {Name: "vm-b", SizeGB: 10, SourceDatastore: "src-b"}}
+	stores := []Datastore{{Name: "dst", CapacityGB: 100}, {Name: "full", CapacityGB: 100, UsedGB: 95}}
+	mover := &targetMover{moves: map[string]string{}}
+	planner := NewPlanner(85).WithLimits(Limits{PerTarget: 1}).WithInventory(staticInventory(vms, stores))
+	summary := planner.ExecutePlan(plan, true, 1, mover)
+	if summary.MigratedCount != 2 || mover.moves["vm-b"] != "dst" {
+		t.Fatalf("expected vm-b re-planned onto dst after vm-a finished, got %+v moves=%v", summary, mover.moves)
+	}
+}
@@ -0,0 +1,304 @@
+// Path: internal/migration/executor.go
+// Description: Execute migration plans concurrently under global, datastore, and host limits.
+package migration
+
+import (
+	"time"
+
+	"github.com/takelley1/hypersphere/internal/schedule"
+)
+
+// Limits caps concurrent moves; zero fields are unlimited.
+type Limits struct {
+	Global    int
+	PerSource int
+	PerTarget int
+	PerHost   int
+}
+
+// ProgressState labels one step transition reported during execution.
+type ProgressState string
+
+const (
+	ProgressStarted   ProgressState = "started"
+	ProgressMigrated  ProgressState = "migrated"
+	ProgressFailed    ProgressState = "failed"
+	ProgressBlocked   ProgressState = "blocked"
+	ProgressReplanned ProgressState = "replanned"
+	ProgressDrifted   ProgressState = "drifted"
+	ProgressPaused    ProgressState = "paused"
+	ProgressResumed   ProgressState = "resumed"
+	ProgressThrottled ProgressState = "throttled"
+)
+
+// ProgressEvent reports one step transition during plan execution. Paused
+// and resumed events carry no step; Until is when a paused run resumes.
+type ProgressEvent struct {
+	Step     PlanStep
+	State    ProgressState
+	Attempts int
+	Err      error
+	Until    time.Time
+}
+
+// WithLimits return a planner that executes moves under the given concurrency limits.
+func (p Planner) WithLimits(limits Limits) Planner {
+	p.limits = limits
+	return p
+}
+
+// WithProgress return a planner that reports execution progress to fn.
+func (p Planner) WithProgress(fn func(ProgressEvent)) Planner {
+	p.progress = fn
+	return p
+}
+
+// WithSchedule return a planner that starts moves only while the gate's
+// maintenance schedule is open, pausing until it reopens.
+func (p Planner) WithSchedule(gate schedule.Gate) Planner {
+	p.gate = &gate
+	return p
+}
+
+// ExecutePlan run plan steps, honoring dry-run mode, retry count, concurrency
+// limits, maintenance windows, and plan order dependencies.
+func (p Planner) ExecutePlan(plan []PlanStep, execute bool, retries int, mover Mover) ExecutionSummary {
+	attemptLimit := retries
+	if attemptLimit < 1 {
+		attemptLimit = 1
+	}
+	run := executor{
+		planner:   p,
+		plan:      append([]PlanStep(nil), plan...),
+		depends:   dependencies(plan),
+		finished:  map[int]bool{},
+		failed:    map[int]bool{},
+		sources:   map[string]int{},
+		targets:   map[string]int{},
+		hosts:     map[string]int{},
+		reserved:  map[string]int{},
+		promised:  map[string]int{},
+		held:      map[int]bool{},
+		results:   newResults(plan, execute),
+		completed: make(chan moveResult),
+	}
+	pending := make([]int, 0, len(plan))
+	for index, step := range plan {
+		if step.SkipReason != "" {
+			continue
+		}
+		if !execute {
+			run.summary.DryRunCount++
+			continue
+		}
+		pending = append(pending, index)
+	}
+	for len(pending) > 0 || run.running > 0 {
+		var held bool
+		if pending, held = run.hold(pending); held {
+			continue
+		}
+		pending = run.admit(pending, attemptLimit, mover)
+		if run.running == 0 {
+			pending = run.backoff(pending)
+			continue
+		}
+		run.release(<-run.completed)
+	}
+	run.summary.Results = run.results
+	return run.summary
+}
+
+type moveResult struct {
+	index    int
+	attempts int
+	err      error
+	started  time.Time
+	finished time.Time
+}
+
+type executor struct {
+	planner   Planner
+	plan      []PlanStep
+	depends   map[int][]int
+	finished  map[int]bool
+	failed    map[int]bool
+	running   int
+	summary   ExecutionSummary
+	sources   map[string]int
+	targets   map[string]int
+	hosts     map[string]int
+	reserved  map[string]int
+	promised  map[string]int
+	live      *liveInventory
+	held      map[int]bool
+	results   []StepResult
+	backedOff time.Duration
+	completed chan moveResult
+}
+
+// hold check the maintenance schedule before admitting more steps. While it
+// is closed, running moves finish and then execution sleeps until it reopens;
+// when it never reopens every pending step fails. It report whether it held
+// admission this round.
+func (e *executor) hold(pending []int) ([]int, bool) {
+	if e.planner.gate == nil || len(pending) == 0 {
+		return pending, false
+	}
+	pause, err := e.planner.gate.Check()
+	if err != nil {
+		for _, index := range pending {
+			e.summary.FailedCount++
+			e.finish(index, ProgressEvent{Step: e.plan[index], State: ProgressFailed, Err: err})
+		}
+		return nil, true
+	}
+	if pause.Until.IsZero() {
+		return pending, false
+	}
+	if e.running > 0 {
+		e.release(<-e.completed)
+		return pending, true
+	}
+	e.report(ProgressEvent{State: ProgressPaused, Until: pause.Until})
+	e.planner.gate.Wait(pause)
+	e.summary.PausedCount++
+	e.summary.Paused += pause.Duration()
+	e.report(ProgressEvent{State: ProgressResumed})
+	return pending, true
+}
+
+// admit start every pending step whose dependencies finished, whose limits
+// have room, and which still holds against live inventory, in plan order, and
+// return the steps still waiting.
+func (e *executor) admit(pending []int, attempts int, mover Mover) []int {
+	e.live = nil
+	waiting := pending[:0]
+	for _, index := range pending {
+		state := e.dependencyState(index)
+		if state == dependencyFailed {
+			e.summary.FailedCount++
+			e.finish(index, ProgressEvent{Step: e.plan[index], State: ProgressBlocked})
+			continue
+		}
+		if state == dependencyRunning || !e.fits(e.plan[index]) {
+			waiting = append(waiting, index)
+			continue
+		}
+		if !e.revalidate(index) {
+			continue
+		}
+		if !e.fits(e.plan[index]) || e.throttled(index) {
+			waiting = append(waiting, index)
+			continue
+		}
+		e.acquire(e.plan[index])
+		e.report(ProgressEvent{Step: e.plan[index], State: ProgressStarted})
+		go func(step PlanStep) {
+			started := e.planner.now()
+			used, err := e.planner.runMove(step, attempts, mover)
+			e.completed <- moveResult{index: index, attempts: used, err: err, started: started, finished: e.planner.now()}
+		}(e.plan[index])
+	}
+	return waiting
+}
+
+func (e *executor) release(result moveResult) {
+	step := e.plan[result.index]
+	e.running--
+	e.sources[step.SourceDatastore]--
+	e.targets[step.TargetDatastore]--
+	e.hosts[step.Host]--
+	committed, provisioned := stepLoad(step)
+	e.reserved[step.TargetDatastore] -= committed
+	e.promised[step.TargetDatastore] -= provisioned
+	event := ProgressEvent{Step: step, State: ProgressMigrated, Attempts: result.attempts}
+	e.results[result.index].Started = result.started
+	e.results[result.index].Finished = result.finished
+	if result.err != nil {
+		e.summary.FailedCount++
+		event.State = ProgressFailed
+		event.Err = result.err
+		e.finish(result.index, event)
+		return
+	}
+	e.summary.MigratedCount++
+	e.finished[result.index] = true
+	e.settle(result.index, event)
+	e.report(event)
+}
+
+// finish mark a step done without a successful move so dependents are blocked.
+func (e *executor) finish(index int, event ProgressEvent) {
+	e.finished[index] = true
+	e.failed[index] = true
+	e.settle(index, event)
+	e.report(event)
+}
+
+func (e *executor) fits(step PlanStep) bool {
+	return underLimit(e.running, e.planner.limits.Global) &&
+		underLimit(e.sources[step.SourceDatastore], e.planner.limits.PerSource) &&
+		underLimit(e.targets[step.TargetDatastore], e.planner.limits.PerTarget) &&
+		(step.Host == "" || underLimit(e.hosts[step.Host], e.planner.limits.PerHost))
+}
+
+func (e *executor) acquire(step PlanStep) {
+	e.backedOff = 0
+	e.running++
+	e.sources[step.SourceDatastore]++
+	e.targets[step.TargetDatastore]++
+	e.hosts[step.Host]++
+	committed, provisioned := stepLoad(step)
+	e.reserved[step.TargetDatastore] += committed
+	e.promised[step.TargetDatastore] += provisioned
+}
+
+func (e *executor) report(event ProgressEvent) {
+	if e.planner.progress != nil {
+		e.planner.progress(event)
+	}
+}
+
+type dependencyState int
+
+const (
+	dependencyDone dependencyState = iota
+	dependencyRunning
+	dependencyFailed
+)
+
+func (e *executor) dependencyState(index int) dependencyState {
+	state := dependencyDone
+	for _, earlier := range e.depends[index] {
+		if e.failed[earlier] {
+			return dependencyFailed
+		}
+		if !e.finished[earlier] {
+			state = dependencyRunning
+		}
+	}
+	return state
+}
+
+// dependencies map each step to the earlier executable steps it must wait for:
+// moves of the same VM, and moves onto the datastore it is leaving.
+func dependencies(plan []PlanStep) map[int][]int {
+	depends := map[int][]int{}
+	for later := range plan {
+		for earlier := 0; earlier < later; earlier++ {
+			if plan[earlier].SkipReason != "" {
+				continue
+			}
+			if plan[earlier].VMName == plan[later].VMName ||
+				plan[earlier].TargetDatastore == plan[later].SourceDatastore {
+				depends[later] = append(depends[later], earlier)
+			}
+		}
+	}
+	return depends
+}
+
+func underLimit(current int, limit int) bool {
+	return limit <= 0 || current < limit
+}
@@ -0,0 +1,174 @@
+// Path: internal/migration/executor_test.go
+// Description: Validate concurrent plan execution limits, dependencies, and progress events.
+package migration
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/takelley1/hypersphere/internal/schedule"
+)
+
+type concurrentMover struct {
+	mu     sync.Mutex
+	active map[string]int
+	peak   map[string]int
+	fail   map[string]bool
+	calls  []string
+	keys   func(vmName string, target string) []string
+}
+
+func newConcurrentMover(keys func(vmName string, target string) []string) *concurrentMover {
+	return &concurrentMover{active: map[string]int{}, peak: map[string]int{}, fail: map[string]bool{}, keys: keys}
+}
+
+func (m *concurrentMover) Move(vmName string, target string) error {
+	keys := append([]string{"global"}, m.keys(vmName, target)...)
+	m.mu.Lock()
+	m.calls = append(m.calls, vmName)
+	for _, key := range keys {
+		m.active[key]++
+		m.peak[key] = max(m.peak[key], m.active[key])
+	}
+	m.mu.Unlock()
+	time.Sleep(5 * time.Millisecond)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, key := range keys {
+		m.active[key]--
+	}
+	if m.fail[vmName] {
+		return busyError("relocate failed")
+	}
+	return nil
+}
+
+func TestExecutePlanRunsConcurrentlyUnderLimits(t *testing.T) {
+	plan := []PlanStep{
+		{VMName: "vm-a", SourceDatastore: "src-1", TargetDatastore: "dst-1", Host: "esx-1"},
+		{VMName: "vm-b", SourceDatastore: "src-1", TargetDatastore: "dst-2", Host: "esx-2"},
+		{VMName: "vm-c", SourceDatastore: "src-2", TargetDatastore: "dst-1", Host: "esx-1"},
+		{VMName: "vm-d", SourceDatastore: "src-2", TargetDatastore: "dst-2", Host: "esx-2"},
+		{VMName: "vm-e", SourceDatastore: "src-3", TargetDatastore: "dst-3", Host: "esx-3"},
+	}
+	hosts := map[string]string{"vm-a": "esx-1", "vm-b": "esx-2", "vm-c": "esx-1", "vm-d": "esx-2", "vm-e": "esx-3"}
+	sources := map[string]string{"vm-a": "src-1", "vm-b": "src-1", "vm-c": "src-2", "vm-d": "src-2", "vm-e": "src-3"}
+	cases := map[string]struct {
+		limits Limits
+		key    string
+		keys   func(string, string) []string
+		want   int
+	}{
+		"global":     {Limits{Global: 2}, "global", func(string, string) []string { return nil }, 2},
+		"per-source": {Limits{PerSource: 1}, "src-1", func(vm string, _ string) []string { return []string{sources[vm]} }, 1},
+		"per-target": {Limits{PerTarget: 1}, "dst-1", func(_ string, target string) []string { return []string{target} }, 1},
+		"per-host":   {Limits{PerHost: 1}, "esx-1", func(vm string, _ string) []string { return []string{hosts[vm]} }, 1},
+		"unlimited":  {Limits{}, "global", func(string, string) []string { return nil }, 5},
+	}
+	for name, tc := range cases {
+		mover := newConcurrentMover(tc.keys)
+		summary := NewPlanner(85).WithLimits(tc.limits).ExecutePlan(plan, true, 1, mover)
+		if summary.MigratedCount != len(plan) {
+			t.Fatalf("%s: expected every step migrated, got %+v", name, summary)
+		}
+		if mover.peak[tc.key] != tc.want {
+			t.Fatalf("%s: expected peak %d for %s, got %d", name, tc.want, tc.key, mover.peak[tc.key])
+		}
+	}
+}
+
+func TestExecutePlanWaitsForDependenciesAndBlocksOnFailure(t *testing.T) {
+	plan := []PlanStep{
+		{VMName: "vm-a", SourceDatastore: "src", TargetDatastore: "mid"},
+		{VMName: "vm-b", SourceDatastore: "mid", TargetDatastore: "dst"},
+		{VMName: "vm-c", SkipReason: SkipNoEligibleTarget},
+		{VMName: "vm-d", SourceDatastore: "src", TargetDatastore: "dst"},
+		{VMName: "vm-a", SourceDatastore: "mid", TargetDatastore: "dst"},
+	}
+	mover := newConcurrentMover(func(string, string) []string { return nil })
+	mover.fail["vm-a"] = true
+	events := []ProgressEvent{}
+	planner := NewPlanner(85).WithLimits(Limits{}).WithProgress(func(event ProgressEvent) {
+		events = append(events, event)
+	})
+	summary := planner.ExecutePlan(plan, true, 2, mover)
+	if summary.MigratedCount != 1 || summary.FailedCount != 3 {
+		t.Fatalf("expected vm-d migrated and vm-a, vm-b, and the second vm-a move failed, got %+v", summary)
+	}
+	attempted := map[string]int{}
+	for _, call := range mover.calls {
+		attempted[call]++
+	}
+	if len(mover.calls) != 3 || attempted["vm-a"] != 2 || attempted["vm-d"] != 1 {
+		t.Fatalf("expected vm-b and the dependent vm-a move never attempted, got %v", mover.calls)
+	}
+	states := map[string]int{}
+	for _, event := range events {
+		states[string(event.State)]++
+		if event.State == ProgressFailed && (event.Attempts != 2 || event.Err == nil) {
+			t.Fatalf("expected failed event with attempts and error, got %+v", event)
+		}
+	}
+	if states["started"] != 2 || states["migrated"] != 1 || states["failed"] != 1 || states["blocked"] != 2 {
+		t.Fatalf("unexpected progress events: %+v", states)
+	}
+}
+
+type windowClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *windowClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *windowClock) Sleep(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func (c *windowClock) Move(string, string) error {
+	c.Sleep(2 * time.Hour)
+	return nil
+}
+
+func TestExecutePlanPausesOutsideMaintenanceWindow(t *testing.T) {
+	window, err := schedule.Parse([]byte(`{"windows":[{"start":"0 22 * * *","duration":"1h"}]}`))
+	if err != nil {
+		t.Fatalf("parse schedule: %v", err)
+	}
+	clock := &windowClock{now: time.Date(2026, 10, 16, 21, 0, 0, 0, time.UTC)}
+	plan := []PlanStep{
+		{Order: 1, VMName: "vm-a", SourceDatastore: "src-1", TargetDatastore: "dst-1"},
+		{Order: 2, VMName: "vm-b", SourceDatastore: "src-2", TargetDatastore: "dst-2"},
+		{Order: 3, VMName: "vm-c", SourceDatastore: "src-3", TargetDatastore: "dst-3"},
+	}
+	events := []ProgressEvent{}
+	summary := NewPlanner(85).
+		WithLimits(Limits{Global: 2}).
+		WithSchedule(schedule.NewGate(window).WithClock(clock.Now, clock.Sleep)).
+		WithProgress(func(event ProgressEvent) { events = append(events, event) }).
+		ExecutePlan(plan, true, 1, clock)
+	if summary.MigratedCount != 3 || summary.PausedCount != 2 || summary.Paused != 21*time.Hour {
+		t.Fatalf("expected two pauses totalling 21h, got %+v", summary)
+	}
+	if events[0].State != ProgressPaused || !events[0].Until.Equal(time.Date(2026, 10, 16, 22, 0, 0, 0, time.UTC)) ||
+		events[1].State != ProgressResumed || events[len(events)-1].Step.VMName != "vm-c" {
+		t.Fatalf("unexpected pause events %+v", events)
+	}
+	never, _ := schedule.Spec{Windows: []schedule.WindowSpec{{Start: "0 0 30 2 *", Duration: "1h"}}}.Schedule()
+	events = events[:0]
+	summary = NewPlanner(85).
+		WithSchedule(schedule.NewGate(never).WithClock(clock.Now, clock.Sleep)).
+		WithProgress(func(event ProgressEvent) { events = append(events, event) }).
+		ExecutePlan(plan, true, 1, clock)
+	if summary.FailedCount != 3 || summary.MigratedCount != 0 || !errors.Is(events[0].Err, schedule.ErrNoOpening) {
+		t.Fatalf("expected every step failed without an opening, got %+v %+v", summary, events)
+	}
+}
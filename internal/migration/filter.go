@@ -0,0 +1,73 @@
+// Path: internal/migration/filter.go
+// Description: Select migration candidates by source datastore, cluster, folder, or tag.
+package migration
+
+import "strings"
+
+// Filter narrows migration candidates; empty fields match everything.
+type Filter struct {
+	SourceDatastore string
+	Cluster         string
+	Folder          string
+	Tag             string
+}
+
+// Apply return the VMs matching the filter and the datastores they may move to.
+func (f Filter) Apply(vms []VM, stores []Datastore) ([]VM, []Datastore) {
+	selected := make([]VM, 0, len(vms))
+	for _, vm := range vms {
+		if f.Match(vm) {
+			selected = append(selected, vm)
+		}
+	}
+	if f.Cluster == "" {
+		return selected, copyDatastores(stores)
+	}
+	candidates := make([]Datastore, 0, len(stores))
+	for _, store := range stores {
+		if store.Cluster == "" || store.Cluster == f.Cluster {
+			candidates = append(candidates, store)
+		}
+	}
+	return selected, candidates
+}
+
+// Inventory return an inventory that applies the filter to every load.
+func (f Filter) Inventory(inventory Inventory) Inventory {
+	return InventoryFunc(func() ([]VM, []Datastore, error) {
+		vms, stores, err := inventory.Load()
+		if err != nil {
+			return nil, nil, err
+		}
+		vms, stores = f.Apply(vms, stores)
+		return vms, stores, nil
+	})
+}
+
+// Match report whether a VM satisfies every populated filter field.
+func (f Filter) Match(vm VM) bool {
+	if f.SourceDatastore != "" && vm.SourceDatastore != f.SourceDatastore {
+		return false
+	}
+	if f.Cluster != "" && vm.Cluster != f.Cluster {
+		return false
+	}
+	if f.Folder != "" && !inFolder(vm.Folder, f.Folder) {
+		return false
+	}
+	return f.Tag == "" || hasTag(vm.Tags, f.Tag)
+}
+
+func inFolder(path string, folder string) bool {
+	folder = strings.TrimSuffix(folder, "/")
+	return path == folder || strings.HasPrefix(path, folder+"/")
+}
+
+func hasTag(tags []string, tag string) bool {
+	for _, candidate := range tags {
+		if strings.EqualFold(strings.TrimSpace(candidate), strings.TrimSpace(tag)) {
+			return true
+		}
+	}
+	return false
+}
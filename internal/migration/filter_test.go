@@ -0,0 +1,75 @@
+// Path: internal/migration/filter_test.go
+// Description: Validate migration candidate filtering by datastore, cluster, folder, and tag.
+package migration
+
+import (
+	"errors"
+	"testing"
+)
+
+func filterVMs() []VM {
+	return []VM{
+		{Name: "vm-a", SourceDatastore: "ds-1", Cluster: "east", Folder: "/dc-1/vm/Prod/web", Tags: []string{"prod", "linux"}},
+		{Name: "vm-b", SourceDatastore: "ds-2", Cluster: "west", Folder: "/dc-1/vm/Dev", Tags: []string{"dev"}},
+		{Name: "vm-c", SourceDatastore: "ds-1", Cluster: "east", Folder: "/dc-1/vm/Production", Tags: []string{"Prod"}},
+	}
+}
+
+func TestFilterMatchesEveryPopulatedField(t *testing.T) {
+	cases := map[string]struct {
+		filter Filter
+		want   []string
+	}{
+		"empty":      {Filter{}, []string{"vm-a", "vm-b", "vm-c"}},
+		"datastore":  {Filter{SourceDatastore: "ds-1"}, []string{"vm-a", "vm-c"}},
+		"cluster":    {Filter{Cluster: "west"}, []string{"vm-b"}},
+		"folder":     {Filter{Folder: "/dc-1/vm/Prod/"}, []string{"vm-a"}},
+		"tag":        {Filter{Tag: " prod "}, []string{"vm-a", "vm-c"}},
+		"combined":   {Filter{SourceDatastore: "ds-1", Tag: "linux"}, []string{"vm-a"}},
+		"no-matches": {Filter{Cluster: "east", Tag: "dev"}, nil},
+	}
+	for name, tc := range cases {
+		selected, _ := tc.filter.Apply(filterVMs(), nil)
+		got := []string{}
+		for _, vm := range selected {
+			got = append(got, vm.Name)
+		}
+		if len(got) != len(tc.want) {
+			t.Fatalf("%s: expected %v, got %v", name, tc.want, got)
+		}
+		for index := range got {
+			if got[index] != tc.want[index] {
+				t.Fatalf("%s: expected %v, got %v", name, tc.want, got)
+			}
+		}
+	}
+}
+
+func TestFilterLimitsTargetsToSelectedCluster(t *testing.T) {
+	stores := []Datastore{{Name: "ds-east", Cluster: "east"}, {Name: "ds-west", Cluster: "west"}, {Name: "ds-shared"}}
+	_, all := Filter{}.Apply(nil, stores)
+	if len(all) != 3 {
+		t.Fatalf("expected every datastore without a cluster filter, got %+v", all)
+	}
+	_, east := Filter{Cluster: "east"}.Apply(nil, stores)
+	if len(east) != 2 || east[0].Name != "ds-east" || east[1].Name != "ds-shared" {
+		t.Fatalf("expected east and unclustered datastores, got %+v", east)
+	}
+}
+
+func TestFilterInventoryFiltersEveryLoad(t *testing.T) {
+	stores := []Datastore{{Name: "ds-east", Cluster: "east"}, {Name: "ds-west", Cluster: "west"}}
+	source := InventoryFunc(func() ([]VM, []Datastore, error) {
+		return filterVMs(), stores, nil
+	})
+	vms, targets, err := Filter{Cluster: "east"}.Inventory(source).Load()
+	if err != nil || len(vms) != 2 || len(targets) != 1 || targets[0].Name != "ds-east" {
+		t.Fatalf("expected filtered load, got %+v %+v err=%v", vms, targets, err)
+	}
+	failing := InventoryFunc(func() ([]VM, []Datastore, error) {
+		return nil, nil, errors.New("reload failed")
+	})
+	if _, _, err := (Filter{}).Inventory(failing).Load(); err == nil {
+		t.Fatalf("expected load error to propagate")
+	}
+}
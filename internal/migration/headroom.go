@@ -0,0 +1,61 @@
+// Path: internal/migration/headroom.go
+// Description: Project committed and provisioned datastore headroom for thin disks, snapshots, and swap.
+package migration
+
+// SkipOverProvisioned marks a step whose targets fit its committed size but
+// would exceed the provisioned threshold if its thin disks filled.
+const SkipOverProvisioned = "OVER_PROVISIONED"
+
+// footprint return the committed GB a VM occupies: disks, snapshot deltas, and swap.
+func (vm VM) footprint() int {
+	return vm.SizeGB + vm.SnapshotGB + vm.SwapGB
+}
+
+// provisioned return the GB a VM may grow to once its thin disks fill.
+func (vm VM) provisioned() int {
+	return max(vm.ProvisionedGB, vm.SizeGB) + vm.SnapshotGB + vm.SwapGB
+}
+
+// provisioned return the GB promised to disks on the datastore, at least its used space.
+func (d Datastore) provisioned() int {
+	return max(d.ProvisionedGB, d.UsedGB)
+}
+
+// plus return the datastore after adding committed and provisioned GB.
+func (d Datastore) plus(committed int, provisioned int) Datastore {
+	d.ProvisionedGB = d.provisioned() + provisioned
+	d.UsedGB += committed
+	return d
+}
+
+func provisionedUtil(target Datastore) int {
+	if target.CapacityGB == 0 {
+		return 100
+	}
+	return (target.provisioned() * 100) / target.CapacityGB
+}
+
+// stepLoad return the committed and provisioned GB a planned step moves.
+func stepLoad(step PlanStep) (int, int) {
+	return step.SizeGB, max(step.ProvisionedGB, step.SizeGB)
+}
+
+// fits report whether a target stays under its tier's committed threshold and
+// the provisioned threshold after taking the given GB.
+func (p Planner) fits(target Datastore, committed int, provisioned int) bool {
+	projected := target.plus(committed, provisioned)
+	if projectedUtil(projected, 0) > p.threshold(target.Tier) {
+		return false
+	}
+	return p.provisionedThreshold <= 0 || provisionedUtil(projected) <= p.provisionedThreshold
+}
+
+// overReason name the threshold that left a VM without a target.
+func (p Planner) overReason(vm VM, targets []Datastore) string {
+	for _, target := range targets {
+		if projectedUtil(target, vm.footprint()) <= p.threshold(target.Tier) {
+			return SkipOverProvisioned
+		}
+	}
+	return SkipOverThreshold
+}
@@ -0,0 +1,72 @@
+// Path: internal/migration/headroom_test.go
+// Description: Validate committed and provisioned headroom checks for snapshots, swap, and thin disks.
+package migration
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestPlannerCountsSnapshotsAndSwapAgainstCommittedThreshold(t *testing.T) {
+	vms := []VM{{Name: "vm-a", SizeGB: 20, SnapshotGB: 5, SwapGB: 5, SourceDatastore: "src"}}
+	stores := []Datastore{{Name: "a", CapacityGB: 100, UsedGB: 60}, {Name: "b", CapacityGB: 100, UsedGB: 61}}
+	plan := NewPlanner(85).BuildPlan(vms, stores)
+	if plan[0].SkipReason != SkipOverThreshold || plan[0].SizeGB != 30 {
+		t.Fatalf("expected snapshots and swap to push past the threshold, got %+v", plan[0])
+	}
+	stores[0].UsedGB = 50
+	plan = NewPlanner(85).BuildPlan(vms, stores)
+	if plan[0].TargetDatastore != "a" || plan[0].ProjectedUtil != 80 || plan[0].ProvisionedGB != 30 || plan[0].ProjectedProvisioned != 80 {
+		t.Fatalf("expected vm-a on a at 80%%, got %+v", plan[0])
+	}
+}
+
+func TestPlannerChecksProvisionedThresholdSeparately(t *testing.T) {
+	vms := []VM{{Name: "thin", SizeGB: 10, ProvisionedGB: 40, SourceDatastore: "src"}}
+	stores := []Datastore{{Name: "nfs", CapacityGB: 100, UsedGB: 20, ProvisionedGB: 150}}
+	if plan := NewPlanner(85).BuildPlan(vms, stores); plan[0].TargetDatastore != "nfs" || plan[0].ProjectedProvisioned != 190 {
+		t.Fatalf("expected no provisioned limit by default, got %+v", plan[0])
+	}
+	planner := NewPlanner(85).WithPolicy(Policy{ProvisionedThreshold: 180})
+	if plan := planner.BuildPlan(vms, stores); plan[0].SkipReason != SkipOverProvisioned {
+		t.Fatalf("expected provisioned overcommit skip, got %+v", plan[0])
+	}
+	stores[0].ProvisionedGB = 120
+	if plan := planner.BuildPlan(vms, stores); plan[0].TargetDatastore != "nfs" || plan[0].ProjectedProvisioned != 160 {
+		t.Fatalf("expected overcommit under 180%% to be allowed, got %+v", plan[0])
+	}
+	if util := provisionedUtil(Datastore{}); util != 100 {
+		t.Fatalf("expected zero capacity to count as full, got %d", util)
+	}
+}
+
+func TestExecutePlanReservesProvisionedSpaceOfRunningMoves(t *testing.T) {
+	plan := []PlanStep{
+		{Order: 1, VMName: "vm-a", SourceDatastore: "src", TargetDatastore: "dst", SizeGB: 10, ProvisionedGB: 40},
+		{Order: 2, VMName: "vm-b", SourceDatastore: "src", TargetDatastore: "dst", SizeGB: 10, ProvisionedGB: 40},
+	}
+	vms := []VM{
+		{Name: "vm-a", SizeGB: 10, ProvisionedGB: 40, SourceDatastore: "src"},
+		{Name: "vm-b", SizeGB: 10, ProvisionedGB: 40, SourceDatastore: "src"},
+	}
+	stores := []Datastore{
+		{Name: "src", CapacityGB: 100, UsedGB: 90},
+		{Name: "dst", CapacityGB: 100, UsedGB: 10, ProvisionedGB: 100},
+		{Name: "alt", CapacityGB: 100, UsedGB: 30},
+	}
+	mover := &targetMover{moves: map[string]string{}}
+	planner := NewPlanner(85).WithLimits(Limits{}).WithPolicy(Policy{ProvisionedThreshold: 150}).WithInventory(staticInventory(vms, stores))
+	summary := planner.ExecutePlan(plan, true, 1, mover)
+	if summary.MigratedCount != 2 || mover.moves["vm-a"] != "dst" || mover.moves["vm-b"] != "alt" {
+		t.Fatalf("expected vm-b re-planned off the overcommitted target, got %+v %v", summary, mover.moves)
+	}
+}
+
+func TestParsePolicyRejectsNegativeProvisionedThreshold(t *testing.T) {
+	if policy, err := ParsePolicy([]byte(`{"provisioned_threshold":200}`)); err != nil || policy.ProvisionedThreshold != 200 {
+		t.Fatalf("unexpected policy %+v err=%v", policy, err)
+	}
+	if _, err := ParsePolicy([]byte(`{"provisioned_threshold":-1}`)); !errors.Is(err, ErrInvalidPolicy) {
+		t.Fatalf("expected invalid policy, got %v", err)
+	}
+}
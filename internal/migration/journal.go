@@ -0,0 +1,122 @@
+// Path: internal/migration/journal.go
+// Description: Record per-step plan execution to an append-only journal so apply can resume.
+package migration
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+// JournalEntry records one step transition during plan apply or rollback,
+// including the datastore a VM left so a rollback can return it.
+type JournalEntry struct {
+	Time            time.Time     `json:"time"`
+	Order           int           `json:"order"`
+	VMName          string        `json:"vm"`
+	SourceDatastore string        `json:"source_datastore,omitempty"`
+	TargetDatastore string        `json:"target_datastore"`
+	State           ProgressState `json:"state"`
+	Error           string        `json:"error,omitempty"`
+	Rollback        bool          `json:"rollback,omitempty"`
+}
+
+// Journal appends step transitions as JSON lines written synchronously to disk.
+type Journal struct {
+	file *os.File
+	now  func() time.Time
+}
+
+// JournalPath return the journal location kept beside a plan file.
+func JournalPath(planPath string) string {
+	return planPath + ".journal"
+}
+
+// OpenJournal open a journal for appending, creating it when absent.
+func OpenJournal(path string) (*Journal, error) {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY|os.O_SYNC, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	return &Journal{file: file, now: time.Now}, nil
+}
+
+// Record append one progress event to the journal.
+func (j *Journal) Record(event ProgressEvent) error {
+	entry := JournalEntry{
+		Time:            j.now().UTC(),
+		Order:           event.Step.Order,
+		VMName:          event.Step.VMName,
+		SourceDatastore: event.Step.SourceDatastore,
+		TargetDatastore: event.Step.TargetDatastore,
+		State:           event.State,
+		Rollback:        event.Step.Rollback,
+	}
+	if event.Err != nil {
+		entry.Error = event.Err.Error()
+	}
+	content, _ := json.Marshal(entry)
+	_, err := j.file.Write(append(content, '\n'))
+	return err
+}
+
+// Close close the journal file.
+func (j *Journal) Close() error {
+	return j.file.Close()
+}
+
+// ReadJournal load journal entries, returning none when the journal is absent.
+// A torn final line from a crash mid-write is ignored.
+func ReadJournal(path string) ([]JournalEntry, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer func() { _ = file.Close() }()
+	entries := []JournalEntry{}
+	scanner := bufio.NewScanner(file)
+	for line := 1; scanner.Scan(); line++ {
+		entry := JournalEntry{}
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			if !scanner.Scan() {
+				break
+			}
+			return nil, fmt.Errorf("%w: journal line %d: %v", ErrInvalidPlanFile, line, err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, scanner.Err()
+}
+
+// Completed return the plan orders the journal records as migrated; the target
+// may differ from the plan when the step was re-planned before moving. Steps
+// whose VM a later rollback returned are no longer completed.
+func Completed(entries []JournalEntry, plan []PlanStep) map[int]bool {
+	steps := map[int]PlanStep{}
+	for _, step := range plan {
+		steps[step.Order] = step
+	}
+	completed := map[int]bool{}
+	for _, entry := range entries {
+		if entry.State != ProgressMigrated {
+			continue
+		}
+		if entry.Rollback {
+			for order, step := range steps {
+				if step.VMName == entry.VMName {
+					delete(completed, order)
+				}
+			}
+			continue
+		}
+		if step, ok := steps[entry.Order]; ok && step.VMName == entry.VMName {
+			completed[entry.Order] = true
+		}
+	}
+	return completed
+}
@@ -0,0 +1,206 @@
+// Path: internal/migration/planfile.go
+// Description: Persist reviewed migration plans with inventory fingerprints and detect drift before apply.
+package migration
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+const planFileVersion = 1
+
+var (
+	// ErrInvalidPlanFile indicates a plan file that cannot be decoded or has an unknown version.
+	ErrInvalidPlanFile = errors.New("invalid migration plan file")
+	// ErrPlanDrift indicates inventory that changed since the plan was built.
+	ErrPlanDrift = errors.New("inventory drifted since plan was built")
+)
+
+// VMFingerprint records the placement and size of a planned VM.
+type VMFingerprint struct {
+	Name      string `json:"name"`
+	Datastore string `json:"datastore"`
+	SizeGB    int    `json:"size_gb"`
+}
+
+// DatastoreFingerprint records the capacity of a planned source or target datastore.
+type DatastoreFingerprint struct {
+	Name       string `json:"name"`
+	CapacityGB int    `json:"capacity_gb"`
+	UsedGB     int    `json:"used_gb"`
+}
+
+// PlanFile stores a migration plan with the inventory it was built from.
+type PlanFile struct {
+	Version          int                    `json:"version"`
+	CreatedAt        time.Time              `json:"created_at"`
+	ThresholdPercent int                    `json:"threshold_percent"`
+	Strategy         Strategy               `json:"strategy,omitempty"`
+	Steps            []PlanStep             `json:"steps"`
+	VMs              []VMFingerprint        `json:"vms"`
+	Datastores       []DatastoreFingerprint `json:"datastores"`
+}
+
+// NewPlanFile capture a plan with fingerprints of the VMs and datastores it references.
+func NewPlanFile(plan []PlanStep, vms []VM, stores []Datastore, thresholdPercent int, now time.Time) PlanFile {
+	file := PlanFile{
+		Version:          planFileVersion,
+		CreatedAt:        now.UTC(),
+		ThresholdPercent: thresholdPercent,
+		Steps:            plan,
+		VMs:              []VMFingerprint{},
+		Datastores:       []DatastoreFingerprint{},
+	}
+	planned := map[string]bool{}
+	for _, step := range plan {
+		planned[step.VMName] = true
+		planned["ds:"+step.SourceDatastore] = true
+		planned["ds:"+step.TargetDatastore] = true
+	}
+	for _, vm := range vms {
+		if planned[vm.Name] {
+			file.VMs = append(file.VMs, VMFingerprint{Name: vm.Name, Datastore: vm.SourceDatastore, SizeGB: vm.SizeGB})
+		}
+	}
+	for _, store := range stores {
+		if planned["ds:"+store.Name] {
+			file.Datastores = append(file.Datastores, DatastoreFingerprint{Name: store.Name, CapacityGB: store.CapacityGB, UsedGB: store.UsedGB})
+		}
+	}
+	return file
+}
+
+// WritePlanFile save a plan file as indented JSON.
+func WritePlanFile(path string, file PlanFile) error {
+	content, _ := json.MarshalIndent(file, "", "  ")
+	return os.WriteFile(path, append(content, '\n'), 0o600)
+}
+
+// ReadPlanFile load and validate a plan file.
+func ReadPlanFile(path string) (PlanFile, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return PlanFile{}, err
+	}
+	file := PlanFile{}
+	if err := json.Unmarshal(content, &file); err != nil {
+		return PlanFile{}, fmt.Errorf("%w: %v", ErrInvalidPlanFile, err)
+	}
+	if file.Version != planFileVersion {
+		return PlanFile{}, fmt.Errorf("%w: unsupported version %d", ErrInvalidPlanFile, file.Version)
+	}
+	return file, nil
+}
+
+// Scope return an inventory limited to the datastores the plan was built over,
+// so re-planned steps stay within the reviewed targets.
+func (f PlanFile) Scope(inventory Inventory) Inventory {
+	return InventoryFunc(func() ([]VM, []Datastore, error) {
+		vms, stores, err := inventory.Load()
+		if err != nil {
+			return nil, nil, err
+		}
+		planned := map[string]bool{}
+		for _, recorded := range f.Datastores {
+			planned[recorded.Name] = true
+		}
+		scoped := make([]Datastore, 0, len(f.Datastores))
+		for _, store := range stores {
+			if planned[store.Name] {
+				scoped = append(scoped, store)
+			}
+		}
+		return vms, scoped, nil
+	})
+}
+
+// Pending return the steps still to run given the journal and the live inventory.
+// Steps recorded as migrated, or whose VM already sits on the target, are dropped.
+// Missing or relocated VMs, resized VMs, and targets that can no longer take the
+// remaining moves under the plan threshold are reported as ErrPlanDrift.
+func (f PlanFile) Pending(completed map[int]bool, vms []VM, stores []Datastore) ([]PlanStep, error) {
+	located := map[string]VM{}
+	for _, vm := range vms {
+		located[vm.Name] = vm
+	}
+	at := map[string]string{}
+	for name, vm := range located {
+		at[name] = vm.SourceDatastore
+	}
+	drift := []string{}
+	pending := make([]PlanStep, 0, len(f.Steps))
+	incoming := map[string]int{}
+	for _, step := range f.Steps {
+		if step.SkipReason != "" {
+			pending = append(pending, step)
+			continue
+		}
+		if completed[step.Order] {
+			continue
+		}
+		current, ok := at[step.VMName]
+		switch {
+		case !ok:
+			drift = append(drift, fmt.Sprintf("vm %s no longer exists", step.VMName))
+		case current == step.TargetDatastore:
+		case current != step.SourceDatastore:
+			drift = append(drift, fmt.Sprintf("vm %s is on %s, plan expects %s", step.VMName, current, step.SourceDatastore))
+		default:
+			at[step.VMName] = step.TargetDatastore
+			incoming[step.TargetDatastore] += located[step.VMName].footprint()
+			pending = append(pending, step)
+		}
+	}
+	drift = append(drift, f.vmDrift(pending, located)...)
+	drift = append(drift, f.datastoreDrift(incoming, stores)...)
+	if len(drift) > 0 {
+		return nil, fmt.Errorf("%w: %s", ErrPlanDrift, strings.Join(drift, "; "))
+	}
+	return pending, nil
+}
+
+func (f PlanFile) vmDrift(pending []PlanStep, located map[string]VM) []string {
+	drift := []string{}
+	waiting := map[string]bool{}
+	for _, step := range pending {
+		if step.SkipReason == "" {
+			waiting[step.VMName] = true
+		}
+	}
+	for _, recorded := range f.VMs {
+		if current := located[recorded.Name]; waiting[recorded.Name] && current.SizeGB != recorded.SizeGB {
+			drift = append(drift, fmt.Sprintf("vm %s size changed from %d to %d GB", recorded.Name, recorded.SizeGB, current.SizeGB))
+		}
+	}
+	return drift
+}
+
+func (f PlanFile) datastoreDrift(incoming map[string]int, stores []Datastore) []string {
+	live := map[string]Datastore{}
+	for _, store := range stores {
+		live[store.Name] = store
+	}
+	drift := []string{}
+	for _, recorded := range f.Datastores {
+		size, ok := incoming[recorded.Name]
+		if !ok {
+			continue
+		}
+		store, exists := live[recorded.Name]
+		if !exists {
+			drift = append(drift, fmt.Sprintf("datastore %s no longer exists", recorded.Name))
+			continue
+		}
+		if projected := projectedUtil(store, size); projected > f.ThresholdPercent {
+			drift = append(drift, fmt.Sprintf(
+				"datastore %s used %d of %d GB at plan time, now %d of %d GB; projected %d%% exceeds threshold %d%%",
+				recorded.Name, recorded.UsedGB, recorded.CapacityGB, store.UsedGB, store.CapacityGB, projected, f.ThresholdPercent,
+			))
+		}
+	}
+	return drift
+}
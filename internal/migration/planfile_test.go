@@ -0,0 +1,203 @@
+// Path: internal/migration/planfile_test.go
+// Description: Validate plan file persistence, journal resume, and drift detection.
+package migration
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func planFileInputs() ([]VM, []Datastore) {
+	vms := []VM{{Name: "vm-a", SizeGB: 10, SourceDatastore: "src"}, {Name: "vm-b", SizeGB: 20, SourceDatastore: "src"}, {Name: "vm-x", SizeGB: 5, SourceDatastore: "other"}}
+	stores := []Datastore{{Name: "src", CapacityGB: 100, UsedGB: 60}, {Name: "dst", CapacityGB: 100, UsedGB: 20}, {Name: "other", CapacityGB: 100}}
+	return vms, stores
+}
+
+func planFileSteps() []PlanStep {
+	return []PlanStep{
+		{Order: 1, VMName: "vm-a", SourceDatastore: "src", TargetDatastore: "dst"},
+		{Order: 2, VMName: "vm-b", SourceDatastore: "src", TargetDatastore: "dst"},
+		{Order: 3, VMName: "vm-a", SourceDatastore: "dst", TargetDatastore: "src"},
+		{Order: 4, VMName: "vm-c", SkipReason: SkipNoEligibleTarget},
+	}
+}
+
+func TestPlanFileRoundTripsWithFingerprints(t *testing.T) {
+	vms, stores := planFileInputs()
+	path := filepath.Join(t.TempDir(), "plan.json")
+	created := time.Date(2026, 10, 16, 9, 0, 0, 0, time.FixedZone("EDT", -4*3600))
+	if err := WritePlanFile(path, NewPlanFile(planFileSteps(), vms, stores, 85, created)); err != nil {
+		t.Fatalf("WritePlanFile returned error: %v", err)
+	}
+	file, err := ReadPlanFile(path)
+	if err != nil {
+		t.Fatalf("ReadPlanFile returned error: %v", err)
+	}
+	if len(file.Steps) != 4 || file.Steps[1].TargetDatastore != "dst" || file.ThresholdPercent != 85 || !file.CreatedAt.Equal(created) {
+		t.Fatalf("unexpected plan file: %+v", file)
+	}
+	if len(file.VMs) != 2 || file.VMs[1] != (VMFingerprint{Name: "vm-b", Datastore: "src", SizeGB: 20}) {
+		t.Fatalf("expected fingerprints for planned VMs only, got %+v", file.VMs)
+	}
+	if len(file.Datastores) != 2 || file.Datastores[0].UsedGB != 60 {
+		t.Fatalf("expected fingerprints for planned datastores only, got %+v", file.Datastores)
+	}
+}
+
+func TestReadPlanFileRejectsInvalidContent(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := ReadPlanFile(filepath.Join(dir, "missing.json")); !os.IsNotExist(err) {
+		t.Fatalf("expected missing file error, got %v", err)
+	}
+	for index, content := range []string{"not json", `{"version":9}`} {
+		path := filepath.Join(dir, "plan.json")
+		_ = os.WriteFile(path, []byte(content), 0o600)
+		if _, err := ReadPlanFile(path); !errors.Is(err, ErrInvalidPlanFile) {
+			t.Fatalf("case %d: expected invalid plan file, got %v", index, err)
+		}
+	}
+	if err := WritePlanFile(filepath.Join(dir, "absent", "plan.json"), PlanFile{}); err == nil {
+		t.Fatalf("expected write failure for missing directory")
+	}
+}
+
+func TestPendingResumesFromJournalAndLiveInventory(t *testing.T) {
+	vms, stores := planFileInputs()
+	file := NewPlanFile(planFileSteps(), vms, stores, 85, time.Now())
+	pending, err := file.Pending(nil, vms, stores)
+	if err != nil || len(pending) != 4 {
+		t.Fatalf("expected every step pending on a fresh apply, got %+v err=%v", pending, err)
+	}
+	moved := []VM{{Name: "vm-a", SizeGB: 10, SourceDatastore: "dst"}, {Name: "vm-b", SizeGB: 20, SourceDatastore: "dst"}}
+	pending, err = file.Pending(map[int]bool{1: true}, moved, stores)
+	if err != nil || len(pending) != 2 || pending[0].Order != 3 || pending[1].Order != 4 {
+		t.Fatalf("expected journaled and already-moved steps dropped, got %+v err=%v", pending, err)
+	}
+}
+
+func TestPendingReportsDrift(t *testing.T) {
+	vms, stores := planFileInputs()
+	file := NewPlanFile(planFileSteps(), vms, stores, 85, time.Now())
+	drifted := []VM{{Name: "vm-a", SizeGB: 30, SourceDatastore: "src"}, {Name: "vm-b", SizeGB: 20, SourceDatastore: "other"}}
+	full := []Datastore{{Name: "src", CapacityGB: 100, UsedGB: 60}, {Name: "dst", CapacityGB: 100, UsedGB: 70}}
+	_, err := file.Pending(nil, drifted, full)
+	if !errors.Is(err, ErrPlanDrift) {
+		t.Fatalf("expected drift error, got %v", err)
+	}
+	for _, want := range []string{
+		"vm vm-b is on other, plan expects src",
+		"vm vm-a size changed from 10 to 30 GB",
+		"datastore dst used 20 of 100 GB at plan time, now 70 of 100 GB; projected 100% exceeds threshold 85%",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Fatalf("expected %q in drift error, got %v", want, err)
+		}
+	}
+	_, err = file.Pending(nil, nil, []Datastore{{Name: "src", CapacityGB: 100}})
+	if !strings.Contains(err.Error(), "vm vm-a no longer exists") {
+		t.Fatalf("expected missing VM drift, got %v", err)
+	}
+	_, err = file.Pending(nil, vms, []Datastore{{Name: "src", CapacityGB: 100}})
+	if !strings.Contains(err.Error(), "datastore dst no longer exists") {
+		t.Fatalf("expected missing datastore drift, got %v", err)
+	}
+}
+
+func TestJournalRecordsAndResumesCompletedSteps(t *testing.T) {
+	path := JournalPath(filepath.Join(t.TempDir(), "plan.json"))
+	if entries, err := ReadJournal(path); err != nil || entries != nil {
+		t.Fatalf("expected empty journal when absent, got %+v err=%v", entries, err)
+	}
+	journal, err := OpenJournal(path)
+	if err != nil {
+		t.Fatalf("OpenJournal returned error: %v", err)
+	}
+	steps := planFileSteps()
+	for _, event := range []ProgressEvent{
+		{Step: steps[0], State: ProgressStarted},
+		{Step: steps[0], State: ProgressMigrated, Attempts: 1},
+		{Step: steps[1], State: ProgressFailed, Err: errors.New("boom")},
+		{Step: PlanStep{Order: 3, VMName: "vm-z", TargetDatastore: "src"}, State: ProgressMigrated},
+		{Step: PlanStep{Order: 9, VMName: "vm-a"}, State: ProgressMigrated},
+	} {
+		if err := journal.Record(event); err != nil {
+			t.Fatalf("Record returned error: %v", err)
+		}
+	}
+	_ = journal.Close()
+	if err := journal.Record(ProgressEvent{}); err == nil {
+		t.Fatalf("expected record failure on closed journal")
+	}
+	file, _ := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o600)
+	_, _ = file.WriteString(`{"order":2,"vm":"vm-b","sta`)
+	_ = file.Close()
+	entries, err := ReadJournal(path)
+	if err != nil || len(entries) != 5 || entries[2].Error != "boom" || entries[1].SourceDatastore != steps[0].SourceDatastore {
+		t.Fatalf("expected five entries with a torn tail ignored, got %+v err=%v", entries, err)
+	}
+	completed := Completed(entries, steps)
+	if len(completed) != 1 || !completed[1] {
+		t.Fatalf("expected only step 1 completed, got %v", completed)
+	}
+}
+
+func TestCompletedIgnoresRolledBackSteps(t *testing.T) {
+	steps := planFileSteps()
+	entries := []JournalEntry{
+		{Order: 1, VMName: steps[0].VMName, State: ProgressMigrated},
+		{Order: 2, VMName: steps[1].VMName, State: ProgressMigrated},
+		{Order: 1, VMName: steps[0].VMName, State: ProgressFailed, Rollback: true},
+		{Order: 2, VMName: steps[0].VMName, State: ProgressMigrated, Rollback: true},
+	}
+	if completed := Completed(entries, steps); len(completed) != 1 || !completed[2] {
+		t.Fatalf("expected the rolled-back step no longer completed, got %v", completed)
+	}
+	if moves := journaledMoves(entries); len(moves) != 1 || moves[0].vm != steps[1].VMName {
+		t.Fatalf("expected the rolled-back VM left out of later rollbacks, got %+v", moves)
+	}
+	entries = append(entries, JournalEntry{Order: 1, VMName: steps[0].VMName, State: ProgressMigrated})
+	if completed := Completed(entries, steps); len(completed) != 2 {
+		t.Fatalf("expected a re-applied step completed again, got %v", completed)
+	}
+}
+
+func TestReadJournalRejectsCorruptEntries(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "plan.json.journal")
+	_ = os.WriteFile(path, []byte("garbage\n{\"order\":1}\n"), 0o600)
+	if _, err := ReadJournal(path); !errors.Is(err, ErrInvalidPlanFile) {
+		t.Fatalf("expected corrupt journal error, got %v", err)
+	}
+	_ = os.WriteFile(path, []byte(strings.Repeat("x", 70*1024)), 0o600)
+	if _, err := ReadJournal(path); err == nil {
+		t.Fatalf("expected oversized journal line error")
+	}
+	if _, err := ReadJournal(dir); err == nil {
+		t.Fatalf("expected read failure for a directory")
+	}
+	if _, err := ReadJournal(filepath.Join(path, "nested")); err == nil {
+		t.Fatalf("expected open failure beneath a file")
+	}
+	if _, err := OpenJournal(filepath.Join(dir, "absent", "journal")); err == nil {
+		t.Fatalf("expected open failure for missing directory")
+	}
+}
+
+func TestPlanFileScopeLimitsDatastoresToPlannedOnes(t *testing.T) {
+	vms, stores := planFileInputs()
+	file := NewPlanFile(planFileSteps(), vms, stores, 85, time.Now())
+	_, scoped, err := file.Scope(staticInventory(vms, stores)).Load()
+	if err != nil || len(scoped) != 2 || scoped[0].Name != "src" || scoped[1].Name != "dst" {
+		t.Fatalf("expected only planned datastores, got %+v err=%v", scoped, err)
+	}
+	failing := InventoryFunc(func() ([]VM, []Datastore, error) {
+		return nil, nil, errors.New("reload failed")
+	})
+	if _, _, err := file.Scope(failing).Load(); err == nil {
+		t.Fatalf("expected load error to propagate")
+	}
+}
@@ -2,11 +2,18 @@
 // Description: Plan and execute datastore migrations with threshold and retry controls.
 package migration
 
-import "sort"
+import (
+	"math/rand/v2"
+	"sort"
+	"time"
+
+	"github.com/takelley1/hypersphere/internal/schedule"
+)
 
 const (
 	SkipOverThreshold    = "OVER_85"
 	SkipNoEligibleTarget = "NO_ELIGIBLE_TARGET"
+	SkipDrifted          = "DRIFTED"
 )
 
 // Tier labels target datastore priority.
@@ -22,30 +29,68 @@ func (t Tier) String() string {
 	return string(t)
 }
 
-// VM represents a VM migration candidate.
+// VM represents a VM migration candidate. SizeGB is committed disk space,
+// ProvisionedGB is the size thin disks may grow to (zero means fully
+// committed), SnapshotGB is held by snapshot deltas, and SwapGB is the swap
+// file the VM still needs when it powers on. CPUMHz and MemoryMB are the
+// compute demand a host takes on with the VM. Snapshots, ISODatastore,
+// IndependentDisks, RDMDisks, and BackingUp feed the pre-flight checks.
 type VM struct {
-	Name            string
-	SizeGB          int
-	SourceDatastore string
-}
-
-// Datastore represents migration destination capacity.
+	Name             string
+	SizeGB           int
+	ProvisionedGB    int
+	SnapshotGB       int
+	SwapGB           int
+	CPUMHz           int
+	MemoryMB         int
+	SourceDatastore  string
+	Cluster          string
+	Folder           string
+	Host             string
+	Tags             []string
+	Snapshots        int
+	ISODatastore     string
+	IndependentDisks int
+	RDMDisks         int
+	BackingUp        bool
+}
+
+// Datastore represents migration destination capacity. ProvisionedGB is the
+// space promised to thin disks; zero means UsedGB. LatencyMS is the current
+// IO latency the executor throttles on. Local marks host-local storage and
+// Hosts lists the hosts that mount the datastore, empty when unknown.
 type Datastore struct {
-	Name       string
-	CapacityGB int
-	UsedGB     int
-	Tier       Tier
-}
-
-// PlanStep stores one planned migration operation.
+	Name          string
+	CapacityGB    int
+	UsedGB        int
+	ProvisionedGB int
+	Tier          Tier
+	Cluster       string
+	LatencyMS     int
+	Local         bool
+	Hosts         []string
+}
+
+// PlanStep stores one planned migration operation. SizeGB is the committed
+// space the move takes, including snapshots and swap. Compute moves set
+// TargetHost instead of TargetDatastore. Rollback steps return a VM to its
+// origin and are never re-planned onto another datastore.
 type PlanStep struct {
-	Order           int
-	VMName          string
-	SourceDatastore string
-	TargetDatastore string
-	ProjectedUtil   int
-	Tier            string
-	SkipReason      string
+	Order                int      `json:"order"`
+	VMName               string   `json:"vm"`
+	SourceDatastore      string   `json:"source_datastore"`
+	TargetDatastore      string   `json:"target_datastore"`
+	Host                 string   `json:"host,omitempty"`
+	TargetHost           string   `json:"target_host,omitempty"`
+	SizeGB               int      `json:"size_gb"`
+	ProvisionedGB        int      `json:"provisioned_gb,omitempty"`
+	ProjectedUtil        int      `json:"projected_util"`
+	ProjectedProvisioned int      `json:"projected_provisioned,omitempty"`
+	Tier                 string   `json:"tier"`
+	SkipReason           string   `json:"skip_reason,omitempty"`
+	Rule                 string   `json:"rule,omitempty"`
+	Rollback             bool     `json:"rollback,omitempty"`
+	Warnings             []string `json:"warnings,omitempty"`
 }
 
 // Mover executes one VM move.
@@ -53,91 +98,110 @@ type Mover interface {
 	Move(vmName string, target string) error
 }
 
-// ExecutionSummary tracks plan execution outcomes.
+// ExecutionSummary tracks plan execution outcomes. PausedCount and Paused
+// record how often and how long execution waited for a maintenance window;
+// ThrottledCount and Throttled count steps held on datastore latency and the
+// time spent backing off. Results holds each plan step's outcome in plan order.
 type ExecutionSummary struct {
-	MigratedCount int
-	FailedCount   int
-	DryRunCount   int
+	MigratedCount  int
+	FailedCount    int
+	DryRunCount    int
+	DriftedCount   int
+	PausedCount    int
+	Paused         time.Duration
+	ThrottledCount int
+	Throttled      time.Duration
+	Results        []StepResult
 }
 
 // Planner encapsulates migration planning and execution.
 type Planner struct {
-	thresholdPercent int
-}
-
-// NewPlanner build a planner with utilization guardrail.
+	thresholdPercent     int
+	limits               Limits
+	progress             func(ProgressEvent)
+	inventory            Inventory
+	strategy             Strategy
+	tiers                TierPolicy
+	provisionedThreshold int
+	affinity             []PlacementRule
+	antiAffinity         []PlacementRule
+	residents            []VM
+	gate                 *schedule.Gate
+	throttle             Throttle
+	sleep                func(time.Duration)
+	now                  func() time.Time
+	retry                RetryPolicy
+	random               func() float64
+	checks               []Check
+}
+
+// NewPlanner build a planner with utilization guardrail that moves one VM at a time.
 func NewPlanner(thresholdPercent int) Planner {
-	return Planner{thresholdPercent: thresholdPercent}
+	return Planner{thresholdPercent: thresholdPercent, limits: Limits{Global: 1}, strategy: StrategyGreedy, sleep: time.Sleep, now: time.Now, random: rand.Float64}
 }
 
 // BuildPlan create a migration plan from VM and datastore inputs.
 func (p Planner) BuildPlan(vms []VM, candidates []Datastore) []PlanStep {
 	state := copyDatastores(candidates)
+	placed := p.newPlacement(p.residents, vms)
 	plan := make([]PlanStep, 0, len(vms))
-	for index, vm := range vms {
-		step := p.planStep(index+1, vm, state)
+	for index, vm := range p.placementOrder(vms) {
+		step := p.planStep(index+1, vm, state, placed)
 		if step.SkipReason == "" {
-			applyProjection(state, step.TargetDatastore, vm.SizeGB)
+			applyProjection(state, step.TargetDatastore, vm.footprint(), vm.provisioned())
 		}
+		placed.record(vm, step)
 		plan = append(plan, step)
 	}
 	return plan
 }
 
-func (p Planner) planStep(order int, vm VM, state []Datastore) PlanStep {
-	step := PlanStep{Order: order, VMName: vm.Name, SourceDatastore: vm.SourceDatastore}
-	targets := targetsForSource(state, vm.SourceDatastore)
-	if len(targets) == 0 {
-		step.SkipReason = SkipNoEligibleTarget
-		step.Tier = "-"
-		return step
+func (p Planner) planStep(order int, vm VM, state []Datastore, placed *placement) PlanStep {
+	step := PlanStep{
+		Order:           order,
+		VMName:          vm.Name,
+		SourceDatastore: vm.SourceDatastore,
+		Host:            vm.Host,
+		SizeGB:          vm.footprint(),
+		ProvisionedGB:   vm.provisioned(),
 	}
-	sortTargets(targets)
-	for _, target := range targets {
-		projected := projectedUtil(target, vm.SizeGB)
-		if projected <= p.thresholdPercent {
+	targets, reason, rule := p.eligibleTargets(vm, state, placed)
+	if reason == "" {
+		var load VM
+		targets, load, rule = p.colocate(vm, targets, state, placed)
+		reason = SkipAffinity
+		if target, ok := p.chooseTarget(load, targets, state); ok {
 			step.TargetDatastore = target.Name
-			step.ProjectedUtil = projected
+			step.ProjectedUtil = projectedUtil(target, step.SizeGB)
+			step.ProjectedProvisioned = provisionedUtil(target.plus(step.SizeGB, step.ProvisionedGB))
 			step.Tier = target.Tier.String()
-			return step
+			return p.preflight(step, vm, target, state)
+		}
+		if rule == "" {
+			reason = p.overReason(vm, targets)
 		}
 	}
-	step.SkipReason = SkipOverThreshold
+	step.SkipReason = reason
+	step.Rule = rule
 	step.Tier = "-"
 	return step
 }
 
-// ExecutePlan run plan steps, honoring dry-run mode and retry count.
-func (p Planner) ExecutePlan(plan []PlanStep, execute bool, retries int, mover Mover) ExecutionSummary {
-	summary := ExecutionSummary{}
-	attemptLimit := retries
-	if attemptLimit < 1 {
-		attemptLimit = 1
+// eligibleTargets return the targets a VM may move to under tier and
+// anti-affinity rules, or the skip reason and rule that left none.
+func (p Planner) eligibleTargets(vm VM, state []Datastore, placed *placement) ([]Datastore, string, string) {
+	targets := targetsForSource(state, vm.SourceDatastore)
+	if len(targets) == 0 {
+		return nil, SkipNoEligibleTarget, ""
 	}
-	for _, step := range plan {
-		if step.SkipReason != "" {
-			continue
-		}
-		if !execute {
-			summary.DryRunCount++
-			continue
-		}
-		if runMove(step, attemptLimit, mover) {
-			summary.MigratedCount++
-			continue
-		}
-		summary.FailedCount++
+	if targets = p.tierEligible(vm, targets, state); len(targets) == 0 {
+		return nil, SkipTierPolicy, ""
 	}
-	return summary
-}
-
-func runMove(step PlanStep, attempts int, mover Mover) bool {
-	for i := 0; i < attempts; i++ {
-		if mover.Move(step.VMName, step.TargetDatastore) == nil {
-			return true
-		}
+	targets, rule := p.separated(vm, targets, placed)
+	if rule != "" {
+		return nil, SkipAntiAffinity, rule
 	}
-	return false
+	return targets, "", ""
 }
 
 func copyDatastores(candidates []Datastore) []Datastore {
@@ -146,10 +210,10 @@ func copyDatastores(candidates []Datastore) []Datastore {
 	return copied
 }
 
-func applyProjection(state []Datastore, target string, size int) {
+func applyProjection(state []Datastore, target string, committed int, provisioned int) {
 	for i := range state {
 		if state[i].Name == target {
-			state[i].UsedGB += size
+			state[i] = state[i].plus(committed, provisioned)
 			return
 		}
 	}
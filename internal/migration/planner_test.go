@@ -2,10 +2,7 @@
 // Description: Validate datastore migration planning and execution safeguards.
 package migration
 
-import (
-	"errors"
-	"testing"
-)
+import "testing"
 
 type fakeMover struct {
 	calls []string
@@ -77,7 +74,7 @@ func TestExecutePlanHonorsDryRun(t *testing.T) {
 func TestExecutePlanRetriesMove(t *testing.T) {
 	planner := NewPlanner(85)
 	plan := []PlanStep{{VMName: "vm-a", TargetDatastore: "ds-1"}}
-	mover := &fakeMover{errAt: map[string]error{"vm-a": errors.New("temporary")}}
+	mover := &fakeMover{errAt: map[string]error{"vm-a": busyError("temporary")}}
 	summary := planner.ExecutePlan(plan, true, 2, mover)
 	if len(mover.calls) != 2 {
 		t.Fatalf("expected 2 attempts, got %d", len(mover.calls))
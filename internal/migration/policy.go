@@ -0,0 +1,58 @@
+// Path: internal/migration/policy.go
+// Description: Load migration placement policy rules from a JSON file.
+package migration
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/takelley1/hypersphere/internal/jsonfile"
+)
+
+// ErrInvalidPolicy indicates a malformed migration policy file.
+var ErrInvalidPolicy = errors.New("invalid migration policy")
+
+// Policy groups the placement rules the planner enforces.
+type Policy struct {
+	Tiers TierPolicy `json:"tiers"`
+	// AntiAffinity groups VMs that must not share a datastore.
+	AntiAffinity []PlacementRule `json:"anti_affinity"`
+	// Affinity groups VMs that must move together onto one datastore.
+	Affinity []PlacementRule `json:"affinity"`
+	// ProvisionedThreshold caps target provisioned space as a percentage of
+	// capacity, which may exceed 100 to allow thin overcommit; zero disables it.
+	ProvisionedThreshold int `json:"provisioned_threshold"`
+}
+
+// LoadPolicy read a migration policy, returning an empty policy when the file is absent.
+func LoadPolicy(path string) (Policy, error) {
+	return jsonfile.Load(path, ParsePolicy)
+}
+
+// ParsePolicy decode and validate a JSON migration policy.
+func ParsePolicy(content []byte) (Policy, error) {
+	return jsonfile.Decode(content, ErrInvalidPolicy, (*Policy).validate)
+}
+
+// WithPolicy return a planner that enforces the policy's placement rules.
+func (p Planner) WithPolicy(policy Policy) Planner {
+	p.tiers = policy.Tiers
+	p.affinity = policy.Affinity
+	p.antiAffinity = policy.AntiAffinity
+	p.provisionedThreshold = policy.ProvisionedThreshold
+	return p
+}
+
+func (p Policy) validate() error {
+	if err := p.Tiers.validate(); err != nil {
+		return err
+	}
+	if p.ProvisionedThreshold < 0 {
+		return fmt.Errorf("provisioned threshold %d below zero", p.ProvisionedThreshold)
+	}
+	seen := map[string]bool{}
+	if err := validateRules("affinity", p.Affinity, seen); err != nil {
+		return err
+	}
+	return validateRules("anti-affinity", p.AntiAffinity, seen)
+}
@@ -0,0 +1,141 @@
+// Path: internal/migration/preflight.go
+// Description: Run pluggable pre-flight checks that warn about or skip risky Storage vMotion moves.
+package migration
+
+import (
+	"fmt"
+	"slices"
+)
+
+const (
+	SkipSnapshots       = "TOO_MANY_SNAPSHOTS"
+	SkipBackupRunning   = "BACKUP_RUNNING"
+	SkipHostUnreachable = "HOST_CANNOT_REACH_TARGET"
+)
+
+// Finding reports one pre-flight check result. A non-empty SkipReason keeps
+// the step from running; a Warning is recorded on the step without stopping
+// it. The zero Finding means the check passed.
+type Finding struct {
+	Warning    string
+	SkipReason string
+}
+
+// Check inspects a VM and the target datastore chosen for it before the move
+// is executed. Stores holds the projected datastore state during planning.
+type Check interface {
+	Inspect(vm VM, target Datastore, stores []Datastore) Finding
+}
+
+// CheckFunc adapts a function to Check.
+type CheckFunc func(vm VM, target Datastore, stores []Datastore) Finding
+
+// Inspect call the wrapped function.
+func (f CheckFunc) Inspect(vm VM, target Datastore, stores []Datastore) Finding {
+	return f(vm, target, stores)
+}
+
+// WithChecks return a planner that runs the checks, in order, on every step
+// it places. The first check to return a skip reason skips the step.
+func (p Planner) WithChecks(checks ...Check) Planner {
+	p.checks = checks
+	return p
+}
+
+// DefaultChecks return the built-in checks, skip checks first, with the given
+// snapshot limit.
+func DefaultChecks(maxSnapshots int) []Check {
+	return []Check{
+		SnapshotCheck(maxSnapshots),
+		BackupCheck(),
+		ReachabilityCheck(),
+		LocalMediaCheck(),
+		IndependentDiskCheck(),
+		RDMCheck(),
+	}
+}
+
+// SnapshotCheck skip VMs carrying more than limit snapshots, which make a
+// Storage vMotion slow and prone to failure.
+func SnapshotCheck(limit int) Check {
+	return CheckFunc(func(vm VM, _ Datastore, _ []Datastore) Finding {
+		if vm.Snapshots > limit {
+			return Finding{SkipReason: SkipSnapshots}
+		}
+		return Finding{}
+	})
+}
+
+// BackupCheck skip VMs a backup job is running against.
+func BackupCheck() Check {
+	return CheckFunc(func(vm VM, _ Datastore, _ []Datastore) Finding {
+		if vm.BackingUp {
+			return Finding{SkipReason: SkipBackupRunning}
+		}
+		return Finding{}
+	})
+}
+
+// ReachabilityCheck skip moves onto a datastore the VM's host does not mount.
+// Targets with no recorded hosts are assumed reachable.
+func ReachabilityCheck() Check {
+	return CheckFunc(func(vm VM, target Datastore, _ []Datastore) Finding {
+		if vm.Host != "" && len(target.Hosts) > 0 && !slices.Contains(target.Hosts, vm.Host) {
+			return Finding{SkipReason: SkipHostUnreachable}
+		}
+		return Finding{}
+	})
+}
+
+// LocalMediaCheck warn when a VM mounts an ISO from a host-local datastore.
+func LocalMediaCheck() Check {
+	return CheckFunc(func(vm VM, _ Datastore, stores []Datastore) Finding {
+		for _, store := range stores {
+			if store.Name == vm.ISODatastore && store.Local {
+				return Finding{Warning: fmt.Sprintf("ISO mounted from local datastore %s", store.Name)}
+			}
+		}
+		return Finding{}
+	})
+}
+
+// IndependentDiskCheck warn about independent disks, which snapshots skip.
+func IndependentDiskCheck() Check {
+	return CheckFunc(func(vm VM, _ Datastore, _ []Datastore) Finding {
+		if vm.IndependentDisks > 0 {
+			return Finding{Warning: fmt.Sprintf("independent disks: %d", vm.IndependentDisks)}
+		}
+		return Finding{}
+	})
+}
+
+// RDMCheck warn about raw device mappings, whose data stays on the mapped LUN.
+func RDMCheck() Check {
+	return CheckFunc(func(vm VM, _ Datastore, _ []Datastore) Finding {
+		if vm.RDMDisks > 0 {
+			return Finding{Warning: fmt.Sprintf("raw device mappings: %d", vm.RDMDisks)}
+		}
+		return Finding{}
+	})
+}
+
+// preflight run the planner's checks on a placed step, recording warnings
+// and clearing the target when a check skips it.
+func (p Planner) preflight(step PlanStep, vm VM, target Datastore, stores []Datastore) PlanStep {
+	step.Warnings = nil
+	for _, check := range p.checks {
+		finding := check.Inspect(vm, target, stores)
+		if finding.Warning != "" {
+			step.Warnings = append(step.Warnings, finding.Warning)
+		}
+		if finding.SkipReason != "" {
+			step.SkipReason = finding.SkipReason
+			step.TargetDatastore = ""
+			step.ProjectedUtil = 0
+			step.ProjectedProvisioned = 0
+			step.Tier = "-"
+			return step
+		}
+	}
+	return step
+}
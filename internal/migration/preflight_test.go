@@ -0,0 +1,89 @@
+// Path: internal/migration/preflight_test.go
+// Description: Validate pre-flight check warnings, skip reasons, and their re-run against live inventory.
+package migration
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestBuildPlanRunsPreflightChecks(t *testing.T) {
+	vms := []VM{
+		{Name: "vm-a", SizeGB: 10, SourceDatastore: "src", Snapshots: 3},
+		{Name: "vm-b", SizeGB: 10, SourceDatastore: "src", BackingUp: true},
+		{Name: "vm-c", SizeGB: 10, SourceDatastore: "src", Host: "esx-2"},
+		{Name: "vm-d", SizeGB: 10, SourceDatastore: "src", Host: "esx-1", Snapshots: 2, ISODatastore: "local-1", IndependentDisks: 1, RDMDisks: 2},
+		{Name: "vm-e", SizeGB: 10, SourceDatastore: "src", ISODatastore: "dst"},
+	}
+	stores := []Datastore{
+		{Name: "src", CapacityGB: 100, UsedGB: 90},
+		{Name: "dst", CapacityGB: 100, Hosts: []string{"esx-1"}},
+		{Name: "local-1", Local: true},
+	}
+	plan := NewPlanner(85).WithChecks(DefaultChecks(2)...).BuildPlan(vms, stores)
+	for index, reason := range []string{SkipSnapshots, SkipBackupRunning, SkipHostUnreachable} {
+		if step := plan[index]; step.SkipReason != reason || step.TargetDatastore != "" || step.Tier != "-" || step.ProjectedUtil != 0 {
+			t.Fatalf("expected step %d skipped with %s, got %+v", index, reason, step)
+		}
+	}
+	want := []string{"ISO mounted from local datastore local-1", "independent disks: 1", "raw device mappings: 2"}
+	if plan[3].SkipReason != "" || plan[3].ProjectedUtil != 10 || !slices.Equal(plan[3].Warnings, want) {
+		t.Fatalf("expected vm-d placed with warnings, got %+v", plan[3])
+	}
+	if plan[4].SkipReason != "" || plan[4].ProjectedUtil != 20 || plan[4].Warnings != nil {
+		t.Fatalf("expected vm-e placed without warnings, got %+v", plan[4])
+	}
+}
+
+func TestWithChecksRunsCustomChecksInOrder(t *testing.T) {
+	calls := []string{}
+	check := func(name string, finding Finding) Check {
+		return CheckFunc(func(VM, Datastore, []Datastore) Finding {
+			calls = append(calls, name)
+			return finding
+		})
+	}
+	planner := NewPlanner(85).WithChecks(
+		check("warn", Finding{Warning: "change freeze"}),
+		check("skip", Finding{SkipReason: "CHANGE_FREEZE"}),
+		check("never", Finding{}),
+	)
+	plan := planner.BuildPlan([]VM{{Name: "vm-a", SizeGB: 10, SourceDatastore: "src"}}, []Datastore{{Name: "src"}, {Name: "dst", CapacityGB: 100}})
+	if plan[0].SkipReason != "CHANGE_FREEZE" || !slices.Equal(plan[0].Warnings, []string{"change freeze"}) || !slices.Equal(calls, []string{"warn", "skip"}) {
+		t.Fatalf("expected the chain to stop at the first skip, got %+v calls=%v", plan[0], calls)
+	}
+}
+
+func TestExecutePlanRerunsPreflightChecksOnLiveInventory(t *testing.T) {
+	plan := []PlanStep{
+		{Order: 1, VMName: "vm-a", SourceDatastore: "src", TargetDatastore: "dst", SizeGB: 10},
+		{Order: 2, VMName: "vm-b", SourceDatastore: "src", TargetDatastore: "dst", SizeGB: 10, Warnings: []string{"stale"}},
+	}
+	inventory := staticInventory(
+		[]VM{{Name: "vm-a", SizeGB: 10, SourceDatastore: "src", BackingUp: true}, {Name: "vm-b", SizeGB: 10, SourceDatastore: "src", RDMDisks: 1}},
+		[]Datastore{{Name: "src", CapacityGB: 100}, {Name: "dst", CapacityGB: 100}},
+	)
+	started := []PlanStep{}
+	var driftErr error
+	summary := NewPlanner(85).
+		WithChecks(DefaultChecks(0)...).
+		WithInventory(inventory).
+		WithProgress(func(event ProgressEvent) {
+			switch event.State {
+			case ProgressStarted:
+				started = append(started, event.Step)
+			case ProgressDrifted:
+				driftErr = event.Err
+			}
+		}).
+		ExecutePlan(plan, true, 1, &targetMover{moves: map[string]string{}})
+	if summary.DriftedCount != 1 || summary.MigratedCount != 1 || !errors.Is(driftErr, ErrStepDrifted) ||
+		!strings.Contains(driftErr.Error(), "vm vm-a failed pre-flight check BACKUP_RUNNING") {
+		t.Fatalf("expected vm-a drifted on its live backup, got %+v err=%v", summary, driftErr)
+	}
+	if len(started) != 1 || !slices.Equal(started[0].Warnings, []string{"raw device mappings: 1"}) {
+		t.Fatalf("expected vm-b warnings refreshed from live inventory, got %+v", started)
+	}
+}
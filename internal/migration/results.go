@@ -0,0 +1,86 @@
+// Path: internal/migration/results.go
+// Description: Record per-step execution outcomes with attempts, errors, timing, and bytes moved.
+package migration
+
+import (
+	"encoding/json"
+	"os"
+	"time"
+)
+
+const bytesPerGB = 1 << 30
+
+const (
+	// ProgressDryRun marks a step result that was planned but not executed.
+	ProgressDryRun ProgressState = "dry_run"
+	// ProgressSkipped marks a step result the planner skipped.
+	ProgressSkipped ProgressState = "skipped"
+)
+
+// StepResult records the final outcome of one plan step. Error holds the
+// last move error, or the skip reason for skipped steps. BytesMoved is the
+// committed footprint of a migrated VM.
+type StepResult struct {
+	Order           int           `json:"order"`
+	VMName          string        `json:"vm"`
+	SourceDatastore string        `json:"source_datastore"`
+	TargetDatastore string        `json:"target_datastore"`
+	Status          ProgressState `json:"status"`
+	Attempts        int           `json:"attempts"`
+	Error           string        `json:"error,omitempty"`
+	Started         time.Time     `json:"started,omitzero"`
+	Finished        time.Time     `json:"finished,omitzero"`
+	BytesMoved      int64         `json:"bytes_moved"`
+}
+
+// Duration return how long the move ran, or zero when it never started.
+func (r StepResult) Duration() time.Duration {
+	if r.Started.IsZero() {
+		return 0
+	}
+	return r.Finished.Sub(r.Started)
+}
+
+// WriteResults save step results as indented JSON.
+func WriteResults(path string, results []StepResult) error {
+	content, _ := json.MarshalIndent(results, "", "  ")
+	return os.WriteFile(path, append(content, '\n'), 0o600)
+}
+
+func newResults(plan []PlanStep, execute bool) []StepResult {
+	results := make([]StepResult, len(plan))
+	for index, step := range plan {
+		results[index] = StepResult{
+			Order:           step.Order,
+			VMName:          step.VMName,
+			SourceDatastore: step.SourceDatastore,
+			TargetDatastore: step.TargetDatastore,
+		}
+		switch {
+		case step.SkipReason != "":
+			results[index].Status = ProgressSkipped
+			results[index].Error = step.SkipReason
+		case !execute:
+			results[index].Status = ProgressDryRun
+		}
+	}
+	return results
+}
+
+// settle record a step's final event, keeping move timing when it ran.
+func (e *executor) settle(index int, event ProgressEvent) {
+	step := e.plan[index]
+	result := &e.results[index]
+	result.TargetDatastore = step.TargetDatastore
+	result.Status = event.State
+	result.Attempts = event.Attempts
+	if event.Err != nil {
+		result.Error = event.Err.Error()
+	}
+	if result.Finished.IsZero() {
+		result.Finished = e.planner.now()
+	}
+	if event.State == ProgressMigrated {
+		result.BytesMoved = int64(step.SizeGB) * bytesPerGB
+	}
+}
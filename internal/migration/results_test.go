@@ -0,0 +1,83 @@
+// Path: internal/migration/results_test.go
+// Description: Validate per-step execution results, timing, bytes moved, and JSON export.
+package migration
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+type tickingClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *tickingClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(time.Second)
+	return c.now
+}
+
+func TestExecutePlanRecordsPerStepResults(t *testing.T) {
+	plan := []PlanStep{
+		{Order: 1, VMName: "vm-a", SourceDatastore: "src-1", TargetDatastore: "dst-1", SizeGB: 40},
+		{Order: 2, VMName: "vm-b", SourceDatastore: "src-2", TargetDatastore: "dst-2", SizeGB: 10},
+		{Order: 3, VMName: "vm-c", SourceDatastore: "dst-2", TargetDatastore: "dst-3", SizeGB: 10},
+		{Order: 4, VMName: "vm-d", SourceDatastore: "src-1", SkipReason: SkipOverThreshold},
+	}
+	mover := newConcurrentMover(func(string, string) []string { return nil })
+	mover.fail["vm-b"] = true
+	planner := NewPlanner(85)
+	planner.now = (&tickingClock{now: time.Date(2026, 10, 16, 22, 0, 0, 0, time.UTC)}).Now
+	results := planner.ExecutePlan(plan, true, 2, mover).Results
+	want := []struct {
+		status   ProgressState
+		attempts int
+		err      string
+		bytes    int64
+		ran      bool
+	}{
+		{ProgressMigrated, 1, "", 40 << 30, true},
+		{ProgressFailed, 2, "relocate failed", 0, true},
+		{ProgressBlocked, 0, "", 0, false},
+		{ProgressSkipped, 0, SkipOverThreshold, 0, false},
+	}
+	for index, expected := range want {
+		result := results[index]
+		if result.Order != index+1 || result.Status != expected.status || result.Attempts != expected.attempts ||
+			result.Error != expected.err || result.BytesMoved != expected.bytes || (result.Duration() > 0) != expected.ran {
+			t.Fatalf("unexpected result %d: %+v", index, result)
+		}
+	}
+	if results[2].Finished.IsZero() || !results[3].Finished.IsZero() {
+		t.Fatalf("expected blocked steps finished and skipped steps untimed, got %+v", results)
+	}
+	dry := NewPlanner(85).ExecutePlan(plan[:1], false, 1, mover).Results
+	if len(dry) != 1 || dry[0].Status != ProgressDryRun || dry[0].TargetDatastore != "dst-1" {
+		t.Fatalf("expected dry-run result, got %+v", dry)
+	}
+}
+
+func TestWriteResultsExportsJSON(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "results.json")
+	results := []StepResult{{Order: 1, VMName: "vm-a", Status: ProgressFailed, Attempts: 2, Error: "boom"}}
+	if err := WriteResults(path, results); err != nil {
+		t.Fatalf("WriteResults returned error: %v", err)
+	}
+	content, _ := os.ReadFile(path)
+	decoded := []map[string]any{}
+	if err := json.Unmarshal(content, &decoded); err != nil || decoded[0]["error"] != "boom" || decoded[0]["status"] != "failed" {
+		t.Fatalf("unexpected results JSON %s err=%v", content, err)
+	}
+	if _, ok := decoded[0]["started"]; ok {
+		t.Fatalf("expected unset times omitted, got %s", content)
+	}
+	if err := WriteResults(filepath.Join(path, "nested"), results); err == nil {
+		t.Fatalf("expected write failure under a file")
+	}
+}
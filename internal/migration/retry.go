@@ -0,0 +1,96 @@
+// Path: internal/migration/retry.go
+// Description: Retry failed moves with exponential backoff, jitter, an elapsed-time budget, and error classification.
+package migration
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+const maxRetryDelay = 24 * time.Hour
+
+// RetryPolicy paces retries of a failed move. The first retry waits Backoff
+// and each later one doubles it, up to MaxBackoff when set. Every delay is
+// scaled by a random factor within plus or minus Jitter, a fraction such as
+// 0.2. Once MaxElapsed has passed since the first attempt, or would pass
+// during the next delay, the move gives up. The zero policy retries at once
+// with no time limit.
+type RetryPolicy struct {
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+	MaxElapsed time.Duration
+	Jitter     float64
+}
+
+// WithRetry return a planner that paces move retries with the policy.
+func (p Planner) WithRetry(policy RetryPolicy) Planner {
+	p.retry = policy
+	return p
+}
+
+type retriableError interface {
+	Retriable() bool
+}
+
+type fatalError struct {
+	err error
+}
+
+func (e fatalError) Error() string {
+	return e.err.Error()
+}
+
+func (e fatalError) Unwrap() error {
+	return e.err
+}
+
+func (e fatalError) Retriable() bool {
+	return false
+}
+
+// Fatal wrap a move error so it is never retried.
+func Fatal(err error) error {
+	return fatalError{err: err}
+}
+
+// IsRetriable report whether a move error may succeed on retry. Only errors
+// that implement Retriable and report true, such as transient vCenter faults
+// and timeouts, are retried. Unclassified errors fail the step at once, since
+// repeating a failure nobody recognized may repeat its damage.
+func IsRetriable(err error) bool {
+	var classified retriableError
+	return errors.As(err, &classified) && classified.Retriable()
+}
+
+// delay return the jittered wait before the given retry, counting from one.
+func (r RetryPolicy) delay(retry int, random func() float64) time.Duration {
+	delay := r.Backoff
+	for i := 1; i < retry && delay < maxRetryDelay; i++ {
+		delay *= 2
+	}
+	if r.MaxBackoff > 0 {
+		delay = min(delay, r.MaxBackoff)
+	}
+	return time.Duration(float64(delay) * (1 + r.Jitter*(2*random()-1)))
+}
+
+// runMove try a move up to attempts times, stopping early on fatal errors or
+// when the retry policy's elapsed-time budget runs out.
+func (p Planner) runMove(step PlanStep, attempts int, mover Mover) (int, error) {
+	started := p.now()
+	for attempt := 1; ; attempt++ {
+		err := mover.Move(step.VMName, step.TargetDatastore)
+		if err == nil {
+			return attempt, nil
+		}
+		if attempt >= attempts || !IsRetriable(err) {
+			return attempt, err
+		}
+		delay := p.retry.delay(attempt, p.random)
+		if p.retry.MaxElapsed > 0 && p.now().Add(delay).Sub(started) > p.retry.MaxElapsed {
+			return attempt, fmt.Errorf("%w (retry budget %s exhausted)", err, p.retry.MaxElapsed)
+		}
+		p.sleep(delay)
+	}
+}
@@ -0,0 +1,118 @@
+// Path: internal/migration/retry_test.go
+// Description: Validate move retry backoff, jitter, elapsed-time budgets, and error classification.
+package migration
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+type flakyMover struct {
+	errs  []error
+	calls int
+}
+
+func (m *flakyMover) Move(string, string) error {
+	m.calls++
+	if len(m.errs) == 0 {
+		return nil
+	}
+	err := m.errs[0]
+	m.errs = m.errs[1:]
+	return err
+}
+
+type transientError struct {
+	retriable bool
+}
+
+func (e transientError) Error() string {
+	return "transient"
+}
+
+func (e transientError) Retriable() bool {
+	return e.retriable
+}
+
+// busyError is a retriable move failure with its own message.
+type busyError string
+
+func (e busyError) Error() string {
+	return string(e)
+}
+
+func (e busyError) Retriable() bool {
+	return true
+}
+
+func retryPlanner(policy RetryPolicy, clock *windowClock) Planner {
+	planner := NewPlanner(85).WithRetry(policy)
+	planner.now = clock.Now
+	planner.sleep = clock.Sleep
+	planner.random = func() float64 { return 1 }
+	return planner
+}
+
+func TestRunMoveBacksOffExponentiallyWithJitter(t *testing.T) {
+	clock := &windowClock{now: time.Date(2026, 10, 16, 22, 0, 0, 0, time.UTC)}
+	planner := retryPlanner(RetryPolicy{Backoff: time.Second, MaxBackoff: 3 * time.Second, Jitter: 0.5}, clock)
+	waits := []time.Duration{}
+	planner.sleep = func(d time.Duration) {
+		waits = append(waits, d)
+		clock.Sleep(d)
+	}
+	boom := busyError("boom")
+	mover := &flakyMover{errs: []error{boom, boom, boom}}
+	attempts, err := planner.runMove(PlanStep{VMName: "vm-a"}, 4, mover)
+	if err != nil || attempts != 4 {
+		t.Fatalf("expected success on the fourth attempt, got %d %v", attempts, err)
+	}
+	want := []time.Duration{1500 * time.Millisecond, 3 * time.Second, 4500 * time.Millisecond}
+	if len(waits) != len(want) {
+		t.Fatalf("expected waits %v, got %v", want, waits)
+	}
+	for index := range want {
+		if waits[index] != want[index] {
+			t.Fatalf("expected waits %v, got %v", want, waits)
+		}
+	}
+}
+
+func TestRunMoveStopsOnFatalErrorsAndExhaustedBudget(t *testing.T) {
+	clock := &windowClock{now: time.Date(2026, 10, 16, 22, 0, 0, 0, time.UTC)}
+	planner := retryPlanner(RetryPolicy{Backoff: time.Minute, MaxElapsed: 2 * time.Minute}, clock)
+	missing := errors.New("vm not found")
+	fatal := &flakyMover{errs: []error{Fatal(missing)}}
+	if attempts, err := planner.runMove(PlanStep{VMName: "vm-a"}, 5, fatal); attempts != 1 || !errors.Is(err, missing) ||
+		err.Error() != "vm not found" || IsRetriable(err) {
+		t.Fatalf("expected fatal error without retry, got %d %v", attempts, err)
+	}
+	declined := &flakyMover{errs: []error{transientError{retriable: false}}}
+	if attempts, _ := planner.runMove(PlanStep{VMName: "vm-a"}, 5, declined); attempts != 1 {
+		t.Fatalf("expected classified error without retry, got %d attempts", attempts)
+	}
+	unknown := &flakyMover{errs: []error{errors.New("disk gone")}}
+	if attempts, _ := planner.runMove(PlanStep{VMName: "vm-a"}, 5, unknown); attempts != 1 {
+		t.Fatalf("expected unclassified error without retry, got %d attempts", attempts)
+	}
+	busy := &flakyMover{errs: []error{transientError{retriable: true}, transientError{retriable: true}, transientError{retriable: true}}}
+	attempts, err := planner.runMove(PlanStep{VMName: "vm-a"}, 5, busy)
+	if attempts != 2 || !strings.Contains(err.Error(), "retry budget 2m0s exhausted") || !errors.As(err, &transientError{}) {
+		t.Fatalf("expected retry budget to stop the move, got %d %v", attempts, err)
+	}
+	if IsRetriable(nil) {
+		t.Fatalf("expected nil error not retriable")
+	}
+}
+
+func TestExecutePlanRetriesWithPolicy(t *testing.T) {
+	clock := &windowClock{now: time.Date(2026, 10, 16, 22, 0, 0, 0, time.UTC)}
+	plan := []PlanStep{{Order: 1, VMName: "vm-a", SourceDatastore: "src-1", TargetDatastore: "dst-1"}}
+	mover := &flakyMover{errs: []error{busyError("busy")}}
+	summary := retryPlanner(RetryPolicy{Backoff: 10 * time.Second}, clock).ExecutePlan(plan, true, 3, mover)
+	if summary.MigratedCount != 1 || summary.Results[0].Attempts != 2 || clock.Now().Sub(time.Date(2026, 10, 16, 22, 0, 0, 0, time.UTC)) != 10*time.Second {
+		t.Fatalf("expected one paced retry, got %+v at %s", summary, clock.Now())
+	}
+}
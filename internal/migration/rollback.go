@@ -0,0 +1,101 @@
+// Path: internal/migration/rollback.go
+// Description: Build a reverse plan that returns journaled migrations to their origin datastores.
+package migration
+
+import "slices"
+
+// SkipNoOrigin marks a journaled move whose source datastore was not recorded.
+const SkipNoOrigin = "NO_ORIGIN"
+
+type journaledMove struct {
+	vm     string
+	origin string
+	target string
+}
+
+// Rollback build a plan that returns every VM the journal records as migrated
+// to the datastore it first left, most recent move first. Each step goes
+// through the planner's checks against live inventory with its origin as the
+// only candidate. VMs already back on their origin are dropped, and VMs no
+// longer where the journal left them are skipped as drifted.
+func (p Planner) Rollback(entries []JournalEntry, vms []VM, stores []Datastore) []PlanStep {
+	live := map[string]VM{}
+	for _, vm := range vms {
+		live[vm.Name] = vm
+	}
+	state := copyDatastores(stores)
+	moves := journaledMoves(entries)
+	moving := []VM{}
+	for _, move := range moves {
+		if vm, ok := live[move.vm]; ok {
+			moving = append(moving, vm)
+		}
+	}
+	placed := p.newPlacement(vms, moving)
+	plan := []PlanStep{}
+	for _, move := range moves {
+		vm, ok := live[move.vm]
+		if ok && vm.SourceDatastore == move.origin && move.origin != "" {
+			continue
+		}
+		step := PlanStep{Order: len(plan) + 1, VMName: move.vm, SourceDatastore: move.target, Tier: "-", Rollback: true}
+		switch {
+		case move.origin == "":
+			step.SkipReason = SkipNoOrigin
+		case !ok || vm.SourceDatastore != move.target:
+			step.SkipReason = SkipDrifted
+		default:
+			step = p.planStep(step.Order, vm, rollbackCandidates(state, move), placed)
+			step.Rollback = true
+			if step.SkipReason == "" {
+				applyProjection(state, step.TargetDatastore, vm.footprint(), vm.provisioned())
+			}
+			placed.record(vm, step)
+		}
+		plan = append(plan, step)
+	}
+	return plan
+}
+
+// journaledMoves return each migrated VM's first origin and last target,
+// ordered from the most recently moved VM back to the first. VMs a rollback
+// already returned are left out.
+func journaledMoves(entries []JournalEntry) []journaledMove {
+	moves := map[string]journaledMove{}
+	order := []string{}
+	for _, entry := range entries {
+		if entry.State != ProgressMigrated {
+			continue
+		}
+		order = slices.DeleteFunc(order, func(name string) bool { return name == entry.VMName })
+		if entry.Rollback {
+			delete(moves, entry.VMName)
+			continue
+		}
+		move, seen := moves[entry.VMName]
+		if !seen {
+			move = journaledMove{vm: entry.VMName, origin: entry.SourceDatastore}
+		}
+		move.target = entry.TargetDatastore
+		moves[entry.VMName] = move
+		order = append(order, entry.VMName)
+	}
+	reversed := make([]journaledMove, 0, len(order))
+	for index := len(order) - 1; index >= 0; index-- {
+		reversed = append(reversed, moves[order[index]])
+	}
+	return reversed
+}
+
+// rollbackCandidates return the projected state of a move's origin and its
+// current datastore, so the origin is the only target and tier transitions
+// still see the datastore the VM leaves.
+func rollbackCandidates(state []Datastore, move journaledMove) []Datastore {
+	candidates := []Datastore{}
+	for _, store := range state {
+		if store.Name == move.origin || store.Name == move.target {
+			candidates = append(candidates, store)
+		}
+	}
+	return candidates
+}
@@ -0,0 +1,81 @@
+// Path: internal/migration/rollback_test.go
+// Description: Validate reverse plans built from the journal and their execution-time capacity checks.
+package migration
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRollbackReturnsJournaledMovesToTheirOrigin(t *testing.T) {
+	entries := []JournalEntry{
+		{Order: 1, VMName: "vm-a", SourceDatastore: "src-1", TargetDatastore: "dst-1", State: ProgressMigrated},
+		{Order: 2, VMName: "vm-b", SourceDatastore: "src-2", TargetDatastore: "dst-2", State: ProgressFailed},
+		{Order: 2, VMName: "vm-b", SourceDatastore: "src-2", TargetDatastore: "dst-2", State: ProgressMigrated},
+		{Order: 3, VMName: "vm-c", TargetDatastore: "dst-1", State: ProgressMigrated},
+		{Order: 4, VMName: "vm-d", SourceDatastore: "src-1", TargetDatastore: "dst-1", State: ProgressMigrated},
+		{Order: 5, VMName: "vm-e", SourceDatastore: "src-1", TargetDatastore: "dst-1", State: ProgressMigrated},
+		{Order: 6, VMName: "vm-f", SourceDatastore: "src-3", TargetDatastore: "dst-1", State: ProgressMigrated},
+		{Order: 7, VMName: "vm-f", SourceDatastore: "dst-1", TargetDatastore: "dst-2", State: ProgressMigrated},
+		{Order: 8, VMName: "vm-g", SourceDatastore: "src-4", TargetDatastore: "dst-1", State: ProgressMigrated},
+	}
+	vms := []VM{
+		{Name: "vm-a", SizeGB: 10, SourceDatastore: "dst-1"},
+		{Name: "vm-b", SizeGB: 10, SourceDatastore: "dst-2"},
+		{Name: "vm-c", SizeGB: 10, SourceDatastore: "dst-1"},
+		{Name: "vm-d", SizeGB: 10, SourceDatastore: "dst-2"},
+		{Name: "vm-e", SizeGB: 10, SourceDatastore: "src-1"},
+		{Name: "vm-f", SizeGB: 30, SourceDatastore: "dst-2"},
+		{Name: "vm-g", SizeGB: 10, SourceDatastore: "dst-1"},
+	}
+	stores := []Datastore{
+		{Name: "src-1", CapacityGB: 100, UsedGB: 20},
+		{Name: "src-2", CapacityGB: 100, UsedGB: 80},
+		{Name: "src-3", CapacityGB: 100},
+		{Name: "dst-1", CapacityGB: 100, UsedGB: 50},
+		{Name: "dst-2", CapacityGB: 100, UsedGB: 50},
+	}
+	plan := NewPlanner(85).Rollback(entries, vms, stores)
+	want := []struct {
+		vm     string
+		source string
+		target string
+		skip   string
+	}{
+		{"vm-g", "dst-1", "", SkipNoEligibleTarget},
+		{"vm-f", "dst-2", "src-3", ""},
+		{"vm-d", "dst-1", "", SkipDrifted},
+		{"vm-c", "dst-1", "", SkipNoOrigin},
+		{"vm-b", "dst-2", "", SkipOverThreshold},
+		{"vm-a", "dst-1", "src-1", ""},
+	}
+	if len(plan) != len(want) {
+		t.Fatalf("expected %d rollback steps, got %+v", len(want), plan)
+	}
+	for index, expected := range want {
+		step := plan[index]
+		if step.Order != index+1 || step.VMName != expected.vm || step.SourceDatastore != expected.source ||
+			step.TargetDatastore != expected.target || step.SkipReason != expected.skip || !step.Rollback {
+			t.Fatalf("unexpected rollback step %d: %+v", index, step)
+		}
+	}
+	if plan[5].ProjectedUtil != 30 {
+		t.Fatalf("expected origin capacity re-validated, got %+v", plan[5])
+	}
+}
+
+func TestExecutePlanDriftsRollbackStepsInsteadOfReplanning(t *testing.T) {
+	plan := []PlanStep{{Order: 1, VMName: "vm-a", SourceDatastore: "dst-1", TargetDatastore: "src-1", SizeGB: 10, Rollback: true}}
+	inventory := staticInventory(
+		[]VM{{Name: "vm-a", SizeGB: 10, SourceDatastore: "dst-1"}},
+		[]Datastore{{Name: "src-1", CapacityGB: 100, UsedGB: 95}, {Name: "dst-2", CapacityGB: 100}},
+	)
+	events := []ProgressEvent{}
+	summary := NewPlanner(85).
+		WithInventory(inventory).
+		WithProgress(func(event ProgressEvent) { events = append(events, event) }).
+		ExecutePlan(plan, true, 1, &targetMover{moves: map[string]string{}})
+	if summary.DriftedCount != 1 || summary.MigratedCount != 0 || !errors.Is(events[0].Err, ErrStepDrifted) {
+		t.Fatalf("expected rollback step drifted when its origin filled, got %+v %+v", summary, events)
+	}
+}
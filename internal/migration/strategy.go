@@ -0,0 +1,173 @@
+// Path: internal/migration/strategy.go
+// Description: Select placement strategies for migration planning and score the resulting plans.
+package migration
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// Strategy names how the planner orders VMs and picks their targets.
+type Strategy string
+
+const (
+	// StrategyGreedy places VMs in input order on the least-utilized target.
+	StrategyGreedy Strategy = "greedy"
+	// StrategyFirstFitDecreasing places the largest VMs first on the first target that fits.
+	StrategyFirstFitDecreasing Strategy = "first-fit-decreasing"
+	// StrategyBestFit places the largest VMs first on the fullest target that still fits.
+	StrategyBestFit Strategy = "best-fit"
+	// StrategyBalance places the largest VMs first where utilization variance stays lowest.
+	StrategyBalance Strategy = "balance"
+)
+
+// ErrUnknownStrategy indicates a strategy name the planner does not support.
+var ErrUnknownStrategy = errors.New("unknown placement strategy")
+
+// Strategies list the supported placement strategies.
+func Strategies() []Strategy {
+	return []Strategy{StrategyGreedy, StrategyFirstFitDecreasing, StrategyBestFit, StrategyBalance}
+}
+
+// ParseStrategy resolve a strategy name, accepting ffd as first-fit-decreasing.
+func ParseStrategy(name string) (Strategy, error) {
+	normalized := Strategy(strings.ToLower(strings.TrimSpace(name)))
+	if normalized == "ffd" {
+		return StrategyFirstFitDecreasing, nil
+	}
+	if slices.Contains(Strategies(), normalized) {
+		return normalized, nil
+	}
+	return "", fmt.Errorf("%w: %s", ErrUnknownStrategy, name)
+}
+
+// WithStrategy return a planner that places VMs with the given strategy.
+func (p Planner) WithStrategy(strategy Strategy) Planner {
+	p.strategy = strategy
+	return p
+}
+
+// PlanScore summarizes how well a plan places its VMs so strategies can be
+// compared on the same input. Score is the percentage of requested GB placed
+// minus the standard deviation of projected datastore utilization; higher is better.
+type PlanScore struct {
+	Strategy   Strategy
+	Placed     int
+	Skipped    int
+	PlacedGB   int
+	SkippedGB  int
+	MaxUtil    int
+	UtilStdDev float64
+	Score      float64
+}
+
+// Score rate a plan against the datastores it was built over.
+func (p Planner) Score(plan []PlanStep, stores []Datastore) PlanScore {
+	score := PlanScore{Strategy: p.strategy}
+	state := copyDatastores(stores)
+	for _, step := range plan {
+		if step.SkipReason != "" {
+			score.Skipped++
+			score.SkippedGB += step.SizeGB
+			continue
+		}
+		score.Placed++
+		score.PlacedGB += step.SizeGB
+		committed, provisioned := stepLoad(step)
+		applyProjection(state, step.TargetDatastore, committed, provisioned)
+	}
+	for _, store := range state {
+		score.MaxUtil = max(score.MaxUtil, projectedUtil(store, 0))
+	}
+	score.UtilStdDev = math.Sqrt(utilVariance(state))
+	score.Score = 100 - score.UtilStdDev
+	if requested := score.PlacedGB + score.SkippedGB; requested > 0 {
+		score.Score = float64(score.PlacedGB*100)/float64(requested) - score.UtilStdDev
+	}
+	return score
+}
+
+func (p Planner) placementOrder(vms []VM) []VM {
+	switch p.strategy {
+	case StrategyFirstFitDecreasing, StrategyBestFit, StrategyBalance:
+	default:
+		return vms
+	}
+	ordered := slices.Clone(vms)
+	sort.SliceStable(ordered, func(i int, j int) bool {
+		return ordered[i].footprint() > ordered[j].footprint()
+	})
+	return ordered
+}
+
+// chooseTarget pick the target for a VM among those that stay under the
+// committed and provisioned thresholds.
+func (p Planner) chooseTarget(vm VM, targets []Datastore, state []Datastore) (Datastore, bool) {
+	committed, provisioned := vm.footprint(), vm.provisioned()
+	fitting := make([]Datastore, 0, len(targets))
+	for _, target := range targets {
+		if p.fits(target, committed, provisioned) {
+			fitting = append(fitting, target)
+		}
+	}
+	if len(fitting) == 0 {
+		return Datastore{}, false
+	}
+	switch p.strategy {
+	case StrategyFirstFitDecreasing:
+		sortByRank(fitting, func(Datastore) float64 { return 0 })
+	case StrategyBestFit:
+		sortByRank(fitting, func(target Datastore) float64 { return -float64(projectedUtil(target, committed)) })
+	case StrategyBalance:
+		sortByRank(fitting, func(target Datastore) float64 {
+			projected := copyDatastores(state)
+			applyProjection(projected, target.Name, committed, provisioned)
+			return utilVariance(projected)
+		})
+	default:
+		sortTargets(fitting)
+	}
+	return fitting[0], true
+}
+
+// sortByRank order targets by ascending rank, then tier, then name.
+func sortByRank(targets []Datastore, rank func(Datastore) float64) {
+	ranks := map[string]float64{}
+	for _, target := range targets {
+		ranks[target.Name] = rank(target)
+	}
+	sort.Slice(targets, func(i int, j int) bool {
+		if ranks[targets[i].Name] != ranks[targets[j].Name] {
+			return ranks[targets[i].Name] < ranks[targets[j].Name]
+		}
+		if targets[i].Tier != targets[j].Tier {
+			return targets[i].Tier < targets[j].Tier
+		}
+		return targets[i].Name < targets[j].Name
+	})
+}
+
+func utilVariance(stores []Datastore) float64 {
+	if len(stores) == 0 {
+		return 0
+	}
+	utils := make([]float64, len(stores))
+	mean := 0.0
+	for index, store := range stores {
+		utils[index] = 100
+		if store.CapacityGB > 0 {
+			utils[index] = float64(store.UsedGB*100) / float64(store.CapacityGB)
+		}
+		mean += utils[index]
+	}
+	mean /= float64(len(utils))
+	variance := 0.0
+	for _, util := range utils {
+		variance += (util - mean) * (util - mean)
+	}
+	return variance / float64(len(utils))
+}
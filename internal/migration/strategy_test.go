@@ -0,0 +1,80 @@
+// Path: internal/migration/strategy_test.go
+// Description: Validate placement strategies, strategy parsing, and plan quality scores.
+package migration
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func targetsOf(plan []PlanStep) map[string]string {
+	targets := map[string]string{}
+	for _, step := range plan {
+		targets[step.VMName] = step.TargetDatastore + step.SkipReason
+	}
+	return targets
+}
+
+func TestDecreasingStrategiesPlaceWhatGreedyLeavesOverThreshold(t *testing.T) {
+	vms := []VM{{Name: "small-1", SizeGB: 40, SourceDatastore: "src"}, {Name: "small-2", SizeGB: 40, SourceDatastore: "src"}, {Name: "big", SizeGB: 80, SourceDatastore: "src"}}
+	stores := []Datastore{{Name: "a", CapacityGB: 100}, {Name: "b", CapacityGB: 100}}
+	greedy := NewPlanner(85)
+	plan := greedy.BuildPlan(vms, stores)
+	if plan[2].SkipReason != SkipOverThreshold {
+		t.Fatalf("expected greedy to strand the big VM, got %+v", plan)
+	}
+	score := greedy.Score(plan, stores)
+	if score.Strategy != StrategyGreedy || score.Placed != 2 || score.Skipped != 1 || score.PlacedGB != 80 || score.SkippedGB != 80 ||
+		score.MaxUtil != 40 || score.UtilStdDev != 0 || score.Score != 50 {
+		t.Fatalf("unexpected greedy score: %+v", score)
+	}
+	for _, strategy := range []Strategy{StrategyFirstFitDecreasing, StrategyBestFit, StrategyBalance} {
+		planner := NewPlanner(85).WithStrategy(strategy)
+		plan := planner.BuildPlan(vms, stores)
+		if got := targetsOf(plan); got["big"] != "a" || got["small-1"] != "b" || got["small-2"] != "b" || plan[0].VMName != "big" || plan[0].Order != 1 {
+			t.Fatalf("%s: expected big VM placed first, got %+v", strategy, plan)
+		}
+		if score := planner.Score(plan, stores); score.Placed != 3 || score.Score != 100 || score.MaxUtil != 80 {
+			t.Fatalf("%s: expected full placement to outscore greedy, got %+v", strategy, score)
+		}
+	}
+}
+
+func TestStrategiesDifferOnTargetChoice(t *testing.T) {
+	vms := []VM{{Name: "vm-1", SizeGB: 30, SourceDatastore: "src"}, {Name: "vm-2", SizeGB: 30, SourceDatastore: "src"}}
+	stores := []Datastore{{Name: "c", CapacityGB: 100}, {Name: "b", CapacityGB: 100}, {Name: "a", CapacityGB: 100}}
+	cases := map[Strategy]string{StrategyFirstFitDecreasing: "a", StrategyBestFit: "a", StrategyBalance: "b"}
+	for strategy, want := range cases {
+		plan := NewPlanner(85).WithStrategy(strategy).BuildPlan(vms, stores)
+		if got := targetsOf(plan); got["vm-1"] != "a" || got["vm-2"] != want {
+			t.Fatalf("%s: expected vm-2 on %s, got %v", strategy, want, got)
+		}
+	}
+	tiered := []Datastore{{Name: "a", CapacityGB: 100, Tier: TierSecondary}, {Name: "z", CapacityGB: 100, Tier: TierPrimary}}
+	if plan := NewPlanner(85).WithStrategy(StrategyFirstFitDecreasing).BuildPlan(vms[:1], tiered); plan[0].TargetDatastore != "z" {
+		t.Fatalf("expected first fit to prefer the primary tier, got %+v", plan[0])
+	}
+	balanced := NewPlanner(85).WithStrategy(StrategyBalance)
+	stacked := NewPlanner(85).WithStrategy(StrategyBestFit)
+	if balanced.Score(balanced.BuildPlan(vms, stores), stores).Score <= stacked.Score(stacked.BuildPlan(vms, stores), stores).Score {
+		t.Fatalf("expected balance to score above best-fit on spread")
+	}
+	if score := balanced.Score(nil, nil); score.Score != 100 || math.IsNaN(score.UtilStdDev) {
+		t.Fatalf("expected empty plan to score 100, got %+v", score)
+	}
+	if score := balanced.Score(nil, []Datastore{{Name: "empty"}, {Name: "a", CapacityGB: 100}}); score.MaxUtil != 100 || score.UtilStdDev != 50 {
+		t.Fatalf("expected zero-capacity datastore treated as full, got %+v", score)
+	}
+}
+
+func TestParseStrategy(t *testing.T) {
+	for name, want := range map[string]Strategy{"greedy": StrategyGreedy, " FFD ": StrategyFirstFitDecreasing, "best-fit": StrategyBestFit, "Balance": StrategyBalance} {
+		if got, err := ParseStrategy(name); err != nil || got != want {
+			t.Fatalf("expected %q to parse as %s, got %s err=%v", name, want, got, err)
+		}
+	}
+	if _, err := ParseStrategy("random"); !errors.Is(err, ErrUnknownStrategy) {
+		t.Fatalf("expected unknown strategy error, got %v", err)
+	}
+}
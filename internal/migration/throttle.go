@@ -0,0 +1,78 @@
+// Path: internal/migration/throttle.go
+// Description: Hold new moves while source or target datastore latency runs over a threshold.
+package migration
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrThrottled indicates a move held back because datastore latency is too high.
+var ErrThrottled = errors.New("migration throttled")
+
+const defaultBackoff = 30 * time.Second
+
+// Throttle holds new moves that touch a datastore whose live latency is over
+// LatencyMS, waiting Backoff (default 30s) between re-checks when nothing
+// else can start. MaxWait bounds one uninterrupted back-off before the held
+// steps fail; zero waits indefinitely. A zero LatencyMS disables throttling.
+type Throttle struct {
+	LatencyMS int
+	Backoff   time.Duration
+	MaxWait   time.Duration
+}
+
+// WithThrottle return a planner that throttles moves on datastore latency
+// read from its live inventory.
+func (p Planner) WithThrottle(throttle Throttle) Planner {
+	p.throttle = throttle
+	return p
+}
+
+// throttled report whether a step's source or target datastore is over the
+// latency threshold, logging the first hold of each step.
+func (e *executor) throttled(index int) bool {
+	if e.planner.throttle.LatencyMS <= 0 || e.live == nil {
+		return false
+	}
+	step := e.plan[index]
+	for _, store := range e.live.stores {
+		if store.Name != step.SourceDatastore && store.Name != step.TargetDatastore || store.LatencyMS <= e.planner.throttle.LatencyMS {
+			continue
+		}
+		if !e.held[index] {
+			e.held[index] = true
+			e.summary.ThrottledCount++
+			e.report(ProgressEvent{Step: step, State: ProgressThrottled, Err: fmt.Errorf(
+				"%w: datastore %s latency %dms over %dms", ErrThrottled, store.Name, store.LatencyMS, e.planner.throttle.LatencyMS)})
+		}
+		return true
+	}
+	return false
+}
+
+// backoff wait for latency to drop when every pending step is throttled and
+// nothing is running, failing them once the wait exceeds MaxWait.
+func (e *executor) backoff(pending []int) []int {
+	if len(pending) == 0 {
+		return pending
+	}
+	limit := e.planner.throttle.MaxWait
+	if limit > 0 && e.backedOff >= limit {
+		for _, index := range pending {
+			e.summary.FailedCount++
+			e.finish(index, ProgressEvent{Step: e.plan[index], State: ProgressFailed, Err: fmt.Errorf(
+				"%w: latency stayed over %dms for %s", ErrThrottled, e.planner.throttle.LatencyMS, e.backedOff)})
+		}
+		return nil
+	}
+	interval := e.planner.throttle.Backoff
+	if interval <= 0 {
+		interval = defaultBackoff
+	}
+	e.planner.sleep(interval)
+	e.backedOff += interval
+	e.summary.Throttled += interval
+	return pending
+}
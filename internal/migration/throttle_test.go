@@ -0,0 +1,74 @@
+// Path: internal/migration/throttle_test.go
+// Description: Validate latency throttling holds, back-off, resume, and give-up behavior.
+package migration
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func latencyInventory(hot func(load int) int) InventoryFunc {
+	loads := 0
+	vms := []VM{
+		{Name: "vm-a", SizeGB: 10, SourceDatastore: "src-1"},
+		{Name: "vm-b", SizeGB: 10, SourceDatastore: "src-2"},
+	}
+	return InventoryFunc(func() ([]VM, []Datastore, error) {
+		loads++
+		return vms, []Datastore{
+			{Name: "hot", CapacityGB: 100, LatencyMS: hot(loads)},
+			{Name: "cool", CapacityGB: 100, LatencyMS: 2},
+			{Name: "src-1", CapacityGB: 100, LatencyMS: 2},
+			{Name: "src-2", CapacityGB: 100, LatencyMS: 2},
+		}, nil
+	})
+}
+
+func TestExecutePlanThrottlesMovesOnDatastoreLatency(t *testing.T) {
+	plan := []PlanStep{
+		{Order: 1, VMName: "vm-a", SourceDatastore: "src-1", TargetDatastore: "hot", SizeGB: 10},
+		{Order: 2, VMName: "vm-b", SourceDatastore: "src-2", TargetDatastore: "cool", SizeGB: 10},
+	}
+	events := []ProgressEvent{}
+	cooling := func(load int) int {
+		if load <= 2 {
+			return 50
+		}
+		return 5
+	}
+	planner := NewPlanner(85).
+		WithLimits(Limits{Global: 2}).
+		WithThrottle(Throttle{LatencyMS: 20, Backoff: time.Second}).
+		WithInventory(latencyInventory(cooling)).
+		WithProgress(func(event ProgressEvent) { events = append(events, event) })
+	slept := time.Duration(0)
+	planner.sleep = func(d time.Duration) { slept += d }
+	summary := planner.ExecutePlan(plan, true, 1, newConcurrentMover(func(string, string) []string { return nil }))
+	if summary.MigratedCount != 2 || summary.ThrottledCount != 1 || summary.Throttled != time.Second || slept != time.Second {
+		t.Fatalf("expected one throttled step resumed after one back-off, got %+v", summary)
+	}
+	if events[0].State != ProgressThrottled || events[0].Step.VMName != "vm-a" || !errors.Is(events[0].Err, ErrThrottled) ||
+		events[1].Step.VMName != "vm-b" || events[len(events)-1].Step.VMName != "vm-a" {
+		t.Fatalf("expected vm-b to run while vm-a was held, got %+v", events)
+	}
+}
+
+func TestExecutePlanFailsStepsThrottledPastMaxWait(t *testing.T) {
+	plan := []PlanStep{{Order: 1, VMName: "vm-a", SourceDatastore: "src-1", TargetDatastore: "hot", SizeGB: 10}}
+	events := []ProgressEvent{}
+	planner := NewPlanner(85).
+		WithThrottle(Throttle{LatencyMS: 20, MaxWait: time.Minute}).
+		WithInventory(latencyInventory(func(int) int { return 50 })).
+		WithProgress(func(event ProgressEvent) { events = append(events, event) })
+	planner.sleep = func(time.Duration) {}
+	summary := planner.ExecutePlan(plan, true, 1, newConcurrentMover(func(string, string) []string { return nil }))
+	if summary.FailedCount != 1 || summary.Throttled != 2*defaultBackoff || events[len(events)-1].State != ProgressFailed ||
+		!errors.Is(events[len(events)-1].Err, ErrThrottled) {
+		t.Fatalf("expected throttled step to fail after max wait, got %+v %+v", summary, events)
+	}
+	unchecked := NewPlanner(85).WithThrottle(Throttle{LatencyMS: 20}).ExecutePlan(plan, true, 1, newConcurrentMover(func(string, string) []string { return nil }))
+	if unchecked.MigratedCount != 1 || unchecked.ThrottledCount != 0 {
+		t.Fatalf("expected no throttling without live inventory, got %+v", unchecked)
+	}
+}
@@ -0,0 +1,159 @@
+// Path: internal/migration/tier.go
+// Description: Enforce tier placement rules, allowed tier transitions, and per-tier thresholds.
+package migration
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+)
+
+// SkipTierPolicy marks a step whose every target is excluded by tier rules.
+const SkipTierPolicy = "TIER_POLICY"
+
+// ErrUnknownTier indicates a datastore without a tier while tier rules are set.
+var ErrUnknownTier = errors.New("datastore tier unknown")
+
+// TierPolicy restricts which datastore tiers a VM may land on.
+type TierPolicy struct {
+	// TagTiers maps a VM tag to the tiers such VMs may land on; a VM matching
+	// several tags must satisfy each of them.
+	TagTiers map[string][]Tier `json:"tag_tiers"`
+	// Transitions maps a source tier to the target tiers its VMs may move to;
+	// source tiers without an entry may move anywhere.
+	Transitions map[Tier][]Tier `json:"transitions"`
+	// Thresholds overrides the planner utilization threshold per target tier.
+	Thresholds map[Tier]int `json:"thresholds"`
+	// Datastores assigns tiers by datastore name to datastores without a tier
+	// tag; the first matching pattern wins.
+	Datastores []DatastoreTier `json:"datastores"`
+}
+
+// DatastoreTier assigns Tier to datastores whose name matches the Pattern glob.
+type DatastoreTier struct {
+	Pattern string `json:"pattern"`
+	Tier    Tier   `json:"tier"`
+}
+
+func knownTier(tier Tier) bool {
+	return tier == TierPrimary || tier == TierSecondary || tier == TierTertiary
+}
+
+func (t TierPolicy) validate() error {
+	tiers := []Tier{}
+	for _, allowed := range t.TagTiers {
+		tiers = append(tiers, allowed...)
+	}
+	for source, allowed := range t.Transitions {
+		tiers = append(append(tiers, source), allowed...)
+	}
+	for tier, threshold := range t.Thresholds {
+		if threshold < 1 || threshold > 100 {
+			return fmt.Errorf("tier %s threshold %d outside 1-100", tier, threshold)
+		}
+		tiers = append(tiers, tier)
+	}
+	for _, store := range t.Datastores {
+		if _, err := path.Match(store.Pattern, ""); err != nil || store.Pattern == "" {
+			return fmt.Errorf("datastore tier pattern %q is not a valid glob", store.Pattern)
+		}
+		tiers = append(tiers, store.Tier)
+	}
+	for _, tier := range tiers {
+		if !knownTier(tier) {
+			return fmt.Errorf("unknown tier %q", tier)
+		}
+	}
+	return nil
+}
+
+// Inventory return an inventory that resolves datastore tiers on every load.
+func (t TierPolicy) Inventory(inventory Inventory) Inventory {
+	return InventoryFunc(func() ([]VM, []Datastore, error) {
+		vms, stores, err := inventory.Load()
+		if err != nil {
+			return nil, nil, err
+		}
+		stores, err = t.Resolve(stores)
+		if err != nil {
+			return nil, nil, err
+		}
+		return vms, stores, nil
+	})
+}
+
+// Resolve fill in the tier of datastores without a tier tag from the
+// datastores patterns. A datastore left without a tier is an error while tier
+// rules are set, since they could not be enforced on it, and primary otherwise.
+func (t TierPolicy) Resolve(stores []Datastore) ([]Datastore, error) {
+	resolved := slices.Clone(stores)
+	for index := range resolved {
+		store := &resolved[index]
+		if store.Tier == "" {
+			store.Tier = t.datastoreTier(store.Name)
+		}
+		if store.Tier != "" {
+			continue
+		}
+		if len(t.TagTiers) > 0 || len(t.Transitions) > 0 || len(t.Thresholds) > 0 {
+			return nil, fmt.Errorf("%w: %s has no tier tag and matches no tiers.datastores pattern", ErrUnknownTier, store.Name)
+		}
+		store.Tier = TierPrimary
+	}
+	return resolved, nil
+}
+
+func (t TierPolicy) datastoreTier(name string) Tier {
+	for _, store := range t.Datastores {
+		if matched, _ := path.Match(store.Pattern, name); matched {
+			return store.Tier
+		}
+	}
+	return ""
+}
+
+// allows report whether a VM on the source tier may land on the target tier.
+func (t TierPolicy) allows(vm VM, source Tier, target Tier) bool {
+	if allowed, ok := t.Transitions[source]; source != "" && ok && !slices.Contains(allowed, target) {
+		return false
+	}
+	for tag, allowed := range t.TagTiers {
+		if hasTag(vm.Tags, tag) && !slices.Contains(allowed, target) {
+			return false
+		}
+	}
+	return true
+}
+
+// threshold return the utilization threshold for a target tier.
+func (p Planner) threshold(tier Tier) int {
+	if threshold, ok := p.tiers.Thresholds[tier]; ok {
+		return threshold
+	}
+	return p.thresholdPercent
+}
+
+// tierEligible filter targets to those the tier policy allows for the VM.
+func (p Planner) tierEligible(vm VM, targets []Datastore, state []Datastore) []Datastore {
+	source := Tier("")
+	for _, store := range state {
+		if store.Name == vm.SourceDatastore {
+			source = store.Tier
+		}
+	}
+	eligible := make([]Datastore, 0, len(targets))
+	for _, target := range targets {
+		if p.tiers.allows(vm, source, target.Tier) {
+			eligible = append(eligible, target)
+		}
+	}
+	return eligible
+}
+
+// ParseTier resolve a tier from a datastore tag such as primary or tier=secondary.
+func ParseTier(tag string) (Tier, bool) {
+	tier := Tier(strings.TrimPrefix(strings.ToLower(strings.TrimSpace(tag)), "tier="))
+	return tier, knownTier(tier)
+}
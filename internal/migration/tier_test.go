@@ -0,0 +1,128 @@
+// Path: internal/migration/tier_test.go
+// Description: Validate tier placement rules, transitions, per-tier thresholds, datastore tier resolution, and policy loading.
+package migration
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestTierPolicyRestrictsTaggedVMsAndTransitions(t *testing.T) {
+	policy := Policy{Tiers: TierPolicy{
+		TagTiers:    map[string][]Tier{"gold": {TierPrimary}},
+		Transitions: map[Tier][]Tier{TierTertiary: {TierTertiary, TierSecondary}},
+	}}
+	planner := NewPlanner(85).WithPolicy(policy)
+	stores := []Datastore{
+		{Name: "src-primary", CapacityGB: 100, UsedGB: 90, Tier: TierPrimary},
+		{Name: "src-tertiary", CapacityGB: 100, UsedGB: 90, Tier: TierTertiary},
+		{Name: "fast", CapacityGB: 100, UsedGB: 50, Tier: TierPrimary},
+		{Name: "bulk", CapacityGB: 100, UsedGB: 10, Tier: TierSecondary},
+	}
+	vms := []VM{
+		{Name: "vm-gold", SizeGB: 10, SourceDatastore: "src-primary", Tags: []string{"GOLD"}},
+		{Name: "vm-plain", SizeGB: 10, SourceDatastore: "src-primary"},
+		{Name: "vm-archive", SizeGB: 10, SourceDatastore: "src-tertiary"},
+		{Name: "vm-stuck", SizeGB: 10, SourceDatastore: "src-tertiary", Tags: []string{"gold"}},
+		{Name: "vm-unknown", SizeGB: 10, SourceDatastore: "elsewhere"},
+	}
+	plan := planner.BuildPlan(vms, stores)
+	want := map[string]string{"vm-gold": "fast", "vm-plain": "bulk", "vm-archive": "bulk", "vm-stuck": SkipTierPolicy, "vm-unknown": "bulk"}
+	for vm, target := range want {
+		if got := targetsOf(plan)[vm]; got != target {
+			t.Fatalf("expected %s on %s, got %s (%+v)", vm, target, got, plan)
+		}
+	}
+	if plan[3].Tier != "-" {
+		t.Fatalf("expected tier placeholder on tier policy skip, got %+v", plan[3])
+	}
+}
+
+func TestTierThresholdsOverridePlannerThreshold(t *testing.T) {
+	vms := []VM{{Name: "vm-a", SizeGB: 10, SourceDatastore: "src"}}
+	stores := []Datastore{{Name: "cold", CapacityGB: 100, UsedGB: 82, Tier: TierTertiary}}
+	if plan := NewPlanner(85).BuildPlan(vms, stores); plan[0].SkipReason != SkipOverThreshold {
+		t.Fatalf("expected default threshold to skip, got %+v", plan[0])
+	}
+	planner := NewPlanner(85).WithPolicy(Policy{Tiers: TierPolicy{Thresholds: map[Tier]int{TierTertiary: 95}}})
+	if plan := planner.BuildPlan(vms, stores); plan[0].TargetDatastore != "cold" || plan[0].ProjectedUtil != 92 {
+		t.Fatalf("expected tertiary threshold to admit the move, got %+v", plan[0])
+	}
+}
+
+func TestTierInventoryResolvesDatastoreTiers(t *testing.T) {
+	stores := []Datastore{{Name: "gold-01"}, {Name: "nl-archive"}, {Name: "tagged", Tier: TierTertiary}, {Name: "other"}}
+	source := InventoryFunc(func() ([]VM, []Datastore, error) { return nil, stores, nil })
+	patterns := TierPolicy{Datastores: []DatastoreTier{{Pattern: "gold-*", Tier: TierPrimary}, {Pattern: "*archive", Tier: TierSecondary}}}
+	_, resolved, err := patterns.Inventory(source).Load()
+	if err != nil || resolved[0].Tier != TierPrimary || resolved[1].Tier != TierSecondary || resolved[2].Tier != TierTertiary || resolved[3].Tier != TierPrimary {
+		t.Fatalf("expected pattern, tag, and default tiers, got %+v err=%v", resolved, err)
+	}
+	if stores[0].Tier != "" {
+		t.Fatalf("expected loaded datastores left unchanged, got %+v", stores)
+	}
+	patterns.Thresholds = map[Tier]int{TierSecondary: 90}
+	if _, _, err := patterns.Inventory(source).Load(); !errors.Is(err, ErrUnknownTier) {
+		t.Fatalf("expected unknown tier rejected under tier rules, got %v", err)
+	}
+	failure := errors.New("load failed")
+	failing := InventoryFunc(func() ([]VM, []Datastore, error) { return nil, nil, failure })
+	if _, _, err := patterns.Inventory(failing).Load(); !errors.Is(err, failure) {
+		t.Fatalf("expected load failure returned, got %v", err)
+	}
+}
+
+func TestParsePolicyValidatesTiers(t *testing.T) {
+	policy, err := ParsePolicy([]byte(`{"tiers":{"tag_tiers":{"gold":["primary"]},"transitions":{"primary":["secondary"]},"thresholds":{"tertiary":90}}}`))
+	if err != nil || policy.Tiers.Thresholds[TierTertiary] != 90 || policy.Tiers.TagTiers["gold"][0] != TierPrimary {
+		t.Fatalf("unexpected policy %+v err=%v", policy, err)
+	}
+	if policy, err := ParsePolicy([]byte("  ")); err != nil || policy.Tiers.TagTiers != nil {
+		t.Fatalf("expected empty policy for blank content, got %+v err=%v", policy, err)
+	}
+	for _, content := range []string{
+		`not json`,
+		`{"tiers":{"tag_tiers":{"gold":["platinum"]}}}`,
+		`{"tiers":{"transitions":{"gold":["primary"]}}}`,
+		`{"tiers":{"thresholds":{"primary":120}}}`,
+		`{"tiers":{"thresholds":{"gold":80}}}`,
+		`{"tiers":{"datastores":[{"pattern":"[","tier":"primary"}]}}`,
+		`{"tiers":{"datastores":[{"pattern":"","tier":"primary"}]}}`,
+		`{"tiers":{"datastores":[{"pattern":"gold-*","tier":"gold"}]}}`,
+	} {
+		if _, err := ParsePolicy([]byte(content)); !errors.Is(err, ErrInvalidPolicy) {
+			t.Fatalf("expected invalid policy for %s, got %v", content, err)
+		}
+	}
+}
+
+func TestLoadPolicyReadsFiles(t *testing.T) {
+	dir := t.TempDir()
+	if policy, err := LoadPolicy(""); err != nil || policy.Tiers.Thresholds != nil {
+		t.Fatalf("expected empty policy for empty path, got %+v err=%v", policy, err)
+	}
+	if _, err := LoadPolicy(filepath.Join(dir, "missing.json")); err != nil {
+		t.Fatalf("expected missing policy file to be ignored, got %v", err)
+	}
+	if _, err := LoadPolicy(dir); err == nil {
+		t.Fatalf("expected read failure for a directory")
+	}
+	path := filepath.Join(dir, "migration.json")
+	_ = os.WriteFile(path, []byte(`{"tiers":{"thresholds":{"secondary":75}}}`), 0o600)
+	if policy, err := LoadPolicy(path); err != nil || policy.Tiers.Thresholds[TierSecondary] != 75 {
+		t.Fatalf("expected policy from file, got %+v err=%v", policy, err)
+	}
+}
+
+func TestParseTierAcceptsPlainAndPrefixedTags(t *testing.T) {
+	for tag, want := range map[string]Tier{"primary": TierPrimary, " Tier=Secondary ": TierSecondary, "tertiary": TierTertiary} {
+		if got, ok := ParseTier(tag); !ok || got != want {
+			t.Fatalf("expected %q to parse as %s, got %s", tag, want, got)
+		}
+	}
+	if _, ok := ParseTier("flash"); ok {
+		t.Fatalf("expected non-tier tag to be rejected")
+	}
+}
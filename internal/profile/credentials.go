@@ -0,0 +1,152 @@
+// Path: internal/profile/credentials.go
+// Description: Resolve endpoint passwords from environment variables or an AES-GCM encrypted file.
+package profile
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+)
+
+const (
+	// PasswordEnvPrefix prefixes per-profile password environment variables.
+	PasswordEnvPrefix = "HYPERSPHERE_PASSWORD_"
+	keySize           = 32
+)
+
+var (
+	// ErrCredentialNotFound indicates no password is configured for a profile.
+	ErrCredentialNotFound = errors.New("endpoint credential not found")
+	// ErrCredentialKey indicates a missing or malformed credential encryption key.
+	ErrCredentialKey = errors.New("invalid credential key")
+	// ErrCredentialFile indicates a credential file that cannot be decoded or decrypted.
+	ErrCredentialFile = errors.New("invalid credential file")
+)
+
+// Credentials resolve profile passwords from the environment first, then the encrypted file.
+type Credentials struct {
+	Getenv func(string) string
+	Path   string
+	Key    string
+}
+
+type sealedFile struct {
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// PasswordEnvName return the environment variable that supplies a profile password.
+func PasswordEnvName(name string) string {
+	normalized := strings.Map(func(value rune) rune {
+		if value < unicode.MaxASCII && (unicode.IsLetter(value) || unicode.IsDigit(value)) {
+			return unicode.ToUpper(value)
+		}
+		return '_'
+	}, strings.TrimSpace(name))
+	return PasswordEnvPrefix + normalized
+}
+
+// Password return the password configured for a profile name.
+func (c Credentials) Password(name string) (string, error) {
+	if c.Getenv != nil {
+		if password := c.Getenv(PasswordEnvName(name)); password != "" {
+			return password, nil
+		}
+	}
+	if strings.TrimSpace(c.Path) == "" {
+		return "", fmt.Errorf("%w: %s", ErrCredentialNotFound, name)
+	}
+	content, err := os.ReadFile(c.Path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", fmt.Errorf("%w: %s", ErrCredentialNotFound, name)
+		}
+		return "", err
+	}
+	passwords, err := Open(content, c.Key)
+	if err != nil {
+		return "", err
+	}
+	password, ok := passwords[name]
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrCredentialNotFound, name)
+	}
+	return password, nil
+}
+
+// Store seal the password for a profile into the credential file, keeping
+// the passwords already stored there. The file is created when absent.
+func (c Credentials) Store(name string, password string) error {
+	passwords := map[string]string{}
+	content, err := os.ReadFile(c.Path)
+	switch {
+	case err == nil:
+		if passwords, err = Open(content, c.Key); err != nil {
+			return err
+		}
+	case !os.IsNotExist(err):
+		return err
+	}
+	passwords[strings.TrimSpace(name)] = password
+	sealed, err := Seal(passwords, c.Key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(c.Path), 0o700); err != nil {
+		return err
+	}
+	return os.WriteFile(c.Path, sealed, 0o600)
+}
+
+// Seal encrypt profile passwords with AES-256-GCM under a base64 key.
+func Seal(passwords map[string]string, key string) ([]byte, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	plaintext, _ := json.Marshal(passwords)
+	nonce := make([]byte, aead.NonceSize())
+	_, _ = rand.Read(nonce)
+	return json.Marshal(sealedFile{Nonce: nonce, Ciphertext: aead.Seal(nil, nonce, plaintext, nil)})
+}
+
+// Open decrypt profile passwords sealed by Seal under the same key.
+func Open(content []byte, key string) (map[string]string, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	sealed := sealedFile{}
+	if err := json.Unmarshal(content, &sealed); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrCredentialFile, err)
+	}
+	if len(sealed.Nonce) != aead.NonceSize() {
+		return nil, fmt.Errorf("%w: nonce must be %d bytes", ErrCredentialFile, aead.NonceSize())
+	}
+	plaintext, err := aead.Open(nil, sealed.Nonce, sealed.Ciphertext, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: decryption failed", ErrCredentialFile)
+	}
+	passwords := map[string]string{}
+	if err := json.Unmarshal(plaintext, &passwords); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrCredentialFile, err)
+	}
+	return passwords, nil
+}
+
+func newAEAD(key string) (cipher.AEAD, error) {
+	raw, err := base64.StdEncoding.DecodeString(strings.TrimSpace(key))
+	if err != nil || len(raw) != keySize {
+		return nil, fmt.Errorf("%w: expected %d base64-encoded bytes", ErrCredentialKey, keySize)
+	}
+	block, _ := aes.NewCipher(raw)
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,132 @@
+// Path: internal/profile/credentials_test.go
+// Description: Validate password lookup from environment variables and sealed credential files.
+package profile
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func testKey(fill byte) string {
+	return base64.StdEncoding.EncodeToString([]byte(strings.Repeat(string(fill), keySize)))
+}
+
+func writeSealed(t *testing.T, passwords map[string]string, key string) string {
+	t.Helper()
+	content, err := Seal(passwords, key)
+	if err != nil {
+		t.Fatalf("Seal returned error: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "credentials.enc")
+	if err := os.WriteFile(path, content, 0o600); err != nil {
+		t.Fatalf("write credentials: %v", err)
+	}
+	return path
+}
+
+func TestPasswordEnvNameNormalizesProfileNames(t *testing.T) {
+	if got := PasswordEnvName(" vc-lab.east "); got != "HYPERSPHERE_PASSWORD_VC_LAB_EAST" {
+		t.Fatalf("unexpected env name: %s", got)
+	}
+	if got := PasswordEnvName("vc-é1"); got != "HYPERSPHERE_PASSWORD_VC__1" {
+		t.Fatalf("expected non-ascii characters to be replaced, got %s", got)
+	}
+}
+
+func TestPasswordPrefersEnvironmentOverSealedFile(t *testing.T) {
+	key := testKey('k')
+	credentials := Credentials{
+		Getenv: func(name string) string {
+			if name == "HYPERSPHERE_PASSWORD_VC_LAB" {
+				return "from-env"
+			}
+			return ""
+		},
+		Path: writeSealed(t, map[string]string{"vc-lab": "from-file", "vc-primary": "sealed"}, key),
+		Key:  key,
+	}
+	if password, err := credentials.Password("vc-lab"); err != nil || password != "from-env" {
+		t.Fatalf("expected env password, got %q err=%v", password, err)
+	}
+	if password, err := credentials.Password("vc-primary"); err != nil || password != "sealed" {
+		t.Fatalf("expected sealed password, got %q err=%v", password, err)
+	}
+	if _, err := credentials.Password("vc-other"); !errors.Is(err, ErrCredentialNotFound) {
+		t.Fatalf("expected missing credential error, got %v", err)
+	}
+	credentials.Key = testKey('x')
+	if _, err := credentials.Password("vc-primary"); !errors.Is(err, ErrCredentialFile) {
+		t.Fatalf("expected wrong key to fail decryption, got %v", err)
+	}
+}
+
+func TestPasswordReportsMissingSources(t *testing.T) {
+	directory := t.TempDir()
+	for _, path := range []string{"", filepath.Join(directory, "missing.enc")} {
+		if _, err := (Credentials{Path: path}).Password("vc"); !errors.Is(err, ErrCredentialNotFound) {
+			t.Fatalf("expected missing credential for %q, got %v", path, err)
+		}
+	}
+	if _, err := (Credentials{Path: directory}).Password("vc"); err == nil || errors.Is(err, ErrCredentialNotFound) {
+		t.Fatalf("expected read failure for directory path, got %v", err)
+	}
+}
+
+func TestSealAndOpenRejectInvalidInput(t *testing.T) {
+	if _, err := Seal(map[string]string{}, "not-base64!"); !errors.Is(err, ErrCredentialKey) {
+		t.Fatalf("expected malformed key error, got %v", err)
+	}
+	if _, err := Open([]byte(`{}`), base64.StdEncoding.EncodeToString([]byte("short"))); !errors.Is(err, ErrCredentialKey) {
+		t.Fatalf("expected short key error, got %v", err)
+	}
+	key := testKey('k')
+	aead, _ := newAEAD(key)
+	nonce := make([]byte, aead.NonceSize())
+	notMap, _ := json.Marshal(sealedFile{Nonce: nonce, Ciphertext: aead.Seal(nil, nonce, []byte(`["vc"]`), nil)})
+	for _, content := range []string{`not json`, `{"nonce":"AA==","ciphertext":""}`, string(notMap)} {
+		if _, err := Open([]byte(content), key); !errors.Is(err, ErrCredentialFile) {
+			t.Fatalf("expected credential file error for %s, got %v", content, err)
+		}
+	}
+}
+
+func TestStoreSealsPasswordsIntoTheCredentialFile(t *testing.T) {
+	key := testKey('s')
+	path := filepath.Join(t.TempDir(), "nested", "credentials.enc")
+	credentials := Credentials{Path: path, Key: key}
+	if err := credentials.Store(" vc-lab ", "first"); err != nil {
+		t.Fatalf("Store returned error: %v", err)
+	}
+	if err := credentials.Store("vc-east", "second"); err != nil {
+		t.Fatalf("Store returned error: %v", err)
+	}
+	for name, want := range map[string]string{"vc-lab": "first", "vc-east": "second"} {
+		if password, err := credentials.Password(name); err != nil || password != want {
+			t.Fatalf("expected %s password %q, got %q err=%v", name, want, password, err)
+		}
+	}
+	if info, err := os.Stat(path); err != nil || info.Mode().Perm() != 0o600 {
+		t.Fatalf("expected private credential file, got %v err=%v", info, err)
+	}
+	if err := (Credentials{Path: path, Key: testKey('x')}).Store("vc-lab", "third"); !errors.Is(err, ErrCredentialFile) {
+		t.Fatalf("expected a file sealed under another key rejected, got %v", err)
+	}
+	if err := (Credentials{Path: filepath.Join(t.TempDir(), "new.enc"), Key: "short"}).Store("vc-lab", "pw"); !errors.Is(err, ErrCredentialKey) {
+		t.Fatalf("expected a bad key rejected, got %v", err)
+	}
+	if err := (Credentials{Path: t.TempDir(), Key: key}).Store("vc-lab", "pw"); err == nil {
+		t.Fatalf("expected an unreadable credential path rejected")
+	}
+	dangling := filepath.Join(t.TempDir(), "dangling")
+	if err := os.Symlink(filepath.Join(t.TempDir(), "missing"), dangling); err != nil {
+		t.Fatalf("symlink: %v", err)
+	}
+	if err := (Credentials{Path: filepath.Join(dangling, "credentials.enc"), Key: key}).Store("vc-lab", "pw"); err == nil {
+		t.Fatalf("expected a credential directory that cannot be created rejected")
+	}
+}
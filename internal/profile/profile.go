@@ -0,0 +1,111 @@
+// Path: internal/profile/profile.go
+// Description: Load named vCenter endpoint profiles used by explorer context switching.
+package profile
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/takelley1/hypersphere/internal/jsonfile"
+	"github.com/takelley1/hypersphere/internal/vsphere"
+)
+
+var (
+	// ErrInvalidProfile indicates a malformed or incomplete profile file.
+	ErrInvalidProfile = errors.New("invalid endpoint profile")
+	// ErrUnknownProfile indicates a profile name missing from the profile set.
+	ErrUnknownProfile = errors.New("unknown endpoint profile")
+)
+
+// Profile describes how to reach one named vCenter endpoint.
+type Profile struct {
+	Name       string `json:"name"`
+	URL        string `json:"url"`
+	Username   string `json:"username"`
+	Thumbprint string `json:"thumbprint"`
+	Insecure   bool   `json:"insecure"`
+	Datacenter string `json:"datacenter"`
+}
+
+// Set stores the configured profiles and the profile to activate first.
+type Set struct {
+	Default  string    `json:"default"`
+	Profiles []Profile `json:"profiles"`
+}
+
+// Load read a profile set from a JSON file, returning an empty set when the file is absent.
+func Load(path string) (Set, error) {
+	return jsonfile.Load(path, Parse)
+}
+
+// Parse decode and validate a JSON profile set. A default naming no profile
+// is reported as unknown rather than invalid.
+func Parse(content []byte) (Set, error) {
+	set, err := jsonfile.Decode(content, ErrInvalidProfile, (*Set).normalize)
+	if err != nil {
+		return Set{}, err
+	}
+	set.Default = strings.TrimSpace(set.Default)
+	if set.Default != "" && !slices.Contains(set.Names(), set.Default) {
+		return Set{}, fmt.Errorf("%w: default %s", ErrUnknownProfile, set.Default)
+	}
+	return set, nil
+}
+
+// normalize trim names and URLs and check every profile is complete and unique.
+func (s *Set) normalize() error {
+	seen := map[string]bool{}
+	for index := range s.Profiles {
+		entry := &s.Profiles[index]
+		entry.Name = strings.TrimSpace(entry.Name)
+		entry.URL = strings.TrimSpace(entry.URL)
+		if entry.Name == "" || entry.URL == "" {
+			return fmt.Errorf("profile %d needs a name and url", index+1)
+		}
+		if seen[entry.Name] {
+			return fmt.Errorf("duplicate profile %s", entry.Name)
+		}
+		seen[entry.Name] = true
+	}
+	return nil
+}
+
+// Names return profile names in file order.
+func (s Set) Names() []string {
+	names := make([]string, 0, len(s.Profiles))
+	for _, entry := range s.Profiles {
+		names = append(names, entry.Name)
+	}
+	return names
+}
+
+// Initial return the default profile name, falling back to the first profile.
+func (s Set) Initial() string {
+	if s.Default != "" || len(s.Profiles) == 0 {
+		return s.Default
+	}
+	return s.Profiles[0].Name
+}
+
+// Find return the profile with the given name.
+func (s Set) Find(name string) (Profile, error) {
+	for _, entry := range s.Profiles {
+		if entry.Name == strings.TrimSpace(name) {
+			return entry, nil
+		}
+	}
+	return Profile{}, fmt.Errorf("%w: %s", ErrUnknownProfile, name)
+}
+
+// Endpoint build the vSphere connection settings for the profile.
+func (p Profile) Endpoint(password string) vsphere.Endpoint {
+	return vsphere.Endpoint{
+		URL:        p.URL,
+		Username:   p.Username,
+		Password:   password,
+		Insecure:   p.Insecure,
+		Thumbprint: p.Thumbprint,
+	}
+}
@@ -0,0 +1,79 @@
+// Path: internal/profile/profile_test.go
+// Description: Validate endpoint profile parsing, lookup, and endpoint conversion.
+package profile
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestLoadParsesProfileSet(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "contexts.json")
+	content := `{"default":"vc-lab","profiles":[` +
+		`{"name":" vc-primary ","url":"vc1.example.com","username":"admin","thumbprint":"AA:BB","datacenter":"dc-1"},` +
+		`{"name":"vc-lab","url":" https://vc2.example.com/sdk ","insecure":true}]}`
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("write profiles: %v", err)
+	}
+	set, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if !reflect.DeepEqual(set.Names(), []string{"vc-primary", "vc-lab"}) || set.Initial() != "vc-lab" {
+		t.Fatalf("unexpected profile set: %+v", set)
+	}
+	primary, err := set.Find("vc-primary")
+	if err != nil || primary.Datacenter != "dc-1" {
+		t.Fatalf("expected vc-primary profile, got %+v err=%v", primary, err)
+	}
+	endpoint := primary.Endpoint("secret")
+	if endpoint.URL != "vc1.example.com" || endpoint.Username != "admin" || endpoint.Password != "secret" ||
+		endpoint.Thumbprint != "AA:BB" || endpoint.Insecure {
+		t.Fatalf("unexpected endpoint: %+v", endpoint)
+	}
+	if _, err := set.Find("vc-missing"); !errors.Is(err, ErrUnknownProfile) {
+		t.Fatalf("expected unknown profile error, got %v", err)
+	}
+	set.Default = ""
+	if set.Initial() != "vc-primary" {
+		t.Fatalf("expected first profile to be initial without a default, got %q", set.Initial())
+	}
+}
+
+func TestLoadToleratesMissingAndEmptyFiles(t *testing.T) {
+	directory := t.TempDir()
+	empty := filepath.Join(directory, "empty.yaml")
+	if err := os.WriteFile(empty, []byte(" \n"), 0o600); err != nil {
+		t.Fatalf("write empty profiles: %v", err)
+	}
+	for _, path := range []string{"", filepath.Join(directory, "missing.yaml"), empty} {
+		set, err := Load(path)
+		if err != nil || len(set.Profiles) != 0 || set.Initial() != "" {
+			t.Fatalf("expected empty profile set for %q, got %+v err=%v", path, set, err)
+		}
+	}
+	if _, err := Load(directory); err == nil {
+		t.Fatalf("expected directory read failure")
+	}
+}
+
+func TestParseRejectsInvalidProfiles(t *testing.T) {
+	tests := map[string]error{
+		`{"profiles":`:                                                   ErrInvalidProfile,
+		`{"profiles":[{"name":"vc"}]}`:                                   ErrInvalidProfile,
+		`{"profiles":[{"url":"vc.example.com"}]}`:                        ErrInvalidProfile,
+		`{"profiles":[{"name":"vc","url":"a"},{"name":"vc","url":"b"}]}`: ErrInvalidProfile,
+		`{"default":"vc-missing","profiles":[{"name":"vc","url":"a"}]}`:  ErrUnknownProfile,
+	}
+	for content, want := range tests {
+		if _, err := Parse([]byte(content)); !errors.Is(err, want) {
+			t.Fatalf("expected %v for %s, got %v", want, content, err)
+		}
+	}
+	if _, err := Parse([]byte(`{"default":"vc-missing"}`)); errors.Is(err, ErrInvalidProfile) {
+		t.Fatalf("expected an unknown default kept apart from invalid files, got %v", err)
+	}
+}
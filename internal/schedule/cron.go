@@ -0,0 +1,105 @@
+// Path: internal/schedule/cron.go
+// Description: Parse and match five-field cron expressions used as maintenance window start times.
+package schedule
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cron matches minutes against minute, hour, day-of-month, month, and
+// day-of-week fields. Each field accepts *, numbers, ranges, lists, and steps.
+type cron struct {
+	minutes  uint64
+	hours    uint64
+	days     uint64
+	months   uint64
+	weekdays uint64
+	anyDay   bool
+	anyWeek  bool
+}
+
+var cronFields = []struct {
+	name string
+	min  int
+	max  int
+}{{"minute", 0, 59}, {"hour", 0, 23}, {"day of month", 1, 31}, {"month", 1, 12}, {"day of week", 0, 7}}
+
+func parseCron(expression string) (cron, error) {
+	fields := strings.Fields(expression)
+	if len(fields) != len(cronFields) {
+		return cron{}, fmt.Errorf("cron %q needs %d fields", expression, len(cronFields))
+	}
+	sets := make([]uint64, len(fields))
+	for index, field := range fields {
+		set, err := parseCronField(field, cronFields[index].min, cronFields[index].max)
+		if err != nil {
+			return cron{}, fmt.Errorf("cron %q %s: %w", expression, cronFields[index].name, err)
+		}
+		sets[index] = set
+	}
+	weekdays := sets[4]
+	if weekdays&(1<<7) != 0 {
+		weekdays |= 1
+	}
+	return cron{
+		minutes:  sets[0],
+		hours:    sets[1],
+		days:     sets[2],
+		months:   sets[3],
+		weekdays: weekdays,
+		anyDay:   fields[2] == "*",
+		anyWeek:  fields[4] == "*",
+	}, nil
+}
+
+func parseCronField(field string, low int, high int) (uint64, error) {
+	var set uint64
+	for _, item := range strings.Split(field, ",") {
+		span, step, stepped := strings.Cut(item, "/")
+		every := 1
+		if stepped {
+			value, err := strconv.Atoi(step)
+			if err != nil || value < 1 {
+				return 0, fmt.Errorf("bad step %q", item)
+			}
+			every = value
+		}
+		first, last := low, high
+		if span != "*" {
+			start, end, ranged := strings.Cut(span, "-")
+			var err error
+			if first, err = strconv.Atoi(start); err != nil {
+				return 0, fmt.Errorf("bad value %q", item)
+			}
+			last = first
+			if ranged {
+				if last, err = strconv.Atoi(end); err != nil {
+					return 0, fmt.Errorf("bad value %q", item)
+				}
+			}
+		}
+		if first < low || last > high || first > last {
+			return 0, fmt.Errorf("%q outside %d-%d", item, low, high)
+		}
+		for value := first; value <= last; value += every {
+			set |= 1 << value
+		}
+	}
+	return set, nil
+}
+
+// matches report whether a local time falls on a minute the expression selects.
+func (c cron) matches(t time.Time) bool {
+	if c.minutes&(1<<t.Minute()) == 0 || c.hours&(1<<t.Hour()) == 0 || c.months&(1<<int(t.Month())) == 0 {
+		return false
+	}
+	day := c.days&(1<<t.Day()) != 0
+	weekday := c.weekdays&(1<<int(t.Weekday())) != 0
+	if c.anyDay || c.anyWeek {
+		return day && weekday
+	}
+	return day || weekday
+}
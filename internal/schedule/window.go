@@ -0,0 +1,199 @@
+// Path: internal/schedule/window.go
+// Description: Load maintenance windows and blackout dates that gate when changes may run.
+package schedule
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/takelley1/hypersphere/internal/jsonfile"
+)
+
+var (
+	// ErrInvalidSchedule indicates a malformed maintenance schedule file.
+	ErrInvalidSchedule = errors.New("invalid maintenance schedule")
+	// ErrNoOpening indicates the schedule stays closed for the whole search horizon.
+	ErrNoOpening = errors.New("maintenance schedule never opens")
+)
+
+const (
+	maxWindow = 7 * 24 * time.Hour
+	horizon   = 366 * 24 * time.Hour
+)
+
+// Spec is the JSON form of a schedule. Each window opens at minutes its
+// five-field cron Start selects and stays open for Duration; Blackouts list
+// YYYY-MM-DD dates that stay closed all day. Times use Timezone, or UTC.
+type Spec struct {
+	Timezone  string       `json:"timezone"`
+	Windows   []WindowSpec `json:"windows"`
+	Blackouts []string     `json:"blackouts"`
+}
+
+// WindowSpec describes one recurring maintenance window.
+type WindowSpec struct {
+	Start    string `json:"start"`
+	Duration string `json:"duration"`
+}
+
+// Schedule reports when changes may run. The zero value is always open.
+type Schedule struct {
+	location  *time.Location
+	windows   []window
+	blackouts map[string]bool
+}
+
+type window struct {
+	start    cron
+	duration time.Duration
+}
+
+// Load read a schedule, returning an always-open schedule when the file is absent.
+func Load(path string) (Schedule, error) {
+	return jsonfile.Load(path, Parse)
+}
+
+// Parse decode and validate a JSON schedule.
+func Parse(content []byte) (Schedule, error) {
+	schedule := Schedule{}
+	_, err := jsonfile.Decode(content, ErrInvalidSchedule, func(spec *Spec) error {
+		compiled, err := spec.Schedule()
+		schedule = compiled
+		return err
+	})
+	return schedule, err
+}
+
+// Schedule validate the spec and compile it into a schedule.
+func (s Spec) Schedule() (Schedule, error) {
+	location, err := time.LoadLocation(s.Timezone)
+	if err != nil {
+		return Schedule{}, fmt.Errorf("timezone %q: %v", s.Timezone, err)
+	}
+	schedule := Schedule{location: location, blackouts: map[string]bool{}}
+	for _, spec := range s.Windows {
+		start, err := parseCron(spec.Start)
+		if err != nil {
+			return Schedule{}, err
+		}
+		duration, err := time.ParseDuration(spec.Duration)
+		if err != nil || duration < time.Minute || duration > maxWindow {
+			return Schedule{}, fmt.Errorf("window %q duration %q must be between 1m and %s", spec.Start, spec.Duration, maxWindow)
+		}
+		schedule.windows = append(schedule.windows, window{start: start, duration: duration.Truncate(time.Minute)})
+	}
+	for _, date := range s.Blackouts {
+		if _, err := time.Parse(time.DateOnly, date); err != nil {
+			return Schedule{}, fmt.Errorf("blackout %q is not a YYYY-MM-DD date", date)
+		}
+		schedule.blackouts[date] = true
+	}
+	return schedule, nil
+}
+
+// Open report whether changes may run at now: outside blackout dates and,
+// when windows are defined, inside at least one of them.
+func (s Schedule) Open(now time.Time) bool {
+	local := s.local(now)
+	if s.blackouts[local.Format(time.DateOnly)] {
+		return false
+	}
+	if len(s.windows) == 0 {
+		return true
+	}
+	minute := local.Truncate(time.Minute)
+	for _, window := range s.windows {
+		for back := time.Duration(0); back < window.duration; back += time.Minute {
+			if window.start.matches(s.local(minute.Add(-back))) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// NextOpen return the first moment at or after now when the schedule is
+// open, or false when it stays closed for the next year.
+func (s Schedule) NextOpen(now time.Time) (time.Time, bool) {
+	if s.Open(now) {
+		return now, true
+	}
+	day := s.local(now).Format(time.DateOnly)
+	for next := now.Truncate(time.Minute).Add(time.Minute); next.Sub(now) <= horizon; next = next.Add(time.Minute) {
+		local := s.local(next)
+		started := false
+		for _, window := range s.windows {
+			started = started || window.start.matches(local)
+		}
+		// Only window starts and day changes out of a blackout can open it.
+		if date := local.Format(time.DateOnly); started || date != day {
+			day = date
+			if s.Open(next) {
+				return next, true
+			}
+		}
+	}
+	return time.Time{}, false
+}
+
+func (s Schedule) local(t time.Time) time.Time {
+	if s.location == nil {
+		return t.UTC()
+	}
+	return t.In(s.location)
+}
+
+// Pause records one wait for the schedule to open; the zero value means no wait.
+type Pause struct {
+	Start time.Time
+	Until time.Time
+}
+
+// Duration return how long the pause lasts.
+func (p Pause) Duration() time.Duration {
+	return p.Until.Sub(p.Start)
+}
+
+// Gate checks a schedule against a clock and sleeps until it opens.
+type Gate struct {
+	schedule Schedule
+	now      func() time.Time
+	sleep    func(time.Duration)
+}
+
+// NewGate build a gate for the schedule on the wall clock.
+func NewGate(schedule Schedule) Gate {
+	return Gate{schedule: schedule, now: time.Now, sleep: time.Sleep}
+}
+
+// WithClock return a gate that reads time from now and waits with sleep.
+func (g Gate) WithClock(now func() time.Time, sleep func(time.Duration)) Gate {
+	g.now = now
+	g.sleep = sleep
+	return g
+}
+
+// Now return the gate clock's current time.
+func (g Gate) Now() time.Time {
+	return g.now()
+}
+
+// Check return the pause needed before work may start, which is zero when
+// the schedule is open now, or ErrNoOpening when it never reopens.
+func (g Gate) Check() (Pause, error) {
+	now := g.now()
+	next, ok := g.schedule.NextOpen(now)
+	if !ok {
+		return Pause{}, fmt.Errorf("%w after %s", ErrNoOpening, now.Format(time.RFC3339))
+	}
+	if next.Equal(now) {
+		return Pause{}, nil
+	}
+	return Pause{Start: now, Until: next}, nil
+}
+
+// Wait sleep until the pause ends.
+func (g Gate) Wait(pause Pause) {
+	g.sleep(pause.Duration())
+}
@@ -0,0 +1,165 @@
+// Path: internal/schedule/window_test.go
+// Description: Validate maintenance window parsing, open checks, next-open search, and gate pauses.
+package schedule
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+const nightly = `{"timezone":"America/Chicago","windows":[{"start":"0 22 * * 1-5","duration":"7h"}],"blackouts":["2026-12-24"]}`
+
+func chicago(t *testing.T, value string) time.Time {
+	t.Helper()
+	location, err := time.LoadLocation("America/Chicago")
+	if err != nil {
+		t.Fatalf("load location: %v", err)
+	}
+	parsed, err := time.ParseInLocation("2006-01-02 15:04", value, location)
+	if err != nil {
+		t.Fatalf("parse time: %v", err)
+	}
+	return parsed
+}
+
+func TestScheduleOpensInsideNightlyWindowOutsideBlackouts(t *testing.T) {
+	schedule, err := Parse([]byte(nightly))
+	if err != nil {
+		t.Fatalf("parse schedule: %v", err)
+	}
+	cases := map[string]bool{
+		"2026-10-16 22:00": true,
+		"2026-10-17 04:59": true,
+		"2026-10-17 05:00": false,
+		"2026-10-16 21:59": false,
+		"2026-10-17 22:30": false,
+		"2026-12-23 23:00": true,
+		"2026-12-24 00:30": false,
+		"2026-12-24 22:00": false,
+	}
+	for value, want := range cases {
+		if got := schedule.Open(chicago(t, value)); got != want {
+			t.Fatalf("expected open=%v at %s", want, value)
+		}
+	}
+	next, ok := schedule.NextOpen(chicago(t, "2026-10-17 05:00"))
+	if !ok || !next.Equal(chicago(t, "2026-10-19 22:00")) {
+		t.Fatalf("expected Monday night opening, got %s ok=%v", next, ok)
+	}
+	next, ok = schedule.NextOpen(chicago(t, "2026-12-24 00:30"))
+	if !ok || !next.Equal(chicago(t, "2026-12-25 00:00")) {
+		t.Fatalf("expected window to resume after blackout, got %s ok=%v", next, ok)
+	}
+	now := chicago(t, "2026-10-16 23:15")
+	if next, ok = schedule.NextOpen(now); !ok || !next.Equal(now) {
+		t.Fatalf("expected open schedule to open now, got %s", next)
+	}
+}
+
+func TestScheduleCronFieldsAndDefaults(t *testing.T) {
+	if !(Schedule{}).Open(time.Now()) {
+		t.Fatalf("expected zero schedule to be always open")
+	}
+	schedule, err := Spec{Windows: []WindowSpec{
+		{Start: "*/30 1,3 1 * 7", Duration: "1m"},
+		{Start: "15 2 * 6 *", Duration: "1m"},
+	}}.Schedule()
+	if err != nil {
+		t.Fatalf("build schedule: %v", err)
+	}
+	cases := map[time.Time]bool{
+		time.Date(2026, 10, 1, 1, 30, 0, 0, time.UTC): true,
+		time.Date(2026, 10, 4, 3, 0, 0, 0, time.UTC):  true,
+		time.Date(2026, 10, 5, 3, 0, 0, 0, time.UTC):  false,
+		time.Date(2026, 10, 1, 1, 15, 0, 0, time.UTC): false,
+		time.Date(2026, 6, 9, 2, 15, 0, 0, time.UTC):  true,
+		time.Date(2026, 7, 9, 2, 15, 0, 0, time.UTC):  false,
+	}
+	for value, want := range cases {
+		if got := schedule.Open(value); got != want {
+			t.Fatalf("expected open=%v at %s", want, value)
+		}
+	}
+	never, err := Spec{Windows: []WindowSpec{{Start: "0 0 30 2 *", Duration: "1h"}}}.Schedule()
+	if err != nil {
+		t.Fatalf("build schedule: %v", err)
+	}
+	if _, ok := never.NextOpen(time.Date(2026, 1, 1, 0, 30, 0, 0, time.UTC)); ok {
+		t.Fatalf("expected February 30 window never to open")
+	}
+}
+
+func TestParseRejectsInvalidSchedules(t *testing.T) {
+	invalid := []string{
+		`{`,
+		`{"timezone":"Mars/Olympus"}`,
+		`{"windows":[{"start":"0 22 * *","duration":"1h"}]}`,
+		`{"windows":[{"start":"x 22 * * *","duration":"1h"}]}`,
+		`{"windows":[{"start":"0 1-x * * *","duration":"1h"}]}`,
+		`{"windows":[{"start":"*/0 22 * * *","duration":"1h"}]}`,
+		`{"windows":[{"start":"0 24 * * *","duration":"1h"}]}`,
+		`{"windows":[{"start":"0 5-1 * * *","duration":"1h"}]}`,
+		`{"windows":[{"start":"0 22 * * *","duration":"soon"}]}`,
+		`{"windows":[{"start":"0 22 * * *","duration":"30s"}]}`,
+		`{"windows":[{"start":"0 22 * * *","duration":"200h"}]}`,
+		`{"blackouts":["12/24/2026"]}`,
+	}
+	for _, content := range invalid {
+		if _, err := Parse([]byte(content)); !errors.Is(err, ErrInvalidSchedule) {
+			t.Fatalf("expected invalid schedule for %s, got %v", content, err)
+		}
+	}
+	if schedule, err := Parse([]byte("  ")); err != nil || !schedule.Open(time.Now()) {
+		t.Fatalf("expected blank schedule to be always open, err=%v", err)
+	}
+}
+
+func TestLoadReadsScheduleFiles(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "schedule.json")
+	if err := os.WriteFile(path, []byte(nightly), 0o600); err != nil {
+		t.Fatalf("write schedule: %v", err)
+	}
+	schedule, err := Load(path)
+	if err != nil || schedule.Open(chicago(t, "2026-10-16 12:00")) {
+		t.Fatalf("expected loaded nightly schedule closed at noon, err=%v", err)
+	}
+	for _, missing := range []string{"", filepath.Join(dir, "absent.json")} {
+		if schedule, err := Load(missing); err != nil || len(schedule.windows) != 0 {
+			t.Fatalf("expected missing schedule to be always open, err=%v", err)
+		}
+	}
+	if _, err := Load(dir); err == nil {
+		t.Fatalf("expected reading a directory to fail")
+	}
+}
+
+func TestGateChecksAndWaitsForOpening(t *testing.T) {
+	schedule, _ := Parse([]byte(nightly))
+	now := chicago(t, "2026-10-16 20:00")
+	slept := time.Duration(0)
+	gate := NewGate(schedule).WithClock(func() time.Time { return now }, func(d time.Duration) {
+		slept += d
+		now = now.Add(d)
+	})
+	pause, err := gate.Check()
+	if err != nil || !pause.Until.Equal(chicago(t, "2026-10-16 22:00")) || pause.Duration() != 2*time.Hour {
+		t.Fatalf("expected two hour pause, got %+v err=%v", pause, err)
+	}
+	gate.Wait(pause)
+	if slept != 2*time.Hour || !gate.Now().Equal(pause.Until) {
+		t.Fatalf("expected gate to sleep until opening, slept %s", slept)
+	}
+	if pause, err = gate.Check(); err != nil || !pause.Until.IsZero() {
+		t.Fatalf("expected no pause inside window, got %+v err=%v", pause, err)
+	}
+	never, _ := Spec{Windows: []WindowSpec{{Start: "0 0 30 2 *", Duration: "1h"}}}.Schedule()
+	_, err = NewGate(never).Check()
+	if !errors.Is(err, ErrNoOpening) || !strings.Contains(err.Error(), "after") {
+		t.Fatalf("expected no opening error, got %v", err)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"os"
 	"regexp"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -106,30 +107,67 @@ var resourceAliasMap = map[string]Resource{
 	"benchmark":     ResourcePerf,
 }
 
-// VMRow represents one VM row in the resource table.
+// Origin records the vCenter that supplied a row when several endpoints are aggregated.
+type Origin struct {
+	VCenter string
+}
+
+// SetVCenter record the vCenter endpoint that supplied a row.
+func (o *Origin) SetVCenter(name string) {
+	o.VCenter = name
+}
+
+func (o Origin) origin() Origin {
+	return o
+}
+
+// qualify prefix a row ID with its vCenter so aggregated IDs stay unique.
+func (o Origin) qualify(id string) string {
+	if o.VCenter == "" {
+		return id
+	}
+	return o.VCenter + "/" + id
+}
+
+type sourcedRow interface {
+	origin() Origin
+}
+
+// VMRow represents one VM row in the resource table. ProvisionedStorageGB
+// adds uncommitted thin-disk space to UsedStorageGB, and SwapGB is configured
+// memory minus its reservation.
 type VMRow struct {
-	Name            string
-	Tags            string
-	Cluster         string
-	Host            string
-	Network         string
-	PowerState      string
-	Datastore       string
-	AttachedStorage string
-	IPAddress       string
-	DNSName         string
-	CPUCount        int
-	MemoryMB        int
-	UsedCPUPercent  int
-	UsedMemoryMB    int
-	UsedStorageGB   int
-	LargestDiskGB   int
-	SnapshotTotalGB int
-	Owner           string
-	Comments        string
-	Description     string
-	SnapshotCount   int
-	Snapshots       []VMSnapshot
+	Origin
+	Name                 string
+	Tags                 string
+	Cluster              string
+	Folder               string
+	Host                 string
+	Network              string
+	PowerState           string
+	Datastore            string
+	AttachedStorage      string
+	IPAddress            string
+	DNSName              string
+	CPUCount             int
+	MemoryMB             int
+	UsedCPUPercent       int
+	UsedCPUMHz           int
+	UsedMemoryMB         int
+	UsedStorageGB        int
+	ProvisionedStorageGB int
+	SwapGB               int
+	LargestDiskGB        int
+	SnapshotTotalGB      int
+	Owner                string
+	Comments             string
+	Description          string
+	SnapshotCount        int
+	Snapshots            []VMSnapshot
+	ISODatastore         string
+	IndependentDisks     int
+	RDMDisks             int
+	BackupRunning        bool
 }
 
 // VMSnapshot stores summary fields for one VM snapshot.
@@ -140,6 +178,7 @@ type VMSnapshot struct {
 
 // LUNRow represents one LUN row in the resource table.
 type LUNRow struct {
+	Origin
 	Name       string
 	Tags       string
 	Cluster    string
@@ -150,6 +189,7 @@ type LUNRow struct {
 
 // ClusterRow represents one cluster row in the resource table.
 type ClusterRow struct {
+	Origin
 	Name              string
 	Tags              string
 	Datacenter        string
@@ -163,6 +203,7 @@ type ClusterRow struct {
 
 // DatacenterRow represents one datacenter row in the resource table.
 type DatacenterRow struct {
+	Origin
 	Name            string
 	ClusterCount    int
 	HostCount       int
@@ -174,6 +215,7 @@ type DatacenterRow struct {
 
 // ResourcePoolRow represents one resource pool row in the resource table.
 type ResourcePoolRow struct {
+	Origin
 	Name              string
 	Cluster           string
 	CPUReservationMHz int
@@ -185,6 +227,7 @@ type ResourcePoolRow struct {
 
 // NetworkRow represents one network row in the resource table.
 type NetworkRow struct {
+	Origin
 	Name        string
 	Type        string
 	VLAN        string
@@ -196,6 +239,7 @@ type NetworkRow struct {
 
 // TemplateRow represents one VM template row in the resource table.
 type TemplateRow struct {
+	Origin
 	Name      string
 	OS        string
 	Datastore string
@@ -207,6 +251,7 @@ type TemplateRow struct {
 
 // SnapshotRow represents one VM snapshot row in the resource table.
 type SnapshotRow struct {
+	Origin
 	VM       string
 	Snapshot string
 	Size     string
@@ -218,6 +263,7 @@ type SnapshotRow struct {
 
 // TaskRow represents one vCenter task stream row in the resource table.
 type TaskRow struct {
+	Origin
 	Entity   string
 	Action   string
 	State    string
@@ -228,6 +274,7 @@ type TaskRow struct {
 
 // EventRow represents one inventory event stream row in the resource table.
 type EventRow struct {
+	Origin
 	Time     string
 	Severity string
 	Entity   string
@@ -237,6 +284,7 @@ type EventRow struct {
 
 // AlarmRow represents one active alarm row in the resource table.
 type AlarmRow struct {
+	Origin
 	Entity    string
 	Alarm     string
 	Status    string
@@ -246,6 +294,7 @@ type AlarmRow struct {
 
 // FolderRow represents one inventory folder row in the resource table.
 type FolderRow struct {
+	Origin
 	Path     string
 	Type     string
 	Children int
@@ -254,14 +303,17 @@ type FolderRow struct {
 
 // TagRow represents one tag/category row in the resource table.
 type TagRow struct {
+	Origin
 	Tag             string
 	Category        string
 	Cardinality     string
 	AttachedObjects int
 }
 
-// HostRow represents one host row in the resource table.
+// HostRow represents one host row in the resource table. CPUMHz and
+// MemoryMB are the host's total capacity.
 type HostRow struct {
+	Origin
 	Name            string
 	Tags            string
 	Cluster         string
@@ -271,18 +323,25 @@ type HostRow struct {
 	CoreCount       int
 	ThreadCount     int
 	VMCount         int
+	CPUMHz          int
+	MemoryMB        int
 }
 
 // DatastoreRow represents one datastore row in the resource table.
+// ProvisionedGB adds space promised to thin disks to UsedGB.
 type DatastoreRow struct {
-	Name       string
-	Tags       string
-	Cluster    string
-	CapacityGB int
-	UsedGB     int
-	FreeGB     int
-	Type       string
-	LatencyMS  int
+	Origin
+	Name          string
+	Tags          string
+	Cluster       string
+	CapacityGB    int
+	UsedGB        int
+	FreeGB        int
+	ProvisionedGB int
+	Type          string
+	LatencyMS     int
+	Hosts         string
+	Local         bool
 }
 
 // Catalog stores rows available for each resource view.
@@ -396,6 +455,8 @@ type Session struct {
 	sortColumn       string
 	sortAsc          bool
 	filterText       string
+	filterRegex      *regexp.Regexp
+	filterInverse    bool
 	readOnly         bool
 	marks            map[string]struct{}
 	markAnchor       int
@@ -538,6 +599,7 @@ func (s *Session) ExecuteCommand(command string) error {
 	s.sortColumn = ""
 	s.sortAsc = true
 	s.filterText = ""
+	s.filterRegex = nil
 	s.marks = map[string]struct{}{}
 	s.markAnchor = -1
 	s.faultMode = false
@@ -924,6 +986,7 @@ func (s *Session) SetReadOnly(readOnly bool) {
 // ApplyFilter filters rows by substring match across all columns.
 func (s *Session) ApplyFilter(filter string) {
 	s.filterText = strings.ToLower(strings.TrimSpace(filter))
+	s.filterRegex = nil
 	if s.filterText == "" {
 		s.view = s.baseView
 		s.clampSelectedRow()
@@ -950,6 +1013,7 @@ func (s *Session) ApplyTagFilter(expression string) error {
 		return err
 	}
 	s.filterText = "-t " + strings.Join(criteria, ",")
+	s.filterRegex = nil
 	s.view = filterViewTags(s.baseView, criteria)
 	s.clampSelectedRow()
 	return nil
@@ -962,6 +1026,7 @@ func (s *Session) ApplyFuzzyFilter(query string) error {
 		return fmt.Errorf("%w: empty fuzzy filter", ErrInvalidAction)
 	}
 	s.filterText = "-f " + trimmed
+	s.filterRegex = nil
 	s.view = filterViewFuzzy(s.baseView, trimmed)
 	s.clampSelectedRow()
 	return nil
@@ -978,6 +1043,8 @@ func (s *Session) applyRegexFilter(pattern string, inverse bool) error {
 		return err
 	}
 	s.filterText = trimmed
+	s.filterRegex = compiled
+	s.filterInverse = inverse
 	if inverse {
 		s.filterText = "!" + s.filterText
 	}
@@ -1450,7 +1517,7 @@ func perfView(tasks []TaskRow) ResourceView {
 	}
 }
 
-func buildView[T any](
+func buildView[T sourcedRow](
 	resource Resource,
 	columns []string,
 	sortHotKeys map[string]string,
@@ -1460,9 +1527,17 @@ func buildView[T any](
 ) ResourceView {
 	ids := make([]string, 0, len(rows))
 	viewRows := make([][]string, 0, len(rows))
+	aggregated := slices.ContainsFunc(rows, func(row T) bool { return row.origin().VCenter != "" })
+	if aggregated {
+		columns = append(slices.Clone(columns), "VCENTER")
+	}
 	for _, row := range rows {
 		id, cells := toCells(row)
-		ids = append(ids, id)
+		origin := row.origin()
+		ids = append(ids, origin.qualify(id))
+		if aggregated {
+			cells = append(cells, defaultCell(origin.VCenter))
+		}
 		viewRows = append(viewRows, cells)
 	}
 	return ResourceView{Resource: resource, Columns: columns, Rows: viewRows, IDs: ids, SortHotKeys: sortHotKeys, Actions: actions}
@@ -2288,7 +2363,7 @@ func (s *Session) setHostConnectionState(ids []string, state string) {
 		targets[id] = struct{}{}
 	}
 	for index, row := range s.navigator.catalog.Hosts {
-		if _, ok := targets[row.Name]; ok {
+		if _, ok := targets[row.qualify(row.Name)]; ok {
 			row.ConnectionState = state
 			s.navigator.catalog.Hosts[index] = row
 		}
@@ -2959,7 +3034,7 @@ func (s *Session) vmDetailsByID(id string) (ResourceDetails, error) {
 
 func findVMRowByID(rows []VMRow, id string) (VMRow, bool) {
 	for _, row := range rows {
-		if row.Name == id {
+		if row.qualify(row.Name) == id {
 			return row, true
 		}
 	}
@@ -2968,7 +3043,7 @@ func findVMRowByID(rows []VMRow, id string) (VMRow, bool) {
 
 func findHostRowByID(rows []HostRow, id string) (HostRow, bool) {
 	for _, row := range rows {
-		if row.Name == id {
+		if row.qualify(row.Name) == id {
 			return row, true
 		}
 	}
@@ -2977,7 +3052,7 @@ func findHostRowByID(rows []HostRow, id string) (HostRow, bool) {
 
 func findClusterRowByID(rows []ClusterRow, id string) (ClusterRow, bool) {
 	for _, row := range rows {
-		if row.Name == id {
+		if row.qualify(row.Name) == id {
 			return row, true
 		}
 	}
@@ -2986,7 +3061,7 @@ func findClusterRowByID(rows []ClusterRow, id string) (ClusterRow, bool) {
 
 func findDatacenterRowByID(rows []DatacenterRow, id string) (DatacenterRow, bool) {
 	for _, row := range rows {
-		if row.Name == id {
+		if row.qualify(row.Name) == id {
 			return row, true
 		}
 	}
@@ -3071,6 +3146,7 @@ func (s *Session) datacenterBreadcrumbPath() string {
 func vmDetails(row VMRow) ResourceDetails {
 	fields := []DetailField{
 		{Key: "NAME", Value: row.Name},
+		{Key: "VCENTER", Value: defaultCell(row.VCenter)},
 		{Key: "POWER_STATE", Value: defaultCell(row.PowerState)},
 		{Key: "CPU_COUNT", Value: strconv.Itoa(row.CPUCount)},
 		{Key: "MEMORY_MB", Value: strconv.Itoa(row.MemoryMB)},
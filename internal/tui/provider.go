@@ -0,0 +1,68 @@
+// Path: internal/tui/provider.go
+// Description: Define pluggable inventory providers that populate explorer catalogs.
+package tui
+
+// InventoryProvider lists inventory rows for each explorer resource view.
+type InventoryProvider interface {
+	ListVMs() ([]VMRow, error)
+	ListLUNs() ([]LUNRow, error)
+	ListClusters() ([]ClusterRow, error)
+	ListDatacenters() ([]DatacenterRow, error)
+	ListResourcePools() ([]ResourcePoolRow, error)
+	ListNetworks() ([]NetworkRow, error)
+	ListTemplates() ([]TemplateRow, error)
+	ListSnapshots() ([]SnapshotRow, error)
+	ListTasks() ([]TaskRow, error)
+	ListEvents() ([]EventRow, error)
+	ListAlarms() ([]AlarmRow, error)
+	ListFolders() ([]FolderRow, error)
+	ListTags() ([]TagRow, error)
+	ListHosts() ([]HostRow, error)
+	ListDatastores() ([]DatastoreRow, error)
+}
+
+// LoadCatalog builds a catalog by listing every resource from a provider.
+func LoadCatalog(provider InventoryProvider) (Catalog, error) {
+	catalog := Catalog{}
+	loaders := []func() error{
+		func() (err error) { catalog.VMs, err = provider.ListVMs(); return err },
+		func() (err error) { catalog.LUNs, err = provider.ListLUNs(); return err },
+		func() (err error) { catalog.Clusters, err = provider.ListClusters(); return err },
+		func() (err error) { catalog.Datacenters, err = provider.ListDatacenters(); return err },
+		func() (err error) { catalog.ResourcePools, err = provider.ListResourcePools(); return err },
+		func() (err error) { catalog.Networks, err = provider.ListNetworks(); return err },
+		func() (err error) { catalog.Templates, err = provider.ListTemplates(); return err },
+		func() (err error) { catalog.Snapshots, err = provider.ListSnapshots(); return err },
+		func() (err error) { catalog.Tasks, err = provider.ListTasks(); return err },
+		func() (err error) { catalog.Events, err = provider.ListEvents(); return err },
+		func() (err error) { catalog.Alarms, err = provider.ListAlarms(); return err },
+		func() (err error) { catalog.Folders, err = provider.ListFolders(); return err },
+		func() (err error) { catalog.Tags, err = provider.ListTags(); return err },
+		func() (err error) { catalog.Hosts, err = provider.ListHosts(); return err },
+		func() (err error) { catalog.Datastores, err = provider.ListDatastores(); return err },
+	}
+	for _, load := range loaders {
+		if err := load(); err != nil {
+			return Catalog{}, err
+		}
+	}
+	return catalog, nil
+}
+
+// NewNavigatorFromProvider builds a navigator over provider-listed inventory.
+func NewNavigatorFromProvider(provider InventoryProvider) (Navigator, error) {
+	catalog, err := LoadCatalog(provider)
+	if err != nil {
+		return Navigator{}, err
+	}
+	return NewNavigator(catalog), nil
+}
+
+// NewSessionFromProvider initializes an interactive session over provider-listed inventory.
+func NewSessionFromProvider(provider InventoryProvider) (Session, error) {
+	catalog, err := LoadCatalog(provider)
+	if err != nil {
+		return Session{}, err
+	}
+	return NewSession(catalog), nil
+}
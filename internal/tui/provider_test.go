@@ -0,0 +1,140 @@
+// Path: internal/tui/provider_test.go
+// Description: Validate catalog loading from pluggable inventory providers.
+package tui
+
+import (
+	"errors"
+	"testing"
+)
+
+type fakeInventoryProvider struct {
+	failOn string
+}
+
+func (f fakeInventoryProvider) fail(name string) error {
+	if f.failOn == name {
+		return errors.New("list " + name + " failed")
+	}
+	return nil
+}
+
+func (f fakeInventoryProvider) ListVMs() ([]VMRow, error) {
+	return []VMRow{{Name: "vm-a"}}, f.fail("vms")
+}
+
+func (f fakeInventoryProvider) ListLUNs() ([]LUNRow, error) {
+	return []LUNRow{{Name: "lun-a"}}, f.fail("luns")
+}
+
+func (f fakeInventoryProvider) ListClusters() ([]ClusterRow, error) {
+	return []ClusterRow{{Name: "cluster-a"}}, f.fail("clusters")
+}
+
+func (f fakeInventoryProvider) ListDatacenters() ([]DatacenterRow, error) {
+	return []DatacenterRow{{Name: "dc-a"}}, f.fail("datacenters")
+}
+
+func (f fakeInventoryProvider) ListResourcePools() ([]ResourcePoolRow, error) {
+	return []ResourcePoolRow{{Name: "rp-a"}}, f.fail("resourcepools")
+}
+
+func (f fakeInventoryProvider) ListNetworks() ([]NetworkRow, error) {
+	return []NetworkRow{{Name: "net-a"}}, f.fail("networks")
+}
+
+func (f fakeInventoryProvider) ListTemplates() ([]TemplateRow, error) {
+	return []TemplateRow{{Name: "tpl-a"}}, f.fail("templates")
+}
+
+func (f fakeInventoryProvider) ListSnapshots() ([]SnapshotRow, error) {
+	return []SnapshotRow{{VM: "vm-a", Snapshot: "snap-a"}}, f.fail("snapshots")
+}
+
+func (f fakeInventoryProvider) ListTasks() ([]TaskRow, error) {
+	return []TaskRow{{Entity: "vm-a", Action: "clone"}}, f.fail("tasks")
+}
+
+func (f fakeInventoryProvider) ListEvents() ([]EventRow, error) {
+	return []EventRow{{Entity: "vm-a", Message: "created"}}, f.fail("events")
+}
+
+func (f fakeInventoryProvider) ListAlarms() ([]AlarmRow, error) {
+	return []AlarmRow{{Entity: "vm-a", Alarm: "cpu"}}, f.fail("alarms")
+}
+
+func (f fakeInventoryProvider) ListFolders() ([]FolderRow, error) {
+	return []FolderRow{{Path: "/dc/vm"}}, f.fail("folders")
+}
+
+func (f fakeInventoryProvider) ListTags() ([]TagRow, error) {
+	return []TagRow{{Tag: "env:prod"}}, f.fail("tags")
+}
+
+func (f fakeInventoryProvider) ListHosts() ([]HostRow, error) {
+	return []HostRow{{Name: "esxi-a"}}, f.fail("hosts")
+}
+
+func (f fakeInventoryProvider) ListDatastores() ([]DatastoreRow, error) {
+	return []DatastoreRow{{Name: "ds-a"}}, f.fail("datastores")
+}
+
+func TestLoadCatalogListsEveryResource(t *testing.T) {
+	catalog, err := LoadCatalog(fakeInventoryProvider{})
+	if err != nil {
+		t.Fatalf("LoadCatalog returned error: %v", err)
+	}
+	counts := []int{
+		len(catalog.VMs), len(catalog.LUNs), len(catalog.Clusters), len(catalog.Datacenters),
+		len(catalog.ResourcePools), len(catalog.Networks), len(catalog.Templates), len(catalog.Snapshots),
+		len(catalog.Tasks), len(catalog.Events), len(catalog.Alarms), len(catalog.Folders),
+		len(catalog.Tags), len(catalog.Hosts), len(catalog.Datastores),
+	}
+	for index, count := range counts {
+		if count != 1 {
+			t.Fatalf("expected one row for catalog field %d, got %d", index, count)
+		}
+	}
+}
+
+func TestLoadCatalogStopsOnProviderError(t *testing.T) {
+	for _, name := range []string{"vms", "clusters", "hosts", "datastores"} {
+		catalog, err := LoadCatalog(fakeInventoryProvider{failOn: name})
+		if err == nil {
+			t.Fatalf("expected %s listing error", name)
+		}
+		if len(catalog.VMs) != 0 {
+			t.Fatalf("expected empty catalog on %s error, got %+v", name, catalog)
+		}
+	}
+}
+
+func TestNewSessionFromProviderStartsOnVMView(t *testing.T) {
+	session, err := NewSessionFromProvider(fakeInventoryProvider{})
+	if err != nil {
+		t.Fatalf("NewSessionFromProvider returned error: %v", err)
+	}
+	view := session.CurrentView()
+	if view.Resource != ResourceVM || len(view.IDs) != 1 || view.IDs[0] != "vm-a" {
+		t.Fatalf("expected provider VM rows in session view, got %+v", view)
+	}
+	if _, err := NewSessionFromProvider(fakeInventoryProvider{failOn: "vms"}); err == nil {
+		t.Fatalf("expected provider error from session constructor")
+	}
+}
+
+func TestNewNavigatorFromProviderServesProviderRows(t *testing.T) {
+	navigator, err := NewNavigatorFromProvider(fakeInventoryProvider{})
+	if err != nil {
+		t.Fatalf("NewNavigatorFromProvider returned error: %v", err)
+	}
+	view, err := navigator.Execute(":host")
+	if err != nil {
+		t.Fatalf("Execute returned error: %v", err)
+	}
+	if len(view.IDs) != 1 || view.IDs[0] != "esxi-a" {
+		t.Fatalf("expected provider host rows, got %+v", view.IDs)
+	}
+	if _, err := NewNavigatorFromProvider(fakeInventoryProvider{failOn: "datastores"}); err == nil {
+		t.Fatalf("expected provider error from navigator constructor")
+	}
+}
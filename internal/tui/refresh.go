@@ -0,0 +1,296 @@
+// Path: internal/tui/refresh.go
+// Description: Apply incremental catalog changes and refresh the active session view in place.
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// ErrInvalidCatalogChange indicates a catalog change that does not match its resource table.
+var ErrInvalidCatalogChange = errors.New("invalid catalog change")
+
+// CatalogOp identifies how a catalog change modifies a resource table.
+type CatalogOp string
+
+const (
+	// CatalogUpsert replaces the row with the change ID or appends it when absent.
+	CatalogUpsert CatalogOp = "upsert"
+	// CatalogRemove deletes the row with the change ID.
+	CatalogRemove CatalogOp = "remove"
+	// CatalogReplace swaps the whole resource table for the change rows.
+	CatalogReplace CatalogOp = "replace"
+)
+
+// CatalogChange describes one row-level or table-level catalog update.
+type CatalogChange struct {
+	Op       CatalogOp
+	Resource Resource
+	ID       string
+	Row      any
+	Rows     any
+}
+
+type catalogTable interface {
+	resource() Resource
+	apply(catalog *Catalog, change CatalogChange, owned bool) error
+	diff(previous Catalog, next Catalog) []CatalogChange
+}
+
+type rowTable[T sourcedRow] struct {
+	kind  Resource
+	rows  func(*Catalog) *[]T
+	cells func(T) (string, []string)
+}
+
+var catalogTables = []catalogTable{
+	rowTable[VMRow]{ResourceVM, func(c *Catalog) *[]VMRow { return &c.VMs }, vmCells},
+	rowTable[LUNRow]{ResourceLUN, func(c *Catalog) *[]LUNRow { return &c.LUNs }, lunCells},
+	rowTable[ClusterRow]{ResourceCluster, func(c *Catalog) *[]ClusterRow { return &c.Clusters }, clusterCells},
+	rowTable[DatacenterRow]{ResourceDatacenter, func(c *Catalog) *[]DatacenterRow { return &c.Datacenters }, datacenterCells},
+	rowTable[ResourcePoolRow]{ResourcePool, func(c *Catalog) *[]ResourcePoolRow { return &c.ResourcePools }, resourcePoolCells},
+	rowTable[NetworkRow]{ResourceNetwork, func(c *Catalog) *[]NetworkRow { return &c.Networks }, networkCells},
+	rowTable[TemplateRow]{ResourceTemplate, func(c *Catalog) *[]TemplateRow { return &c.Templates }, templateCells},
+	rowTable[SnapshotRow]{ResourceSnapshot, func(c *Catalog) *[]SnapshotRow { return &c.Snapshots }, snapshotCells},
+	rowTable[TaskRow]{ResourceTask, func(c *Catalog) *[]TaskRow { return &c.Tasks }, taskCells},
+	rowTable[EventRow]{ResourceEvent, func(c *Catalog) *[]EventRow { return &c.Events }, eventCells},
+	rowTable[AlarmRow]{ResourceAlarm, func(c *Catalog) *[]AlarmRow { return &c.Alarms }, alarmCells},
+	rowTable[FolderRow]{ResourceFolder, func(c *Catalog) *[]FolderRow { return &c.Folders }, folderCells},
+	rowTable[TagRow]{ResourceTag, func(c *Catalog) *[]TagRow { return &c.Tags }, tagCells},
+	rowTable[HostRow]{ResourceHost, func(c *Catalog) *[]HostRow { return &c.Hosts }, hostCells},
+	rowTable[DatastoreRow]{ResourceDatastore, func(c *Catalog) *[]DatastoreRow { return &c.Datastores }, datastoreCells},
+}
+
+// Apply applies changes in order and leaves the catalog untouched when any change is invalid.
+func (c *Catalog) Apply(changes []CatalogChange) error {
+	next := *c
+	owned := map[Resource]bool{}
+	for _, change := range changes {
+		table, ok := catalogTableFor(change.Resource)
+		if !ok {
+			return fmt.Errorf("%w: unknown resource %q", ErrInvalidCatalogChange, change.Resource)
+		}
+		if err := table.apply(&next, change, owned[change.Resource]); err != nil {
+			return err
+		}
+		owned[change.Resource] = true
+	}
+	*c = next
+	return nil
+}
+
+// DiffCatalog returns the changes that turn the previous catalog into the next one.
+func DiffCatalog(previous Catalog, next Catalog) []CatalogChange {
+	changes := []CatalogChange{}
+	for _, table := range catalogTables {
+		changes = append(changes, table.diff(previous, next)...)
+	}
+	return changes
+}
+
+// ApplyCatalogChanges updates the session catalog and refreshes the active view in place.
+func (s *Session) ApplyCatalogChanges(changes []CatalogChange) error {
+	if len(changes) == 0 {
+		return nil
+	}
+	if err := s.navigator.catalog.Apply(changes); err != nil {
+		return err
+	}
+	return s.RefreshView()
+}
+
+// Catalog returns the catalog backing the session views.
+func (s *Session) Catalog() Catalog {
+	return s.navigator.catalog
+}
+
+// RefreshView rebuilds the active view from the catalog and keeps selection, marks, filter, and sort.
+func (s *Session) RefreshView() error {
+	selectedID, _, selectErr := s.selectedRowContext()
+	view, err := s.navigator.TableFor(s.view.Resource)
+	if err != nil {
+		return err
+	}
+	if s.view.Resource == ResourceXRay {
+		view = xrayView(s.navigator.catalog, s.xrayDepth)
+	}
+	view, err = s.applyStoredColumns(view)
+	if err != nil {
+		return err
+	}
+	s.baseView = view
+	s.reapplyFilter()
+	if s.faultMode {
+		s.view = filterFaultRows(s.view)
+	}
+	if index := findColumnIndex(s.view.Columns, s.sortColumn); s.sortColumn != "" && index >= 0 {
+		s.reorderRows(index, s.sortAsc)
+	}
+	s.pruneMarks()
+	if selectErr == nil {
+		if index := indexOfID(s.view.IDs, selectedID); index >= 0 {
+			s.selectedRow = index
+		}
+	}
+	s.clampSelectedRow()
+	return nil
+}
+
+func (s *Session) reapplyFilter() {
+	switch {
+	case s.filterText == "":
+		s.view = s.baseView
+	case s.filterRegex != nil:
+		s.view = filterViewRegex(s.baseView, s.filterRegex, s.filterInverse)
+	case strings.HasPrefix(s.filterText, "-t "):
+		s.view = filterViewTags(s.baseView, strings.Split(strings.TrimPrefix(s.filterText, "-t "), ","))
+	case strings.HasPrefix(s.filterText, "-f "):
+		s.view = filterViewFuzzy(s.baseView, strings.TrimPrefix(s.filterText, "-f "))
+	default:
+		s.view = filterView(s.baseView, s.filterText)
+	}
+}
+
+func (s *Session) pruneMarks() {
+	present := map[string]struct{}{}
+	for _, id := range s.baseView.IDs {
+		present[id] = struct{}{}
+	}
+	for id := range s.marks {
+		if _, ok := present[id]; !ok {
+			delete(s.marks, id)
+		}
+	}
+	if s.markAnchor >= len(s.view.Rows) {
+		s.markAnchor = -1
+	}
+}
+
+func catalogTableFor(resource Resource) (catalogTable, bool) {
+	for _, table := range catalogTables {
+		if table.resource() == resource {
+			return table, true
+		}
+	}
+	return nil, false
+}
+
+func (t rowTable[T]) resource() Resource {
+	return t.kind
+}
+
+func (t rowTable[T]) apply(catalog *Catalog, change CatalogChange, owned bool) error {
+	rows := t.rows(catalog)
+	switch change.Op {
+	case CatalogReplace:
+		replacement, ok := change.Rows.([]T)
+		if !ok {
+			return fmt.Errorf("%w: %s rows have type %T", ErrInvalidCatalogChange, t.kind, change.Rows)
+		}
+		*rows = slices.Clone(replacement)
+		return nil
+	case CatalogUpsert:
+		row, ok := change.Row.(T)
+		if !ok {
+			return fmt.Errorf("%w: %s row has type %T", ErrInvalidCatalogChange, t.kind, change.Row)
+		}
+		if id := t.id(row); id != change.ID {
+			return fmt.Errorf("%w: %s row id %q does not match %q", ErrInvalidCatalogChange, t.kind, id, change.ID)
+		}
+		if !owned {
+			*rows = slices.Clone(*rows)
+		}
+		if index := t.indexOf(*rows, change.ID); index >= 0 {
+			(*rows)[index] = row
+			return nil
+		}
+		*rows = append(*rows, row)
+		return nil
+	case CatalogRemove:
+		if !owned {
+			*rows = slices.Clone(*rows)
+		}
+		*rows = slices.DeleteFunc(*rows, func(row T) bool {
+			return t.id(row) == change.ID
+		})
+		return nil
+	default:
+		return fmt.Errorf("%w: unknown op %q", ErrInvalidCatalogChange, change.Op)
+	}
+}
+
+func (t rowTable[T]) diff(previous Catalog, next Catalog) []CatalogChange {
+	before := *t.rows(&previous)
+	after := *t.rows(&next)
+	if len(before) == 0 && len(after) == 0 {
+		return nil
+	}
+	beforeIDs, beforeIndex, beforeUnique := t.ids(before)
+	afterIDs, afterIndex, afterUnique := t.ids(after)
+	if !beforeUnique || !afterUnique || !appendOnlyOrder(beforeIDs, afterIDs, afterIndex) {
+		if reflect.DeepEqual(before, after) {
+			return nil
+		}
+		return []CatalogChange{{Op: CatalogReplace, Resource: t.kind, Rows: slices.Clone(after)}}
+	}
+	changes := []CatalogChange{}
+	for _, id := range beforeIDs {
+		if _, ok := afterIndex[id]; !ok {
+			changes = append(changes, CatalogChange{Op: CatalogRemove, Resource: t.kind, ID: id})
+		}
+	}
+	for index, row := range after {
+		id := afterIDs[index]
+		if previousIndex, ok := beforeIndex[id]; ok && reflect.DeepEqual(before[previousIndex], row) {
+			continue
+		}
+		changes = append(changes, CatalogChange{Op: CatalogUpsert, Resource: t.kind, ID: id, Row: row})
+	}
+	return changes
+}
+
+func (t rowTable[T]) ids(rows []T) ([]string, map[string]int, bool) {
+	ids := make([]string, 0, len(rows))
+	index := make(map[string]int, len(rows))
+	for position, row := range rows {
+		id := t.id(row)
+		if _, ok := index[id]; ok {
+			return nil, nil, false
+		}
+		ids = append(ids, id)
+		index[id] = position
+	}
+	return ids, index, true
+}
+
+func (t rowTable[T]) id(row T) string {
+	id, _ := t.cells(row)
+	return row.origin().qualify(id)
+}
+
+func (t rowTable[T]) indexOf(rows []T, id string) int {
+	for index, row := range rows {
+		if t.id(row) == id {
+			return index
+		}
+	}
+	return -1
+}
+
+// appendOnlyOrder reports whether surviving rows keep their order and new rows only append.
+func appendOnlyOrder(before []string, after []string, afterIndex map[string]int) bool {
+	kept := 0
+	for _, id := range before {
+		if _, ok := afterIndex[id]; !ok {
+			continue
+		}
+		if after[kept] != id {
+			return false
+		}
+		kept++
+	}
+	return true
+}
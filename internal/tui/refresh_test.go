@@ -0,0 +1,250 @@
+// Path: internal/tui/refresh_test.go
+// Description: Validate incremental catalog changes and in-place session view refresh.
+package tui
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func refreshCatalog() Catalog {
+	return Catalog{
+		VMs: []VMRow{
+			{Name: "vm-a", PowerState: "on", Cluster: "east"},
+			{Name: "vm-b", PowerState: "off", Cluster: "west"},
+			{Name: "vm-c", PowerState: "on", Cluster: "east"},
+		},
+		Datastores: []DatastoreRow{
+			{Name: "ds-a", Tags: "tier=gold"},
+			{Name: "ds-b", Tags: "tier=silver"},
+		},
+	}
+}
+
+func TestDiffCatalogRoundTripsEveryResource(t *testing.T) {
+	previous, err := LoadCatalog(fakeInventoryProvider{})
+	if err != nil {
+		t.Fatalf("LoadCatalog returned error: %v", err)
+	}
+	next := Catalog{
+		VMs:        []VMRow{{Name: "vm-a", PowerState: "off"}, {Name: "vm-new"}},
+		Events:     []EventRow{{Time: "t2"}, {Time: "t1"}},
+		Tags:       []TagRow{{Tag: "env"}, {Tag: "env"}},
+		Datastores: previous.Datastores,
+	}
+	changes := DiffCatalog(previous, next)
+	applied := previous
+	if err := applied.Apply(changes); err != nil {
+		t.Fatalf("Apply returned error: %v", err)
+	}
+	if remaining := DiffCatalog(applied, next); len(remaining) != 0 {
+		t.Fatalf("expected applied diff to equal next catalog, remaining %+v", remaining)
+	}
+	if previous.VMs[0].PowerState != "" {
+		t.Fatalf("expected apply to leave previous catalog rows untouched")
+	}
+	if len(DiffCatalog(next, next)) != 0 {
+		t.Fatalf("expected identical catalogs to produce no changes")
+	}
+}
+
+func TestDiffCatalogEmitsRowChangesForAppendOnlyTables(t *testing.T) {
+	previous := refreshCatalog()
+	next := refreshCatalog()
+	next.VMs = []VMRow{next.VMs[0], {Name: "vm-c", PowerState: "off"}, {Name: "vm-d"}}
+	changes := DiffCatalog(previous, next)
+	want := []CatalogChange{
+		{Op: CatalogRemove, Resource: ResourceVM, ID: "vm-b"},
+		{Op: CatalogUpsert, Resource: ResourceVM, ID: "vm-c", Row: VMRow{Name: "vm-c", PowerState: "off"}},
+		{Op: CatalogUpsert, Resource: ResourceVM, ID: "vm-d", Row: VMRow{Name: "vm-d"}},
+	}
+	if !reflect.DeepEqual(changes, want) {
+		t.Fatalf("unexpected changes: %+v", changes)
+	}
+	reordered := refreshCatalog()
+	reordered.VMs = []VMRow{reordered.VMs[1], reordered.VMs[0], reordered.VMs[2]}
+	changes = DiffCatalog(previous, reordered)
+	if len(changes) != 1 || changes[0].Op != CatalogReplace {
+		t.Fatalf("expected reorder to replace the table, got %+v", changes)
+	}
+	duplicated := Catalog{Tags: []TagRow{{Tag: "env"}, {Tag: "env"}}}
+	if len(DiffCatalog(duplicated, duplicated)) != 0 {
+		t.Fatalf("expected identical duplicate rows to produce no changes")
+	}
+}
+
+func TestCatalogApplyRejectsInvalidChanges(t *testing.T) {
+	invalid := []CatalogChange{
+		{Op: CatalogUpsert, Resource: ResourcePulse, ID: "x"},
+		{Op: CatalogUpsert, Resource: ResourceVM, ID: "vm-a", Row: HostRow{Name: "vm-a"}},
+		{Op: CatalogUpsert, Resource: ResourceVM, ID: "vm-z", Row: VMRow{Name: "vm-a"}},
+		{Op: CatalogReplace, Resource: ResourceVM, Rows: []HostRow{}},
+		{Op: "merge", Resource: ResourceVM, ID: "vm-a"},
+	}
+	for _, change := range invalid {
+		catalog := refreshCatalog()
+		changes := []CatalogChange{{Op: CatalogRemove, Resource: ResourceVM, ID: "vm-b"}, change}
+		if err := catalog.Apply(changes); !errors.Is(err, ErrInvalidCatalogChange) {
+			t.Fatalf("expected invalid change error for %+v, got %v", change, err)
+		}
+		if len(catalog.VMs) != 3 {
+			t.Fatalf("expected failed apply to leave catalog untouched, got %+v", catalog.VMs)
+		}
+	}
+}
+
+func TestApplyCatalogChangesPreservesSelectionMarksAndSort(t *testing.T) {
+	session := NewSession(refreshCatalog())
+	session.sortByColumn("NAME", false)
+	session.marks["vm-a"] = struct{}{}
+	session.marks["vm-b"] = struct{}{}
+	session.markAnchor = 2
+	session.SetSelection(indexOfID(session.CurrentView().IDs, "vm-a"), 1)
+	err := session.ApplyCatalogChanges([]CatalogChange{
+		{Op: CatalogRemove, Resource: ResourceVM, ID: "vm-b"},
+		{Op: CatalogUpsert, Resource: ResourceVM, ID: "vm-a", Row: VMRow{Name: "vm-a", PowerState: "suspended"}},
+	})
+	if err != nil {
+		t.Fatalf("ApplyCatalogChanges returned error: %v", err)
+	}
+	view := session.CurrentView()
+	if !reflect.DeepEqual(view.IDs, []string{"vm-c", "vm-a"}) {
+		t.Fatalf("expected descending sort to survive refresh, got %v", view.IDs)
+	}
+	if session.SelectedRow() != 1 || session.SelectedColumn() != 1 || view.Rows[1][1] != "suspended" {
+		t.Fatalf("expected selection to follow vm-a, got row=%d col=%d rows=%v", session.SelectedRow(), session.SelectedColumn(), view.Rows)
+	}
+	if !session.IsMarked("vm-a") || session.IsMarked("vm-b") || session.markAnchor != -1 {
+		t.Fatalf("expected marks to be pruned to surviving rows, got %v anchor=%d", session.marks, session.markAnchor)
+	}
+	if len(session.Catalog().VMs) != 2 {
+		t.Fatalf("expected session catalog to include applied changes, got %+v", session.Catalog().VMs)
+	}
+	if err := session.ApplyCatalogChanges(nil); err != nil {
+		t.Fatalf("expected empty change set to be a no-op, got %v", err)
+	}
+	if err := session.ApplyCatalogChanges([]CatalogChange{{Op: "merge", Resource: ResourceVM}}); err == nil {
+		t.Fatalf("expected invalid change to fail")
+	}
+}
+
+func TestRefreshViewReappliesActiveFilters(t *testing.T) {
+	session := NewSession(refreshCatalog())
+	upsert := func(name string, state string, cluster string) {
+		t.Helper()
+		row := VMRow{Name: name, PowerState: state, Cluster: cluster}
+		if err := session.ApplyCatalogChanges([]CatalogChange{{Op: CatalogUpsert, Resource: ResourceVM, ID: name, Row: row}}); err != nil {
+			t.Fatalf("ApplyCatalogChanges returned error: %v", err)
+		}
+	}
+	session.ApplyFilter("east")
+	upsert("vm-e", "on", "east")
+	if !reflect.DeepEqual(session.CurrentView().IDs, []string{"vm-a", "vm-c", "vm-e"}) {
+		t.Fatalf("expected substring filter to include new match, got %v", session.CurrentView().IDs)
+	}
+	if err := session.ApplyInverseRegexFilter("^vm-[ab]$"); err != nil {
+		t.Fatalf("ApplyInverseRegexFilter returned error: %v", err)
+	}
+	upsert("vm-b", "on", "east")
+	if !reflect.DeepEqual(session.CurrentView().IDs, []string{"vm-c", "vm-e"}) {
+		t.Fatalf("expected inverse regex filter to survive refresh, got %v", session.CurrentView().IDs)
+	}
+	if err := session.ApplyFuzzyFilter("vme"); err != nil {
+		t.Fatalf("ApplyFuzzyFilter returned error: %v", err)
+	}
+	upsert("vm-f", "off", "west")
+	if !reflect.DeepEqual(session.CurrentView().IDs, []string{"vm-e"}) {
+		t.Fatalf("expected fuzzy filter to survive refresh, got %v", session.CurrentView().IDs)
+	}
+	session.ApplyFilter("")
+	session.toggleFaultMode()
+	upsert("vm-c", "suspended", "east")
+	if !reflect.DeepEqual(session.CurrentView().IDs, []string{"vm-c"}) {
+		t.Fatalf("expected fault mode to survive refresh, got %v", session.CurrentView().IDs)
+	}
+}
+
+func TestRefreshViewHandlesTagFiltersColumnsAndXRay(t *testing.T) {
+	session := NewSession(refreshCatalog())
+	if err := session.ExecuteCommand(":ds"); err != nil {
+		t.Fatalf("ExecuteCommand returned error: %v", err)
+	}
+	if err := session.SetVisibleColumns([]string{"NAME", "TAGS"}); err != nil {
+		t.Fatalf("SetVisibleColumns returned error: %v", err)
+	}
+	if err := session.ApplyTagFilter("tier=gold"); err != nil {
+		t.Fatalf("ApplyTagFilter returned error: %v", err)
+	}
+	row := DatastoreRow{Name: "ds-c", Tags: "tier=gold"}
+	if err := session.ApplyCatalogChanges([]CatalogChange{{Op: CatalogUpsert, Resource: ResourceDatastore, ID: "ds-c", Row: row}}); err != nil {
+		t.Fatalf("ApplyCatalogChanges returned error: %v", err)
+	}
+	view := session.CurrentView()
+	if !reflect.DeepEqual(view.IDs, []string{"ds-a", "ds-c"}) || len(view.Columns) != 2 {
+		t.Fatalf("expected tag filter and stored columns to survive refresh, got %v %v", view.IDs, view.Columns)
+	}
+	session.columnSelection[ResourceDatastore] = []string{"MISSING"}
+	if err := session.RefreshView(); !errors.Is(err, ErrInvalidColumns) {
+		t.Fatalf("expected stored column error, got %v", err)
+	}
+	if err := session.ExecuteCommand(":xray"); err != nil {
+		t.Fatalf("ExecuteCommand returned error: %v", err)
+	}
+	session.expandXRayOneLevel()
+	expanded := len(session.CurrentView().Rows)
+	if err := session.RefreshView(); err != nil || len(session.CurrentView().Rows) != expanded {
+		t.Fatalf("expected xray depth to survive refresh, got %d rows err=%v", len(session.CurrentView().Rows), err)
+	}
+	session.view.Resource = "bogus"
+	if err := session.RefreshView(); !errors.Is(err, ErrUnknownResource) {
+		t.Fatalf("expected unknown resource error, got %v", err)
+	}
+}
+
+func TestAggregatedRowsQualifyIDsAndShowVCenterColumn(t *testing.T) {
+	sourced := func(vcenter string, row HostRow) HostRow {
+		row.SetVCenter(vcenter)
+		return row
+	}
+	previous := Catalog{
+		Hosts: []HostRow{
+			sourced("vc-a", HostRow{Name: "esxi-01", ConnectionState: "connected"}),
+			sourced("vc-b", HostRow{Name: "esxi-01", ConnectionState: "connected"}),
+		},
+	}
+	session := NewSession(previous)
+	if err := session.ExecuteCommand(":host"); err != nil {
+		t.Fatalf("ExecuteCommand returned error: %v", err)
+	}
+	view := session.CurrentView()
+	if !reflect.DeepEqual(view.IDs, []string{"vc-a/esxi-01", "vc-b/esxi-01"}) {
+		t.Fatalf("expected vcenter-qualified ids, got %v", view.IDs)
+	}
+	if column := findColumnIndex(view.Columns, "VCENTER"); column != len(view.Columns)-1 || view.Rows[1][column] != "vc-b" {
+		t.Fatalf("expected trailing VCENTER column, got %v %v", view.Columns, view.Rows)
+	}
+	if findColumnIndex(vmView(refreshCatalog().VMs).Columns, "VCENTER") >= 0 {
+		t.Fatalf("expected single-endpoint views to omit the VCENTER column")
+	}
+	session.selectedRow = 1
+	executor := &fakeExecutor{}
+	if err := session.ApplyAction("enter-maintenance", executor); err != nil {
+		t.Fatalf("enter-maintenance returned error: %v", err)
+	}
+	hosts := session.Catalog().Hosts
+	if executor.ids[0] != "vc-b/esxi-01" || hosts[0].ConnectionState != "connected" || hosts[1].ConnectionState != "maintenance" {
+		t.Fatalf("expected only the vc-b host to enter maintenance, ids=%v hosts=%+v", executor.ids, hosts)
+	}
+	next := Catalog{Hosts: []HostRow{previous.Hosts[0], sourced("vc-b", HostRow{Name: "esxi-01", ConnectionState: "disconnected"})}}
+	changes := DiffCatalog(previous, next)
+	if len(changes) != 1 || changes[0].Op != CatalogUpsert || changes[0].ID != "vc-b/esxi-01" {
+		t.Fatalf("expected a row-level upsert for the vc-b host, got %+v", changes)
+	}
+	if err := previous.Apply(append(changes, CatalogChange{Op: CatalogRemove, Resource: ResourceHost, ID: "vc-a/esxi-01"})); err != nil {
+		t.Fatalf("Apply returned error: %v", err)
+	}
+	if len(previous.Hosts) != 1 || previous.Hosts[0].ConnectionState != "disconnected" {
+		t.Fatalf("expected qualified apply to update vc-b and remove vc-a, got %+v", previous.Hosts)
+	}
+}
@@ -5,6 +5,7 @@ package tui
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/takelley1/hypersphere/internal/deletion"
 	"github.com/takelley1/hypersphere/internal/migration"
@@ -20,19 +21,73 @@ func RenderMigrationPlan(plan []migration.PlanStep) string {
 		if step.SkipReason != "" {
 			status = step.SkipReason
 		}
+		if step.Rule != "" {
+			status += "(" + step.Rule + ")"
+		}
 		line := fmt.Sprintf("%d %s %s %s %s %s\n", step.Order, step.VMName, step.SourceDatastore, step.TargetDatastore, step.Tier, status)
 		builder.WriteString(line)
+		for _, warning := range step.Warnings {
+			builder.WriteString("  warning: " + warning + "\n")
+		}
+	}
+	return builder.String()
+}
+
+// RenderComputePlan format host move rows with the target's projected utilization.
+func RenderComputePlan(plan []migration.PlanStep) string {
+	builder := &strings.Builder{}
+	builder.WriteString("Compute Plan\n")
+	builder.WriteString("# VM SOURCE TARGET UTIL STATUS\n")
+	for _, step := range plan {
+		target, util, status := step.TargetHost, fmt.Sprintf("%d%%", step.ProjectedUtil), "READY"
+		if step.SkipReason != "" {
+			target, util, status = "-", "-", step.SkipReason
+		}
+		line := fmt.Sprintf("%d %s %s %s %s %s\n", step.Order, step.VMName, step.Host, target, util, status)
+		builder.WriteString(line)
+	}
+	return builder.String()
+}
+
+// RenderStepResults format per-step execution outcomes with attempts, move
+// duration, gigabytes moved, and the last error.
+func RenderStepResults(results []migration.StepResult) string {
+	builder := &strings.Builder{}
+	builder.WriteString("Step Results\n")
+	builder.WriteString("# VM SOURCE TARGET STATUS ATTEMPTS DURATION GB ERROR\n")
+	for _, result := range results {
+		target, errText := result.TargetDatastore, result.Error
+		if target == "" {
+			target = "-"
+		}
+		if errText == "" {
+			errText = "-"
+		}
+		line := fmt.Sprintf(
+			"%d %s %s %s %s %d %s %d %s\n",
+			result.Order,
+			result.VMName,
+			result.SourceDatastore,
+			target,
+			result.Status,
+			result.Attempts,
+			result.Duration().Round(time.Millisecond),
+			result.BytesMoved>>30,
+			errText,
+		)
+		builder.WriteString(line)
 	}
 	return builder.String()
 }
 
-// RenderDeletionPlan format lifecycle action rows.
+// RenderDeletionPlan format lifecycle action rows with the policy each VM
+// was planned under.
 func RenderDeletionPlan(actions []deletion.Action) string {
 	builder := &strings.Builder{}
 	builder.WriteString("Pending Deletion Plan\n")
-	builder.WriteString("ACTION VM NOTES\n")
+	builder.WriteString("ACTION VM POLICY NOTES\n")
 	for _, action := range actions {
-		line := fmt.Sprintf("%s %s %s\n", action.Type, action.VMName, action.Notes)
+		line := fmt.Sprintf("%s %s %s %s\n", action.Type, action.VMName, action.Policy, action.Notes)
 		builder.WriteString(line)
 	}
 	return builder.String()
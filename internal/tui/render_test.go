@@ -5,6 +5,7 @@ package tui
 import (
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/takelley1/hypersphere/internal/deletion"
 	"github.com/takelley1/hypersphere/internal/migration"
@@ -16,12 +17,46 @@ func TestRenderMigrationPlan(t *testing.T) {
 	if !strings.Contains(out, "Migration Plan") || !strings.Contains(out, "OVER_85") {
 		t.Fatalf("unexpected render output: %s", out)
 	}
+	ruled := RenderMigrationPlan([]migration.PlanStep{{Order: 1, VMName: "db-2", SourceDatastore: "src", SkipReason: migration.SkipAntiAffinity, Rule: "db", Tier: "-"}})
+	if !strings.Contains(ruled, "ANTI_AFFINITY(db)") {
+		t.Fatalf("expected rule in status column: %s", ruled)
+	}
+	warned := RenderMigrationPlan([]migration.PlanStep{{Order: 1, VMName: "vm-a", SourceDatastore: "src", TargetDatastore: "ds-1", Tier: "primary", Warnings: []string{"raw device mappings: 2"}}})
+	if !strings.Contains(warned, "1 vm-a src ds-1 primary READY\n  warning: raw device mappings: 2\n") {
+		t.Fatalf("expected warning under the step row: %s", warned)
+	}
+}
+
+func TestRenderComputePlan(t *testing.T) {
+	plan := []migration.PlanStep{
+		{Order: 1, VMName: "vm-a", Host: "esx-1", TargetHost: "esx-2", ProjectedUtil: 64},
+		{Order: 2, VMName: "vm-b", Host: "esx-1", SkipReason: migration.SkipHostOverloaded},
+	}
+	want := "Compute Plan\n# VM SOURCE TARGET UTIL STATUS\n1 vm-a esx-1 esx-2 64% READY\n2 vm-b esx-1 - - HOST_OVERLOADED\n"
+	if out := RenderComputePlan(plan); out != want {
+		t.Fatalf("unexpected render output: %q", out)
+	}
+}
+
+func TestRenderStepResults(t *testing.T) {
+	started := time.Date(2026, 10, 16, 22, 0, 0, 0, time.UTC)
+	results := []migration.StepResult{
+		{Order: 1, VMName: "vm-a", SourceDatastore: "src", TargetDatastore: "ds-1", Status: migration.ProgressMigrated, Attempts: 1,
+			Started: started, Finished: started.Add(90 * time.Second), BytesMoved: 40 << 30},
+		{Order: 2, VMName: "vm-b", SourceDatastore: "src", Status: migration.ProgressSkipped, Error: migration.SkipOverThreshold},
+	}
+	want := "Step Results\n# VM SOURCE TARGET STATUS ATTEMPTS DURATION GB ERROR\n" +
+		"1 vm-a src ds-1 migrated 1 1m30s 40 -\n2 vm-b src - skipped 0 0s 0 OVER_85\n"
+	if out := RenderStepResults(results); out != want {
+		t.Fatalf("unexpected render output: %q", out)
+	}
 }
 
 func TestRenderDeletionPlan(t *testing.T) {
-	actions := []deletion.Action{{Type: deletion.ActionMark, VMName: "vm-a", Notes: "delete_on=2026-03-01"}, {Type: deletion.ActionPurge, VMName: "vm-b", Notes: "expired"}}
+	actions := []deletion.Action{{Type: deletion.ActionMark, VMName: "vm-a", Policy: "dev", Notes: "delete_on=2026-03-01"}, {Type: deletion.ActionPurge, VMName: "vm-b", Policy: "default", Notes: "expired"}}
 	out := RenderDeletionPlan(actions)
-	if !strings.Contains(out, "Pending Deletion Plan") || !strings.Contains(out, "purge") {
+	if !strings.Contains(out, "Pending Deletion Plan") || !strings.Contains(out, "purge") ||
+		!strings.Contains(out, "ACTION VM POLICY NOTES\nmark vm-a dev delete_on=2026-03-01\n") {
 		t.Fatalf("unexpected render output: %s", out)
 	}
 }
@@ -0,0 +1,436 @@
+// Path: internal/vsphere/client.go
+// Description: Speak the vim25 SOAP protocol to a vCenter endpoint over net/http.
+package vsphere
+
+import (
+	"context"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"slices"
+	"strings"
+	"syscall"
+	"time"
+)
+
+const (
+	soapAction     = "urn:vim25/7.0.3.0"
+	envelopeHeader = `<?xml version="1.0" encoding="UTF-8"?>` +
+		`<soapenv:Envelope xmlns:soapenv="http://schemas.xmlsoap.org/soap/envelope/"` +
+		` xmlns:xsd="http://www.w3.org/2001/XMLSchema"` +
+		` xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance"><soapenv:Body>`
+	envelopeFooter = `</soapenv:Body></soapenv:Envelope>`
+	requestTimeout = 60 * time.Second
+)
+
+var (
+	// ErrEndpointRequired indicates a missing vCenter URL.
+	ErrEndpointRequired = errors.New("vcenter endpoint url is required")
+	// ErrSOAPTransport indicates an HTTP or envelope failure outside a SOAP fault.
+	ErrSOAPTransport = errors.New("vsphere soap transport failed")
+	// ErrThumbprintMismatch indicates a server certificate that does not match the pinned thumbprint.
+	ErrThumbprintMismatch = errors.New("vcenter certificate thumbprint mismatch")
+)
+
+// Endpoint describes how to reach and authenticate against one vCenter.
+type Endpoint struct {
+	URL      string
+	Username string
+	Password string
+	Insecure bool
+	// Thumbprint pins the SHA-1 or SHA-256 fingerprint of the server certificate.
+	Thumbprint string
+}
+
+// Fault reports a SOAP fault returned by vCenter.
+type Fault struct {
+	Code    string
+	Message string
+	Kind    string
+}
+
+// Error format the fault kind and message.
+func (f *Fault) Error() string {
+	if f.Kind == "" {
+		return "vsphere fault: " + f.Message
+	}
+	return "vsphere fault " + f.Kind + ": " + f.Message
+}
+
+// transientFaults lists the fault kinds that report a passing condition, such
+// as an object busy with another task or a host briefly out of contact.
+var transientFaults = []string{
+	"TaskInProgress", "ResourceInUse", "ConcurrentAccess",
+	"HostCommunication", "HostNotConnected", "HostNotReachable",
+}
+
+// Retriable report whether repeating the call may succeed. Only transient
+// fault kinds are retried; any other fault, including a kind this package
+// does not know, fails the same way on every attempt.
+func (f *Fault) Retriable() bool {
+	return retriableFault(f.Kind)
+}
+
+func retriableFault(kind string) bool {
+	return slices.Contains(transientFaults, kind)
+}
+
+// TransportError reports a request that got no HTTP response, such as a
+// refused connection or a request timeout.
+type TransportError struct {
+	Err error
+}
+
+// Error format the transport failure.
+func (e *TransportError) Error() string {
+	return fmt.Sprintf("%v: %v", ErrSOAPTransport, e.Err)
+}
+
+// Unwrap return ErrSOAPTransport and the underlying failure.
+func (e *TransportError) Unwrap() []error {
+	return []error{ErrSOAPTransport, e.Err}
+}
+
+// Retriable report whether the request timed out or its connection was
+// refused or dropped, which a later attempt may not hit. Certificate and
+// other failures are not retried.
+func (e *TransportError) Retriable() bool {
+	var network net.Error
+	if errors.As(e.Err, &network) && network.Timeout() {
+		return true
+	}
+	return errors.Is(e.Err, syscall.ECONNREFUSED) || errors.Is(e.Err, syscall.ECONNRESET) ||
+		errors.Is(e.Err, io.EOF) || errors.Is(e.Err, io.ErrUnexpectedEOF)
+}
+
+// AboutInfo identifies the vCenter product and API version.
+type AboutInfo struct {
+	FullName     string `xml:"fullName"`
+	APIVersion   string `xml:"apiVersion"`
+	InstanceUUID string `xml:"instanceUuid"`
+}
+
+// ServiceContent lists the singleton managed objects of a vCenter.
+type ServiceContent struct {
+	RootFolder          ManagedObjectReference `xml:"rootFolder"`
+	PropertyCollector   ManagedObjectReference `xml:"propertyCollector"`
+	ViewManager         ManagedObjectReference `xml:"viewManager"`
+	About               AboutInfo              `xml:"about"`
+	SessionManager      ManagedObjectReference `xml:"sessionManager"`
+	TaskManager         ManagedObjectReference `xml:"taskManager"`
+	EventManager        ManagedObjectReference `xml:"eventManager"`
+	CustomFieldsManager ManagedObjectReference `xml:"customFieldsManager"`
+	PerfManager         ManagedObjectReference `xml:"perfManager"`
+}
+
+// Client issues vim25 SOAP calls against one vCenter session.
+type Client struct {
+	url     string
+	http    *http.Client
+	content ServiceContent
+}
+
+// NewClient build an unauthenticated client for an endpoint.
+func NewClient(endpoint Endpoint) (*Client, error) {
+	target, err := sdkURL(endpoint.URL)
+	if err != nil {
+		return nil, err
+	}
+	jar, _ := cookiejar.New(nil)
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig(endpoint)
+	return &Client{
+		url:  target,
+		http: &http.Client{Transport: transport, Jar: jar, Timeout: requestTimeout},
+	}, nil
+}
+
+// Dial connect to a vCenter endpoint and log in.
+func Dial(ctx context.Context, endpoint Endpoint) (*Client, error) {
+	client, err := NewClient(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	if err := client.RetrieveServiceContent(ctx); err != nil {
+		return nil, err
+	}
+	if err := client.Login(ctx, endpoint.Username, endpoint.Password); err != nil {
+		return nil, err
+	}
+	return client, nil
+}
+
+// ServiceContent return the service content retrieved at connect time.
+func (c *Client) ServiceContent() ServiceContent {
+	return c.content
+}
+
+// RetrieveServiceContent fetch the singleton managed object references.
+func (c *Client) RetrieveServiceContent(ctx context.Context) error {
+	request := struct {
+		XMLName xml.Name               `xml:"urn:vim25 RetrieveServiceContent"`
+		This    ManagedObjectReference `xml:"_this"`
+	}{This: ManagedObjectReference{Type: "ServiceInstance", Value: "ServiceInstance"}}
+	response := struct {
+		Returnval ServiceContent `xml:"returnval"`
+	}{}
+	if err := c.call(ctx, request, &response); err != nil {
+		return err
+	}
+	c.content = response.Returnval
+	return nil
+}
+
+// Login open an authenticated session.
+func (c *Client) Login(ctx context.Context, username string, password string) error {
+	request := struct {
+		XMLName  xml.Name               `xml:"urn:vim25 Login"`
+		This     ManagedObjectReference `xml:"_this"`
+		UserName string                 `xml:"userName"`
+		Password string                 `xml:"password"`
+	}{This: c.content.SessionManager, UserName: username, Password: password}
+	return c.call(ctx, request, nil)
+}
+
+// Logout close the authenticated session.
+func (c *Client) Logout(ctx context.Context) error {
+	request := struct {
+		XMLName xml.Name               `xml:"urn:vim25 Logout"`
+		This    ManagedObjectReference `xml:"_this"`
+	}{This: c.content.SessionManager}
+	return c.call(ctx, request, nil)
+}
+
+// CreateContainerView create a view over objects of the given types below a container.
+func (c *Client) CreateContainerView(
+	ctx context.Context,
+	container ManagedObjectReference,
+	types []string,
+	recursive bool,
+) (ManagedObjectReference, error) {
+	request := struct {
+		XMLName   xml.Name               `xml:"urn:vim25 CreateContainerView"`
+		This      ManagedObjectReference `xml:"_this"`
+		Container ManagedObjectReference `xml:"container"`
+		Type      []string               `xml:"type"`
+		Recursive bool                   `xml:"recursive"`
+	}{This: c.content.ViewManager, Container: container, Type: types, Recursive: recursive}
+	response := struct {
+		Returnval ManagedObjectReference `xml:"returnval"`
+	}{}
+	err := c.call(ctx, request, &response)
+	return response.Returnval, err
+}
+
+// DestroyView release a view created by CreateContainerView.
+func (c *Client) DestroyView(ctx context.Context, view ManagedObjectReference) error {
+	request := struct {
+		XMLName xml.Name               `xml:"urn:vim25 DestroyView"`
+		This    ManagedObjectReference `xml:"_this"`
+	}{This: view}
+	return c.call(ctx, request, nil)
+}
+
+// RetrieveProperties retrieve every page of objects matching a filter spec.
+func (c *Client) RetrieveProperties(
+	ctx context.Context,
+	spec PropertyFilterSpec,
+) ([]ObjectContent, error) {
+	request := struct {
+		XMLName xml.Name               `xml:"urn:vim25 RetrievePropertiesEx"`
+		This    ManagedObjectReference `xml:"_this"`
+		SpecSet []PropertyFilterSpec   `xml:"specSet"`
+		Options struct{}               `xml:"options"`
+	}{This: c.content.PropertyCollector, SpecSet: []PropertyFilterSpec{spec}}
+	response := struct {
+		Returnval RetrieveResult `xml:"returnval"`
+	}{}
+	if err := c.call(ctx, request, &response); err != nil {
+		return nil, err
+	}
+	objects := response.Returnval.Objects
+	token := response.Returnval.Token
+	for token != "" {
+		page, err := c.continueRetrieve(ctx, token)
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, page.Objects...)
+		token = page.Token
+	}
+	return objects, nil
+}
+
+// RetrieveInventory retrieve properties for every object of the spec types in the inventory.
+func (c *Client) RetrieveInventory(
+	ctx context.Context,
+	specs []PropertySpec,
+) ([]ObjectContent, error) {
+	view, err := c.CreateContainerView(ctx, c.content.RootFolder, specTypes(specs), true)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = c.DestroyView(ctx, view) }()
+	return c.RetrieveProperties(ctx, PropertyFilterSpec{
+		PropSet:   specs,
+		ObjectSet: []ObjectSpec{containerViewObjectSpec(view)},
+	})
+}
+
+// RetrieveObjects retrieve properties for an explicit list of objects.
+func (c *Client) RetrieveObjects(
+	ctx context.Context,
+	refs []ManagedObjectReference,
+	specs []PropertySpec,
+) ([]ObjectContent, error) {
+	if len(refs) == 0 {
+		return nil, nil
+	}
+	objectSet := make([]ObjectSpec, 0, len(refs))
+	for _, ref := range refs {
+		objectSet = append(objectSet, ObjectSpec{Obj: ref})
+	}
+	return c.RetrieveProperties(ctx, PropertyFilterSpec{PropSet: specs, ObjectSet: objectSet})
+}
+
+func (c *Client) continueRetrieve(ctx context.Context, token string) (RetrieveResult, error) {
+	request := struct {
+		XMLName xml.Name               `xml:"urn:vim25 ContinueRetrievePropertiesEx"`
+		This    ManagedObjectReference `xml:"_this"`
+		Token   string                 `xml:"token"`
+	}{This: c.content.PropertyCollector, Token: token}
+	response := struct {
+		Returnval RetrieveResult `xml:"returnval"`
+	}{}
+	err := c.call(ctx, request, &response)
+	return response.Returnval, err
+}
+
+type responseEnvelope struct {
+	Body struct {
+		Fault *soapFault `xml:"Fault"`
+		Inner []byte     `xml:",innerxml"`
+	} `xml:"Body"`
+}
+
+type soapFault struct {
+	Code   string `xml:"faultcode"`
+	String string `xml:"faultstring"`
+	Detail struct {
+		Faults []faultDetail `xml:",any"`
+	} `xml:"detail"`
+}
+
+type faultDetail struct {
+	XMLName xml.Name
+	Attrs   []xml.Attr `xml:",any,attr"`
+}
+
+func (f soapFault) fault() *Fault {
+	fault := &Fault{Code: f.Code, Message: f.String}
+	if len(f.Detail.Faults) == 0 {
+		return fault
+	}
+	detail := f.Detail.Faults[0]
+	fault.Kind = xsiType(detail.Attrs)
+	if fault.Kind == "" {
+		fault.Kind = strings.TrimSuffix(detail.XMLName.Local, "Fault")
+	}
+	return fault
+}
+
+func (c *Client) call(ctx context.Context, request any, response any) error {
+	body, err := xml.Marshal(request)
+	if err != nil {
+		return err
+	}
+	payload := envelopeHeader + string(body) + envelopeFooter
+	httpRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, strings.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	httpRequest.Header.Set("Content-Type", `text/xml; charset="utf-8"`)
+	httpRequest.Header.Set("SOAPAction", soapAction)
+	httpResponse, err := c.http.Do(httpRequest)
+	if err != nil && ctx.Err() != nil {
+		return fmt.Errorf("%w: %w", ErrSOAPTransport, err)
+	}
+	if err != nil {
+		return &TransportError{Err: err}
+	}
+	defer httpResponse.Body.Close()
+	envelope := responseEnvelope{}
+	if err := xml.NewDecoder(httpResponse.Body).Decode(&envelope); err != nil {
+		return fmt.Errorf("%w: %s: %v", ErrSOAPTransport, httpResponse.Status, err)
+	}
+	if envelope.Body.Fault != nil {
+		return envelope.Body.Fault.fault()
+	}
+	if httpResponse.StatusCode != http.StatusOK {
+		return fmt.Errorf("%w: %s", ErrSOAPTransport, httpResponse.Status)
+	}
+	if response == nil {
+		return nil
+	}
+	if err := xml.Unmarshal(envelope.Body.Inner, response); err != nil {
+		return fmt.Errorf("%w: %v", ErrSOAPTransport, err)
+	}
+	return nil
+}
+
+func tlsConfig(endpoint Endpoint) *tls.Config {
+	pinned := normalizeThumbprint(endpoint.Thumbprint)
+	if pinned == "" {
+		return &tls.Config{InsecureSkipVerify: endpoint.Insecure}
+	}
+	return &tls.Config{
+		InsecureSkipVerify: true,
+		VerifyPeerCertificate: func(raw [][]byte, _ [][]*x509.Certificate) error {
+			if len(raw) > 0 && certificateThumbprint(raw[0], len(pinned)) == pinned {
+				return nil
+			}
+			return fmt.Errorf("%w: expected %s", ErrThumbprintMismatch, endpoint.Thumbprint)
+		},
+	}
+}
+
+func normalizeThumbprint(raw string) string {
+	return strings.ToUpper(strings.NewReplacer(":", "", " ", "").Replace(strings.TrimSpace(raw)))
+}
+
+func certificateThumbprint(der []byte, size int) string {
+	if size == sha1.Size*2 {
+		sum := sha1.Sum(der)
+		return strings.ToUpper(hex.EncodeToString(sum[:]))
+	}
+	sum := sha256.Sum256(der)
+	return strings.ToUpper(hex.EncodeToString(sum[:]))
+}
+
+func sdkURL(raw string) (string, error) {
+	trimmed := strings.TrimSpace(raw)
+	if trimmed == "" {
+		return "", ErrEndpointRequired
+	}
+	if !strings.Contains(trimmed, "://") {
+		trimmed = "https://" + trimmed
+	}
+	parsed, err := url.Parse(trimmed)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrEndpointRequired, err)
+	}
+	if parsed.Path == "" || parsed.Path == "/" {
+		parsed.Path = "/sdk"
+	}
+	return parsed.String(), nil
+}
@@ -0,0 +1,207 @@
+// Path: internal/vsphere/client_test.go
+// Description: Verify vim25 SOAP client session handling and fault reporting.
+package vsphere
+
+import (
+	"context"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/takelley1/hypersphere/internal/migration"
+)
+
+func TestDialLogsInAndReadsServiceContent(t *testing.T) {
+	sim := newSimulator(t)
+	client := sim.dial(t)
+	content := client.ServiceContent()
+	if content.RootFolder.String() != "Folder:group-d1" || content.About.APIVersion != "7.0.3.0" {
+		t.Fatalf("unexpected service content: %+v", content)
+	}
+	if err := client.Logout(t.Context()); err != nil {
+		t.Fatalf("Logout returned error: %v", err)
+	}
+}
+
+func TestDialRejectsBadEndpointsAndCredentials(t *testing.T) {
+	if _, err := Dial(t.Context(), Endpoint{}); !errors.Is(err, ErrEndpointRequired) {
+		t.Fatalf("expected missing endpoint error, got %v", err)
+	}
+	sim := newSimulator(t)
+	endpoint := sim.endpoint()
+	endpoint.Password = "wrong"
+	_, err := Dial(t.Context(), endpoint)
+	fault := &Fault{}
+	if !errors.As(err, &fault) || fault.Kind != "InvalidLogin" {
+		t.Fatalf("expected InvalidLogin fault, got %v", err)
+	}
+	sim.failOn("RetrieveServiceContent", 1)
+	if _, err := Dial(t.Context(), sim.endpoint()); err == nil {
+		t.Fatalf("expected service content failure")
+	}
+}
+
+func TestDialVerifiesCertificatesUnlessInsecure(t *testing.T) {
+	sim := newSimulator(t)
+	endpoint := sim.endpoint()
+	endpoint.Insecure = false
+	if _, err := Dial(t.Context(), endpoint); !errors.Is(err, ErrSOAPTransport) || migration.IsRetriable(err) {
+		t.Fatalf("expected certificate verification failure without retry, got %v", err)
+	}
+}
+
+func TestDialPinsCertificateThumbprints(t *testing.T) {
+	sim := newSimulator(t)
+	raw := sim.server.Certificate().Raw
+	sha1Sum := sha1.Sum(raw)
+	sha256Sum := sha256.Sum256(raw)
+	pairs := []string{}
+	for _, b := range sha1Sum {
+		pairs = append(pairs, fmt.Sprintf("%02x", b))
+	}
+	for _, thumbprint := range []string{strings.Join(pairs, ":"), hex.EncodeToString(sha256Sum[:])} {
+		endpoint := sim.endpoint()
+		endpoint.Insecure = false
+		endpoint.Thumbprint = thumbprint
+		if _, err := Dial(t.Context(), endpoint); err != nil {
+			t.Fatalf("expected pinned thumbprint %s to connect, got %v", thumbprint, err)
+		}
+	}
+	endpoint := sim.endpoint()
+	endpoint.Thumbprint = strings.Repeat("AB:", 19) + "AB"
+	if _, err := Dial(t.Context(), endpoint); !errors.Is(err, ErrSOAPTransport) || !strings.Contains(err.Error(), "thumbprint") {
+		t.Fatalf("expected thumbprint mismatch to fail even when insecure, got %v", err)
+	}
+}
+
+func TestRetrieveRequiresAuthenticatedSession(t *testing.T) {
+	sim := newSimulator(t)
+	client, err := NewClient(sim.endpoint())
+	if err != nil {
+		t.Fatalf("NewClient returned error: %v", err)
+	}
+	if err := client.RetrieveServiceContent(t.Context()); err != nil {
+		t.Fatalf("RetrieveServiceContent returned error: %v", err)
+	}
+	_, err = client.RetrieveInventory(t.Context(), inventorySpecs)
+	fault := &Fault{}
+	if !errors.As(err, &fault) || fault.Kind != "NotAuthenticated" {
+		t.Fatalf("expected NotAuthenticated fault, got %v", err)
+	}
+}
+
+func TestRetrievePropertiesFailsOnContinuationFault(t *testing.T) {
+	sim := newSimulator(t)
+	sim.pageSize = 1
+	client := sim.dial(t)
+	sim.failOn("ContinueRetrievePropertiesEx", 1)
+	if _, err := client.RetrieveInventory(t.Context(), inventorySpecs); err == nil {
+		t.Fatalf("expected continuation failure")
+	}
+	objects, err := client.RetrieveObjects(t.Context(), nil, detailSpecs)
+	if err != nil || objects != nil {
+		t.Fatalf("expected empty object list to skip retrieval, got %v err=%v", objects, err)
+	}
+}
+
+func TestCallReportsTransportAndEnvelopeFailures(t *testing.T) {
+	responses := map[string]func(http.ResponseWriter){
+		"not xml": func(w http.ResponseWriter) { _, _ = io.WriteString(w, "gateway timeout") },
+		"status": func(w http.ResponseWriter) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = io.WriteString(w, envelopeHeader+envelopeFooter)
+		},
+		"empty body": func(w http.ResponseWriter) { _, _ = io.WriteString(w, envelopeHeader+envelopeFooter) },
+	}
+	for name, respond := range responses {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) { respond(w) }))
+		client, _ := NewClient(Endpoint{URL: server.URL})
+		err := client.RetrieveServiceContent(t.Context())
+		server.Close()
+		if !errors.Is(err, ErrSOAPTransport) {
+			t.Fatalf("%s: expected transport error, got %v", name, err)
+		}
+	}
+	client, _ := NewClient(Endpoint{URL: "http://127.0.0.1:1"})
+	if err := client.Logout(t.Context()); !errors.Is(err, ErrSOAPTransport) {
+		t.Fatalf("expected connection failure, got %v", err)
+	}
+	if err := client.call(t.Context(), make(chan int), nil); err == nil {
+		t.Fatalf("expected marshal failure")
+	}
+	broken := &Client{url: "://missing-scheme", http: http.DefaultClient}
+	if err := broken.call(t.Context(), struct {
+		XMLName xml.Name `xml:"urn:vim25 Ping"`
+	}{}, nil); err == nil || errors.Is(err, ErrSOAPTransport) {
+		t.Fatalf("expected request construction failure, got %v", err)
+	}
+}
+
+func TestTransportErrorsRetryOnlyTimeoutsAndDroppedConnections(t *testing.T) {
+	slow := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		_, _ = io.ReadAll(r.Body)
+		<-r.Context().Done()
+	}))
+	defer slow.Close()
+	dropped := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		conn, _, _ := w.(http.Hijacker).Hijack()
+		_ = conn.Close()
+	}))
+	defer dropped.Close()
+	clients := map[string]*Client{
+		"refused": {url: "http://127.0.0.1:1", http: http.DefaultClient},
+		"timeout": {url: slow.URL, http: &http.Client{Timeout: 20 * time.Millisecond}},
+		"dropped": {url: dropped.URL, http: http.DefaultClient},
+	}
+	var transport *TransportError
+	for name, client := range clients {
+		err := client.Logout(t.Context())
+		if !errors.As(err, &transport) || !errors.Is(err, ErrSOAPTransport) || !migration.IsRetriable(err) {
+			t.Fatalf("%s: expected a retriable transport error, got %v", name, err)
+		}
+	}
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+	if err := clients["refused"].Logout(ctx); !errors.Is(err, ErrSOAPTransport) || errors.As(err, &transport) || migration.IsRetriable(err) {
+		t.Fatalf("expected a canceled call left unretried, got %v", err)
+	}
+}
+
+func TestFaultDescribesDetailKind(t *testing.T) {
+	plain := soapFault{Code: "ServerFaultCode", String: "boom"}.fault()
+	if plain.Kind != "" || plain.Error() != "vsphere fault: boom" {
+		t.Fatalf("unexpected plain fault: %+v %q", plain, plain.Error())
+	}
+	named := soapFault{String: "no"}
+	named.Detail.Faults = []faultDetail{{XMLName: xml.Name{Local: "NoPermissionFault"}}}
+	fault := named.fault()
+	if fault.Kind != "NoPermission" || !strings.Contains(fault.Error(), "NoPermission: no") {
+		t.Fatalf("unexpected named fault: %+v", fault)
+	}
+}
+
+func TestSDKURLNormalizesEndpoints(t *testing.T) {
+	tests := map[string]string{
+		"vc.example.com":                 "https://vc.example.com/sdk",
+		"https://vc.example.com/":        "https://vc.example.com/sdk",
+		"https://vc.example.com:8443/vc": "https://vc.example.com:8443/vc",
+	}
+	for input, want := range tests {
+		got, err := sdkURL(input)
+		if err != nil || got != want {
+			t.Fatalf("sdkURL(%q) = %q, %v; want %q", input, got, err, want)
+		}
+	}
+	if _, err := sdkURL("https://[::1"); !errors.Is(err, ErrEndpointRequired) {
+		t.Fatalf("expected malformed url error, got %v", err)
+	}
+}
@@ -0,0 +1,168 @@
+// Path: internal/vsphere/custom_fields.go
+// Description: Read and write VM custom attributes through the CustomFieldsManager to persist deletion metadata.
+package vsphere
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"maps"
+	"slices"
+
+	"github.com/takelley1/hypersphere/internal/deletion"
+)
+
+var customFieldSpecs = []PropertySpec{{Type: "CustomFieldsManager", PathSet: []string{"field"}}}
+
+var customValueSpecs = []PropertySpec{{Type: "VirtualMachine", PathSet: []string{"name", "customValue"}}}
+
+// CustomFields return the custom attribute definitions of the vCenter.
+func (c *Client) CustomFields(ctx context.Context) ([]CustomFieldDef, error) {
+	objects, err := c.RetrieveObjects(ctx, []ManagedObjectReference{c.content.CustomFieldsManager}, customFieldSpecs)
+	if err != nil {
+		return nil, err
+	}
+	fields := struct {
+		Items []CustomFieldDef `xml:"CustomFieldDef"`
+	}{}
+	if len(objects) > 0 {
+		_ = objects[0].Properties()["field"].Decode(&fields)
+	}
+	return fields.Items, nil
+}
+
+// AddCustomField define a string custom attribute for a managed object type.
+func (c *Client) AddCustomField(ctx context.Context, name string, objectType string) (CustomFieldDef, error) {
+	request := struct {
+		XMLName    xml.Name               `xml:"urn:vim25 AddCustomFieldDef"`
+		This       ManagedObjectReference `xml:"_this"`
+		Name       string                 `xml:"name"`
+		ObjectType string                 `xml:"moType"`
+	}{This: c.content.CustomFieldsManager, Name: name, ObjectType: objectType}
+	response := struct {
+		Returnval CustomFieldDef `xml:"returnval"`
+	}{}
+	err := c.call(ctx, request, &response)
+	return response.Returnval, err
+}
+
+// SetCustomField set the value of a custom attribute on an entity; an empty
+// value clears it.
+func (c *Client) SetCustomField(ctx context.Context, entity ManagedObjectReference, key int, value string) error {
+	request := struct {
+		XMLName xml.Name               `xml:"urn:vim25 SetField"`
+		This    ManagedObjectReference `xml:"_this"`
+		Entity  ManagedObjectReference `xml:"entity"`
+		Key     int                    `xml:"key"`
+		Value   string                 `xml:"value"`
+	}{This: c.content.CustomFieldsManager, Entity: entity, Key: key, Value: value}
+	return c.call(ctx, request, nil)
+}
+
+// AttributeStore keeps deletion lifecycle fields in VM custom attributes
+// named after the fields, so every run against the vCenter sees the same
+// state. Attributes are defined on first use, along with the owner-set
+// pd_snooze_until attribute, which owners then fill in from the vSphere
+// Client without defining it themselves.
+type AttributeStore struct {
+	provider *Provider
+}
+
+// NewAttributeStore build a custom attribute metadata store over a provider's inventory.
+func NewAttributeStore(provider *Provider) *AttributeStore {
+	return &AttributeStore{provider: provider}
+}
+
+// Load read the custom attributes of every VM, keyed by VM and attribute name.
+func (s *AttributeStore) Load() (map[string]map[string]string, error) {
+	ctx := context.Background()
+	defs, err := s.provider.client.CustomFields(ctx)
+	if err != nil {
+		return nil, err
+	}
+	names := map[int]string{}
+	for _, def := range defs {
+		names[def.Key] = def.Name
+	}
+	objects, err := s.provider.client.RetrieveInventory(ctx, customValueSpecs)
+	if err != nil {
+		return nil, err
+	}
+	stored := map[string]map[string]string{}
+	for _, object := range objects {
+		properties := object.Properties()
+		values := struct {
+			Items []CustomFieldValue `xml:"CustomFieldValue"`
+		}{}
+		_ = properties["customValue"].Decode(&values)
+		fields := map[string]string{}
+		for _, value := range values.Items {
+			if name, ok := names[value.Key]; ok {
+				fields[name] = value.Value
+			}
+		}
+		stored[properties["name"].String()] = fields
+	}
+	return stored, nil
+}
+
+// Save set each field as a custom attribute on the named VM. Cleared fields
+// are blanked, and attributes are only defined for fields with a value and
+// for the snooze date owners set.
+// Clearing every field of a VM that is gone, such as a destroyed or renamed
+// VM, succeeds since it has nothing left to clear.
+func (s *AttributeStore) Save(vmName string, fields map[string]string) error {
+	ctx := context.Background()
+	inventory, err := s.provider.inventory()
+	if err != nil {
+		return err
+	}
+	vm, err := inventory.find("VirtualMachine", vmName)
+	if errors.Is(err, ErrObjectNotFound) && blank(fields) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defs, err := s.provider.client.CustomFields(ctx)
+	if err != nil {
+		return err
+	}
+	keys := map[string]int{}
+	for _, def := range defs {
+		if def.ManagedObjectType == "" || def.ManagedObjectType == "VirtualMachine" {
+			keys[def.Name] = def.Key
+		}
+	}
+	if _, ok := keys[deletion.FieldSnoozeUntil]; !ok {
+		if _, err := s.provider.client.AddCustomField(ctx, deletion.FieldSnoozeUntil, "VirtualMachine"); err != nil {
+			return err
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(fields)) {
+		key, ok := keys[name]
+		if !ok {
+			if fields[name] == "" {
+				continue
+			}
+			def, err := s.provider.client.AddCustomField(ctx, name, "VirtualMachine")
+			if err != nil {
+				return err
+			}
+			key = def.Key
+		}
+		if err := s.provider.client.SetCustomField(ctx, vm, key, fields[name]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func blank(fields map[string]string) bool {
+	for _, value := range fields {
+		if value != "" {
+			return false
+		}
+	}
+	return true
+}
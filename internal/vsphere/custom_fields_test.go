@@ -0,0 +1,80 @@
+// Path: internal/vsphere/custom_fields_test.go
+// Description: Validate custom attribute reads and writes backing the deletion metadata store.
+package vsphere
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/takelley1/hypersphere/internal/deletion"
+)
+
+func TestAttributeStoreLoadsAndSavesCustomAttributes(t *testing.T) {
+	sim := newSimulator(t)
+	store := NewAttributeStore(sim.provider(t))
+	stored, err := store.Load()
+	if err != nil || stored["vm-b"]["pd_pending_since"] != "2026-02-01" || stored["vm-b"]["Owner"] != "ops@example.com" {
+		t.Fatalf("expected vm-b custom attributes, got %v err=%v", stored, err)
+	}
+	if len(stored["vm-a"]) != 0 {
+		t.Fatalf("expected vm-a without custom attributes, got %v", stored["vm-a"])
+	}
+	fields := map[string]string{"pd_pending_since": "", "pd_delete_on": "2026-02-15", "pd_original_name": ""}
+	if err := store.Save("vm-b", fields); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+	if sim.calls["AddCustomFieldDef"] != 2 || sim.calls["SetField"] != 2 {
+		t.Fatalf("expected the snooze and one new attribute defined and two values set, got %v", sim.calls)
+	}
+	defs, err := sim.dial(t).CustomFields(t.Context())
+	if err != nil || !slices.ContainsFunc(defs, func(def CustomFieldDef) bool { return def.Name == deletion.FieldSnoozeUntil }) {
+		t.Fatalf("expected the snooze attribute defined for owners, got %v err=%v", defs, err)
+	}
+	stored, err = store.Load()
+	if err != nil || stored["vm-b"]["pd_delete_on"] != "2026-02-15" || stored["vm-b"]["pd_pending_since"] != "" ||
+		stored["vm-b"]["Owner"] != "ops@example.com" {
+		t.Fatalf("expected saved attributes to read back, got %v err=%v", stored["vm-b"], err)
+	}
+	if err := store.Save("vm-a", map[string]string{"pd_delete_on": "2026-03-01"}); err != nil ||
+		sim.calls["AddCustomFieldDef"] != 2 {
+		t.Fatalf("expected the existing attribute reused, got %v err=%v", sim.calls, err)
+	}
+}
+
+func TestAttributeStoreReportsFaults(t *testing.T) {
+	sim := newSimulator(t)
+	store := NewAttributeStore(sim.provider(t))
+	if err := store.Save("vm-missing", map[string]string{"pd_delete_on": "2026-02-15"}); !errors.Is(err, ErrObjectNotFound) {
+		t.Fatalf("expected missing VM rejected, got %v", err)
+	}
+	if err := store.Save("vm-missing", map[string]string{"pd_delete_on": ""}); err != nil {
+		t.Fatalf("expected clearing a missing VM to succeed, got %v", err)
+	}
+	var fault *Fault
+	for _, method := range []string{"AddCustomFieldDef", "SetField", "RetrievePropertiesEx"} {
+		sim.failOn(method, 1)
+		if err := store.Save("vm-a", map[string]string{"pd_new": "value"}); !errors.As(err, &fault) {
+			t.Fatalf("expected %s fault, got %v", method, err)
+		}
+	}
+	sim.failOn("AddCustomFieldDef", 1)
+	if err := store.Save("vm-a", map[string]string{"pd_other": "value"}); !errors.As(err, &fault) {
+		t.Fatalf("expected a field definition fault once the snooze attribute exists, got %v", err)
+	}
+	for call := 1; call <= 2; call++ {
+		sim.failOn("RetrievePropertiesEx", call)
+		if _, err := store.Load(); !errors.As(err, &fault) {
+			t.Fatalf("expected load fault on retrieve %d, got %v", call, err)
+		}
+	}
+	failing := newSimulator(t)
+	failing.failOn("CreateContainerView", 1)
+	if err := NewAttributeStore(failing.provider(t)).Save("vm-a", nil); err == nil {
+		t.Fatalf("expected inventory load failure")
+	}
+	sim.destroy(mor("CustomFieldsManager", "CustomFieldsManager"))
+	if defs, err := sim.dial(t).CustomFields(t.Context()); err != nil || len(defs) != 0 {
+		t.Fatalf("expected no definitions without a CustomFieldsManager, got %v err=%v", defs, err)
+	}
+}
@@ -0,0 +1,196 @@
+// Path: internal/vsphere/lifecycle.go
+// Description: Power off, rename, move, and destroy VMs by name, creating missing pending folders, for the pending-deletion lifecycle.
+package vsphere
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"path"
+	"slices"
+	"time"
+)
+
+// PowerOffVM start a hard power off of a VM and return its task.
+func (c *Client) PowerOffVM(ctx context.Context, vm ManagedObjectReference) (ManagedObjectReference, error) {
+	request := struct {
+		XMLName xml.Name               `xml:"urn:vim25 PowerOffVM_Task"`
+		This    ManagedObjectReference `xml:"_this"`
+	}{This: vm}
+	return c.callTask(ctx, request)
+}
+
+// RenameEntity start renaming a managed entity and return its task.
+func (c *Client) RenameEntity(ctx context.Context, entity ManagedObjectReference, name string) (ManagedObjectReference, error) {
+	request := struct {
+		XMLName xml.Name               `xml:"urn:vim25 Rename_Task"`
+		This    ManagedObjectReference `xml:"_this"`
+		NewName string                 `xml:"newName"`
+	}{This: entity, NewName: name}
+	return c.callTask(ctx, request)
+}
+
+// MoveIntoFolder start moving entities into a folder and return its task.
+func (c *Client) MoveIntoFolder(
+	ctx context.Context,
+	folder ManagedObjectReference,
+	entities []ManagedObjectReference,
+) (ManagedObjectReference, error) {
+	request := struct {
+		XMLName xml.Name                 `xml:"urn:vim25 MoveIntoFolder_Task"`
+		This    ManagedObjectReference   `xml:"_this"`
+		List    []ManagedObjectReference `xml:"list"`
+	}{This: folder, List: entities}
+	return c.callTask(ctx, request)
+}
+
+// CreateFolder create a child folder of a folder and return it.
+func (c *Client) CreateFolder(ctx context.Context, parent ManagedObjectReference, name string) (ManagedObjectReference, error) {
+	request := struct {
+		XMLName xml.Name               `xml:"urn:vim25 CreateFolder"`
+		This    ManagedObjectReference `xml:"_this"`
+		Name    string                 `xml:"name"`
+	}{This: parent, Name: name}
+	response := struct {
+		Returnval ManagedObjectReference `xml:"returnval"`
+	}{}
+	err := c.call(ctx, request, &response)
+	return response.Returnval, err
+}
+
+// DestroyEntity start deleting a managed entity, and a VM's files, and return its task.
+func (c *Client) DestroyEntity(ctx context.Context, entity ManagedObjectReference) (ManagedObjectReference, error) {
+	request := struct {
+		XMLName xml.Name               `xml:"urn:vim25 Destroy_Task"`
+		This    ManagedObjectReference `xml:"_this"`
+	}{This: entity}
+	return c.callTask(ctx, request)
+}
+
+func (c *Client) callTask(ctx context.Context, request any) (ManagedObjectReference, error) {
+	response := struct {
+		Returnval ManagedObjectReference `xml:"returnval"`
+	}{}
+	err := c.call(ctx, request, &response)
+	return response.Returnval, err
+}
+
+// LifecycleExecutor performs pending-deletion side effects on VMs by name,
+// waiting for each vCenter task. Renames and deletes reload the provider's
+// inventory so later steps find VMs under their new names.
+type LifecycleExecutor struct {
+	provider *Provider
+	poll     time.Duration
+}
+
+// NewLifecycleExecutor build a lifecycle executor over a provider's inventory.
+func NewLifecycleExecutor(provider *Provider) *LifecycleExecutor {
+	return &LifecycleExecutor{provider: provider, poll: defaultTaskPoll}
+}
+
+// PowerOff power off the named VM unless the inventory shows it powered off.
+func (e *LifecycleExecutor) PowerOff(vmName string) error {
+	inventory, vm, err := e.find(vmName)
+	if err != nil {
+		return err
+	}
+	if inventory.prop(vm, "runtime.powerState").String() == "poweredOff" {
+		return nil
+	}
+	return e.run(func(ctx context.Context) (ManagedObjectReference, error) {
+		return e.provider.client.PowerOffVM(ctx, vm)
+	})
+}
+
+// Rename rename the named VM.
+func (e *LifecycleExecutor) Rename(vmName string, newName string) error {
+	_, vm, err := e.find(vmName)
+	if err != nil {
+		return err
+	}
+	if err := e.run(func(ctx context.Context) (ManagedObjectReference, error) {
+		return e.provider.client.RenameEntity(ctx, vm, newName)
+	}); err != nil {
+		return err
+	}
+	return e.provider.Reload()
+}
+
+// MoveToFolder move the named VM into the VM folder at an inventory path,
+// such as /Datacenters/dc-1/vm/Prod, the form VM rows report folders in. A
+// missing folder is created when its parent is an existing VM folder.
+func (e *LifecycleExecutor) MoveToFolder(vmName string, folder string) error {
+	inventory, vm, err := e.find(vmName)
+	if err != nil {
+		return err
+	}
+	target, err := e.folder(inventory, folder)
+	if err != nil {
+		return err
+	}
+	return e.run(func(ctx context.Context) (ManagedObjectReference, error) {
+		return e.provider.client.MoveIntoFolder(ctx, target, []ManagedObjectReference{vm})
+	})
+}
+
+// Destroy delete the named VM and its files.
+func (e *LifecycleExecutor) Destroy(vmName string) error {
+	_, vm, err := e.find(vmName)
+	if err != nil {
+		return err
+	}
+	if err := e.run(func(ctx context.Context) (ManagedObjectReference, error) {
+		return e.provider.client.DestroyEntity(ctx, vm)
+	}); err != nil {
+		return err
+	}
+	return e.provider.Reload()
+}
+
+// folder resolve a VM folder by path, creating its last element under an
+// existing VM folder, so a pending folder nobody created yet does not strand a
+// VM that was already powered off and renamed.
+func (e *LifecycleExecutor) folder(inventory *snapshot, folder string) (ManagedObjectReference, error) {
+	target, err := inventory.findPath("Folder", folder)
+	if err == nil {
+		return target, nil
+	}
+	parent, parentErr := inventory.findPath("Folder", path.Dir(folder))
+	if parentErr != nil || !slices.Contains(inventory.prop(parent, "childType").Strings(), "VirtualMachine") {
+		return ManagedObjectReference{}, err
+	}
+	created, err := e.provider.client.CreateFolder(context.Background(), parent, path.Base(folder))
+	if err != nil {
+		return ManagedObjectReference{}, err
+	}
+	return created, e.provider.Reload()
+}
+
+func (e *LifecycleExecutor) find(vmName string) (*snapshot, ManagedObjectReference, error) {
+	inventory, err := e.provider.inventory()
+	if err != nil {
+		return nil, ManagedObjectReference{}, err
+	}
+	vm, err := inventory.find("VirtualMachine", vmName)
+	return inventory, vm, err
+}
+
+// findPath find an object by its full inventory path, so a same-named object
+// in another folder or datacenter is never picked.
+func (s *snapshot) findPath(objectType string, path string) (ManagedObjectReference, error) {
+	for _, ref := range s.ofType(objectType) {
+		if s.path(ref) == path {
+			return ref, nil
+		}
+	}
+	return ManagedObjectReference{}, fmt.Errorf("%w: %s %s", ErrObjectNotFound, objectType, path)
+}
+
+func (e *LifecycleExecutor) run(start func(ctx context.Context) (ManagedObjectReference, error)) error {
+	ctx := context.Background()
+	task, err := start(ctx)
+	if err != nil {
+		return err
+	}
+	return e.provider.client.WaitForTask(ctx, task, e.poll)
+}
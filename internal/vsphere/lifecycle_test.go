@@ -0,0 +1,173 @@
+// Path: internal/vsphere/lifecycle_test.go
+// Description: Validate lifecycle power off, rename, move, and destroy tasks, VM name resolution, and their use by the deletion engine.
+package vsphere
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/takelley1/hypersphere/internal/deletion"
+	"github.com/takelley1/hypersphere/internal/migration"
+)
+
+func TestLifecycleExecutorRunsVCenterTasks(t *testing.T) {
+	sim := newSimulator(t)
+	executor := NewLifecycleExecutor(sim.provider(t))
+	executor.poll = time.Millisecond
+	if err := executor.PowerOff("vm-b"); err != nil || sim.calls["PowerOffVM_Task"] != 0 {
+		t.Fatalf("expected powered-off vm-b left alone, got %v calls=%v", err, sim.calls)
+	}
+	if err := executor.PowerOff("vm-a"); err != nil || sim.object(mor("VirtualMachine", "vm-1")).props["runtime.powerState"] != valString("poweredOff") {
+		t.Fatalf("expected vm-a powered off, got %v", err)
+	}
+	if err := executor.Rename("vm-a", "PD_vm-a"); err != nil {
+		t.Fatalf("Rename returned error: %v", err)
+	}
+	if err := executor.MoveToFolder("PD_vm-a", "/Datacenters/dc-1/vm/archive"); err != nil ||
+		sim.object(mor("VirtualMachine", "vm-1")).props["parent"] != valRef(mor("Folder", "group-x1")) {
+		t.Fatalf("expected renamed VM moved into archive, got %v", err)
+	}
+	if err := executor.Destroy("PD_vm-a"); err != nil || sim.object(mor("VirtualMachine", "vm-1")) != nil {
+		t.Fatalf("expected vm-a destroyed, got %v", err)
+	}
+	if err := executor.PowerOff("PD_vm-a"); !errors.Is(err, ErrObjectNotFound) {
+		t.Fatalf("expected destroyed VM gone from the reloaded inventory, got %v", err)
+	}
+}
+
+func TestLifecycleExecutorReportsFailures(t *testing.T) {
+	sim := newSimulator(t)
+	executor := NewLifecycleExecutor(sim.provider(t))
+	executor.poll = time.Millisecond
+	steps := map[string]func(vmName string) error{
+		"PowerOffVM_Task":     executor.PowerOff,
+		"Rename_Task":         func(vmName string) error { return executor.Rename(vmName, "renamed") },
+		"MoveIntoFolder_Task": func(vmName string) error { return executor.MoveToFolder(vmName, "/Datacenters/dc-1/vm/archive") },
+		"Destroy_Task":        executor.Destroy,
+	}
+	var fault *Fault
+	for method, step := range steps {
+		if err := step("vm-missing"); !errors.Is(err, ErrObjectNotFound) {
+			t.Fatalf("expected %s on a missing VM rejected, got %v", method, err)
+		}
+		sim.failOn(method, 1)
+		if err := step("vm-c"); !errors.As(err, &fault) {
+			t.Fatalf("expected %s fault, got %v", method, err)
+		}
+	}
+	for _, folder := range []string{"folder-missing", "archive", "/Datacenters/dc-2/vm/archive"} {
+		if err := executor.MoveToFolder("vm-c", folder); !errors.Is(err, ErrObjectNotFound) {
+			t.Fatalf("expected folder %q resolved only by its full path, got %v", folder, err)
+		}
+	}
+	for _, step := range []func() error{
+		func() error { return executor.Rename("vm-c", "vm-c2") },
+		func() error { return executor.Destroy("vm-b") },
+	} {
+		sim.failOn("CreateContainerView", 1)
+		if err := step(); !errors.As(err, &fault) {
+			t.Fatalf("expected inventory reload fault, got %v", err)
+		}
+	}
+	failing := newSimulator(t)
+	failing.failOn("CreateContainerView", 1)
+	if err := NewLifecycleExecutor(failing.provider(t)).PowerOff("vm-a"); err == nil {
+		t.Fatalf("expected inventory load failure")
+	}
+}
+
+func TestLifecycleExecutorCreatesMissingPendingFolder(t *testing.T) {
+	sim := newSimulator(t)
+	executor := NewLifecycleExecutor(sim.provider(t))
+	executor.poll = time.Millisecond
+	for _, vmName := range []string{"vm-c", "vm-a"} {
+		if err := executor.MoveToFolder(vmName, "/Datacenters/dc-1/vm/pending"); err != nil {
+			t.Fatalf("expected %s moved into a created folder, got %v", vmName, err)
+		}
+	}
+	created := sim.object(mor("VirtualMachine", "vm-4")).props["parent"]
+	if sim.calls["CreateFolder"] != 1 || sim.object(mor("VirtualMachine", "vm-1")).props["parent"] != created ||
+		created == valRef(mor("Folder", "group-v1")) {
+		t.Fatalf("expected one pending folder created and reused, got calls=%v parent=%s", sim.calls, created)
+	}
+	for _, folder := range []string{"/Datacenters/dc-1/vm/archive/old", "/Datacenters/pending"} {
+		if err := executor.MoveToFolder("vm-c", folder); !errors.Is(err, ErrObjectNotFound) || sim.calls["CreateFolder"] != 1 {
+			t.Fatalf("expected no folder created outside a VM folder for %q, got %v", folder, err)
+		}
+	}
+	var fault *Fault
+	sim.failOn("CreateFolder", 1)
+	if err := executor.MoveToFolder("vm-c", "/Datacenters/dc-1/vm/held"); !errors.As(err, &fault) {
+		t.Fatalf("expected folder creation fault, got %v", err)
+	}
+	sim.failOn("CreateContainerView", 1)
+	if err := executor.MoveToFolder("vm-c", "/Datacenters/dc-1/vm/held"); !errors.As(err, &fault) {
+		t.Fatalf("expected inventory reload fault after creating the folder, got %v", err)
+	}
+}
+
+func TestLifecycleExecutorRefusesTemplatesAndAmbiguousNames(t *testing.T) {
+	sim := newSimulator(t)
+	sim.add("VirtualMachine", "vm-9", map[string]string{
+		"name": valString("vm-c"), "parent": valRef(mor("Folder", "group-x1")), "runtime.powerState": valString("poweredOn"),
+	})
+	provider := sim.provider(t)
+	executor := NewLifecycleExecutor(provider)
+	executor.poll = time.Millisecond
+	if err := executor.Destroy("tpl-rhel9"); !errors.Is(err, ErrObjectNotFound) || sim.object(mor("VirtualMachine", "vm-3")) == nil {
+		t.Fatalf("expected a template never destroyed as a VM, got %v", err)
+	}
+	err := executor.Destroy("vm-c")
+	if !errors.Is(err, ErrAmbiguousObject) || !strings.Contains(err.Error(), "/Datacenters/dc-1/vm/archive/vm-c") ||
+		sim.calls["Destroy_Task"] != 0 {
+		t.Fatalf("expected a shared VM name refused before any task, got %v calls=%v", err, sim.calls)
+	}
+	if err := NewMover(context.Background(), provider).Move("vm-c", "san-a"); !errors.Is(err, ErrAmbiguousObject) || migration.IsRetriable(err) {
+		t.Fatalf("expected the mover to refuse a shared VM name without retrying, got %v", err)
+	}
+}
+
+func TestDeletionEngineMarksVMsThroughVCenter(t *testing.T) {
+	sim := newSimulator(t)
+	provider := sim.provider(t)
+	executor := NewLifecycleExecutor(provider)
+	executor.poll = time.Millisecond
+	audit, err := deletion.OpenAuditLog(filepath.Join(t.TempDir(), "audit.jsonl"))
+	if err != nil {
+		t.Fatalf("OpenAuditLog returned error: %v", err)
+	}
+	defer audit.Close()
+	store := NewAttributeStore(provider)
+	policy := deletion.Policy{MarkAfterDays: 30, PurgeAfterDays: 14, PendingFolder: "archive", RenamePrefix: "PD_"}
+	engine := deletion.NewEngine(policy).WithExecutor(executor, audit)
+	vms, err := deletion.LoadMetadata(store, []deletion.VM{{Name: "vm-c", Folder: "/Datacenters/dc-1/vm", PoweredOffDays: 45}})
+	if err != nil {
+		t.Fatalf("LoadMetadata returned error: %v", err)
+	}
+	now := time.Date(2026, 2, 16, 0, 0, 0, 0, time.UTC)
+	applied, summary := engine.ApplyPlan(vms, engine.Plan(vms, deletion.ModeAll, now), now)
+	if summary.AppliedCount != 1 || len(summary.StepErrors) != 0 {
+		t.Fatalf("expected vm-c marked, got %+v", summary)
+	}
+	if err := deletion.SaveMetadata(store, vms, applied); err != nil {
+		t.Fatalf("SaveMetadata returned error: %v", err)
+	}
+	stored, err := store.Load()
+	if err != nil || stored["PD_vm-c"][deletion.FieldOriginalName] != "vm-c" || stored["PD_vm-c"][deletion.FieldDeleteOn] != "2026-03-02" {
+		t.Fatalf("expected mark stored on the renamed VM, got %v err=%v", stored, err)
+	}
+	if sim.object(mor("VirtualMachine", "vm-4")).props["parent"] != valRef(mor("Folder", "group-x1")) ||
+		stored["PD_vm-c"][deletion.FieldOriginalFolder] != "/Datacenters/dc-1/vm" {
+		t.Fatalf("expected vm-c moved into the pending folder of its datacenter, got %v", stored)
+	}
+	reclaimed := applied[0]
+	reclaimed.Folder = "/Datacenters/dc-1/vm/Templates"
+	reset, err := engine.Apply(reclaimed, deletion.Action{Type: deletion.ActionReset}, now)
+	if err != nil || reset.Folder != "/Datacenters/dc-1/vm" || sim.object(mor("VirtualMachine", "vm-4")).props["parent"] != valRef(mor("Folder", "group-v1")) {
+		t.Fatalf("expected reset to move vm-c back to its original folder path, got %+v err=%v", reset, err)
+	}
+}
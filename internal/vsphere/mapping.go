@@ -0,0 +1,800 @@
+// Path: internal/vsphere/mapping.go
+// Description: Map vCenter inventory snapshots into explorer resource rows.
+package vsphere
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/takelley1/hypersphere/internal/tui"
+)
+
+const (
+	bytesPerGB = 1024 * 1024 * 1024
+	bytesPerMB = 1024 * 1024
+	kbPerGB    = 1024 * 1024
+)
+
+var snapshotDiskPattern = regexp.MustCompile(`-\d{6}(-delta|-sesparse)?\.vmdk$`)
+
+// ListVMs return VM rows for every non-template virtual machine.
+func (p *Provider) ListVMs() ([]tui.VMRow, error) {
+	inventory, err := p.inventory()
+	if err != nil {
+		return nil, err
+	}
+	return inventory.vmRows(), nil
+}
+
+// ListLUNs return one LUN row per VMFS datastore extent.
+func (p *Provider) ListLUNs() ([]tui.LUNRow, error) {
+	inventory, err := p.inventory()
+	if err != nil {
+		return nil, err
+	}
+	return inventory.lunRows(), nil
+}
+
+// ListClusters return cluster rows with host-aggregated usage.
+func (p *Provider) ListClusters() ([]tui.ClusterRow, error) {
+	inventory, err := p.inventory()
+	if err != nil {
+		return nil, err
+	}
+	return inventory.clusterRows(), nil
+}
+
+// ListDatacenters return datacenter rows with inventory counts.
+func (p *Provider) ListDatacenters() ([]tui.DatacenterRow, error) {
+	inventory, err := p.inventory()
+	if err != nil {
+		return nil, err
+	}
+	return inventory.datacenterRows(), nil
+}
+
+// ListResourcePools return resource pool rows with allocation settings.
+func (p *Provider) ListResourcePools() ([]tui.ResourcePoolRow, error) {
+	inventory, err := p.inventory()
+	if err != nil {
+		return nil, err
+	}
+	return inventory.resourcePoolRows(), nil
+}
+
+// ListNetworks return standard and distributed port group rows.
+func (p *Provider) ListNetworks() ([]tui.NetworkRow, error) {
+	inventory, err := p.inventory()
+	if err != nil {
+		return nil, err
+	}
+	return inventory.networkRows(), nil
+}
+
+// ListTemplates return rows for virtual machines marked as templates.
+func (p *Provider) ListTemplates() ([]tui.TemplateRow, error) {
+	inventory, err := p.inventory()
+	if err != nil {
+		return nil, err
+	}
+	return inventory.templateRows(p.now()), nil
+}
+
+// ListSnapshots return one row per snapshot in every VM snapshot tree.
+func (p *Provider) ListSnapshots() ([]tui.SnapshotRow, error) {
+	inventory, err := p.inventory()
+	if err != nil {
+		return nil, err
+	}
+	return inventory.snapshotRows(p.now()), nil
+}
+
+// ListTasks return rows for the vCenter recent task list.
+func (p *Provider) ListTasks() ([]tui.TaskRow, error) {
+	inventory, err := p.inventory()
+	if err != nil {
+		return nil, err
+	}
+	return inventory.taskRows(p.now()), nil
+}
+
+// ListEvents return rows for events from the last day.
+func (p *Provider) ListEvents() ([]tui.EventRow, error) {
+	inventory, err := p.inventory()
+	if err != nil {
+		return nil, err
+	}
+	return inventory.eventRows(), nil
+}
+
+// ListAlarms return rows for alarms triggered anywhere in the inventory.
+func (p *Provider) ListAlarms() ([]tui.AlarmRow, error) {
+	inventory, err := p.inventory()
+	if err != nil {
+		return nil, err
+	}
+	return inventory.alarmRows(), nil
+}
+
+// ListFolders return folder rows keyed by inventory path.
+func (p *Provider) ListFolders() ([]tui.FolderRow, error) {
+	inventory, err := p.inventory()
+	if err != nil {
+		return nil, err
+	}
+	return inventory.folderRows(), nil
+}
+
+// ListTags return no rows because tags live in the vSphere Automation API, not vim25.
+func (p *Provider) ListTags() ([]tui.TagRow, error) {
+	if _, err := p.inventory(); err != nil {
+		return nil, err
+	}
+	return []tui.TagRow{}, nil
+}
+
+// ListHosts return host rows with usage and connection state.
+func (p *Provider) ListHosts() ([]tui.HostRow, error) {
+	inventory, err := p.inventory()
+	if err != nil {
+		return nil, err
+	}
+	return inventory.hostRows(), nil
+}
+
+// ListDatastores return datastore rows with capacity and usage.
+func (p *Provider) ListDatastores() ([]tui.DatastoreRow, error) {
+	inventory, err := p.inventory()
+	if err != nil {
+		return nil, err
+	}
+	return inventory.datastoreRows(), nil
+}
+
+func (s *snapshot) catalog(now time.Time) tui.Catalog {
+	return tui.Catalog{
+		VMs:           s.vmRows(),
+		LUNs:          s.lunRows(),
+		Clusters:      s.clusterRows(),
+		Datacenters:   s.datacenterRows(),
+		ResourcePools: s.resourcePoolRows(),
+		Networks:      s.networkRows(),
+		Templates:     s.templateRows(now),
+		Snapshots:     s.snapshotRows(now),
+		Tasks:         s.taskRows(now),
+		Events:        s.eventRows(),
+		Alarms:        s.alarmRows(),
+		Folders:       s.folderRows(),
+		Tags:          []tui.TagRow{},
+		Hosts:         s.hostRows(),
+		Datastores:    s.datastoreRows(),
+	}
+}
+
+func (s *snapshot) vmRows() []tui.VMRow {
+	rows := []tui.VMRow{}
+	for _, ref := range s.ofType("VirtualMachine") {
+		if !s.prop(ref, "config.template").Bool() {
+			rows = append(rows, s.vmRow(ref))
+		}
+	}
+	return rows
+}
+
+func (s *snapshot) lunRows() []tui.LUNRow {
+	rows := []tui.LUNRow{}
+	for _, ref := range s.ofType("Datastore") {
+		rows = append(rows, s.datastoreLUNRows(ref)...)
+	}
+	return rows
+}
+
+func (s *snapshot) clusterRows() []tui.ClusterRow {
+	rows := []tui.ClusterRow{}
+	for _, ref := range s.ofType("ClusterComputeResource") {
+		rows = append(rows, s.clusterRow(ref))
+	}
+	return rows
+}
+
+func (s *snapshot) datacenterRows() []tui.DatacenterRow {
+	rows := []tui.DatacenterRow{}
+	for _, ref := range s.ofType("Datacenter") {
+		rows = append(rows, s.datacenterRow(ref))
+	}
+	return rows
+}
+
+func (s *snapshot) resourcePoolRows() []tui.ResourcePoolRow {
+	rows := []tui.ResourcePoolRow{}
+	for _, ref := range s.ofType("ResourcePool") {
+		rows = append(rows, s.resourcePoolRow(ref))
+	}
+	return rows
+}
+
+func (s *snapshot) networkRows() []tui.NetworkRow {
+	rows := []tui.NetworkRow{}
+	for _, ref := range s.ofType("Network") {
+		rows = append(rows, s.networkRow(ref))
+	}
+	for _, ref := range s.ofType("DistributedVirtualPortgroup") {
+		rows = append(rows, s.portgroupRow(ref))
+	}
+	return rows
+}
+
+func (s *snapshot) templateRows(now time.Time) []tui.TemplateRow {
+	rows := []tui.TemplateRow{}
+	for _, ref := range s.ofType("VirtualMachine") {
+		if s.prop(ref, "config.template").Bool() {
+			rows = append(rows, s.templateRow(ref, now))
+		}
+	}
+	return rows
+}
+
+func (s *snapshot) snapshotRows(now time.Time) []tui.SnapshotRow {
+	rows := []tui.SnapshotRow{}
+	for _, ref := range s.ofType("VirtualMachine") {
+		for _, tree := range flattenSnapshots(s.snapshotRoots(ref)) {
+			rows = append(rows, tui.SnapshotRow{
+				VM:       s.name(ref),
+				Snapshot: tree.Name,
+				Size:     "-",
+				Created:  formatTime(tree.CreateTime),
+				Age:      age(tree.CreateTime, now),
+				Quiesced: yesNo(tree.Quiesced),
+				Owner:    "-",
+			})
+		}
+	}
+	return rows
+}
+
+func (s *snapshot) taskRows(now time.Time) []tui.TaskRow {
+	rows := []tui.TaskRow{}
+	for _, manager := range s.ofType("TaskManager") {
+		for _, ref := range s.prop(manager, "recentTask").Refs() {
+			info := TaskInfo{}
+			_ = s.prop(ref, "info").Decode(&info)
+			rows = append(rows, tui.TaskRow{
+				Entity:   info.EntityName,
+				Action:   info.DescriptionID,
+				State:    info.State,
+				Started:  formatTime(info.StartTime),
+				Duration: taskDuration(info, now),
+				Owner:    dashIfEmpty(info.Reason.UserName),
+			})
+		}
+	}
+	return rows
+}
+
+func (s *snapshot) eventRows() []tui.EventRow {
+	rows := make([]tui.EventRow, 0, len(s.events))
+	for _, event := range s.events {
+		rows = append(rows, tui.EventRow{
+			Time:     formatTime(event.CreatedTime),
+			Severity: eventSeverity(event),
+			Entity:   dashIfEmpty(event.EntityName()),
+			Message:  event.FullFormattedMessage,
+			User:     dashIfEmpty(event.UserName),
+		})
+	}
+	return rows
+}
+
+func (s *snapshot) alarmRows() []tui.AlarmRow {
+	rows := []tui.AlarmRow{}
+	for _, state := range s.alarmStates() {
+		rows = append(rows, tui.AlarmRow{
+			Entity:    s.name(state.Entity),
+			Alarm:     s.prop(state.Alarm, "info.name").String(),
+			Status:    state.OverallStatus,
+			Triggered: formatTime(state.Time),
+			AckedBy:   dashIfEmpty(state.AcknowledgedByUser),
+		})
+	}
+	return rows
+}
+
+func (s *snapshot) folderRows() []tui.FolderRow {
+	rows := []tui.FolderRow{}
+	for _, ref := range s.ofType("Folder") {
+		rows = append(rows, s.folderRow(ref))
+	}
+	return rows
+}
+
+func (s *snapshot) hostRows() []tui.HostRow {
+	rows := []tui.HostRow{}
+	for _, ref := range s.ofType("HostSystem") {
+		rows = append(rows, s.hostRow(ref))
+	}
+	return rows
+}
+
+func (s *snapshot) datastoreRows() []tui.DatastoreRow {
+	rows := []tui.DatastoreRow{}
+	for _, ref := range s.ofType("Datastore") {
+		rows = append(rows, s.datastoreRow(ref))
+	}
+	return rows
+}
+
+func (s *snapshot) vmRow(ref ManagedObjectReference) tui.VMRow {
+	host := s.prop(ref, "runtime.host").Ref()
+	datastores := s.names(s.prop(ref, "datastore").Refs())
+	snapshots := flattenSnapshots(s.snapshotRoots(ref))
+	committed := s.prop(ref, "summary.storage.committed").Int64()
+	memoryMB := s.prop(ref, "config.hardware.memoryMB").Int()
+	row := tui.VMRow{
+		Name:            s.name(ref),
+		Cluster:         s.name(s.ancestor(host, "ClusterComputeResource")),
+		Folder:          s.path(s.prop(ref, "parent").Ref()),
+		Host:            s.name(host),
+		Network:         strings.Join(s.names(s.prop(ref, "network").Refs()), ","),
+		PowerState:      powerState(s.prop(ref, "runtime.powerState").String()),
+		AttachedStorage: strings.Join(datastores, ","),
+		IPAddress:       s.prop(ref, "guest.ipAddress").String(),
+		DNSName:         s.prop(ref, "guest.hostName").String(),
+		CPUCount:        s.prop(ref, "config.hardware.numCPU").Int(),
+		MemoryMB:        memoryMB,
+		UsedCPUPercent: percent(
+			s.prop(ref, "summary.quickStats.overallCpuUsage").Int64(),
+			s.prop(ref, "runtime.maxCpuUsage").Int64(),
+		),
+		UsedCPUMHz:           s.prop(ref, "summary.quickStats.overallCpuUsage").Int(),
+		UsedMemoryMB:         s.prop(ref, "summary.quickStats.guestMemoryUsage").Int(),
+		UsedStorageGB:        int(committed / bytesPerGB),
+		ProvisionedStorageGB: int((committed + s.prop(ref, "summary.storage.uncommitted").Int64()) / bytesPerGB),
+		SwapGB:               swapGB(memoryMB, s.prop(ref, "config.memoryAllocation.reservation").Int()),
+		LargestDiskGB:        largestDiskGB(s.prop(ref, "config.hardware.device")),
+		SnapshotTotalGB:      int(snapshotFileBytes(s.prop(ref, "layoutEx.file")) / bytesPerGB),
+		Description:          s.prop(ref, "config.annotation").String(),
+		SnapshotCount:        len(snapshots),
+		BackupRunning:        slices.Contains(s.prop(ref, "disabledMethod").Strings(), "RelocateVM_Task"),
+	}
+	if len(datastores) > 0 {
+		row.Datastore = datastores[0]
+	}
+	mapDeviceBackings(&row, s.prop(ref, "config.hardware.device"))
+	for _, tree := range snapshots {
+		row.Snapshots = append(row.Snapshots, tui.VMSnapshot{
+			Identifier: tree.Name,
+			Timestamp:  formatTime(tree.CreateTime),
+		})
+	}
+	return row
+}
+
+func (s *snapshot) datastoreLUNRows(ref ManagedObjectReference) []tui.LUNRow {
+	info := struct {
+		Extents []VmfsExtent `xml:"vmfs>extent"`
+	}{}
+	_ = s.prop(ref, "info").Decode(&info)
+	rows := []tui.LUNRow{}
+	if len(info.Extents) == 0 {
+		return rows
+	}
+	capacity := s.prop(ref, "summary.capacity").Int64()
+	used := capacity - s.prop(ref, "summary.freeSpace").Int64()
+	extents := int64(len(info.Extents))
+	for _, extent := range info.Extents {
+		rows = append(rows, tui.LUNRow{
+			Name:       extent.DiskName,
+			Cluster:    s.datastoreCluster(ref),
+			Datastore:  s.name(ref),
+			CapacityGB: int(capacity / extents / bytesPerGB),
+			UsedGB:     int(used / extents / bytesPerGB),
+		})
+	}
+	return rows
+}
+
+func (s *snapshot) clusterRow(ref ManagedObjectReference) tui.ClusterRow {
+	hosts := s.prop(ref, "host").Refs()
+	cpu, mem := s.hostUsage(hosts)
+	return tui.ClusterRow{
+		Name:              s.name(ref),
+		Datacenter:        s.name(s.ancestor(ref, "Datacenter")),
+		Hosts:             len(hosts),
+		VMCount:           s.countVMs(func(vm ManagedObjectReference) bool { return s.vmCluster(vm) == ref }),
+		CPUUsagePercent:   cpu,
+		MemUsagePercent:   mem,
+		ResourcePoolCount: s.childPoolCount(ref),
+		NetworkCount:      len(s.prop(ref, "network").Refs()),
+	}
+}
+
+func (s *snapshot) datacenterRow(ref ManagedObjectReference) tui.DatacenterRow {
+	inDatacenter := func(item ManagedObjectReference) bool { return s.ancestor(item, "Datacenter") == ref }
+	hosts := s.filter("HostSystem", inDatacenter)
+	cpu, mem := s.hostUsage(hosts)
+	return tui.DatacenterRow{
+		Name:            s.name(ref),
+		ClusterCount:    len(s.filter("ClusterComputeResource", inDatacenter)),
+		HostCount:       len(hosts),
+		VMCount:         s.countVMs(inDatacenter),
+		DatastoreCount:  len(s.filter("Datastore", inDatacenter)),
+		CPUUsagePercent: cpu,
+		MemUsagePercent: mem,
+	}
+}
+
+func (s *snapshot) resourcePoolRow(ref ManagedObjectReference) tui.ResourcePoolRow {
+	return tui.ResourcePoolRow{
+		Name:              s.name(ref),
+		Cluster:           s.name(s.prop(ref, "owner").Ref()),
+		CPUReservationMHz: s.prop(ref, "config.cpuAllocation.reservation").Int(),
+		MemReservationMB:  s.prop(ref, "config.memoryAllocation.reservation").Int(),
+		VMCount:           len(s.prop(ref, "vm").Refs()),
+		CPULimitMHz:       s.prop(ref, "config.cpuAllocation.limit").Int(),
+		MemLimitMB:        s.prop(ref, "config.memoryAllocation.limit").Int(),
+	}
+}
+
+func (s *snapshot) networkRow(ref ManagedObjectReference) tui.NetworkRow {
+	return tui.NetworkRow{
+		Name:        s.name(ref),
+		Type:        "standard-portgroup",
+		VLAN:        "-",
+		Switch:      "-",
+		AttachedVMs: len(s.prop(ref, "vm").Refs()),
+	}
+}
+
+func (s *snapshot) portgroupRow(ref ManagedObjectReference) tui.NetworkRow {
+	setting := struct {
+		VlanID struct {
+			Text  string `xml:",chardata"`
+			Start []int  `xml:"start"`
+		} `xml:"vlan>vlanId"`
+	}{}
+	_ = s.prop(ref, "config.defaultPortConfig").Decode(&setting)
+	vlan := strings.TrimSpace(setting.VlanID.Text)
+	if len(setting.VlanID.Start) > 0 {
+		vlan = "trunk"
+	}
+	uplinks := struct {
+		Names []string `xml:"uplinkPortName"`
+	}{}
+	dvs := s.prop(ref, "config.distributedVirtualSwitch").Ref()
+	_ = s.prop(dvs, "config.uplinkPortPolicy").Decode(&uplinks)
+	return tui.NetworkRow{
+		Name:        s.name(ref),
+		Type:        "distributed-portgroup",
+		VLAN:        dashIfEmpty(vlan),
+		Switch:      dashIfEmpty(s.name(dvs)),
+		AttachedVMs: len(s.prop(ref, "vm").Refs()),
+		MTU:         s.prop(dvs, "config.maxMtu").Int(),
+		Uplinks:     len(uplinks.Names),
+	}
+}
+
+func (s *snapshot) templateRow(ref ManagedObjectReference, now time.Time) tui.TemplateRow {
+	datastore := ""
+	if datastores := s.names(s.prop(ref, "datastore").Refs()); len(datastores) > 0 {
+		datastore = datastores[0]
+	}
+	createDate := s.prop(ref, "config.createDate").String()
+	return tui.TemplateRow{
+		Name:      s.name(ref),
+		OS:        s.prop(ref, "config.guestId").String(),
+		Datastore: datastore,
+		Folder:    s.path(s.prop(ref, "parent").Ref()),
+		Age:       age(createDate, now),
+		CPUCount:  s.prop(ref, "config.hardware.numCPU").Int(),
+		MemoryMB:  s.prop(ref, "config.hardware.memoryMB").Int(),
+	}
+}
+
+func (s *snapshot) folderRow(ref ManagedObjectReference) tui.FolderRow {
+	children := s.prop(ref, "childEntity").Refs()
+	vmCount := 0
+	for _, child := range children {
+		if child.Type == "VirtualMachine" {
+			vmCount++
+		}
+	}
+	return tui.FolderRow{
+		Path:     s.path(ref),
+		Type:     folderType(s.prop(ref, "childType").Strings()),
+		Children: len(children),
+		VMCount:  vmCount,
+	}
+}
+
+func (s *snapshot) hostRow(ref ManagedObjectReference) tui.HostRow {
+	cpu, mem := s.hostUsage([]ManagedObjectReference{ref})
+	state := s.prop(ref, "runtime.connectionState").String()
+	if s.prop(ref, "runtime.inMaintenanceMode").Bool() {
+		state = "maintenance"
+	}
+	return tui.HostRow{
+		Name:            s.name(ref),
+		Cluster:         s.name(s.ancestor(ref, "ClusterComputeResource")),
+		CPUUsagePercent: cpu,
+		MemUsagePercent: mem,
+		ConnectionState: state,
+		CoreCount:       s.prop(ref, "summary.hardware.numCpuCores").Int(),
+		ThreadCount:     s.prop(ref, "summary.hardware.numCpuThreads").Int(),
+		VMCount:         len(s.prop(ref, "vm").Refs()),
+		CPUMHz:          s.prop(ref, "summary.hardware.cpuMhz").Int() * s.prop(ref, "summary.hardware.numCpuCores").Int(),
+		MemoryMB:        int(s.prop(ref, "summary.hardware.memorySize").Int64() / bytesPerMB),
+	}
+}
+
+func (s *snapshot) datastoreRow(ref ManagedObjectReference) tui.DatastoreRow {
+	capacity := s.prop(ref, "summary.capacity").Int64()
+	free := s.prop(ref, "summary.freeSpace").Int64()
+	return tui.DatastoreRow{
+		Name:          s.name(ref),
+		Cluster:       s.datastoreCluster(ref),
+		CapacityGB:    int(capacity / bytesPerGB),
+		UsedGB:        int((capacity - free) / bytesPerGB),
+		FreeGB:        int(free / bytesPerGB),
+		ProvisionedGB: int((capacity - free + s.prop(ref, "summary.uncommitted").Int64()) / bytesPerGB),
+		Type:          strings.ToLower(s.prop(ref, "summary.type").String()),
+		Hosts:         strings.Join(s.datastoreHosts(ref), ","),
+		Local:         s.prop(ref, "summary.multipleHostAccess").String() == "false",
+		LatencyMS:     s.latency[s.datastoreInstance(ref)],
+	}
+}
+
+func (s *snapshot) hostMounts(ref ManagedObjectReference) []HostMount {
+	mounts := struct {
+		Items []HostMount `xml:"DatastoreHostMount"`
+	}{}
+	_ = s.prop(ref, "host").Decode(&mounts)
+	return mounts.Items
+}
+
+func (s *snapshot) datastoreCluster(ref ManagedObjectReference) string {
+	for _, mount := range s.hostMounts(ref) {
+		if cluster := s.ancestor(mount.Key, "ClusterComputeResource"); cluster.Value != "" {
+			return s.name(cluster)
+		}
+	}
+	return ""
+}
+
+// datastoreHosts return the names of inventory hosts that can reach the datastore.
+func (s *snapshot) datastoreHosts(ref ManagedObjectReference) []string {
+	hosts := []string{}
+	for _, mount := range s.hostMounts(ref) {
+		if name := s.name(mount.Key); name != "" && mount.Accessible != "false" {
+			hosts = append(hosts, name)
+		}
+	}
+	return hosts
+}
+
+func (s *snapshot) hostUsage(hosts []ManagedObjectReference) (int, int) {
+	var cpuUsed, cpuTotal, memUsed, memTotal int64
+	for _, host := range hosts {
+		cpuUsed += s.prop(host, "summary.quickStats.overallCpuUsage").Int64()
+		cpuTotal += s.prop(host, "summary.hardware.cpuMhz").Int64() *
+			s.prop(host, "summary.hardware.numCpuCores").Int64()
+		memUsed += s.prop(host, "summary.quickStats.overallMemoryUsage").Int64()
+		memTotal += s.prop(host, "summary.hardware.memorySize").Int64() / bytesPerMB
+	}
+	return percent(cpuUsed, cpuTotal), percent(memUsed, memTotal)
+}
+
+func (s *snapshot) vmCluster(ref ManagedObjectReference) ManagedObjectReference {
+	return s.ancestor(s.prop(ref, "runtime.host").Ref(), "ClusterComputeResource")
+}
+
+func (s *snapshot) countVMs(match func(ManagedObjectReference) bool) int {
+	return len(s.filter("VirtualMachine", func(ref ManagedObjectReference) bool {
+		return !s.prop(ref, "config.template").Bool() && match(ref)
+	}))
+}
+
+func (s *snapshot) childPoolCount(cluster ManagedObjectReference) int {
+	return len(s.filter("ResourcePool", func(ref ManagedObjectReference) bool {
+		return s.prop(ref, "owner").Ref() == cluster && s.prop(ref, "parent").Ref() != cluster
+	}))
+}
+
+func (s *snapshot) filter(
+	objectType string,
+	match func(ManagedObjectReference) bool,
+) []ManagedObjectReference {
+	refs := []ManagedObjectReference{}
+	for _, ref := range s.ofType(objectType) {
+		if match(ref) {
+			refs = append(refs, ref)
+		}
+	}
+	return refs
+}
+
+func (s *snapshot) names(refs []ManagedObjectReference) []string {
+	names := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		names = append(names, s.name(ref))
+	}
+	return names
+}
+
+func (s *snapshot) snapshotRoots(ref ManagedObjectReference) []SnapshotTree {
+	info := struct {
+		Roots []SnapshotTree `xml:"rootSnapshotList"`
+	}{}
+	_ = s.prop(ref, "snapshot").Decode(&info)
+	return info.Roots
+}
+
+func flattenSnapshots(trees []SnapshotTree) []SnapshotTree {
+	flat := []SnapshotTree{}
+	for _, tree := range trees {
+		flat = append(flat, tree)
+		flat = append(flat, flattenSnapshots(tree.Children)...)
+	}
+	return flat
+}
+
+func largestDiskGB(value Value) int {
+	devices := struct {
+		Items []VirtualDevice `xml:"VirtualDevice"`
+	}{}
+	_ = value.Decode(&devices)
+	var largest int64
+	for _, device := range devices.Items {
+		if xsiType(device.Attrs) == "VirtualDisk" && device.CapacityInKB > largest {
+			largest = device.CapacityInKB
+		}
+	}
+	return int(largest / kbPerGB)
+}
+
+// mapDeviceBackings record the datastore of a mounted ISO and count
+// independent disks and raw device mappings.
+func mapDeviceBackings(row *tui.VMRow, value Value) {
+	devices := struct {
+		Items []VirtualDevice `xml:"VirtualDevice"`
+	}{}
+	_ = value.Decode(&devices)
+	for _, device := range devices.Items {
+		backing := xsiType(device.Backing.Attrs)
+		switch {
+		case backing == "VirtualCdromIsoBackingInfo" && row.ISODatastore == "":
+			row.ISODatastore = datastoreOfPath(device.Backing.FileName)
+		case xsiType(device.Attrs) != "VirtualDisk":
+			continue
+		case strings.HasPrefix(backing, "VirtualDiskRawDiskMapping"):
+			row.RDMDisks++
+		case strings.HasPrefix(device.Backing.DiskMode, "independent"):
+			row.IndependentDisks++
+		}
+	}
+}
+
+// datastoreOfPath return the datastore of a path such as "[iso-store] linux.iso".
+func datastoreOfPath(path string) string {
+	name, _, found := strings.Cut(strings.TrimPrefix(path, "["), "]")
+	if !found || !strings.HasPrefix(path, "[") {
+		return ""
+	}
+	return name
+}
+
+func snapshotFileBytes(value Value) int64 {
+	files := struct {
+		Items []FileLayout `xml:"VirtualMachineFileLayoutExFileInfo"`
+	}{}
+	_ = value.Decode(&files)
+	var total int64
+	for _, file := range files.Items {
+		switch {
+		case file.Type == "snapshotData", file.Type == "snapshotMemory":
+			total += file.Size
+		case file.Type == "diskExtent" && snapshotDiskPattern.MatchString(file.Name):
+			total += file.Size
+		}
+	}
+	return total
+}
+
+func folderType(childTypes []string) string {
+	kinds := map[string]string{
+		"VirtualMachine":  "vm-folder",
+		"ComputeResource": "host-folder",
+		"Datastore":       "datastore-folder",
+		"Network":         "network-folder",
+		"Datacenter":      "datacenter-folder",
+	}
+	for _, childType := range childTypes {
+		if kind, ok := kinds[childType]; ok {
+			return kind
+		}
+	}
+	return "folder"
+}
+
+func powerState(state string) string {
+	switch state {
+	case "poweredOn":
+		return "on"
+	case "poweredOff":
+		return "off"
+	default:
+		return state
+	}
+}
+
+func eventSeverity(event Event) string {
+	if event.Severity != "" {
+		return strings.ToLower(event.Severity)
+	}
+	kind := event.Kind()
+	switch {
+	case strings.Contains(kind, "Failed"), strings.Contains(kind, "Error"):
+		return "error"
+	case strings.Contains(kind, "Warning"), strings.Contains(kind, "Disconnected"):
+		return "warning"
+	default:
+		return "info"
+	}
+}
+
+func taskDuration(info TaskInfo, now time.Time) string {
+	started, err := time.Parse(time.RFC3339Nano, info.StartTime)
+	if err != nil {
+		return "-"
+	}
+	finished, err := time.Parse(time.RFC3339Nano, info.CompleteTime)
+	if err != nil {
+		finished = now
+	}
+	return finished.Sub(started).Round(time.Second).String()
+}
+
+func formatTime(raw string) string {
+	parsed, err := time.Parse(time.RFC3339Nano, raw)
+	if err != nil {
+		return dashIfEmpty(raw)
+	}
+	return parsed.UTC().Format(time.RFC3339)
+}
+
+func age(raw string, now time.Time) string {
+	parsed, err := time.Parse(time.RFC3339Nano, raw)
+	if err != nil {
+		return "-"
+	}
+	return fmt.Sprintf("%dd", int(now.Sub(parsed).Hours()/24))
+}
+
+func percent(used int64, total int64) int {
+	if total <= 0 {
+		return 0
+	}
+	return int(used * 100 / total)
+}
+
+// swapGB return the swap file a VM needs: configured memory its reservation
+// does not cover, rounded up to whole GB.
+func swapGB(memoryMB int, reservationMB int) int {
+	return (max(memoryMB-reservationMB, 0) + 1023) / 1024
+}
+
+func yesNo(value bool) string {
+	if value {
+		return "yes"
+	}
+	return "no"
+}
+
+func dashIfEmpty(value string) string {
+	if strings.TrimSpace(value) == "" {
+		return "-"
+	}
+	return value
+}
@@ -0,0 +1,256 @@
+// Path: internal/vsphere/mapping_test.go
+// Description: Verify vCenter inventory maps into explorer rows against the simulator.
+package vsphere
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/takelley1/hypersphere/internal/tui"
+)
+
+func TestListVMsMapsRuntimeStorageAndSnapshots(t *testing.T) {
+	provider := newSimulator(t).provider(t)
+	rows, err := provider.ListVMs()
+	if err != nil {
+		t.Fatalf("ListVMs returned error: %v", err)
+	}
+	if len(rows) != 3 {
+		t.Fatalf("expected 3 non-template VMs, got %d", len(rows))
+	}
+	want := tui.VMRow{
+		Name:                 "vm-a",
+		Cluster:              "cluster-east",
+		Folder:               "/Datacenters/dc-1/vm",
+		Host:                 "esxi-01",
+		Network:              "VM Network,dvpg-prod-100",
+		PowerState:           "on",
+		Datastore:            "vsan-east",
+		AttachedStorage:      "vsan-east,san-a",
+		IPAddress:            "10.10.1.21",
+		DNSName:              "vm-a.prod.local",
+		CPUCount:             4,
+		MemoryMB:             8192,
+		UsedCPUPercent:       50,
+		UsedCPUMHz:           4000,
+		UsedMemoryMB:         2048,
+		UsedStorageGB:        20,
+		ProvisionedStorageGB: 50,
+		SwapGB:               5,
+		LargestDiskGB:        40,
+		SnapshotTotalGB:      3,
+		Description:          "web & api tier",
+		SnapshotCount:        2,
+		Snapshots: []tui.VMSnapshot{
+			{Identifier: "pre-patch", Timestamp: "2026-02-10T12:00:00Z"},
+			{Identifier: "post-patch", Timestamp: "2026-02-12T12:00:00Z"},
+		},
+		ISODatastore:     "san-a",
+		IndependentDisks: 1,
+		RDMDisks:         1,
+	}
+	if !reflect.DeepEqual(rows[0], want) {
+		t.Fatalf("unexpected vm-a row:\n got %+v\nwant %+v", rows[0], want)
+	}
+	if rows[1].PowerState != "off" || rows[1].Datastore != "" || rows[1].UsedCPUPercent != 0 || !rows[1].BackupRunning || rows[0].BackupRunning {
+		t.Fatalf("unexpected vm-b row: %+v", rows[1])
+	}
+	if rows[2].PowerState != "suspended" || rows[2].Cluster != "" {
+		t.Fatalf("unexpected vm-c row: %+v", rows[2])
+	}
+}
+
+func TestListHostsAndClustersAggregateUsage(t *testing.T) {
+	provider := newSimulator(t).provider(t)
+	hosts, err := provider.ListHosts()
+	if err != nil {
+		t.Fatalf("ListHosts returned error: %v", err)
+	}
+	wantHosts := []tui.HostRow{
+		{Name: "esxi-01", Cluster: "cluster-east", CPUUsagePercent: 25, MemUsagePercent: 50, ConnectionState: "connected", CoreCount: 10, ThreadCount: 20, VMCount: 1, CPUMHz: 20000, MemoryMB: 65536},
+		{Name: "esxi-02", Cluster: "cluster-east", CPUUsagePercent: 75, MemUsagePercent: 25, ConnectionState: "maintenance", CoreCount: 10, ThreadCount: 20, VMCount: 1, CPUMHz: 20000, MemoryMB: 65536},
+	}
+	if !reflect.DeepEqual(hosts, wantHosts) {
+		t.Fatalf("unexpected hosts:\n got %+v\nwant %+v", hosts, wantHosts)
+	}
+	clusters, err := provider.ListClusters()
+	if err != nil {
+		t.Fatalf("ListClusters returned error: %v", err)
+	}
+	wantCluster := tui.ClusterRow{Name: "cluster-east", Datacenter: "dc-1", Hosts: 2, VMCount: 2, CPUUsagePercent: 50, MemUsagePercent: 37, ResourcePoolCount: 1, NetworkCount: 2}
+	if len(clusters) != 1 || clusters[0] != wantCluster {
+		t.Fatalf("unexpected clusters: %+v", clusters)
+	}
+	datacenters, err := provider.ListDatacenters()
+	if err != nil {
+		t.Fatalf("ListDatacenters returned error: %v", err)
+	}
+	wantDatacenter := tui.DatacenterRow{Name: "dc-1", ClusterCount: 1, HostCount: 2, VMCount: 3, DatastoreCount: 2, CPUUsagePercent: 50, MemUsagePercent: 37}
+	if len(datacenters) != 1 || datacenters[0] != wantDatacenter {
+		t.Fatalf("unexpected datacenters: %+v", datacenters)
+	}
+}
+
+func TestListStorageRows(t *testing.T) {
+	provider := newSimulator(t).provider(t)
+	datastores, err := provider.ListDatastores()
+	if err != nil {
+		t.Fatalf("ListDatastores returned error: %v", err)
+	}
+	wantDatastores := []tui.DatastoreRow{
+		{Name: "vsan-east", Cluster: "cluster-east", CapacityGB: 100, UsedGB: 60, FreeGB: 40, ProvisionedGB: 140, Type: "vsan", LatencyMS: 9, Hosts: "esxi-01"},
+		{Name: "san-a", CapacityGB: 200, UsedGB: 100, FreeGB: 100, ProvisionedGB: 100, Type: "vmfs", LatencyMS: 12, Local: true},
+	}
+	if !reflect.DeepEqual(datastores, wantDatastores) {
+		t.Fatalf("unexpected datastores: %+v", datastores)
+	}
+	luns, err := provider.ListLUNs()
+	if err != nil {
+		t.Fatalf("ListLUNs returned error: %v", err)
+	}
+	wantLUNs := []tui.LUNRow{
+		{Name: "naa.600a0980", Datastore: "san-a", CapacityGB: 100, UsedGB: 50},
+		{Name: "naa.600a0981", Datastore: "san-a", CapacityGB: 100, UsedGB: 50},
+	}
+	if !reflect.DeepEqual(luns, wantLUNs) {
+		t.Fatalf("unexpected luns: %+v", luns)
+	}
+}
+
+func TestListNetworksAndResourcePools(t *testing.T) {
+	provider := newSimulator(t).provider(t)
+	networks, err := provider.ListNetworks()
+	if err != nil {
+		t.Fatalf("ListNetworks returned error: %v", err)
+	}
+	wantNetworks := []tui.NetworkRow{
+		{Name: "VM Network", Type: "standard-portgroup", VLAN: "-", Switch: "-", AttachedVMs: 1},
+		{Name: "dvpg-prod-100", Type: "distributed-portgroup", VLAN: "100", Switch: "dvs-core-a", AttachedVMs: 1, MTU: 9000, Uplinks: 2},
+		{Name: "dvpg-trunk", Type: "distributed-portgroup", VLAN: "trunk", Switch: "-"},
+	}
+	if !reflect.DeepEqual(networks, wantNetworks) {
+		t.Fatalf("unexpected networks:\n got %+v\nwant %+v", networks, wantNetworks)
+	}
+	pools, err := provider.ListResourcePools()
+	if err != nil {
+		t.Fatalf("ListResourcePools returned error: %v", err)
+	}
+	wantPool := tui.ResourcePoolRow{Name: "rp-prod", Cluster: "cluster-east", CPUReservationMHz: 6400, MemReservationMB: 8192, VMCount: 1, CPULimitMHz: -1, MemLimitMB: 16384}
+	if len(pools) != 2 || pools[1] != wantPool || pools[0].Name != "Resources" {
+		t.Fatalf("unexpected pools: %+v", pools)
+	}
+}
+
+func TestListTemplatesSnapshotsAndFolders(t *testing.T) {
+	provider := newSimulator(t).provider(t)
+	templates, err := provider.ListTemplates()
+	if err != nil {
+		t.Fatalf("ListTemplates returned error: %v", err)
+	}
+	wantTemplates := []tui.TemplateRow{
+		{Name: "tpl-rhel9", OS: "rhel9_64Guest", Datastore: "vsan-east", Folder: "/Datacenters/dc-1/vm/Templates", Age: "45d", CPUCount: 2, MemoryMB: 4096},
+		{Name: "tpl-empty", Folder: "/Datacenters/dc-1/vm/Templates", Age: "-"},
+	}
+	if !reflect.DeepEqual(templates, wantTemplates) {
+		t.Fatalf("unexpected templates:\n got %+v\nwant %+v", templates, wantTemplates)
+	}
+	snapshots, err := provider.ListSnapshots()
+	if err != nil {
+		t.Fatalf("ListSnapshots returned error: %v", err)
+	}
+	wantSnapshots := []tui.SnapshotRow{
+		{VM: "vm-a", Snapshot: "pre-patch", Size: "-", Created: "2026-02-10T12:00:00Z", Age: "6d", Quiesced: "no", Owner: "-"},
+		{VM: "vm-a", Snapshot: "post-patch", Size: "-", Created: "2026-02-12T12:00:00Z", Age: "4d", Quiesced: "yes", Owner: "-"},
+	}
+	if !reflect.DeepEqual(snapshots, wantSnapshots) {
+		t.Fatalf("unexpected snapshots: %+v", snapshots)
+	}
+	folders, err := provider.ListFolders()
+	if err != nil {
+		t.Fatalf("ListFolders returned error: %v", err)
+	}
+	wantFolders := []tui.FolderRow{
+		{Path: "/Datacenters/dc-1/vm", Type: "vm-folder", Children: 4, VMCount: 3},
+		{Path: "/Datacenters/dc-1/vm/Templates", Type: "vm-folder", Children: 2, VMCount: 2},
+		{Path: "/Datacenters/dc-1/host", Type: "host-folder", Children: 1},
+		{Path: "/Datacenters/dc-1/datastore", Type: "datastore-folder", Children: 2},
+		{Path: "/Datacenters/dc-1/network", Type: "network-folder"},
+		{Path: "/Datacenters/dc-1/vm/archive", Type: "folder"},
+		{Path: "/Datacenters", Type: "datacenter-folder", Children: 1},
+	}
+	if !reflect.DeepEqual(folders, wantFolders) {
+		t.Fatalf("unexpected folders:\n got %+v\nwant %+v", folders, wantFolders)
+	}
+}
+
+func TestListTaskEventAndAlarmStreams(t *testing.T) {
+	provider := newSimulator(t).provider(t)
+	tasks, err := provider.ListTasks()
+	if err != nil {
+		t.Fatalf("ListTasks returned error: %v", err)
+	}
+	wantTasks := []tui.TaskRow{
+		{Entity: "vm-a", Action: "VirtualMachine.powerOff", State: "success", Started: "2026-02-16T08:10:00Z", Duration: "24s", Owner: "ops@example.com"},
+		{Entity: "vm-b", Action: "VirtualMachine.clone", State: "running", Started: "2026-02-16T11:57:46Z", Duration: "2m14s", Owner: "-"},
+		{Entity: "esxi-01", Action: "HostSystem.reconnect", State: "queued", Started: "pending", Duration: "-", Owner: "-"},
+	}
+	if !reflect.DeepEqual(tasks, wantTasks) {
+		t.Fatalf("unexpected tasks:\n got %+v\nwant %+v", tasks, wantTasks)
+	}
+	events, err := provider.ListEvents()
+	if err != nil {
+		t.Fatalf("ListEvents returned error: %v", err)
+	}
+	wantEvents := []tui.EventRow{
+		{Time: "2026-02-16T08:04:00Z", Severity: "info", Entity: "vm-a", Message: "vm-a on esxi-01 is powered on", User: "ops@example.com"},
+		{Time: "2026-02-16T08:09:00Z", Severity: "warning", Entity: "esxi-02", Message: "Host esxi-02 disconnected", User: "-"},
+		{Time: "2026-02-16T08:10:00Z", Severity: "error", Entity: "vm-b", Message: "Cannot power on vm-b", User: "-"},
+		{Time: "2026-02-16T08:11:00Z", Severity: "warning", Entity: "san-a", Message: "Datastore usage high", User: "-"},
+		{Time: "2026-02-16T08:12:00Z", Severity: "info", Entity: "-", Message: "maintenance note", User: "-"},
+	}
+	if !reflect.DeepEqual(events, wantEvents) {
+		t.Fatalf("unexpected events:\n got %+v\nwant %+v", events, wantEvents)
+	}
+	alarms, err := provider.ListAlarms()
+	if err != nil {
+		t.Fatalf("ListAlarms returned error: %v", err)
+	}
+	wantAlarms := []tui.AlarmRow{
+		{Entity: "vm-a", Alarm: "CPU usage high", Status: "red", Triggered: "2026-02-16T08:05:00Z", AckedBy: "-"},
+		{Entity: "esxi-01", Alarm: "Host connection lost", Status: "yellow", Triggered: "2026-02-16T08:12:00Z", AckedBy: "ops@example.com"},
+		{Entity: "esxi-02", Alarm: "CPU usage high", Status: "yellow", Triggered: "2026-02-16T08:13:00Z", AckedBy: "-"},
+	}
+	if !reflect.DeepEqual(alarms, wantAlarms) {
+		t.Fatalf("unexpected alarms:\n got %+v\nwant %+v", alarms, wantAlarms)
+	}
+	tags, err := provider.ListTags()
+	if err != nil || len(tags) != 0 {
+		t.Fatalf("expected no vim25 tags, got %+v err=%v", tags, err)
+	}
+}
+
+func TestProviderLoadsCatalogWithPagedRetrieval(t *testing.T) {
+	sim := newSimulator(t)
+	sim.pageSize = 2
+	catalog, err := tui.LoadCatalog(sim.provider(t))
+	if err != nil {
+		t.Fatalf("LoadCatalog returned error: %v", err)
+	}
+	if len(catalog.VMs) != 3 || len(catalog.Hosts) != 2 || len(catalog.Folders) != 7 {
+		t.Fatalf("unexpected catalog sizes: vms=%d hosts=%d folders=%d", len(catalog.VMs), len(catalog.Hosts), len(catalog.Folders))
+	}
+	if sim.calls["ContinueRetrievePropertiesEx"] == 0 {
+		t.Fatalf("expected paged retrieval to continue with a token")
+	}
+	if sim.calls["RetrievePropertiesEx"] != 4 || sim.calls["QueryEvents"] != 1 || sim.calls["QueryPerf"] != 1 {
+		t.Fatalf("expected one cached inventory load, got calls %+v", sim.calls)
+	}
+}
+
+func TestDatastoreOfPathNeedsABracketedName(t *testing.T) {
+	for path, want := range map[string]string{"[iso] linux/rhel9.iso": "iso", "linux/rhel9.iso": "", "[iso": "", "x[iso] a": ""} {
+		if got := datastoreOfPath(path); got != want {
+			t.Fatalf("expected %q from %q, got %q", want, path, got)
+		}
+	}
+}
@@ -0,0 +1,171 @@
+// Path: internal/vsphere/performance.go
+// Description: Read datastore latency from the real-time host statistics of the PerformanceManager.
+package vsphere
+
+import (
+	"context"
+	"encoding/xml"
+	"path"
+	"slices"
+	"strings"
+)
+
+// realtimeInterval is the sampling period, in seconds, of real-time statistics.
+const realtimeInterval = 20
+
+var latencyCounterNames = []string{"datastore.totalReadLatency.average", "datastore.totalWriteLatency.average"}
+
+var perfCounterSpecs = []PropertySpec{{Type: "PerformanceManager", PathSet: []string{"perfCounter"}}}
+
+// PerfCounter stores the identity of one performance counter.
+type PerfCounter struct {
+	Key    int32  `xml:"key"`
+	Group  string `xml:"groupInfo>key"`
+	Name   string `xml:"nameInfo>key"`
+	Rollup string `xml:"rollupType"`
+}
+
+// ID return the dotted counter name, such as datastore.totalReadLatency.average.
+func (c PerfCounter) ID() string {
+	return c.Group + "." + c.Name + "." + c.Rollup
+}
+
+// PerfSample stores the latest value of one counter instance on an entity.
+type PerfSample struct {
+	Entity   ManagedObjectReference
+	Counter  int32
+	Instance string
+	Value    int64
+}
+
+type perfMetricID struct {
+	CounterID int32  `xml:"counterId"`
+	Instance  string `xml:"instance"`
+}
+
+type perfQuerySpec struct {
+	Entity     ManagedObjectReference `xml:"entity"`
+	MaxSample  int                    `xml:"maxSample"`
+	MetricID   []perfMetricID         `xml:"metricId"`
+	IntervalID int                    `xml:"intervalId"`
+}
+
+// PerfCounters return the performance counters the vCenter collects.
+func (c *Client) PerfCounters(ctx context.Context) ([]PerfCounter, error) {
+	objects, err := c.RetrieveObjects(ctx, []ManagedObjectReference{c.content.PerfManager}, perfCounterSpecs)
+	if err != nil {
+		return nil, err
+	}
+	counters := struct {
+		Items []PerfCounter `xml:"PerfCounterInfo"`
+	}{}
+	if len(objects) > 0 {
+		_ = objects[0].Properties()["perfCounter"].Decode(&counters)
+	}
+	return counters.Items, nil
+}
+
+// QueryLatestPerf return the latest real-time sample of counters, across every
+// instance, on entities.
+func (c *Client) QueryLatestPerf(
+	ctx context.Context,
+	entities []ManagedObjectReference,
+	counters []int32,
+) ([]PerfSample, error) {
+	if len(entities) == 0 || len(counters) == 0 {
+		return nil, nil
+	}
+	metrics := make([]perfMetricID, 0, len(counters))
+	for _, counter := range counters {
+		metrics = append(metrics, perfMetricID{CounterID: counter, Instance: "*"})
+	}
+	request := struct {
+		XMLName   xml.Name               `xml:"urn:vim25 QueryPerf"`
+		This      ManagedObjectReference `xml:"_this"`
+		QuerySpec []perfQuerySpec        `xml:"querySpec"`
+	}{This: c.content.PerfManager}
+	for _, entity := range entities {
+		request.QuerySpec = append(request.QuerySpec, perfQuerySpec{
+			Entity: entity, MaxSample: 1, MetricID: metrics, IntervalID: realtimeInterval,
+		})
+	}
+	response := struct {
+		Returnval []struct {
+			Entity ManagedObjectReference `xml:"entity"`
+			Value  []struct {
+				ID     perfMetricID `xml:"id"`
+				Values []int64      `xml:"value"`
+			} `xml:"value"`
+		} `xml:"returnval"`
+	}{}
+	if err := c.call(ctx, request, &response); err != nil {
+		return nil, err
+	}
+	samples := []PerfSample{}
+	for _, metric := range response.Returnval {
+		for _, series := range metric.Value {
+			for _, value := range series.Values {
+				samples = append(samples, PerfSample{
+					Entity: metric.Entity, Counter: series.ID.CounterID, Instance: series.ID.Instance, Value: value,
+				})
+			}
+		}
+	}
+	return samples, nil
+}
+
+// loadLatency record on a snapshot the highest read or write latency any
+// connected host reports for each datastore, skipping the host-wide aggregate.
+func (p *Provider) loadLatency(ctx context.Context, next *snapshot) error {
+	counters, err := p.latencyCounters(ctx)
+	if err != nil {
+		return err
+	}
+	hosts := next.filter("HostSystem", func(ref ManagedObjectReference) bool {
+		return next.prop(ref, "runtime.connectionState").String() == "connected"
+	})
+	samples, err := p.client.QueryLatestPerf(ctx, hosts, counters)
+	if err != nil {
+		return err
+	}
+	latency := map[string]int{}
+	for _, sample := range samples {
+		if sample.Instance == "" {
+			continue
+		}
+		latency[sample.Instance] = max(latency[sample.Instance], int(sample.Value))
+	}
+	next.latency = latency
+	return nil
+}
+
+// latencyCounters return the keys of the datastore latency counters, looked
+// up once per provider since counter keys never change on a running vCenter.
+func (p *Provider) latencyCounters(ctx context.Context) ([]int32, error) {
+	p.mu.Lock()
+	cached := p.counters
+	p.mu.Unlock()
+	if cached != nil {
+		return cached, nil
+	}
+	counters, err := p.client.PerfCounters(ctx)
+	if err != nil {
+		return nil, err
+	}
+	keys := []int32{}
+	for _, counter := range counters {
+		if slices.Contains(latencyCounterNames, counter.ID()) {
+			keys = append(keys, counter.Key)
+		}
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.counters = keys
+	return keys, nil
+}
+
+// datastoreInstance return the performance instance of a datastore, the last
+// element of its ds:///vmfs/volumes/<uuid>/ URL.
+func (s *snapshot) datastoreInstance(ref ManagedObjectReference) string {
+	return path.Base(strings.TrimSuffix(s.prop(ref, "summary.url").String(), "/"))
+}
@@ -0,0 +1,65 @@
+// Path: internal/vsphere/performance_test.go
+// Description: Validate datastore latency read from host performance statistics on load and watch.
+package vsphere
+
+import (
+	"testing"
+
+	"github.com/takelley1/hypersphere/internal/tui"
+)
+
+func datastoreLatency(t *testing.T, provider *Provider) map[string]int {
+	t.Helper()
+	rows, err := provider.ListDatastores()
+	if err != nil {
+		t.Fatalf("ListDatastores returned error: %v", err)
+	}
+	latency := map[string]int{}
+	for _, row := range rows {
+		latency[row.Name] = row.LatencyMS
+	}
+	return latency
+}
+
+func TestProviderReadsDatastoreLatencyFromConnectedHosts(t *testing.T) {
+	sim := newSimulator(t)
+	sim.set(mor("HostSystem", "host-2"), "runtime.connectionState", valString("disconnected"))
+	provider := sim.provider(t)
+	if latency := datastoreLatency(t, provider); latency["vsan-east"] != 9 || latency["san-a"] != 3 {
+		t.Fatalf("expected the worst read or write average from connected hosts, got %+v", latency)
+	}
+	retrievals := sim.calls["RetrievePropertiesEx"]
+	if err := provider.Reload(); err != nil {
+		t.Fatalf("Reload returned error: %v", err)
+	}
+	if sim.calls["RetrievePropertiesEx"]-retrievals != 3 || sim.calls["QueryPerf"] != 2 {
+		t.Fatalf("expected counters looked up once and samples queried per load, got %+v", sim.calls)
+	}
+}
+
+func TestProviderSkipsLatencyWithoutCounters(t *testing.T) {
+	sim := newSimulator(t)
+	sim.unset(mor("PerformanceManager", "PerfMgr"), "perfCounter")
+	provider := sim.provider(t)
+	if latency := datastoreLatency(t, provider); latency["vsan-east"] != 0 || latency["san-a"] != 0 || sim.calls["QueryPerf"] != 0 {
+		t.Fatalf("expected no latency query without latency counters, got %+v calls=%+v", latency, sim.calls)
+	}
+}
+
+func TestWatchChangesReportsDatastoreLatency(t *testing.T) {
+	sim := newSimulator(t)
+	provider := sim.provider(t)
+	catalog := loadCatalog(t, provider)
+	watchInto(t, provider, &catalog)
+	sim.mu.Lock()
+	sim.perf[simSample{mor("HostSystem", "host-1"), 171, "vsan:52e1"}] = 25
+	sim.mu.Unlock()
+	changes := watchInto(t, provider, &catalog)
+	if len(changes) != 1 || changes[0].Op != tui.CatalogUpsert || changes[0].ID != "vsan-east" {
+		t.Fatalf("expected a single vsan-east upsert, got %+v", changes)
+	}
+	if latency := datastoreLatency(t, provider); latency["vsan-east"] != 25 {
+		t.Fatalf("expected watched latency, got %+v", latency)
+	}
+	assertCatalogMatchesFreshLoad(t, sim, catalog)
+}
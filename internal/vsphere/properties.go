@@ -0,0 +1,196 @@
+// Path: internal/vsphere/properties.go
+// Description: Model vim25 managed object references and PropertyCollector payloads.
+package vsphere
+
+import (
+	"encoding/xml"
+	"strconv"
+	"strings"
+)
+
+// ManagedObjectReference identifies one vim25 managed object.
+type ManagedObjectReference struct {
+	Type  string `xml:"type,attr"`
+	Value string `xml:",chardata"`
+}
+
+// String format the reference as Type:value.
+func (r ManagedObjectReference) String() string {
+	return r.Type + ":" + r.Value
+}
+
+// UnmarshalXML decode the reference, ignoring the xsi:type annotation vCenter adds.
+func (r *ManagedObjectReference) UnmarshalXML(decoder *xml.Decoder, start xml.StartElement) error {
+	for _, attr := range start.Attr {
+		if attr.Name.Space == "" && attr.Name.Local == "type" {
+			r.Type = attr.Value
+		}
+	}
+	value := ""
+	if err := decoder.DecodeElement(&value, &start); err != nil {
+		return err
+	}
+	r.Value = strings.TrimSpace(value)
+	return nil
+}
+
+// Value stores one raw PropertyCollector value and decodes it on demand.
+type Value struct {
+	Attrs []xml.Attr `xml:",any,attr"`
+	Inner string     `xml:",innerxml"`
+}
+
+// XSIType return the xsi:type attribute of the value.
+func (v Value) XSIType() string {
+	return xsiType(v.Attrs)
+}
+
+// String decode a scalar value as text.
+func (v Value) String() string {
+	text := struct {
+		Value string `xml:",chardata"`
+	}{}
+	if err := v.Decode(&text); err != nil {
+		return ""
+	}
+	return strings.TrimSpace(text.Value)
+}
+
+// Int64 decode a numeric value, returning zero when the value is not numeric.
+func (v Value) Int64() int64 {
+	parsed, err := strconv.ParseInt(v.String(), 10, 64)
+	if err != nil {
+		return 0
+	}
+	return parsed
+}
+
+// Int decode a numeric value as int.
+func (v Value) Int() int {
+	return int(v.Int64())
+}
+
+// Bool decode a boolean value, returning false when the value is not boolean.
+func (v Value) Bool() bool {
+	parsed, err := strconv.ParseBool(v.String())
+	return err == nil && parsed
+}
+
+// Ref decode a ManagedObjectReference value.
+func (v Value) Ref() ManagedObjectReference {
+	ref := ManagedObjectReference{}
+	for _, attr := range v.Attrs {
+		if attr.Name.Space == "" && attr.Name.Local == "type" {
+			ref = ManagedObjectReference{Type: attr.Value, Value: v.String()}
+		}
+	}
+	return ref
+}
+
+// Refs decode an ArrayOfManagedObjectReference value.
+func (v Value) Refs() []ManagedObjectReference {
+	array := struct {
+		Items []ManagedObjectReference `xml:"ManagedObjectReference"`
+	}{}
+	_ = v.Decode(&array)
+	return array.Items
+}
+
+// Strings decode an ArrayOfString value.
+func (v Value) Strings() []string {
+	array := struct {
+		Items []string `xml:"string"`
+	}{}
+	_ = v.Decode(&array)
+	return array.Items
+}
+
+// Decode unmarshal the raw value into a typed structure.
+func (v Value) Decode(target any) error {
+	return xml.Unmarshal([]byte("<val>"+v.Inner+"</val>"), target)
+}
+
+// DynamicProperty stores one property path and value.
+type DynamicProperty struct {
+	Name string `xml:"name"`
+	Val  Value  `xml:"val"`
+}
+
+// ObjectContent stores properties retrieved for one managed object.
+type ObjectContent struct {
+	Obj     ManagedObjectReference `xml:"obj"`
+	PropSet []DynamicProperty      `xml:"propSet"`
+}
+
+// Properties index the retrieved properties by path.
+func (o ObjectContent) Properties() map[string]Value {
+	properties := make(map[string]Value, len(o.PropSet))
+	for _, property := range o.PropSet {
+		properties[property.Name] = property.Val
+	}
+	return properties
+}
+
+// PropertySpec selects properties to retrieve for one managed object type.
+type PropertySpec struct {
+	Type    string   `xml:"type"`
+	PathSet []string `xml:"pathSet"`
+}
+
+// SelectionSpec is a traversal step inside an ObjectSpec.
+type SelectionSpec struct {
+	XSIType string `xml:"xsi:type,attr"`
+	Name    string `xml:"name,omitempty"`
+	Type    string `xml:"type"`
+	Path    string `xml:"path"`
+	Skip    bool   `xml:"skip"`
+}
+
+// ObjectSpec selects the starting object of a property retrieval.
+type ObjectSpec struct {
+	Obj       ManagedObjectReference `xml:"obj"`
+	Skip      bool                   `xml:"skip"`
+	SelectSet []SelectionSpec        `xml:"selectSet,omitempty"`
+}
+
+// PropertyFilterSpec combines property and object selection.
+type PropertyFilterSpec struct {
+	PropSet   []PropertySpec `xml:"propSet"`
+	ObjectSet []ObjectSpec   `xml:"objectSet"`
+}
+
+// RetrieveResult stores one page of retrieved objects.
+type RetrieveResult struct {
+	Token   string          `xml:"token"`
+	Objects []ObjectContent `xml:"objects"`
+}
+
+func containerViewObjectSpec(view ManagedObjectReference) ObjectSpec {
+	return ObjectSpec{
+		Obj:  view,
+		Skip: true,
+		SelectSet: []SelectionSpec{{
+			XSIType: "TraversalSpec",
+			Name:    "traverseView",
+			Type:    "ContainerView",
+			Path:    "view",
+		}},
+	}
+}
+
+func xsiType(attrs []xml.Attr) string {
+	for _, attr := range attrs {
+		if attr.Name.Space != "" && attr.Name.Local == "type" {
+			return attr.Value
+		}
+	}
+	return ""
+}
+
+func specTypes(specs []PropertySpec) []string {
+	types := make([]string, 0, len(specs))
+	for _, spec := range specs {
+		types = append(types, spec.Type)
+	}
+	return types
+}
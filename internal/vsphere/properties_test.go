@@ -0,0 +1,53 @@
+// Path: internal/vsphere/properties_test.go
+// Description: Verify PropertyCollector value decoding helpers.
+package vsphere
+
+import (
+	"encoding/xml"
+	"testing"
+)
+
+func refAttrs(objectType string) []xml.Attr {
+	return []xml.Attr{{Name: xml.Name{Local: "type"}, Value: objectType}}
+}
+
+func TestValueDecodesScalarsAndArrays(t *testing.T) {
+	value := Value{
+		Attrs: []xml.Attr{{Name: xml.Name{Space: "http://www.w3.org/2001/XMLSchema-instance", Local: "type"}, Value: "xsd:int"}},
+		Inner: " 42 ",
+	}
+	if value.XSIType() != "xsd:int" || value.Int() != 42 || value.String() != "42" {
+		t.Fatalf("unexpected scalar decode: type=%q int=%d", value.XSIType(), value.Int())
+	}
+	if (Value{Inner: "many"}).Int64() != 0 || (Value{Inner: "nope"}).Bool() || !(Value{Inner: "true"}).Bool() {
+		t.Fatalf("unexpected fallback decode for invalid scalars")
+	}
+	if (Value{Inner: "<broken>"}).String() != "" {
+		t.Fatalf("expected malformed value to decode as empty text")
+	}
+	if ref := (Value{Inner: "vm-1"}).Ref(); ref.Value != "" {
+		t.Fatalf("expected untyped value to decode as empty reference, got %v", ref)
+	}
+	refs := Value{Inner: `<ManagedObjectReference type="Datastore" xsi:type="ManagedObjectReference">datastore-1</ManagedObjectReference>`}.Refs()
+	if len(refs) != 1 || refs[0].String() != "Datastore:datastore-1" {
+		t.Fatalf("expected xsi:type to be ignored on references, got %+v", refs)
+	}
+	strings := Value{Inner: "<string>Folder</string><string>VirtualMachine</string>"}.Strings()
+	if len(strings) != 2 || strings[1] != "VirtualMachine" {
+		t.Fatalf("unexpected string array: %+v", strings)
+	}
+}
+
+func TestManagedObjectReferenceRejectsMalformedXML(t *testing.T) {
+	ref := ManagedObjectReference{}
+	if err := xml.Unmarshal([]byte(`<obj type="VirtualMachine">vm-1<child></obj>`), &ref); err == nil {
+		t.Fatalf("expected malformed reference to fail decoding")
+	}
+}
+
+func TestObjectContentIndexesProperties(t *testing.T) {
+	content := ObjectContent{PropSet: []DynamicProperty{{Name: "name", Val: Value{Inner: "vm-a"}}}}
+	if content.Properties()["name"].String() != "vm-a" {
+		t.Fatalf("expected indexed name property")
+	}
+}
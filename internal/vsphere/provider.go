@@ -0,0 +1,288 @@
+// Path: internal/vsphere/provider.go
+// Description: Load a vCenter inventory snapshot through the PropertyCollector.
+package vsphere
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+const eventWindow = 24 * time.Hour
+
+// ErrDatacenterNotFound indicates a datacenter scope that matches no inventory datacenter.
+var ErrDatacenterNotFound = errors.New("vcenter datacenter not found")
+
+var inventorySpecs = []PropertySpec{
+	{Type: "VirtualMachine", PathSet: []string{
+		"name", "parent", "config.template", "config.guestId", "config.annotation",
+		"config.createDate", "config.hardware.numCPU", "config.hardware.memoryMB",
+		"config.hardware.device", "runtime.powerState", "runtime.host", "runtime.maxCpuUsage",
+		"datastore", "network", "guest.ipAddress", "guest.hostName",
+		"summary.quickStats.overallCpuUsage", "summary.quickStats.guestMemoryUsage",
+		"summary.storage.committed", "summary.storage.uncommitted", "config.memoryAllocation.reservation",
+		"snapshot", "layoutEx.file", "disabledMethod",
+	}},
+	{Type: "HostSystem", PathSet: []string{
+		"name", "parent", "runtime.connectionState", "runtime.inMaintenanceMode",
+		"summary.hardware.cpuMhz", "summary.hardware.numCpuCores",
+		"summary.hardware.numCpuThreads", "summary.hardware.memorySize",
+		"summary.quickStats.overallCpuUsage", "summary.quickStats.overallMemoryUsage", "vm",
+	}},
+	{Type: "ClusterComputeResource", PathSet: []string{"name", "parent", "host", "network"}},
+	{Type: "Datacenter", PathSet: []string{"name", "parent"}},
+	{Type: "Datastore", PathSet: []string{
+		"name", "parent", "summary.capacity", "summary.freeSpace", "summary.uncommitted", "summary.type",
+		"summary.multipleHostAccess", "summary.url", "host", "info",
+	}},
+	{Type: "Network", PathSet: []string{"name", "parent", "vm"}},
+	{Type: "DistributedVirtualPortgroup", PathSet: []string{
+		"name", "parent", "vm", "config.distributedVirtualSwitch", "config.defaultPortConfig",
+	}},
+	{Type: "DistributedVirtualSwitch", PathSet: []string{
+		"name", "parent", "config.maxMtu", "config.uplinkPortPolicy",
+	}},
+	{Type: "ResourcePool", PathSet: []string{
+		"name", "parent", "owner", "vm",
+		"config.cpuAllocation.reservation", "config.cpuAllocation.limit",
+		"config.memoryAllocation.reservation", "config.memoryAllocation.limit",
+	}},
+	{Type: "Folder", PathSet: []string{"name", "parent", "childType", "childEntity"}},
+}
+
+var rootSpecs = []PropertySpec{
+	{Type: "Folder", PathSet: []string{"name", "childType", "childEntity", "triggeredAlarmState"}},
+	{Type: "TaskManager", PathSet: []string{"recentTask"}},
+}
+
+var detailSpecs = []PropertySpec{
+	{Type: "Alarm", PathSet: []string{"info.name"}},
+	{Type: "Task", PathSet: []string{"info"}},
+}
+
+// Provider lists live vCenter inventory for the explorer.
+type Provider struct {
+	client     *Client
+	now        func() time.Time
+	datacenter string
+	mu         sync.Mutex
+	cache      *snapshot
+	watchMu    sync.Mutex
+	watch      *watchState
+	counters   []int32
+}
+
+// NewProvider build a vCenter inventory provider over a logged-in client.
+func NewProvider(client *Client) *Provider {
+	return &Provider{client: client, now: time.Now}
+}
+
+// Client return the underlying SOAP client.
+func (p *Provider) Client() *Client {
+	return p.client
+}
+
+// ScopeDatacenter limit inventory listings to objects under the named datacenter.
+func (p *Provider) ScopeDatacenter(name string) {
+	p.datacenter = strings.TrimSpace(name)
+}
+
+// Reload fetch a fresh inventory snapshot from vCenter.
+func (p *Provider) Reload() error {
+	ctx := context.Background()
+	content := p.client.ServiceContent()
+	next := newSnapshot(content.RootFolder)
+	objects, err := p.client.RetrieveInventory(ctx, inventorySpecs)
+	if err != nil {
+		return err
+	}
+	next.add(objects)
+	roots, err := p.client.RetrieveObjects(
+		ctx,
+		[]ManagedObjectReference{content.RootFolder, content.TaskManager},
+		rootSpecs,
+	)
+	if err != nil {
+		return err
+	}
+	next.add(roots)
+	details, err := p.client.RetrieveObjects(ctx, next.detailRefs(content.TaskManager), detailSpecs)
+	if err != nil {
+		return err
+	}
+	next.add(details)
+	events, err := p.client.QueryEvents(ctx, p.now().Add(-eventWindow))
+	if err != nil {
+		return err
+	}
+	next.events = events
+	if err := p.loadLatency(ctx, next); err != nil {
+		return err
+	}
+	p.publish(next)
+	return nil
+}
+
+// Close release watch filters and log out of the vCenter session.
+func (p *Provider) Close() error {
+	ctx := context.Background()
+	p.stopWatch(ctx)
+	return p.client.Logout(ctx)
+}
+
+func (p *Provider) inventory() (*snapshot, error) {
+	if cache := p.cached(); cache != nil {
+		return cache.scoped(p.datacenter)
+	}
+	if err := p.Reload(); err != nil {
+		return nil, err
+	}
+	return p.cached().scoped(p.datacenter)
+}
+
+func (p *Provider) cached() *snapshot {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.cache
+}
+
+func (p *Provider) publish(next *snapshot) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.cache = next
+}
+
+type snapshot struct {
+	root    ManagedObjectReference
+	order   []ManagedObjectReference
+	objects map[ManagedObjectReference]map[string]Value
+	events  []Event
+	latency map[string]int
+}
+
+func newSnapshot(root ManagedObjectReference) *snapshot {
+	return &snapshot{root: root, objects: map[ManagedObjectReference]map[string]Value{}}
+}
+
+func (s *snapshot) add(contents []ObjectContent) {
+	for _, content := range contents {
+		properties, ok := s.objects[content.Obj]
+		if !ok {
+			properties = map[string]Value{}
+			s.objects[content.Obj] = properties
+			s.order = append(s.order, content.Obj)
+		}
+		for _, property := range content.PropSet {
+			properties[property.Name] = property.Val
+		}
+	}
+}
+
+func (s *snapshot) clone() *snapshot {
+	return &snapshot{
+		root:    s.root,
+		order:   slices.Clone(s.order),
+		objects: maps.Clone(s.objects),
+		events:  slices.Clone(s.events),
+		latency: s.latency,
+	}
+}
+
+func (s *snapshot) scoped(datacenter string) (*snapshot, error) {
+	if datacenter == "" {
+		return s, nil
+	}
+	if !slices.ContainsFunc(s.ofType("Datacenter"), func(ref ManagedObjectReference) bool {
+		return s.name(ref) == datacenter
+	}) {
+		return nil, fmt.Errorf("%w: %s", ErrDatacenterNotFound, datacenter)
+	}
+	order := slices.DeleteFunc(slices.Clone(s.order), func(ref ManagedObjectReference) bool {
+		owner := s.ancestor(ref, "Datacenter")
+		return owner.Value != "" && s.name(owner) != datacenter
+	})
+	return &snapshot{root: s.root, order: order, objects: s.objects, events: s.events, latency: s.latency}, nil
+}
+
+func (s *snapshot) remove(ref ManagedObjectReference) {
+	delete(s.objects, ref)
+	s.order = slices.DeleteFunc(s.order, func(candidate ManagedObjectReference) bool { return candidate == ref })
+}
+
+func (s *snapshot) removeTypes(objectTypes ...string) {
+	s.order = slices.DeleteFunc(s.order, func(ref ManagedObjectReference) bool {
+		if !slices.Contains(objectTypes, ref.Type) {
+			return false
+		}
+		delete(s.objects, ref)
+		return true
+	})
+}
+
+func (s *snapshot) detailRefs(taskManager ManagedObjectReference) []ManagedObjectReference {
+	refs := []ManagedObjectReference{}
+	seen := map[ManagedObjectReference]bool{}
+	for _, state := range s.alarmStates() {
+		if !seen[state.Alarm] {
+			seen[state.Alarm] = true
+			refs = append(refs, state.Alarm)
+		}
+	}
+	return append(refs, s.prop(taskManager, "recentTask").Refs()...)
+}
+
+func (s *snapshot) alarmStates() []AlarmState {
+	states := struct {
+		Items []AlarmState `xml:"AlarmState"`
+	}{}
+	_ = s.prop(s.root, "triggeredAlarmState").Decode(&states)
+	return states.Items
+}
+
+func (s *snapshot) ofType(objectType string) []ManagedObjectReference {
+	refs := []ManagedObjectReference{}
+	for _, ref := range s.order {
+		if ref.Type == objectType {
+			refs = append(refs, ref)
+		}
+	}
+	return refs
+}
+
+func (s *snapshot) prop(ref ManagedObjectReference, path string) Value {
+	return s.objects[ref][path]
+}
+
+func (s *snapshot) name(ref ManagedObjectReference) string {
+	return s.prop(ref, "name").String()
+}
+
+func (s *snapshot) ancestor(ref ManagedObjectReference, objectType string) ManagedObjectReference {
+	seen := map[ManagedObjectReference]bool{}
+	for current := ref; current.Value != "" && !seen[current]; current = s.prop(current, "parent").Ref() {
+		if current.Type == objectType {
+			return current
+		}
+		seen[current] = true
+	}
+	return ManagedObjectReference{}
+}
+
+func (s *snapshot) path(ref ManagedObjectReference) string {
+	names := []string{}
+	seen := map[ManagedObjectReference]bool{}
+	for current := ref; current.Value != "" && !seen[current]; current = s.prop(current, "parent").Ref() {
+		names = append([]string{s.name(current)}, names...)
+		seen[current] = true
+	}
+	path := ""
+	for _, name := range names {
+		path += "/" + name
+	}
+	return path
+}
@@ -0,0 +1,146 @@
+// Path: internal/vsphere/provider_test.go
+// Description: Verify vCenter inventory snapshot loading, caching, and failure handling.
+package vsphere
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestProviderReloadPropagatesEachRetrievalFailure(t *testing.T) {
+	tests := []struct {
+		method string
+		call   int
+	}{
+		{method: "CreateContainerView", call: 1},
+		{method: "RetrievePropertiesEx", call: 1},
+		{method: "RetrievePropertiesEx", call: 2},
+		{method: "RetrievePropertiesEx", call: 3},
+		{method: "QueryEvents", call: 1},
+		{method: "RetrievePropertiesEx", call: 4},
+		{method: "QueryPerf", call: 1},
+	}
+	for _, test := range tests {
+		sim := newSimulator(t)
+		provider := sim.provider(t)
+		sim.failOn(test.method, test.call)
+		err := provider.Reload()
+		fault := &Fault{}
+		if !errors.As(err, &fault) || fault.Kind != "SystemError" {
+			t.Fatalf("%s call %d: expected injected fault, got %v", test.method, test.call, err)
+		}
+	}
+}
+
+func TestProviderListersReturnLoadErrors(t *testing.T) {
+	listers := map[string]func(*Provider) error{
+		"vms":           func(p *Provider) error { _, err := p.ListVMs(); return err },
+		"luns":          func(p *Provider) error { _, err := p.ListLUNs(); return err },
+		"clusters":      func(p *Provider) error { _, err := p.ListClusters(); return err },
+		"datacenters":   func(p *Provider) error { _, err := p.ListDatacenters(); return err },
+		"resourcepools": func(p *Provider) error { _, err := p.ListResourcePools(); return err },
+		"networks":      func(p *Provider) error { _, err := p.ListNetworks(); return err },
+		"templates":     func(p *Provider) error { _, err := p.ListTemplates(); return err },
+		"snapshots":     func(p *Provider) error { _, err := p.ListSnapshots(); return err },
+		"tasks":         func(p *Provider) error { _, err := p.ListTasks(); return err },
+		"events":        func(p *Provider) error { _, err := p.ListEvents(); return err },
+		"alarms":        func(p *Provider) error { _, err := p.ListAlarms(); return err },
+		"folders":       func(p *Provider) error { _, err := p.ListFolders(); return err },
+		"tags":          func(p *Provider) error { _, err := p.ListTags(); return err },
+		"hosts":         func(p *Provider) error { _, err := p.ListHosts(); return err },
+		"datastores":    func(p *Provider) error { _, err := p.ListDatastores(); return err },
+	}
+	for name, list := range listers {
+		sim := newSimulator(t)
+		provider := sim.provider(t)
+		sim.failOn("CreateContainerView", 1)
+		if err := list(provider); err == nil {
+			t.Fatalf("%s: expected load error", name)
+		}
+	}
+}
+
+func TestProviderReloadRefreshesCachedSnapshot(t *testing.T) {
+	sim := newSimulator(t)
+	provider := sim.provider(t)
+	if _, err := provider.ListHosts(); err != nil {
+		t.Fatalf("ListHosts returned error: %v", err)
+	}
+	sim.set(mor("HostSystem", "host-1"), "runtime.connectionState", valString("disconnected"))
+	cached, _ := provider.ListHosts()
+	if cached[0].ConnectionState != "connected" {
+		t.Fatalf("expected cached host state before reload, got %+v", cached[0])
+	}
+	if err := provider.Reload(); err != nil {
+		t.Fatalf("Reload returned error: %v", err)
+	}
+	refreshed, _ := provider.ListHosts()
+	if refreshed[0].ConnectionState != "disconnected" {
+		t.Fatalf("expected refreshed host state, got %+v", refreshed[0])
+	}
+}
+
+func TestProviderScopeDatacenterFiltersInventory(t *testing.T) {
+	sim := newSimulator(t)
+	sim.add("Datacenter", "datacenter-2", map[string]string{
+		"name": valString("dc-2"), "parent": valRef(mor("Folder", "group-d1")),
+	})
+	sim.add("Folder", "group-v9", map[string]string{
+		"name": valString("vm"), "parent": valRef(mor("Datacenter", "datacenter-2")),
+	})
+	sim.add("VirtualMachine", "vm-9", map[string]string{
+		"name": valString("vm-remote"), "parent": valRef(mor("Folder", "group-v9")),
+		"config.template": valBool(false), "runtime.powerState": valString("poweredOn"),
+	})
+	provider := sim.provider(t)
+	all, _ := provider.ListVMs()
+	provider.ScopeDatacenter(" dc-1 ")
+	scoped, err := provider.ListVMs()
+	if err != nil {
+		t.Fatalf("ListVMs returned error: %v", err)
+	}
+	if len(scoped) != len(all)-1 || vmNames(scoped) != "vm-a,vm-b,vm-c" {
+		t.Fatalf("expected dc-2 VMs to be excluded, got %s of %s", vmNames(scoped), vmNames(all))
+	}
+	if datacenters, _ := provider.ListDatacenters(); len(datacenters) != 1 || datacenters[0].Name != "dc-1" {
+		t.Fatalf("expected only the scoped datacenter, got %+v", datacenters)
+	}
+	provider.ScopeDatacenter("dc-missing")
+	if _, err := provider.ListVMs(); !errors.Is(err, ErrDatacenterNotFound) {
+		t.Fatalf("expected missing datacenter error, got %v", err)
+	}
+	if _, err := provider.WatchChanges(t.Context(), time.Second); !errors.Is(err, ErrDatacenterNotFound) {
+		t.Fatalf("expected watch to report missing datacenter, got %v", err)
+	}
+}
+
+func TestProviderCloseLogsOut(t *testing.T) {
+	sim := newSimulator(t)
+	provider := sim.provider(t)
+	if provider.Client() == nil {
+		t.Fatalf("expected provider client")
+	}
+	if err := provider.Close(); err != nil {
+		t.Fatalf("Close returned error: %v", err)
+	}
+	if sim.calls["Logout"] != 1 {
+		t.Fatalf("expected one logout, got %d", sim.calls["Logout"])
+	}
+}
+
+func TestSnapshotAncestorAndPathStopOnCycles(t *testing.T) {
+	a := mor("Folder", "a")
+	b := mor("Folder", "b")
+	inventory := newSnapshot(a)
+	inventory.add([]ObjectContent{
+		{Obj: a, PropSet: []DynamicProperty{{Name: "parent", Val: Value{Attrs: refAttrs("Folder"), Inner: "b"}}}},
+		{Obj: b, PropSet: []DynamicProperty{{Name: "parent", Val: Value{Attrs: refAttrs("Folder"), Inner: "a"}}}},
+	})
+	if ref := inventory.ancestor(a, "Datacenter"); ref.Value != "" {
+		t.Fatalf("expected no datacenter ancestor, got %v", ref)
+	}
+	if path := inventory.path(a); path != "//" {
+		t.Fatalf("expected cycle-safe path, got %q", path)
+	}
+}
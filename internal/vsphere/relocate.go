@@ -0,0 +1,177 @@
+// Path: internal/vsphere/relocate.go
+// Description: Relocate VMs between datastores with Storage vMotion and wait for vCenter tasks.
+package vsphere
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/takelley1/hypersphere/internal/migration"
+)
+
+const defaultTaskPoll = time.Second
+
+var (
+	// ErrObjectNotFound indicates a VM or datastore name missing from the inventory.
+	ErrObjectNotFound = errors.New("vcenter object not found")
+	// ErrAmbiguousObject indicates a VM or datastore name shared by several
+	// objects, such as same-named VMs in different folders or datacenters.
+	ErrAmbiguousObject = errors.New("vcenter object name is ambiguous")
+	// ErrTaskFailed indicates a vCenter task that finished in the error state.
+	ErrTaskFailed = errors.New("vcenter task failed")
+)
+
+var taskInfoSpecs = []PropertySpec{{Type: "Task", PathSet: []string{"info"}}}
+
+// TaskError reports a vCenter task that finished in the error state, keeping
+// the kind of fault it raised so callers can tell whether to retry.
+type TaskError struct {
+	Task    string
+	Kind    string
+	Message string
+}
+
+// Error format the task and the vCenter message.
+func (e *TaskError) Error() string {
+	return fmt.Sprintf("%v: %s: %s", ErrTaskFailed, e.Task, e.Message)
+}
+
+// Unwrap return ErrTaskFailed.
+func (e *TaskError) Unwrap() error {
+	return ErrTaskFailed
+}
+
+// Retriable report whether rerunning the task may succeed, classified like Fault.
+func (e *TaskError) Retriable() bool {
+	return retriableFault(e.Kind)
+}
+
+// RelocateVM start a Storage vMotion of a VM to a datastore and return its task.
+func (c *Client) RelocateVM(
+	ctx context.Context,
+	vm ManagedObjectReference,
+	datastore ManagedObjectReference,
+) (ManagedObjectReference, error) {
+	request := struct {
+		XMLName xml.Name               `xml:"urn:vim25 RelocateVM_Task"`
+		This    ManagedObjectReference `xml:"_this"`
+		Spec    struct {
+			Datastore ManagedObjectReference `xml:"datastore"`
+		} `xml:"spec"`
+	}{This: vm}
+	request.Spec.Datastore = datastore
+	response := struct {
+		Returnval ManagedObjectReference `xml:"returnval"`
+	}{}
+	err := c.call(ctx, request, &response)
+	return response.Returnval, err
+}
+
+// WaitForTask poll a task until it succeeds, fails, or the context ends.
+func (c *Client) WaitForTask(ctx context.Context, task ManagedObjectReference, poll time.Duration) error {
+	for {
+		objects, err := c.RetrieveObjects(ctx, []ManagedObjectReference{task}, taskInfoSpecs)
+		if err != nil {
+			return err
+		}
+		info := TaskInfo{}
+		if len(objects) > 0 {
+			_ = objects[0].Properties()["info"].Decode(&info)
+		}
+		switch info.State {
+		case "success":
+			return nil
+		case "error":
+			return &TaskError{Task: task.Value, Kind: xsiType(info.Error.Fault.Attrs), Message: info.Error.LocalizedMessage}
+		}
+		timer := time.NewTimer(poll)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// Mover moves VMs to target datastores by name through Storage vMotion.
+type Mover struct {
+	provider *Provider
+	ctx      context.Context
+	timeout  time.Duration
+	poll     time.Duration
+}
+
+// NewMover build a Storage vMotion mover over a provider's inventory whose
+// moves run under ctx.
+func NewMover(ctx context.Context, provider *Provider) *Mover {
+	return &Mover{provider: provider, ctx: ctx, poll: defaultTaskPoll}
+}
+
+// WithTimeout bound each move, from the relocate call to the end of its task,
+// by timeout. Zero leaves moves bounded only by the mover's context.
+func (m *Mover) WithTimeout(timeout time.Duration) *Mover {
+	m.timeout = timeout
+	return m
+}
+
+// Move relocate the named VM to the named datastore and wait for the task.
+// Names missing from the inventory are fatal, so the move is not retried. A
+// wait cut short by the context or timeout stops polling but leaves the
+// vCenter task running.
+func (m *Mover) Move(vmName string, target string) error {
+	ctx, cancel := m.context()
+	defer cancel()
+	inventory, err := m.provider.inventory()
+	if err != nil {
+		return err
+	}
+	vm, err := inventory.find("VirtualMachine", vmName)
+	if err != nil {
+		return migration.Fatal(err)
+	}
+	datastore, err := inventory.find("Datastore", target)
+	if err != nil {
+		return migration.Fatal(err)
+	}
+	task, err := m.provider.client.RelocateVM(ctx, vm, datastore)
+	if err != nil {
+		return err
+	}
+	return m.provider.client.WaitForTask(ctx, task, m.poll)
+}
+
+// context return the context of one move, bounded by the mover's timeout.
+func (m *Mover) context() (context.Context, context.CancelFunc) {
+	if m.timeout > 0 {
+		return context.WithTimeout(m.ctx, m.timeout)
+	}
+	return context.WithCancel(m.ctx)
+}
+
+// find resolve the single object of the type with the name. Templates never
+// stand in for VMs, and a name shared by several objects is an error rather
+// than a guess, so a task never runs against the wrong object.
+func (s *snapshot) find(objectType string, name string) (ManagedObjectReference, error) {
+	matches := []ManagedObjectReference{}
+	for _, ref := range s.ofType(objectType) {
+		if s.name(ref) == name && !s.prop(ref, "config.template").Bool() {
+			matches = append(matches, ref)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return ManagedObjectReference{}, fmt.Errorf("%w: %s %s", ErrObjectNotFound, objectType, name)
+	case 1:
+		return matches[0], nil
+	}
+	paths := make([]string, 0, len(matches))
+	for _, ref := range matches {
+		paths = append(paths, s.path(ref))
+	}
+	return ManagedObjectReference{}, fmt.Errorf("%w: %s %s matches %s", ErrAmbiguousObject, objectType, name, strings.Join(paths, ", "))
+}
@@ -0,0 +1,113 @@
+// Path: internal/vsphere/relocate_test.go
+// Description: Validate Storage vMotion relocation, task polling, and mover name resolution.
+package vsphere
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/takelley1/hypersphere/internal/migration"
+)
+
+func TestMoverRelocatesVMAndWaitsForTask(t *testing.T) {
+	sim := newSimulator(t)
+	sim.relocation = []string{"running", "running", "success"}
+	mover := NewMover(context.Background(), sim.provider(t))
+	mover.poll = time.Millisecond
+	if err := mover.Move("vm-b", "san-a"); err != nil {
+		t.Fatalf("Move returned error: %v", err)
+	}
+	if sim.calls["RelocateVM_Task"] != 1 {
+		t.Fatalf("expected one RelocateVM_Task call, got %d", sim.calls["RelocateVM_Task"])
+	}
+	if got := sim.object(mor("VirtualMachine", "vm-2")).props["datastore"]; got != valRefs(mor("Datastore", "datastore-2")) {
+		t.Fatalf("expected vm-b to be relocated to san-a, got %s", got)
+	}
+	sim.relocation = []string{"running", "error"}
+	var failed *TaskError
+	if err := mover.Move("vm-a", "san-a"); !errors.Is(err, ErrTaskFailed) || !errors.As(err, &failed) ||
+		failed.Kind != "InsufficientDiskSpace" || err.Error() != "vcenter task failed: task-relocate-2: insufficient disk space" {
+		t.Fatalf("expected task failure with vCenter fault and message, got %v", err)
+	}
+}
+
+func TestMoveErrorsAreClassifiedByFaultKind(t *testing.T) {
+	sim := newSimulator(t)
+	mover := NewMover(context.Background(), sim.provider(t))
+	mover.poll = time.Millisecond
+	for kind, retriable := range map[string]bool{
+		"NoPermission":          false,
+		"InvalidArgument":       false,
+		"InsufficientDiskSpace": false,
+		"NotSupported":          false,
+		"SystemError":           false,
+		"":                      false,
+		"TaskInProgress":        true,
+		"ResourceInUse":         true,
+		"HostCommunication":     true,
+	} {
+		sim.relocation = []string{"error:" + kind}
+		if err := mover.Move("vm-a", "san-a"); !errors.Is(err, ErrTaskFailed) || migration.IsRetriable(err) != retriable {
+			t.Fatalf("expected task fault %q retriable=%v, got %v", kind, retriable, err)
+		}
+		if fault := (&Fault{Kind: kind}); migration.IsRetriable(fault) != retriable {
+			t.Fatalf("expected SOAP fault %q retriable=%v", kind, retriable)
+		}
+	}
+}
+
+func TestMoverRejectsUnknownNamesAndFaults(t *testing.T) {
+	sim := newSimulator(t)
+	mover := NewMover(context.Background(), sim.provider(t))
+	for _, names := range [][2]string{{"vm-missing", "san-a"}, {"vm-a", "ds-missing"}} {
+		if err := mover.Move(names[0], names[1]); !errors.Is(err, ErrObjectNotFound) || migration.IsRetriable(err) {
+			t.Fatalf("expected fatal object not found for %v, got %v", names, err)
+		}
+	}
+	sim.failOn("RelocateVM_Task", 1)
+	var fault *Fault
+	if err := mover.Move("vm-a", "san-a"); !errors.As(err, &fault) {
+		t.Fatalf("expected relocate fault, got %v", err)
+	}
+	sim.failOn("RetrievePropertiesEx", 1)
+	if err := mover.Move("vm-a", "san-a"); !errors.As(err, &fault) {
+		t.Fatalf("expected task poll fault, got %v", err)
+	}
+	failing := newSimulator(t)
+	failing.failOn("CreateContainerView", 1)
+	if err := NewMover(context.Background(), failing.provider(t)).Move("vm-a", "san-a"); err == nil {
+		t.Fatalf("expected inventory load failure")
+	}
+}
+
+func TestMoverBoundsTaskWaitByContextAndTimeout(t *testing.T) {
+	sim := newSimulator(t)
+	sim.relocation = []string{"running"}
+	mover := NewMover(context.Background(), sim.provider(t)).WithTimeout(20 * time.Millisecond)
+	mover.poll = time.Millisecond
+	if err := mover.Move("vm-a", "san-a"); !errors.Is(err, context.DeadlineExceeded) || migration.IsRetriable(err) {
+		t.Fatalf("expected move to stop at its timeout, got %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := NewMover(ctx, sim.provider(t)).Move("vm-a", "san-a"); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected move under a canceled context to stop, got %v", err)
+	}
+}
+
+func TestWaitForTaskStopsWhenContextEnds(t *testing.T) {
+	sim := newSimulator(t)
+	client := sim.dial(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := client.WaitForTask(ctx, mor("Task", "task-2"), time.Hour); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline for running task, got %v", err)
+	}
+	ctx, cancel = context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := client.WaitForTask(ctx, mor("Task", "task-missing"), time.Hour); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline for unknown task, got %v", err)
+	}
+}
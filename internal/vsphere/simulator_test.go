@@ -0,0 +1,992 @@
+// Path: internal/vsphere/simulator_test.go
+// Description: Serve a vcsim-style in-memory vCenter SOAP endpoint for tests.
+package vsphere
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+const simSessionCookie = "vmware_soap_session"
+
+var simNow = time.Date(2026, 2, 16, 12, 0, 0, 0, time.UTC)
+
+var simSupertypes = map[string]string{
+	"VmwareDistributedVirtualSwitch": "DistributedVirtualSwitch",
+	"DistributedVirtualPortgroup":    "Network",
+}
+
+type simObject struct {
+	ref   ManagedObjectReference
+	props map[string]string
+}
+
+type simView struct {
+	container ManagedObjectReference
+	types     []string
+}
+
+type simFilter struct {
+	propSet   []PropertySpec
+	objectSet []ObjectSpec
+	reported  map[ManagedObjectReference]map[string]string
+}
+
+type simUpdate struct {
+	filter string
+	object string
+}
+
+type simulator struct {
+	t        *testing.T
+	server   *httptest.Server
+	mu       sync.Mutex
+	objects  []*simObject
+	events   string
+	username string
+	password string
+	pageSize int
+	calls    map[string]int
+	faults   map[string]int
+	views    map[string]simView
+	pages    map[string][]string
+	requests []string
+	filters  map[string]*simFilter
+	version  int
+	pending  []simUpdate
+	// relocation lists the task states reported by successive polls of the next RelocateVM_Task.
+	relocation []string
+	tasks      map[ManagedObjectReference][]string
+	fields     []CustomFieldDef
+	values     map[ManagedObjectReference]map[int]string
+	// perf holds the latest real-time sample of each host counter instance.
+	perf map[simSample]int64
+}
+
+type simSample struct {
+	entity   ManagedObjectReference
+	counter  int32
+	instance string
+}
+
+func newSimulator(t *testing.T) *simulator {
+	t.Helper()
+	sim := &simulator{
+		t:        t,
+		username: "administrator@vsphere.local",
+		password: "secret",
+		pageSize: 4,
+		calls:    map[string]int{},
+		faults:   map[string]int{},
+		views:    map[string]simView{},
+		pages:    map[string][]string{},
+		filters:  map[string]*simFilter{},
+		tasks:    map[ManagedObjectReference][]string{},
+		values:   map[ManagedObjectReference]map[int]string{},
+		perf:     map[simSample]int64{},
+	}
+	sim.seedInventory()
+	sim.server = httptest.NewTLSServer(http.HandlerFunc(sim.serve))
+	t.Cleanup(sim.server.Close)
+	return sim
+}
+
+func (s *simulator) endpoint() Endpoint {
+	return Endpoint{URL: s.server.URL, Username: s.username, Password: s.password, Insecure: true}
+}
+
+func (s *simulator) dial(t *testing.T) *Client {
+	t.Helper()
+	client, err := Dial(t.Context(), s.endpoint())
+	if err != nil {
+		t.Fatalf("dial simulator: %v", err)
+	}
+	return client
+}
+
+func (s *simulator) provider(t *testing.T) *Provider {
+	t.Helper()
+	provider := NewProvider(s.dial(t))
+	provider.now = func() time.Time { return simNow }
+	return provider
+}
+
+func (s *simulator) failOn(method string, call int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults[method] = s.calls[method] + call
+}
+
+func (s *simulator) object(ref ManagedObjectReference) *simObject {
+	for _, object := range s.objects {
+		if object.ref == ref {
+			return object
+		}
+	}
+	return nil
+}
+
+func (s *simulator) add(objectType string, value string, props map[string]string) {
+	s.objects = append(s.objects, &simObject{
+		ref:   ManagedObjectReference{Type: objectType, Value: value},
+		props: props,
+	})
+}
+
+func (s *simulator) set(ref ManagedObjectReference, path string, value string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.object(ref).props[path] = value
+}
+
+func (s *simulator) unset(ref ManagedObjectReference, path string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.object(ref).props, path)
+}
+
+func (s *simulator) destroy(ref ManagedObjectReference) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for index, object := range s.objects {
+		if object.ref == ref {
+			s.objects = append(s.objects[:index], s.objects[index+1:]...)
+			return
+		}
+	}
+}
+
+func (s *simulator) create(objectType string, value string, props map[string]string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.add(objectType, value, props)
+}
+
+func (s *simulator) serve(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	method, payload := soapMethod(body)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.calls[method]++
+	s.requests = append(s.requests, method)
+	if s.faults[method] == s.calls[method] {
+		writeSimFault(w, "SystemError", "injected "+method+" failure")
+		return
+	}
+	if method != "RetrieveServiceContent" && method != "Login" {
+		if _, err := r.Cookie(simSessionCookie); err != nil {
+			writeSimFault(w, "NotAuthenticated", "session is not authenticated")
+			return
+		}
+	}
+	response, fault := s.dispatch(w, method, payload)
+	if fault != "" {
+		writeSimFault(w, fault, method+" rejected")
+		return
+	}
+	writeSimEnvelope(w, http.StatusOK, response)
+}
+
+func (s *simulator) dispatch(w http.ResponseWriter, method string, payload []byte) (string, string) {
+	switch method {
+	case "RetrieveServiceContent":
+		return simResponse(method, simServiceContent), ""
+	case "Login":
+		return s.login(w, payload)
+	case "Logout", "DestroyView", "DestroyPropertyFilter":
+		return simResponse(method, ""), ""
+	case "CreateContainerView":
+		return s.createContainerView(payload), ""
+	case "RetrievePropertiesEx":
+		return s.retrieveProperties(payload), ""
+	case "ContinueRetrievePropertiesEx":
+		return s.continueRetrieve(payload), ""
+	case "QueryEvents":
+		return simResponse(method, s.events), ""
+	case "CreateFilter":
+		return s.createFilter(payload), ""
+	case "WaitForUpdatesEx":
+		return s.waitForUpdates(), ""
+	case "RelocateVM_Task":
+		return s.relocateVM(payload)
+	case "AddCustomFieldDef":
+		return s.addCustomField(payload)
+	case "SetField":
+		return s.setField(payload)
+	case "CreateFolder":
+		return s.createFolder(payload)
+	case "QueryPerf":
+		return s.queryPerf(payload), ""
+	case "PowerOffVM_Task", "Rename_Task", "MoveIntoFolder_Task", "Destroy_Task":
+		return s.lifecycleTask(method, payload)
+	default:
+		return "", "NotImplemented"
+	}
+}
+
+func (s *simulator) login(w http.ResponseWriter, payload []byte) (string, string) {
+	request := struct {
+		UserName string `xml:"userName"`
+		Password string `xml:"password"`
+	}{}
+	_ = xml.Unmarshal(payload, &request)
+	if request.UserName != s.username || request.Password != s.password {
+		return "", "InvalidLogin"
+	}
+	http.SetCookie(w, &http.Cookie{Name: simSessionCookie, Value: "session-1", Path: "/"})
+	return simResponse("Login", "<returnval><userName>"+request.UserName+"</userName></returnval>"), ""
+}
+
+func (s *simulator) createContainerView(payload []byte) string {
+	request := struct {
+		Container ManagedObjectReference `xml:"container"`
+		Type      []string               `xml:"type"`
+	}{}
+	_ = xml.Unmarshal(payload, &request)
+	view := fmt.Sprintf("session[view-%d]", len(s.views)+1)
+	s.views[view] = simView{container: request.Container, types: request.Type}
+	return simResponse("CreateContainerView", `<returnval type="ContainerView">`+view+`</returnval>`)
+}
+
+func (s *simulator) retrieveProperties(payload []byte) string {
+	request := struct {
+		PropSet   []PropertySpec `xml:"specSet>propSet"`
+		ObjectSet []ObjectSpec   `xml:"specSet>objectSet"`
+	}{}
+	_ = xml.Unmarshal(payload, &request)
+	s.advanceTasks(request.ObjectSet)
+	objects := []string{}
+	for _, object := range s.selectObjects(request.ObjectSet) {
+		if content := s.objectContent(object, request.PropSet); content != "" {
+			objects = append(objects, content)
+		}
+	}
+	return s.page("RetrievePropertiesEx", objects)
+}
+
+func (s *simulator) relocateVM(payload []byte) (string, string) {
+	request := struct {
+		This      ManagedObjectReference `xml:"_this"`
+		Datastore ManagedObjectReference `xml:"spec>datastore"`
+	}{}
+	_ = xml.Unmarshal(payload, &request)
+	vm := s.object(request.This)
+	if vm == nil || s.object(request.Datastore) == nil {
+		return "", "ManagedObjectNotFound"
+	}
+	task := mor("Task", fmt.Sprintf("task-relocate-%d", len(s.tasks)+1))
+	states := s.relocation
+	if len(states) == 0 {
+		states = []string{"success"}
+	}
+	s.relocation = nil
+	s.tasks[task] = states
+	s.add("Task", task.Value, map[string]string{"info": simTaskInfo(task, "queued")})
+	if states[len(states)-1] == "success" {
+		vm.props["datastore"] = valRefs(request.Datastore)
+	}
+	return simResponse("RelocateVM_Task", simRef("returnval", task)), ""
+}
+
+func (s *simulator) addCustomField(payload []byte) (string, string) {
+	request := struct {
+		Name       string `xml:"name"`
+		ObjectType string `xml:"moType"`
+	}{}
+	_ = xml.Unmarshal(payload, &request)
+	for _, def := range s.fields {
+		if def.Name == request.Name {
+			return "", "DuplicateName"
+		}
+	}
+	def := CustomFieldDef{Key: 100 + len(s.fields), Name: request.Name, ManagedObjectType: request.ObjectType}
+	s.fields = append(s.fields, def)
+	s.syncCustomFields()
+	return simResponse("AddCustomFieldDef", fmt.Sprintf(
+		"<returnval><key>%d</key><name>%s</name><managedObjectType>%s</managedObjectType></returnval>",
+		def.Key, def.Name, def.ManagedObjectType,
+	)), ""
+}
+
+func (s *simulator) setField(payload []byte) (string, string) {
+	request := struct {
+		Entity ManagedObjectReference `xml:"entity"`
+		Key    int                    `xml:"key"`
+		Value  string                 `xml:"value"`
+	}{}
+	_ = xml.Unmarshal(payload, &request)
+	if s.object(request.Entity) == nil {
+		return "", "ManagedObjectNotFound"
+	}
+	if s.values[request.Entity] == nil {
+		s.values[request.Entity] = map[int]string{}
+	}
+	s.values[request.Entity][request.Key] = request.Value
+	s.syncCustomFields()
+	return simResponse("SetField", ""), ""
+}
+
+// syncCustomFields render the custom attribute definitions and values into
+// the CustomFieldsManager and VM properties.
+func (s *simulator) syncCustomFields() {
+	defs := ""
+	for _, def := range s.fields {
+		defs += fmt.Sprintf("<CustomFieldDef><key>%d</key><name>%s</name><managedObjectType>%s</managedObjectType></CustomFieldDef>",
+			def.Key, def.Name, def.ManagedObjectType)
+	}
+	s.object(mor("CustomFieldsManager", "CustomFieldsManager")).props["field"] = valRaw("ArrayOfCustomFieldDef", defs)
+	for ref, values := range s.values {
+		items := ""
+		for _, def := range s.fields {
+			if value, ok := values[def.Key]; ok {
+				items += fmt.Sprintf(`<CustomFieldValue xsi:type="CustomFieldStringValue"><key>%d</key><value>%s</value></CustomFieldValue>`,
+					def.Key, html.EscapeString(value))
+			}
+		}
+		if object := s.object(ref); object != nil {
+			object.props["customValue"] = valRaw("ArrayOfCustomFieldValue", items)
+		}
+	}
+}
+
+// lifecycleTask apply a power off, rename, move, or destroy at once and
+// return a task that reports success on its first poll.
+func (s *simulator) queryPerf(payload []byte) string {
+	request := struct {
+		QuerySpec []perfQuerySpec `xml:"querySpec"`
+	}{}
+	_ = xml.Unmarshal(payload, &request)
+	metrics := ""
+	for _, spec := range request.QuerySpec {
+		series := ""
+		for _, metric := range spec.MetricID {
+			for sample, value := range s.perf {
+				if sample.entity == spec.Entity && sample.counter == metric.CounterID {
+					series += fmt.Sprintf(`<value xsi:type="PerfMetricIntSeries"><id><counterId>%d</counterId>`+
+						`<instance>%s</instance></id><value>%d</value></value>`, sample.counter, sample.instance, value)
+				}
+			}
+		}
+		metrics += `<returnval xsi:type="PerfEntityMetric">` + simRef("entity", spec.Entity) + series + `</returnval>`
+	}
+	return simResponse("QueryPerf", metrics)
+}
+
+func (s *simulator) createFolder(payload []byte) (string, string) {
+	request := struct {
+		This ManagedObjectReference `xml:"_this"`
+		Name string                 `xml:"name"`
+	}{}
+	_ = xml.Unmarshal(payload, &request)
+	folder := mor("Folder", fmt.Sprintf("group-created-%d", len(s.objects)))
+	s.add("Folder", folder.Value, map[string]string{
+		"name": valString(request.Name), "parent": valRef(request.This),
+		"childType": valStrings("Folder", "VirtualMachine", "VirtualApp"),
+	})
+	return simResponse("CreateFolder", simRef("returnval", folder)), ""
+}
+
+func (s *simulator) lifecycleTask(method string, payload []byte) (string, string) {
+	request := struct {
+		This    ManagedObjectReference   `xml:"_this"`
+		NewName string                   `xml:"newName"`
+		List    []ManagedObjectReference `xml:"list"`
+	}{}
+	_ = xml.Unmarshal(payload, &request)
+	target := s.object(request.This)
+	if target == nil {
+		return "", "ManagedObjectNotFound"
+	}
+	switch method {
+	case "PowerOffVM_Task":
+		target.props["runtime.powerState"] = valString("poweredOff")
+	case "Rename_Task":
+		target.props["name"] = valString(request.NewName)
+	case "MoveIntoFolder_Task":
+		for _, ref := range request.List {
+			s.object(ref).props["parent"] = valRef(request.This)
+		}
+	case "Destroy_Task":
+		s.objects = slices.DeleteFunc(s.objects, func(object *simObject) bool { return object == target })
+	}
+	task := mor("Task", fmt.Sprintf("task-lifecycle-%d", len(s.tasks)+1))
+	s.tasks[task] = []string{"success"}
+	s.add("Task", task.Value, map[string]string{"info": simTaskInfo(task, "queued")})
+	return simResponse(method, simRef("returnval", task)), ""
+}
+
+func (s *simulator) advanceTasks(objectSet []ObjectSpec) {
+	for _, spec := range objectSet {
+		states := s.tasks[spec.Obj]
+		if len(states) == 0 {
+			continue
+		}
+		s.object(spec.Obj).props["info"] = simTaskInfo(spec.Obj, states[0])
+		s.tasks[spec.Obj] = states[1:]
+	}
+}
+
+// simTaskInfo report a task in state; "error" fails it with InsufficientDiskSpace
+// and "error:<kind>" with another fault kind.
+func simTaskInfo(task ManagedObjectReference, state string) string {
+	failure := ""
+	if state == "error" {
+		state = "error:InsufficientDiskSpace"
+	}
+	if kind, failed := strings.CutPrefix(state, "error:"); failed {
+		message := "task failed"
+		if kind == "InsufficientDiskSpace" {
+			message = "insufficient disk space"
+		}
+		state = "error"
+		failure = `<error><fault xsi:type="` + kind + `"></fault><localizedMessage>` + message + `</localizedMessage></error>`
+	}
+	return valRaw("TaskInfo", "<key>"+task.Value+"</key><descriptionId>VirtualMachine.relocate</descriptionId>"+
+		"<state>"+state+"</state>"+failure)
+}
+
+func (s *simulator) continueRetrieve(payload []byte) string {
+	request := struct {
+		Token string `xml:"token"`
+	}{}
+	_ = xml.Unmarshal(payload, &request)
+	objects := s.pages[request.Token]
+	delete(s.pages, request.Token)
+	return s.page("ContinueRetrievePropertiesEx", objects)
+}
+
+func (s *simulator) page(method string, objects []string) string {
+	token := ""
+	if len(objects) > s.pageSize {
+		token = fmt.Sprintf("token-%d", len(s.requests))
+		s.pages[token] = objects[s.pageSize:]
+		objects = objects[:s.pageSize]
+	}
+	result := strings.Join(objects, "")
+	if token != "" {
+		result = "<token>" + token + "</token>" + result
+	}
+	return simResponse(method, "<returnval>"+result+"</returnval>")
+}
+
+func (s *simulator) selectObjects(objectSet []ObjectSpec) []*simObject {
+	selected := []*simObject{}
+	for _, spec := range objectSet {
+		if view, ok := s.views[spec.Obj.Value]; ok {
+			for _, object := range s.objects {
+				if object.ref != view.container && simMatchesAny(object.ref.Type, view.types) {
+					selected = append(selected, object)
+				}
+			}
+			continue
+		}
+		if object := s.object(spec.Obj); object != nil {
+			selected = append(selected, object)
+		}
+	}
+	return selected
+}
+
+func (s *simulator) objectContent(object *simObject, specs []PropertySpec) string {
+	props := ""
+	paths, values := simSelectProps(object, specs)
+	for _, path := range paths {
+		props += "<propSet><name>" + path + "</name>" + values[path] + "</propSet>"
+	}
+	if props == "" {
+		return ""
+	}
+	return "<objects>" + simRef("obj", object.ref) + props + "</objects>"
+}
+
+func (s *simulator) createFilter(payload []byte) string {
+	request := struct {
+		PropSet   []PropertySpec `xml:"spec>propSet"`
+		ObjectSet []ObjectSpec   `xml:"spec>objectSet"`
+	}{}
+	_ = xml.Unmarshal(payload, &request)
+	name := fmt.Sprintf("session[filter-%d]", len(s.filters)+1)
+	s.filters[name] = &simFilter{
+		propSet:   request.PropSet,
+		objectSet: request.ObjectSet,
+		reported:  map[ManagedObjectReference]map[string]string{},
+	}
+	return simResponse("CreateFilter", `<returnval type="PropertyFilter">`+name+`</returnval>`)
+}
+
+func (s *simulator) waitForUpdates() string {
+	if len(s.pending) == 0 {
+		s.pending = s.collectUpdates()
+	}
+	if len(s.pending) == 0 {
+		return simResponse("WaitForUpdatesEx", "")
+	}
+	batch := s.pending
+	truncated := len(batch) > s.pageSize
+	if truncated {
+		batch = batch[:s.pageSize]
+	}
+	s.pending = s.pending[len(batch):]
+	s.version++
+	result := fmt.Sprintf("<version>%d</version>", s.version)
+	for _, update := range batch {
+		result += "<filterSet>" + simRef("filter", mor("PropertyFilter", update.filter)) + update.object + "</filterSet>"
+	}
+	result += fmt.Sprintf("<truncated>%t</truncated>", truncated)
+	return simResponse("WaitForUpdatesEx", "<returnval>"+result+"</returnval>")
+}
+
+func (s *simulator) collectUpdates() []simUpdate {
+	names := make([]string, 0, len(s.filters))
+	for name := range s.filters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	updates := []simUpdate{}
+	for _, name := range names {
+		filter := s.filters[name]
+		current := map[ManagedObjectReference]bool{}
+		for _, object := range s.selectObjects(filter.objectSet) {
+			current[object.ref] = true
+			if update := filter.diff(object); update != "" {
+				updates = append(updates, simUpdate{filter: name, object: update})
+			}
+		}
+		for _, ref := range simSortedRefs(filter.reported) {
+			if !current[ref] {
+				delete(filter.reported, ref)
+				updates = append(updates, simUpdate{
+					filter: name,
+					object: "<objectSet><kind>leave</kind>" + simRef("obj", ref) + "</objectSet>",
+				})
+			}
+		}
+	}
+	return updates
+}
+
+func (f *simFilter) diff(object *simObject) string {
+	paths, values := simSelectProps(object, f.propSet)
+	previous, known := f.reported[object.ref]
+	changes := ""
+	for _, path := range paths {
+		if value, ok := previous[path]; !ok || value != values[path] {
+			changes += "<changeSet><name>" + path + "</name><op>assign</op>" + values[path] + "</changeSet>"
+		}
+	}
+	for _, path := range simSortedKeys(previous) {
+		if _, ok := values[path]; !ok {
+			changes += "<changeSet><name>" + path + "</name><op>remove</op></changeSet>"
+		}
+	}
+	f.reported[object.ref] = values
+	kind := "modify"
+	if !known {
+		kind = "enter"
+	}
+	if known && changes == "" {
+		return ""
+	}
+	return "<objectSet><kind>" + kind + "</kind>" + simRef("obj", object.ref) + changes + "</objectSet>"
+}
+
+func simSelectProps(object *simObject, specs []PropertySpec) ([]string, map[string]string) {
+	paths := []string{}
+	values := map[string]string{}
+	for _, spec := range specs {
+		if !simMatches(object.ref.Type, spec.Type) {
+			continue
+		}
+		for _, path := range spec.PathSet {
+			if value, ok := object.props[path]; ok {
+				paths = append(paths, path)
+				values[path] = value
+			}
+		}
+	}
+	return paths, values
+}
+
+func simSortedRefs(refs map[ManagedObjectReference]map[string]string) []ManagedObjectReference {
+	sorted := make([]ManagedObjectReference, 0, len(refs))
+	for ref := range refs {
+		sorted = append(sorted, ref)
+	}
+	sort.Slice(sorted, func(i int, j int) bool { return sorted[i].String() < sorted[j].String() })
+	return sorted
+}
+
+func simSortedKeys(values map[string]string) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func simMatchesAny(objectType string, types []string) bool {
+	for _, candidate := range types {
+		if simMatches(objectType, candidate) {
+			return true
+		}
+	}
+	return false
+}
+
+func simMatches(objectType string, specType string) bool {
+	return objectType == specType || simSupertypes[objectType] == specType
+}
+
+func soapMethod(body []byte) (string, []byte) {
+	envelope := struct {
+		Body struct {
+			Inner []byte `xml:",innerxml"`
+		} `xml:"Body"`
+	}{}
+	_ = xml.Unmarshal(body, &envelope)
+	decoder := xml.NewDecoder(bytes.NewReader(envelope.Body.Inner))
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return "", nil
+		}
+		if start, ok := token.(xml.StartElement); ok {
+			return start.Name.Local, envelope.Body.Inner
+		}
+	}
+}
+
+func simResponse(method string, inner string) string {
+	return "<" + method + `Response xmlns="urn:vim25">` + inner + "</" + method + "Response>"
+}
+
+func writeSimEnvelope(w http.ResponseWriter, status int, body string) {
+	w.Header().Set("Content-Type", "text/xml")
+	w.WriteHeader(status)
+	_, _ = io.WriteString(w, envelopeHeader+body+envelopeFooter)
+}
+
+func writeSimFault(w http.ResponseWriter, kind string, message string) {
+	writeSimEnvelope(w, http.StatusInternalServerError, `<soapenv:Fault><faultcode>ServerFaultCode</faultcode>`+
+		"<faultstring>"+html.EscapeString(message)+"</faultstring>"+
+		`<detail><`+kind+`Fault xmlns="urn:vim25" xsi:type="`+kind+`"></`+kind+`Fault></detail>`+
+		`</soapenv:Fault>`)
+}
+
+func simRef(element string, ref ManagedObjectReference) string {
+	return "<" + element + ` type="` + ref.Type + `" xsi:type="ManagedObjectReference">` +
+		ref.Value + "</" + element + ">"
+}
+
+func mor(objectType string, value string) ManagedObjectReference {
+	return ManagedObjectReference{Type: objectType, Value: value}
+}
+
+func valString(value string) string {
+	return `<val xsi:type="xsd:string">` + html.EscapeString(value) + `</val>`
+}
+
+func valInt(value int64) string {
+	return fmt.Sprintf(`<val xsi:type="xsd:long">%d</val>`, value)
+}
+
+func valBool(value bool) string {
+	return fmt.Sprintf(`<val xsi:type="xsd:boolean">%t</val>`, value)
+}
+
+func valRef(ref ManagedObjectReference) string {
+	return simRef("val", ref)
+}
+
+func valRefs(refs ...ManagedObjectReference) string {
+	items := ""
+	for _, ref := range refs {
+		items += simRef("ManagedObjectReference", ref)
+	}
+	return `<val xsi:type="ArrayOfManagedObjectReference">` + items + `</val>`
+}
+
+func valStrings(values ...string) string {
+	items := ""
+	for _, value := range values {
+		items += "<string>" + value + "</string>"
+	}
+	return `<val xsi:type="ArrayOfString">` + items + `</val>`
+}
+
+func simCounter(key int32, group string, name string, rollup string) string {
+	return fmt.Sprintf(`<PerfCounterInfo><key>%d</key><nameInfo><key>%s</key></nameInfo>`+
+		`<groupInfo><key>%s</key></groupInfo><rollupType>%s</rollupType></PerfCounterInfo>`, key, name, group, rollup)
+}
+
+func valRaw(xsiType string, inner string) string {
+	return `<val xsi:type="` + xsiType + `">` + inner + `</val>`
+}
+
+const simServiceContent = `<returnval>` +
+	`<rootFolder type="Folder">group-d1</rootFolder>` +
+	`<propertyCollector type="PropertyCollector">propertyCollector</propertyCollector>` +
+	`<viewManager type="ViewManager">ViewManager</viewManager>` +
+	`<about><fullName>VMware vCenter Server 7.0.3 (simulator)</fullName>` +
+	`<apiVersion>7.0.3.0</apiVersion><instanceUuid>sim-uuid</instanceUuid></about>` +
+	`<sessionManager type="SessionManager">SessionManager</sessionManager>` +
+	`<taskManager type="TaskManager">TaskManager</taskManager>` +
+	`<eventManager type="EventManager">EventManager</eventManager>` +
+	`<customFieldsManager type="CustomFieldsManager">CustomFieldsManager</customFieldsManager>` +
+	`<perfManager type="PerformanceManager">PerfMgr</perfManager>` +
+	`</returnval>`
+
+const gib = int64(1024 * 1024 * 1024)
+
+func (s *simulator) seedInventory() {
+	root := mor("Folder", "group-d1")
+	dc := mor("Datacenter", "datacenter-1")
+	vmFolder := mor("Folder", "group-v1")
+	templateFolder := mor("Folder", "group-v2")
+	hostFolder := mor("Folder", "group-h1")
+	cluster := mor("ClusterComputeResource", "domain-c1")
+	host1 := mor("HostSystem", "host-1")
+	host2 := mor("HostSystem", "host-2")
+	ds1 := mor("Datastore", "datastore-1")
+	ds2 := mor("Datastore", "datastore-2")
+	network := mor("Network", "network-1")
+	portgroup := mor("DistributedVirtualPortgroup", "dvportgroup-1")
+	dvs := mor("VmwareDistributedVirtualSwitch", "dvs-1")
+	rootPool := mor("ResourcePool", "resgroup-1")
+	vm1 := mor("VirtualMachine", "vm-1")
+	vm2 := mor("VirtualMachine", "vm-2")
+
+	s.add("Folder", "group-d1", map[string]string{
+		"name":        valString("Datacenters"),
+		"childType":   valStrings("Folder", "Datacenter"),
+		"childEntity": valRefs(dc),
+		"triggeredAlarmState": valRaw("ArrayOfAlarmState",
+			`<AlarmState><key>alarm-1.vm-1</key>`+simRef("entity", vm1)+simRef("alarm", mor("Alarm", "alarm-1"))+
+				`<overallStatus>red</overallStatus><time>2026-02-16T08:05:00.123Z</time></AlarmState>`+
+				`<AlarmState><key>alarm-2.host-1</key>`+simRef("entity", host1)+simRef("alarm", mor("Alarm", "alarm-2"))+
+				`<overallStatus>yellow</overallStatus><time>2026-02-16T08:12:00Z</time>`+
+				`<acknowledgedByUser>ops@example.com</acknowledgedByUser></AlarmState>`+
+				`<AlarmState><key>alarm-1.host-2</key>`+simRef("entity", host2)+simRef("alarm", mor("Alarm", "alarm-1"))+
+				`<overallStatus>yellow</overallStatus><time>2026-02-16T08:13:00Z</time></AlarmState>`),
+	})
+	s.add("TaskManager", "TaskManager", map[string]string{
+		"recentTask": valRefs(mor("Task", "task-1"), mor("Task", "task-2"), mor("Task", "task-3")),
+	})
+	s.add("CustomFieldsManager", "CustomFieldsManager", map[string]string{})
+	s.add("PerformanceManager", "PerfMgr", map[string]string{
+		"perfCounter": valRaw("ArrayOfPerfCounterInfo", simCounter(2, "cpu", "usage", "average")+
+			simCounter(171, "datastore", "totalReadLatency", "average")+
+			simCounter(172, "datastore", "totalWriteLatency", "average")+
+			simCounter(173, "datastore", "totalReadLatency", "maximum")),
+	})
+	s.perf[simSample{host1, 171, "vsan:52e1"}] = 4
+	s.perf[simSample{host1, 172, "vsan:52e1"}] = 9
+	s.perf[simSample{host1, 171, ""}] = 30
+	s.perf[simSample{host1, 173, "5f1c-san-a"}] = 40
+	s.perf[simSample{host2, 171, "5f1c-san-a"}] = 12
+	s.perf[simSample{host1, 172, "5f1c-san-a"}] = 3
+	s.fields = []CustomFieldDef{{Key: 100, Name: "Owner"}, {Key: 101, Name: "pd_pending_since", ManagedObjectType: "VirtualMachine"}}
+	s.values[vm2] = map[int]string{100: "ops@example.com", 101: "2026-02-01"}
+	s.add("Datacenter", "datacenter-1", map[string]string{
+		"name": valString("dc-1"), "parent": valRef(root),
+	})
+	s.add("Folder", "group-v1", map[string]string{
+		"name": valString("vm"), "parent": valRef(dc),
+		"childType":   valStrings("Folder", "VirtualMachine", "VirtualApp"),
+		"childEntity": valRefs(templateFolder, vm1, vm2, mor("VirtualMachine", "vm-4")),
+	})
+	s.add("Folder", "group-v2", map[string]string{
+		"name": valString("Templates"), "parent": valRef(vmFolder),
+		"childType":   valStrings("Folder", "VirtualMachine"),
+		"childEntity": valRefs(mor("VirtualMachine", "vm-3"), mor("VirtualMachine", "vm-5")),
+	})
+	s.add("Folder", "group-h1", map[string]string{
+		"name": valString("host"), "parent": valRef(dc),
+		"childType": valStrings("Folder", "ComputeResource"), "childEntity": valRefs(cluster),
+	})
+	s.add("Folder", "group-s1", map[string]string{
+		"name": valString("datastore"), "parent": valRef(dc),
+		"childType": valStrings("Folder", "Datastore", "StoragePod"), "childEntity": valRefs(ds1, ds2),
+	})
+	s.add("Folder", "group-n1", map[string]string{
+		"name": valString("network"), "parent": valRef(dc),
+		"childType": valStrings("Folder", "Network", "DistributedVirtualSwitch"),
+	})
+	s.add("Folder", "group-x1", map[string]string{
+		"name": valString("archive"), "parent": valRef(vmFolder), "childType": valStrings("Folder"),
+	})
+	s.add("ClusterComputeResource", "domain-c1", map[string]string{
+		"name": valString("cluster-east"), "parent": valRef(hostFolder),
+		"host": valRefs(host1, host2), "network": valRefs(network, portgroup),
+	})
+	hostProps := func(name string, maintenance bool, cpuUsage int64, memUsage int64, vms ...ManagedObjectReference) map[string]string {
+		return map[string]string{
+			"name": valString(name), "parent": valRef(cluster),
+			"runtime.connectionState":               valString("connected"),
+			"runtime.inMaintenanceMode":             valBool(maintenance),
+			"summary.hardware.cpuMhz":               valInt(2000),
+			"summary.hardware.numCpuCores":          valInt(10),
+			"summary.hardware.numCpuThreads":        valInt(20),
+			"summary.hardware.memorySize":           valInt(64 * gib),
+			"summary.quickStats.overallCpuUsage":    valInt(cpuUsage),
+			"summary.quickStats.overallMemoryUsage": valInt(memUsage),
+			"vm":                                    valRefs(vms...),
+		}
+	}
+	s.add("HostSystem", "host-1", hostProps("esxi-01", false, 5000, 32768, vm1))
+	s.add("HostSystem", "host-2", hostProps("esxi-02", true, 15000, 16384, vm2))
+	s.add("Datastore", "datastore-1", map[string]string{
+		"name": valString("vsan-east"), "parent": valRef(mor("Folder", "group-s1")),
+		"summary.capacity": valInt(100 * gib), "summary.freeSpace": valInt(40 * gib),
+		"summary.uncommitted": valInt(80 * gib), "summary.type": valString("vsan"),
+		"summary.url":                valString("ds:///vmfs/volumes/vsan:52e1/"),
+		"summary.multipleHostAccess": valBool(true),
+		"host": valRaw("ArrayOfDatastoreHostMount",
+			`<DatastoreHostMount>`+simRef("key", mor("HostSystem", "host-9"))+`</DatastoreHostMount>`+
+				`<DatastoreHostMount>`+simRef("key", host1)+`<mountInfo><accessible>true</accessible></mountInfo></DatastoreHostMount>`+
+				`<DatastoreHostMount>`+simRef("key", mor("HostSystem", "host-2"))+`<mountInfo><accessible>false</accessible></mountInfo></DatastoreHostMount>`),
+	})
+	s.add("Datastore", "datastore-2", map[string]string{
+		"name": valString("san-a"), "parent": valRef(mor("Folder", "group-s1")),
+		"summary.capacity": valInt(200 * gib), "summary.freeSpace": valInt(100 * gib),
+		"summary.type": valString("VMFS"), "summary.multipleHostAccess": valBool(false),
+		"summary.url": valString("ds:///vmfs/volumes/5f1c-san-a/"),
+		"info": valRaw("VmfsDatastoreInfo", `<name>san-a</name><vmfs><name>san-a</name>`+
+			`<extent><diskName>naa.600a0980</diskName><partition>1</partition></extent>`+
+			`<extent><diskName>naa.600a0981</diskName><partition>1</partition></extent></vmfs>`),
+	})
+	s.add("Network", "network-1", map[string]string{
+		"name": valString("VM Network"), "parent": valRef(mor("Folder", "group-n1")), "vm": valRefs(vm1),
+	})
+	s.add("DistributedVirtualPortgroup", "dvportgroup-1", map[string]string{
+		"name": valString("dvpg-prod-100"), "parent": valRef(mor("Folder", "group-n1")), "vm": valRefs(vm1),
+		"config.distributedVirtualSwitch": valRef(dvs),
+		"config.defaultPortConfig": valRaw("VMwareDVSPortSetting",
+			`<vlan xsi:type="VmwareDistributedVirtualSwitchVlanIdSpec"><inherited>false</inherited><vlanId>100</vlanId></vlan>`),
+	})
+	s.add("DistributedVirtualPortgroup", "dvportgroup-2", map[string]string{
+		"name": valString("dvpg-trunk"), "parent": valRef(mor("Folder", "group-n1")),
+		"config.defaultPortConfig": valRaw("VMwareDVSPortSetting",
+			`<vlan xsi:type="VmwareDistributedVirtualSwitchTrunkVlanSpec"><inherited>false</inherited>`+
+				`<vlanId><start>0</start><end>4094</end></vlanId></vlan>`),
+	})
+	s.add("VmwareDistributedVirtualSwitch", "dvs-1", map[string]string{
+		"name": valString("dvs-core-a"), "parent": valRef(mor("Folder", "group-n1")),
+		"config.maxMtu": valInt(9000),
+		"config.uplinkPortPolicy": valRaw("DVSNameArrayUplinkPortPolicy",
+			`<inherited>false</inherited><uplinkPortName>uplink1</uplinkPortName><uplinkPortName>uplink2</uplinkPortName>`),
+	})
+	s.add("ResourcePool", "resgroup-1", map[string]string{
+		"name": valString("Resources"), "parent": valRef(cluster), "owner": valRef(cluster),
+	})
+	s.add("ResourcePool", "resgroup-2", map[string]string{
+		"name": valString("rp-prod"), "parent": valRef(rootPool), "owner": valRef(cluster), "vm": valRefs(vm1),
+		"config.cpuAllocation.reservation":    valInt(6400),
+		"config.cpuAllocation.limit":          valInt(-1),
+		"config.memoryAllocation.reservation": valInt(8192),
+		"config.memoryAllocation.limit":       valInt(16384),
+	})
+	s.add("VirtualMachine", "vm-1", map[string]string{
+		"name": valString("vm-a"), "parent": valRef(vmFolder),
+		"config.template":          valBool(false),
+		"config.annotation":        valString("web & api tier"),
+		"config.hardware.numCPU":   valInt(4),
+		"config.hardware.memoryMB": valInt(8192),
+		"config.hardware.device": valRaw("ArrayOfVirtualDevice",
+			`<VirtualDevice xsi:type="VirtualE1000"><key>4000</key></VirtualDevice>`+
+				`<VirtualDevice xsi:type="VirtualDisk"><key>2000</key><capacityInKB>41943040</capacityInKB></VirtualDevice>`+
+				`<VirtualDevice xsi:type="VirtualDisk"><key>2001</key><capacityInKB>10485760</capacityInKB>`+
+				`<backing xsi:type="VirtualDiskFlatVer2BackingInfo"><diskMode>independent_persistent</diskMode></backing></VirtualDevice>`+
+				`<VirtualDevice xsi:type="VirtualDisk"><key>2002</key><capacityInKB>1048576</capacityInKB>`+
+				`<backing xsi:type="VirtualDiskRawDiskMappingVer1BackingInfo"><diskMode>independent_persistent</diskMode></backing></VirtualDevice>`+
+				`<VirtualDevice xsi:type="VirtualCdrom"><key>3000</key>`+
+				`<backing xsi:type="VirtualCdromIsoBackingInfo"><fileName>[san-a] iso/rhel9.iso</fileName></backing></VirtualDevice>`),
+		"runtime.powerState":                  valString("poweredOn"),
+		"runtime.host":                        valRef(host1),
+		"runtime.maxCpuUsage":                 valInt(8000),
+		"datastore":                           valRefs(ds1, ds2),
+		"network":                             valRefs(network, portgroup),
+		"guest.ipAddress":                     valString("10.10.1.21"),
+		"guest.hostName":                      valString("vm-a.prod.local"),
+		"summary.quickStats.overallCpuUsage":  valInt(4000),
+		"summary.quickStats.guestMemoryUsage": valInt(2048),
+		"summary.storage.committed":           valInt(20 * gib),
+		"summary.storage.uncommitted":         valInt(30 * gib),
+		"config.memoryAllocation.reservation": valInt(4000),
+		"snapshot": valRaw("VirtualMachineSnapshotInfo",
+			`<currentSnapshot type="VirtualMachineSnapshot">snapshot-2</currentSnapshot>`+
+				`<rootSnapshotList><snapshot type="VirtualMachineSnapshot">snapshot-1</snapshot>`+
+				`<name>pre-patch</name><createTime>2026-02-10T12:00:00Z</createTime><quiesced>false</quiesced>`+
+				`<childSnapshotList><snapshot type="VirtualMachineSnapshot">snapshot-2</snapshot>`+
+				`<name>post-patch</name><createTime>2026-02-12T12:00:00Z</createTime><quiesced>true</quiesced>`+
+				`</childSnapshotList></rootSnapshotList>`),
+		"layoutEx.file": valRaw("ArrayOfVirtualMachineFileLayoutExFileInfo",
+			`<VirtualMachineFileLayoutExFileInfo><key>1</key><name>[vsan-east] vm-a/vm-a-Snapshot1.vmsn</name>`+
+				`<type>snapshotData</type><size>1073741824</size></VirtualMachineFileLayoutExFileInfo>`+
+				`<VirtualMachineFileLayoutExFileInfo><key>2</key><name>[vsan-east] vm-a/vm-a-000001-delta.vmdk</name>`+
+				`<type>diskExtent</type><size>2147483648</size></VirtualMachineFileLayoutExFileInfo>`+
+				`<VirtualMachineFileLayoutExFileInfo><key>3</key><name>[vsan-east] vm-a/vm-a-flat.vmdk</name>`+
+				`<type>diskExtent</type><size>42949672960</size></VirtualMachineFileLayoutExFileInfo>`),
+	})
+	s.add("VirtualMachine", "vm-2", map[string]string{
+		"name": valString("vm-b"), "parent": valRef(vmFolder),
+		"config.template":    valBool(false),
+		"runtime.powerState": valString("poweredOff"),
+		"runtime.host":       valRef(host2),
+		"disabledMethod":     valRaw("ArrayOfString", `<string>Destroy_Task</string><string>RelocateVM_Task</string>`),
+	})
+	s.add("VirtualMachine", "vm-4", map[string]string{
+		"name": valString("vm-c"), "parent": valRef(vmFolder),
+		"config.template":    valBool(false),
+		"runtime.powerState": valString("suspended"),
+	})
+	s.add("VirtualMachine", "vm-3", map[string]string{
+		"name": valString("tpl-rhel9"), "parent": valRef(templateFolder),
+		"config.template":          valBool(true),
+		"config.guestId":           valString("rhel9_64Guest"),
+		"config.createDate":        valString("2026-01-02T12:00:00Z"),
+		"config.hardware.numCPU":   valInt(2),
+		"config.hardware.memoryMB": valInt(4096),
+		"datastore":                valRefs(ds1),
+	})
+	s.add("VirtualMachine", "vm-5", map[string]string{
+		"name": valString("tpl-empty"), "parent": valRef(templateFolder), "config.template": valBool(true),
+	})
+	s.add("Alarm", "alarm-1", map[string]string{"info.name": valString("CPU usage high")})
+	s.add("Alarm", "alarm-2", map[string]string{"info.name": valString("Host connection lost")})
+	s.add("Task", "task-1", map[string]string{"info": valRaw("TaskInfo",
+		`<key>task-1</key><entityName>vm-a</entityName><descriptionId>VirtualMachine.powerOff</descriptionId>`+
+			`<state>success</state><reason xsi:type="TaskReasonUser"><userName>ops@example.com</userName></reason>`+
+			`<startTime>2026-02-16T08:10:00Z</startTime><completeTime>2026-02-16T08:10:24Z</completeTime>`)})
+	s.add("Task", "task-2", map[string]string{"info": valRaw("TaskInfo",
+		`<key>task-2</key><entityName>vm-b</entityName><descriptionId>VirtualMachine.clone</descriptionId>`+
+			`<state>running</state><startTime>2026-02-16T11:57:46Z</startTime>`)})
+	s.add("Task", "task-3", map[string]string{"info": valRaw("TaskInfo",
+		`<key>task-3</key><entityName>esxi-01</entityName><descriptionId>HostSystem.reconnect</descriptionId>`+
+			`<state>queued</state><startTime>pending</startTime>`)})
+	s.events = `<returnval xsi:type="VmPoweredOnEvent"><key>101</key><createdTime>2026-02-16T08:04:00Z</createdTime>` +
+		`<userName>ops@example.com</userName><vm><name>vm-a</name>` + simRef("vm", vm1) + `</vm>` +
+		`<fullFormattedMessage>vm-a on esxi-01 is powered on</fullFormattedMessage></returnval>` +
+		`<returnval xsi:type="HostDisconnectedEvent"><key>102</key><createdTime>2026-02-16T08:09:00Z</createdTime>` +
+		`<host><name>esxi-02</name></host><fullFormattedMessage>Host esxi-02 disconnected</fullFormattedMessage></returnval>` +
+		`<returnval xsi:type="VmFailedToPowerOnEvent"><key>103</key><createdTime>2026-02-16T08:10:00Z</createdTime>` +
+		`<vm><name>vm-b</name></vm><fullFormattedMessage>Cannot power on vm-b</fullFormattedMessage></returnval>` +
+		`<returnval xsi:type="EventEx"><key>104</key><createdTime>2026-02-16T08:11:00Z</createdTime>` +
+		`<ds><name>san-a</name></ds><fullFormattedMessage>Datastore usage high</fullFormattedMessage>` +
+		`<severity>WARNING</severity></returnval>` +
+		`<returnval xsi:type="GeneralUserEvent"><key>105</key><createdTime>2026-02-16T08:12:00Z</createdTime>` +
+		`<fullFormattedMessage>maintenance note</fullFormattedMessage></returnval>`
+	s.syncCustomFields()
+}
@@ -0,0 +1,147 @@
+// Path: internal/vsphere/types.go
+// Description: Decode structured vim25 data objects used by inventory mapping.
+package vsphere
+
+import (
+	"context"
+	"encoding/xml"
+	"time"
+)
+
+// EntityName stores the name of an event-referenced entity.
+type EntityName struct {
+	Name string `xml:"name"`
+}
+
+// Event stores the common fields of a vim25 event.
+type Event struct {
+	Attrs                []xml.Attr  `xml:",any,attr"`
+	Key                  int         `xml:"key"`
+	CreatedTime          string      `xml:"createdTime"`
+	UserName             string      `xml:"userName"`
+	Datacenter           *EntityName `xml:"datacenter"`
+	ComputeResource      *EntityName `xml:"computeResource"`
+	Host                 *EntityName `xml:"host"`
+	VM                   *EntityName `xml:"vm"`
+	Datastore            *EntityName `xml:"ds"`
+	FullFormattedMessage string      `xml:"fullFormattedMessage"`
+	Severity             string      `xml:"severity"`
+}
+
+// Kind return the concrete vim25 event type.
+func (e Event) Kind() string {
+	return xsiType(e.Attrs)
+}
+
+// EntityName return the most specific entity referenced by the event.
+func (e Event) EntityName() string {
+	for _, entity := range []*EntityName{e.VM, e.Host, e.Datastore, e.ComputeResource, e.Datacenter} {
+		if entity != nil && entity.Name != "" {
+			return entity.Name
+		}
+	}
+	return ""
+}
+
+// QueryEvents list events created after the given time.
+func (c *Client) QueryEvents(ctx context.Context, since time.Time) ([]Event, error) {
+	request := struct {
+		XMLName xml.Name               `xml:"urn:vim25 QueryEvents"`
+		This    ManagedObjectReference `xml:"_this"`
+		Filter  struct {
+			Time struct {
+				BeginTime string `xml:"beginTime"`
+			} `xml:"time"`
+		} `xml:"filter"`
+	}{This: c.content.EventManager}
+	request.Filter.Time.BeginTime = since.UTC().Format(time.RFC3339)
+	response := struct {
+		Returnval []Event `xml:"returnval"`
+	}{}
+	err := c.call(ctx, request, &response)
+	return response.Returnval, err
+}
+
+// TaskInfo stores the fields of a vim25 task used in the task stream.
+type TaskInfo struct {
+	EntityName    string `xml:"entityName"`
+	DescriptionID string `xml:"descriptionId"`
+	State         string `xml:"state"`
+	StartTime     string `xml:"startTime"`
+	CompleteTime  string `xml:"completeTime"`
+	Reason        struct {
+		UserName string `xml:"userName"`
+	} `xml:"reason"`
+	Error struct {
+		Fault struct {
+			Attrs []xml.Attr `xml:",any,attr"`
+		} `xml:"fault"`
+		LocalizedMessage string `xml:"localizedMessage"`
+	} `xml:"error"`
+}
+
+// AlarmState stores one triggered alarm on an entity.
+type AlarmState struct {
+	Entity             ManagedObjectReference `xml:"entity"`
+	Alarm              ManagedObjectReference `xml:"alarm"`
+	OverallStatus      string                 `xml:"overallStatus"`
+	Time               string                 `xml:"time"`
+	AcknowledgedByUser string                 `xml:"acknowledgedByUser"`
+}
+
+// SnapshotTree stores one node of a VM snapshot hierarchy.
+type SnapshotTree struct {
+	Snapshot   ManagedObjectReference `xml:"snapshot"`
+	Name       string                 `xml:"name"`
+	CreateTime string                 `xml:"createTime"`
+	Quiesced   bool                   `xml:"quiesced"`
+	Children   []SnapshotTree         `xml:"childSnapshotList"`
+}
+
+// HostMount stores one host mount of a datastore.
+type HostMount struct {
+	Key        ManagedObjectReference `xml:"key"`
+	Accessible string                 `xml:"mountInfo>accessible"`
+}
+
+// VirtualDevice stores the fields of a VM device used for disk sizing and
+// the pre-flight checks on its backing.
+type VirtualDevice struct {
+	Attrs        []xml.Attr    `xml:",any,attr"`
+	CapacityInKB int64         `xml:"capacityInKB"`
+	Backing      DeviceBacking `xml:"backing"`
+}
+
+// DeviceBacking stores the file, disk mode, and type of a device backing.
+type DeviceBacking struct {
+	Attrs    []xml.Attr `xml:",any,attr"`
+	FileName string     `xml:"fileName"`
+	DiskMode string     `xml:"diskMode"`
+}
+
+// FileLayout stores one file of a VM layout.
+type FileLayout struct {
+	Name string `xml:"name"`
+	Type string `xml:"type"`
+	Size int64  `xml:"size"`
+}
+
+// VmfsExtent stores one backing disk partition of a VMFS datastore.
+type VmfsExtent struct {
+	DiskName  string `xml:"diskName"`
+	Partition int    `xml:"partition"`
+}
+
+// CustomFieldDef stores one custom attribute definition. An empty
+// ManagedObjectType applies the attribute to every managed object type.
+type CustomFieldDef struct {
+	Key               int    `xml:"key"`
+	Name              string `xml:"name"`
+	ManagedObjectType string `xml:"managedObjectType"`
+}
+
+// CustomFieldValue stores one custom attribute value set on an entity.
+type CustomFieldValue struct {
+	Key   int    `xml:"key"`
+	Value string `xml:"value"`
+}
@@ -0,0 +1,271 @@
+// Path: internal/vsphere/watch.go
+// Description: Follow PropertyCollector updates and translate them into incremental catalog changes.
+package vsphere
+
+import (
+	"context"
+	"encoding/xml"
+	"maps"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/takelley1/hypersphere/internal/tui"
+)
+
+const maxWatchWait = requestTimeout - 10*time.Second
+
+// PropertyChange stores one changed property path of an updated object.
+type PropertyChange struct {
+	Name string `xml:"name"`
+	Op   string `xml:"op"`
+	Val  Value  `xml:"val"`
+}
+
+// ObjectUpdate stores the property changes of one object that entered, changed, or left a filter.
+type ObjectUpdate struct {
+	Kind      string                 `xml:"kind"`
+	Obj       ManagedObjectReference `xml:"obj"`
+	ChangeSet []PropertyChange       `xml:"changeSet"`
+}
+
+// PropertyFilterUpdate groups object updates reported by one property filter.
+type PropertyFilterUpdate struct {
+	Filter    ManagedObjectReference `xml:"filter"`
+	ObjectSet []ObjectUpdate         `xml:"objectSet"`
+}
+
+// UpdateSet stores one WaitForUpdatesEx result.
+type UpdateSet struct {
+	Version   string                 `xml:"version"`
+	FilterSet []PropertyFilterUpdate `xml:"filterSet"`
+	Truncated bool                   `xml:"truncated"`
+}
+
+// Objects return every object update across filters.
+func (u UpdateSet) Objects() []ObjectUpdate {
+	objects := []ObjectUpdate{}
+	for _, filter := range u.FilterSet {
+		objects = append(objects, filter.ObjectSet...)
+	}
+	return objects
+}
+
+// CreateFilter register a property filter on the session PropertyCollector.
+func (c *Client) CreateFilter(ctx context.Context, spec PropertyFilterSpec) (ManagedObjectReference, error) {
+	request := struct {
+		XMLName        xml.Name               `xml:"urn:vim25 CreateFilter"`
+		This           ManagedObjectReference `xml:"_this"`
+		Spec           PropertyFilterSpec     `xml:"spec"`
+		PartialUpdates bool                   `xml:"partialUpdates"`
+	}{This: c.content.PropertyCollector, Spec: spec}
+	response := struct {
+		Returnval ManagedObjectReference `xml:"returnval"`
+	}{}
+	err := c.call(ctx, request, &response)
+	return response.Returnval, err
+}
+
+// DestroyPropertyFilter release a filter created by CreateFilter.
+func (c *Client) DestroyPropertyFilter(ctx context.Context, filter ManagedObjectReference) error {
+	request := struct {
+		XMLName xml.Name               `xml:"urn:vim25 DestroyPropertyFilter"`
+		This    ManagedObjectReference `xml:"_this"`
+	}{This: filter}
+	return c.call(ctx, request, nil)
+}
+
+// WaitForUpdates wait up to maxWait for changes after version and return every pending update.
+func (c *Client) WaitForUpdates(ctx context.Context, version string, maxWait time.Duration) (UpdateSet, error) {
+	seconds := int(math.Ceil(min(max(maxWait, 0), maxWatchWait).Seconds()))
+	merged := UpdateSet{Version: version}
+	for {
+		request := struct {
+			XMLName xml.Name               `xml:"urn:vim25 WaitForUpdatesEx"`
+			This    ManagedObjectReference `xml:"_this"`
+			Version string                 `xml:"version,omitempty"`
+			Options struct {
+				MaxWaitSeconds int `xml:"maxWaitSeconds"`
+			} `xml:"options"`
+		}{This: c.content.PropertyCollector, Version: merged.Version}
+		request.Options.MaxWaitSeconds = seconds
+		response := struct {
+			Returnval *UpdateSet `xml:"returnval"`
+		}{}
+		if err := c.call(ctx, request, &response); err != nil {
+			return UpdateSet{}, err
+		}
+		if response.Returnval == nil {
+			return merged, nil
+		}
+		merged.Version = response.Returnval.Version
+		merged.FilterSet = append(merged.FilterSet, response.Returnval.FilterSet...)
+		if !response.Returnval.Truncated {
+			return merged, nil
+		}
+	}
+}
+
+type watchState struct {
+	view    ManagedObjectReference
+	filters []ManagedObjectReference
+	version string
+	catalog tui.Catalog
+}
+
+// WatchChanges wait up to maxWait for inventory updates and return the catalog changes they cause.
+func (p *Provider) WatchChanges(ctx context.Context, maxWait time.Duration) ([]tui.CatalogChange, error) {
+	p.watchMu.Lock()
+	defer p.watchMu.Unlock()
+	if p.watch == nil {
+		if err := p.startWatch(ctx); err != nil {
+			return nil, err
+		}
+	}
+	update, err := p.client.WaitForUpdates(ctx, p.watch.version, maxWait)
+	if err != nil {
+		return nil, err
+	}
+	current := p.cached()
+	next := current.clone()
+	if p.watch.version == "" {
+		next = newSnapshot(current.root)
+		next.events = current.events
+		next.latency = current.latency
+	}
+	objects := update.Objects()
+	next.update(objects)
+	latency := next.latency
+	if err := p.refreshDetails(ctx, next); err != nil {
+		return nil, err
+	}
+	now := p.now()
+	catalog := p.watch.catalog
+	if len(objects) > 0 || !maps.Equal(latency, next.latency) {
+		scoped, err := next.scoped(p.datacenter)
+		if err != nil {
+			return nil, err
+		}
+		catalog = scoped.catalog(now)
+	} else {
+		catalog.Tasks = next.taskRows(now)
+		catalog.Events = next.eventRows()
+		catalog.Alarms = next.alarmRows()
+	}
+	changes := tui.DiffCatalog(p.watch.catalog, catalog)
+	p.publish(next)
+	p.watch.version = update.Version
+	p.watch.catalog = catalog
+	return changes, nil
+}
+
+func (p *Provider) startWatch(ctx context.Context) error {
+	current, err := p.inventory()
+	if err != nil {
+		return err
+	}
+	content := p.client.ServiceContent()
+	watch := &watchState{catalog: current.catalog(p.now())}
+	watch.view, err = p.client.CreateContainerView(ctx, content.RootFolder, specTypes(inventorySpecs), true)
+	if err != nil {
+		return err
+	}
+	specs := []PropertyFilterSpec{
+		{PropSet: inventorySpecs, ObjectSet: []ObjectSpec{containerViewObjectSpec(watch.view)}},
+		{PropSet: rootSpecs, ObjectSet: []ObjectSpec{{Obj: content.RootFolder}, {Obj: content.TaskManager}}},
+	}
+	for _, spec := range specs {
+		filter, err := p.client.CreateFilter(ctx, spec)
+		if err != nil {
+			p.release(ctx, watch)
+			return err
+		}
+		watch.filters = append(watch.filters, filter)
+	}
+	p.watch = watch
+	return nil
+}
+
+func (p *Provider) stopWatch(ctx context.Context) {
+	p.watchMu.Lock()
+	defer p.watchMu.Unlock()
+	if p.watch != nil {
+		p.release(ctx, p.watch)
+		p.watch = nil
+	}
+}
+
+func (p *Provider) release(ctx context.Context, watch *watchState) {
+	for _, filter := range watch.filters {
+		_ = p.client.DestroyPropertyFilter(ctx, filter)
+	}
+	_ = p.client.DestroyView(ctx, watch.view)
+}
+
+func (p *Provider) refreshDetails(ctx context.Context, next *snapshot) error {
+	content := p.client.ServiceContent()
+	details, err := p.client.RetrieveObjects(ctx, next.detailRefs(content.TaskManager), detailSpecs)
+	if err != nil {
+		return err
+	}
+	next.removeTypes("Alarm", "Task")
+	next.add(details)
+	now := p.now()
+	events, err := p.client.QueryEvents(ctx, next.latestEvent(now.Add(-eventWindow)))
+	if err != nil {
+		return err
+	}
+	next.mergeEvents(events, now.Add(-eventWindow))
+	return p.loadLatency(ctx, next)
+}
+
+func (s *snapshot) update(objects []ObjectUpdate) {
+	for _, object := range objects {
+		if object.Kind == "leave" {
+			s.remove(object.Obj)
+			continue
+		}
+		properties, ok := s.objects[object.Obj]
+		if !ok {
+			s.order = append(s.order, object.Obj)
+		}
+		properties = maps.Clone(properties)
+		if properties == nil {
+			properties = map[string]Value{}
+		}
+		for _, change := range object.ChangeSet {
+			if change.Op == "remove" || change.Op == "indirectRemove" {
+				delete(properties, change.Name)
+				continue
+			}
+			properties[change.Name] = change.Val
+		}
+		s.objects[object.Obj] = properties
+	}
+}
+
+func (s *snapshot) latestEvent(floor time.Time) time.Time {
+	latest := floor
+	for _, event := range s.events {
+		if created, err := time.Parse(time.RFC3339, event.CreatedTime); err == nil && created.After(latest) {
+			latest = created
+		}
+	}
+	return latest
+}
+
+func (s *snapshot) mergeEvents(events []Event, cutoff time.Time) {
+	seen := map[int]bool{}
+	merged := []Event{}
+	for _, event := range slices.Concat(s.events, events) {
+		if seen[event.Key] {
+			continue
+		}
+		seen[event.Key] = true
+		if created, err := time.Parse(time.RFC3339, event.CreatedTime); err == nil && created.Before(cutoff) {
+			continue
+		}
+		merged = append(merged, event)
+	}
+	s.events = merged
+}
@@ -0,0 +1,191 @@
+// Path: internal/vsphere/watch_test.go
+// Description: Verify PropertyCollector watch updates translate into incremental catalog changes.
+package vsphere
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/takelley1/hypersphere/internal/tui"
+)
+
+func loadCatalog(t *testing.T, provider *Provider) tui.Catalog {
+	t.Helper()
+	catalog, err := tui.LoadCatalog(provider)
+	if err != nil {
+		t.Fatalf("LoadCatalog returned error: %v", err)
+	}
+	return catalog
+}
+
+func watchInto(t *testing.T, provider *Provider, catalog *tui.Catalog) []tui.CatalogChange {
+	t.Helper()
+	changes, err := provider.WatchChanges(t.Context(), time.Second)
+	if err != nil {
+		t.Fatalf("WatchChanges returned error: %v", err)
+	}
+	if err := catalog.Apply(changes); err != nil {
+		t.Fatalf("Apply returned error: %v", err)
+	}
+	return changes
+}
+
+func assertCatalogMatchesFreshLoad(t *testing.T, sim *simulator, catalog tui.Catalog) {
+	t.Helper()
+	if remaining := tui.DiffCatalog(catalog, loadCatalog(t, sim.provider(t))); len(remaining) != 0 {
+		t.Fatalf("expected watched catalog to match a fresh load, remaining %+v", remaining)
+	}
+}
+
+func TestWatchChangesAppliesIncrementalPropertyUpdates(t *testing.T) {
+	sim := newSimulator(t)
+	provider := sim.provider(t)
+	catalog := loadCatalog(t, provider)
+	if changes := watchInto(t, provider, &catalog); len(changes) != 0 {
+		t.Fatalf("expected initial sync to match the loaded catalog, got %+v", changes)
+	}
+	sim.set(mor("VirtualMachine", "vm-1"), "runtime.powerState", valString("poweredOff"))
+	changes := watchInto(t, provider, &catalog)
+	if len(changes) != 1 || changes[0].Op != tui.CatalogUpsert || changes[0].ID != "vm-a" {
+		t.Fatalf("expected a single vm-a upsert, got %+v", changes)
+	}
+	if catalog.VMs[0].PowerState != "off" {
+		t.Fatalf("expected vm-a to power off, got %+v", catalog.VMs[0])
+	}
+	sim.create("VirtualMachine", "vm-6", map[string]string{
+		"name": valString("vm-new"), "parent": valRef(mor("Folder", "group-v1")),
+		"config.template": valBool(false), "runtime.powerState": valString("poweredOn"),
+	})
+	sim.destroy(mor("VirtualMachine", "vm-2"))
+	sim.unset(mor("VirtualMachine", "vm-1"), "guest.ipAddress")
+	watchInto(t, provider, &catalog)
+	assertCatalogMatchesFreshLoad(t, sim, catalog)
+	if names := vmNames(catalog.VMs); names != "vm-a,vm-c,vm-new" {
+		t.Fatalf("expected created and destroyed VMs to be reflected, got %s", names)
+	}
+	if changes := watchInto(t, provider, &catalog); len(changes) != 0 {
+		t.Fatalf("expected quiet cycle to produce no changes, got %+v", changes)
+	}
+}
+
+func TestWatchChangesRefreshesTasksAlarmsAndEvents(t *testing.T) {
+	sim := newSimulator(t)
+	provider := sim.provider(t)
+	catalog := loadCatalog(t, provider)
+	watchInto(t, provider, &catalog)
+	sim.set(mor("TaskManager", "TaskManager"), "recentTask", valRefs(mor("Task", "task-2")))
+	sim.set(mor("Task", "task-2"), "info", valRaw("TaskInfo",
+		`<key>task-2</key><entityName>vm-b</entityName><descriptionId>VirtualMachine.clone</descriptionId>`+
+			`<state>success</state><startTime>2026-02-16T11:57:46Z</startTime><completeTime>2026-02-16T11:59:00Z</completeTime>`))
+	sim.mu.Lock()
+	sim.events += `<returnval xsi:type="GeneralUserEvent"><key>106</key><createdTime>2026-02-16T11:58:00Z</createdTime>` +
+		`<fullFormattedMessage>clone finished</fullFormattedMessage></returnval>`
+	sim.mu.Unlock()
+	changes := watchInto(t, provider, &catalog)
+	assertCatalogMatchesFreshLoad(t, sim, catalog)
+	if len(catalog.Tasks) != 1 || catalog.Tasks[0].State != "success" || len(catalog.Events) != 6 {
+		t.Fatalf("expected task and event streams to refresh, got tasks=%+v events=%d", catalog.Tasks, len(catalog.Events))
+	}
+	for _, change := range changes {
+		if change.Resource != tui.ResourceTask && change.Resource != tui.ResourceEvent {
+			t.Fatalf("expected only task and event changes without inventory updates, got %+v", change)
+		}
+	}
+	provider.now = func() time.Time { return simNow.Add(eventWindow) }
+	sim.mu.Lock()
+	sim.events = `<returnval xsi:type="GeneralUserEvent"><key>107</key><createdTime>unknown</createdTime>` +
+		`<fullFormattedMessage>clock skew</fullFormattedMessage></returnval>`
+	sim.mu.Unlock()
+	watchInto(t, provider, &catalog)
+	if len(catalog.Events) != 1 || catalog.Events[0].Message != "clock skew" {
+		t.Fatalf("expected events outside the window to expire, got %+v", catalog.Events)
+	}
+}
+
+func TestWatchChangesFollowsTruncatedUpdateSets(t *testing.T) {
+	sim := newSimulator(t)
+	sim.pageSize = 1
+	provider := sim.provider(t)
+	catalog := loadCatalog(t, provider)
+	watchInto(t, provider, &catalog)
+	calls := sim.calls["WaitForUpdatesEx"]
+	sim.set(mor("HostSystem", "host-1"), "runtime.connectionState", valString("disconnected"))
+	sim.set(mor("Datastore", "datastore-1"), "summary.freeSpace", valInt(10*gib))
+	watchInto(t, provider, &catalog)
+	if sim.calls["WaitForUpdatesEx"]-calls != 2 {
+		t.Fatalf("expected truncated update set to be continued, got %d calls", sim.calls["WaitForUpdatesEx"]-calls)
+	}
+	assertCatalogMatchesFreshLoad(t, sim, catalog)
+}
+
+func TestWatchChangesPropagatesFailures(t *testing.T) {
+	tests := []struct {
+		method string
+		call   int
+	}{
+		{method: "CreateContainerView", call: 1},
+		{method: "CreateFilter", call: 1},
+		{method: "CreateFilter", call: 2},
+		{method: "WaitForUpdatesEx", call: 1},
+		{method: "RetrievePropertiesEx", call: 1},
+		{method: "QueryEvents", call: 1},
+		{method: "QueryPerf", call: 1},
+	}
+	for _, test := range tests {
+		sim := newSimulator(t)
+		provider := sim.provider(t)
+		loadCatalog(t, provider)
+		sim.failOn(test.method, test.call)
+		_, err := provider.WatchChanges(t.Context(), time.Second)
+		fault := &Fault{}
+		if !errors.As(err, &fault) || fault.Kind != "SystemError" {
+			t.Fatalf("%s call %d: expected injected fault, got %v", test.method, test.call, err)
+		}
+	}
+	sim := newSimulator(t)
+	provider := sim.provider(t)
+	sim.failOn("CreateContainerView", 1)
+	if _, err := provider.WatchChanges(t.Context(), time.Second); err == nil {
+		t.Fatalf("expected initial inventory load failure")
+	}
+}
+
+func TestWatchChangesReportsVanishedDatacenterScope(t *testing.T) {
+	sim := newSimulator(t)
+	provider := sim.provider(t)
+	provider.ScopeDatacenter("dc-1")
+	catalog := loadCatalog(t, provider)
+	watchInto(t, provider, &catalog)
+	sim.set(mor("Datacenter", "datacenter-1"), "name", valString("dc-renamed"))
+	if _, err := provider.WatchChanges(t.Context(), time.Second); !errors.Is(err, ErrDatacenterNotFound) {
+		t.Fatalf("expected renamed scope datacenter to fail the watch, got %v", err)
+	}
+}
+
+func TestProviderCloseReleasesWatchFilters(t *testing.T) {
+	sim := newSimulator(t)
+	provider := sim.provider(t)
+	if _, err := provider.WatchChanges(t.Context(), time.Second); err != nil {
+		t.Fatalf("WatchChanges returned error: %v", err)
+	}
+	if update, err := provider.Client().WaitForUpdates(t.Context(), "1", -time.Second); err != nil || len(update.Objects()) != 0 {
+		t.Fatalf("expected quiet wait to return no updates, got %+v err=%v", update, err)
+	}
+	if err := provider.Close(); err != nil {
+		t.Fatalf("Close returned error: %v", err)
+	}
+	tail := strings.Join(sim.requests[len(sim.requests)-4:], ",")
+	if tail != "DestroyPropertyFilter,DestroyPropertyFilter,DestroyView,Logout" {
+		t.Fatalf("expected watch filters and view to be released before logout, got %s", tail)
+	}
+}
+
+func vmNames(rows []tui.VMRow) string {
+	names := make([]string, 0, len(rows))
+	for _, row := range rows {
+		names = append(names, row.Name)
+	}
+	return strings.Join(names, ",")
+}